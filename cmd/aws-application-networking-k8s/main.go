@@ -17,17 +17,33 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
+	"github.com/aws/aws-application-networking-k8s/pkg/drift"
+	lattice_metrics "github.com/aws/aws-application-networking-k8s/pkg/metrics"
+	"github.com/aws/aws-application-networking-k8s/pkg/resync"
 	"github.com/aws/aws-application-networking-k8s/pkg/webhook"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap/zapcore"
 	k8swebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/tracing"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -35,8 +51,11 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,6 +80,9 @@ var (
 	scheme = runtime.NewScheme()
 )
 
+// applySnapshotCommand deploys a --snapshot-dir and exits instead of running the controller
+const applySnapshotCommand = "apply-snapshot"
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
@@ -89,7 +111,9 @@ func addOptionalCRDs(scheme *runtime.Scheme) {
 		&anv1alpha1.TargetGroupPolicy{}, &anv1alpha1.TargetGroupPolicyList{},
 		&anv1alpha1.AccessLogPolicy{}, &anv1alpha1.AccessLogPolicyList{},
 		&anv1alpha1.VpcAssociationPolicy{}, &anv1alpha1.VpcAssociationPolicyList{},
-		&anv1alpha1.IAMAuthPolicy{}, &anv1alpha1.IAMAuthPolicyList{})
+		&anv1alpha1.IAMAuthPolicy{}, &anv1alpha1.IAMAuthPolicyList{},
+		&anv1alpha1.ServiceNetworkLogPolicy{}, &anv1alpha1.ServiceNetworkLogPolicyList{},
+		&anv1alpha1.ServiceNetworkResourcePolicy{}, &anv1alpha1.ServiceNetworkResourcePolicyList{})
 
 	metav1.AddToGroupVersion(scheme, groupVersion)
 }
@@ -98,12 +122,152 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
-
-	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	var driftSqsUrl string
+	var resyncAddr string
+	var reconcileAddr string
+	var serviceNameStrategy string
+	var otelEndpoint string
+	var logAPIUsage bool
+	var iamAuthPolicyAllowedActions string
+	var legacyFinalizers string
+	var latticeAPITimeout time.Duration
+	var latticeAPIOperationTimeouts string
+	var latticeAPIErrorRetries string
+	var quotaUsagePollInterval time.Duration
+	var ownershipCheckInterval time.Duration
+	var gatewayAPICRDsWaitTimeout time.Duration
+	var statusUpdateWindow time.Duration
+	var emptyEndpointsPolicy string
+	var noValidBackendsPolicy string
+	var policyTargetRefChange string
+	var policyAnnotationRetention string
+	var unknownAnnotations string
+	var runOnce bool
+	var assumeRoleArns string
+	var assumeRoleExternalId string
+	var runOnceTimeout time.Duration
+	var reconcileHistory int
+
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to. "+
+		"The effective configuration of the controller is served as JSON on "+config.EffectiveConfigPath+" of the same address.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&driftSqsUrl, "drift-sqs-url", "",
+		"URL of an SQS queue receiving VPC Lattice change notifications from EventBridge. "+
+			"When set, resources affected by out-of-band changes are reconciled as soon as a notification is received.")
+	flag.StringVar(&resyncAddr, "resync-bind-address", "0",
+		"The address the resync endpoint binds to. A POST to "+resync.Path+" enqueues all managed objects for reconcile. "+
+			"Set this to \"0\" to disable the resync endpoint.")
+	flag.StringVar(&reconcileAddr, "reconcile-bind-address", "127.0.0.1:8083",
+		"The address the reconcile endpoint binds to. A POST to "+resync.ReconcilePath+"<kind>/<namespace>/<name> enqueues the named object for reconcile. "+
+			"Only reachable from within the pod by default. Set this to \"0\" to disable the reconcile endpoint.")
+	flag.StringVar(&serviceNameStrategy, "lattice-service-name-strategy", string(utils.ServiceNameStrategyTruncate),
+		"How VPC Lattice service names are derived from route name and namespace. "+
+			"\"truncate\" truncates them to fit Lattice limits, \"hash\" also appends a hash to keep truncated names unique.")
+	flag.BoolVar(&config.FinalizerRemovalOnLatticeUnreachable, "finalizer-removal-on-lattice-unreachable", false,
+		"Remove finalizers of deleted resources when their VPC Lattice cleanup keeps failing because VPC Lattice is unreachable. "+
+			"Leaked VPC Lattice resources are logged and reported with a LeakedResources event.")
+	flag.IntVar(&config.FinalizerRemovalMaxAttempts, "finalizer-removal-max-attempts", config.FinalizerRemovalMaxAttempts,
+		"Number of consecutive cleanup attempts failing on VPC Lattice connectivity before finalizers are removed, "+
+			"when --finalizer-removal-on-lattice-unreachable is set.")
+	flag.StringVar(&config.HTTPHealthCheckPath, "http-health-check-path", config.HTTPHealthCheckPath,
+		"Default health check path of HTTP and HTTPS target groups, when not set by a TargetGroupPolicy.")
+	flag.StringVar(&config.GRPCHealthCheckPath, "grpc-health-check-path", config.GRPCHealthCheckPath,
+		"Default health check path of GRPC target groups, when not set by a TargetGroupPolicy.")
+	flag.BoolVar(&config.GRPCHealthCheckEnabled, "grpc-health-check-enabled", config.GRPCHealthCheckEnabled,
+		"Enable health checks of GRPC target groups by default, over HTTP2. Servers must answer them with a 200 response.")
+	flag.StringVar(&otelEndpoint, "otel-endpoint", "",
+		"OTLP gRPC endpoint, e.g. http://otel-collector:4317, OpenTelemetry traces of reconciles and AWS API calls are exported to. "+
+			"Tracing is disabled when not set.")
+	flag.BoolVar(&logAPIUsage, "log-api-usage", false,
+		"Count the AWS API requests made by the controller per operation and log the counts every "+
+			services.APIUsageLogInterval.String()+", e.g. to plan service quota increases.")
+	flag.StringVar(&iamAuthPolicyAllowedActions, "iam-auth-policy-allowed-actions", "",
+		"Comma-separated IAM actions, e.g. vpc-lattice-svcs:Invoke, IAMAuthPolicies are allowed to grant. Actions can use * and ? wildcards. "+
+			"IAMAuthPolicies allowing other actions are rejected by the validating webhook. All actions are allowed when not set.")
+	flag.DurationVar(&config.ResyncPeriod, "resync-period", config.ResyncPeriod,
+		"The period after which all cached objects are reconciled again, correcting drift of VPC Lattice resources. "+
+			"Shorter periods correct drift sooner but make more VPC Lattice API requests. Must be at least "+config.MinResyncPeriod.String()+".")
+	flag.StringVar(&config.ControllerID, "controller-id", "",
+		"Identifier of this controller instance, appended to the "+aws.TagManagedBy+" tag of the VPC Lattice resources it creates. "+
+			"Controllers only manage resources with their own tag, set distinct ids when running multiple controllers in the same cluster and VPC.")
+	flag.BoolVar(&config.ServiceNetworkCreationDisabled, "disable-service-network-creation", false,
+		"Never create VPC Lattice service networks, e.g. when they are provisioned with infrastructure as code. "+
+			"Gateways are only programmed on existing service networks, and the default service network is associated with the VPC only if it exists.")
+	flag.DurationVar(&config.ReconcileDebounce, "reconcile-debounce", config.ReconcileDebounce,
+		"Delay of reconciles triggered by spec edits. Edits made within the delay are reconciled once, with the latest spec. "+
+			"Set to 0 to reconcile every edit immediately.")
+	flag.DurationVar(&latticeAPITimeout, "lattice-api-timeout", 0,
+		"Timeout of VPC Lattice API requests, retries included. Requests are not bounded when not set.")
+	flag.StringVar(&latticeAPIOperationTimeouts, "lattice-api-operation-timeouts", "",
+		"Comma-separated timeouts of VPC Lattice API operations overriding --lattice-api-timeout, e.g. RegisterTargets=2m,PutAuthPolicy=10s.")
+	flag.StringVar(&latticeAPIErrorRetries, "lattice-api-error-retries", "",
+		"Comma-separated retry policies of VPC Lattice API error codes overriding the defaults, as <error code>=<max retries>:<backoff>, "+
+			"e.g. ThrottlingException=10:1s,ConflictException=3:2s. The backoff doubles on every retry.")
+	flag.DurationVar(&quotaUsagePollInterval, "quota-usage-poll-interval", 0,
+		"Interval at which the VPC Lattice resources limited by quotas are counted and exposed as quota usage metrics, e.g. 15m. "+
+			"Every poll lists all services, listeners, rules, target groups and targets of the account. Disabled when not set.")
+	flag.IntVar(&config.QuotaBackpressureThreshold, "quota-backpressure-threshold", 0,
+		"Usage of the services per service network quota, in percent of its limit, from which the VPC Lattice services of new routes are not created, e.g. 90. "+
+			"Existing routes are still updated. Requires --quota-usage-poll-interval, the usage is the one of its last poll. Disabled when not set.")
+	flag.StringVar(&emptyEndpointsPolicy, "empty-endpoints-policy", string(config.EmptyEndpointsPolicyAccept),
+		"How target groups of Services without endpoints are built, e.g. of Services whose EndpointSlices are not populated yet. "+
+			"\"accept\" registers no targets, \"requeue\" fails the reconcile and retries it until the Service has endpoints.")
+	flag.StringVar(&noValidBackendsPolicy, "no-valid-backends-policy", string(config.NoValidBackendsPolicyTearDown),
+		"What route rules do once none of their backendRefs is valid, e.g. after their Services were deleted. "+
+			"\"teardown\" responds with 404, \"keep-last-known-good\" keeps forwarding to the target groups the rule was last deployed with.")
+	flag.DurationVar(&config.DriftDetectionInterval, "drift-detection-interval", 0,
+		"Interval at which the VPC Lattice auth policies of IAMAuthPolicies are compared to their desired policy documents, e.g. 30m. "+
+			"Out of band changes are reported with a DriftDetected condition and the drifted_resources metric. Disabled when not set.")
+	flag.DurationVar(&ownershipCheckInterval, "ownership-check-interval", 0,
+		"Interval at which the "+aws.TagManagedBy+" tags of VPC Lattice service networks, services and target groups are checked, e.g. 10m. "+
+			"Resources managed by this controller whose tag is removed or changed out of band are logged and counted in the ownership_conflicts metric. Disabled when not set.")
+	flag.DurationVar(&gatewayAPICRDsWaitTimeout, "gateway-api-crds-wait-timeout", 2*time.Minute,
+		"How long to wait at startup for the GatewayClass, Gateway and HTTPRoute CRDs to be installed. "+
+			"When they are still missing, the controllers of Gateway API resources are not started, the other controllers are.")
+	flag.DurationVar(&statusUpdateWindow, "status-update-window", 0,
+		"Minimum interval between two status writes of a Gateway API or controller resource, e.g. 10s. Status changes within the interval "+
+			"are coalesced and only the final status is written, statuses equal to the last written one are not written again. Disabled when not set.")
+	flag.StringVar(&policyAnnotationRetention, "policy-annotation-retention", string(config.PolicyAnnotationRetentionHash),
+		"How much of the last applied IAMAuthPolicy document is kept in its annotations. \"hash\" keeps only its hash, "+
+			"\"full\" also keeps the document, which helps debugging but grows the object by the size of the document.")
+	flag.StringVar(&policyTargetRefChange, "policy-target-ref-change", string(config.PolicyTargetRefChangeReject),
+		"What happens when the targetRef of an IAMAuthPolicy is edited. \"reject\" makes the validating webhook reject the change, "+
+			"\"move\" accepts it and moves the policy from the previous target to the new one.")
+	flag.StringVar(&config.RouteDNSConfigMap, "route-dns-configmap", "",
+		"Name of a ConfigMap maintained in every namespace with routes, mapping each route, as <kind>.<name>, to the DNS name of its VPC Lattice service, "+
+			"e.g. for in-cluster service discovery without AWS credentials. Disabled when not set.")
+	flag.StringVar(&config.SnapshotDir, "snapshot-dir", "",
+		"Directory the desired VPC Lattice configuration of every route is saved into as JSON once deployed, e.g. a persistent volume. "+
+			"Running the controller with \""+applySnapshotCommand+" <dir>\" deploys a saved snapshot again, e.g. after a loss of the VPC Lattice configuration. Disabled when not set.")
+	flag.StringVar(&legacyFinalizers, "legacy-finalizers", "",
+		"Comma-separated finalizers, e.g. of earlier controller versions, removed from deleted objects along with the finalizers of the controller. "+
+			"Objects still carrying finalizers no controller removes anymore are otherwise never deleted.")
+	flag.DurationVar(&config.BackendHealthGatingInterval, "backend-health-gating-interval", 0,
+		"Interval at which routes with weighted backendRefs are reconciled again, e.g. 1m. Backends without healthy targets are left out "+
+			"of the weighted target groups, their weight goes to the remaining backends. Every reconcile lists the targets of these backends. Disabled when not set.")
+	flag.StringVar(&config.RegionOverride, "aws-region", "",
+		"AWS region of the VPC Lattice endpoint, e.g. us-west-2. Overrides the REGION and AWS_REGION environment variables "+
+			"and the region of the EC2 instance metadata, which is not available outside of EC2.")
+	flag.StringVar(&unknownAnnotations, "unknown-annotations", string(config.UnknownAnnotationsLenient),
+		"How Gateways and routes with unknown "+k8s.AnnotationPrefix+" annotations, e.g. typos of the annotations of the controller, are handled. "+
+			"\"lenient\" ignores them, \"strict\" does not accept the Gateway or route and names the unknown annotations in its status.")
+	flag.StringVar(&assumeRoleArns, "assume-role-arn", "",
+		"ARN of an IAM role the controller assumes to call VPC Lattice, e.g. of a role in another account. A comma-separated list of ARNs "+
+			"is a chain of roles assumed in turn, each with the credentials of the previous one. The credentials of the pod are used when not set.")
+	flag.StringVar(&assumeRoleExternalId, "assume-role-external-id", "",
+		"External ID passed when assuming the last role of --assume-role-arn, when its trust policy requires one.")
+	flag.BoolVar(&runOnce, "run-once", false,
+		"Reconcile every Gateway, route, ServiceExport and policy of the cluster a single time, then exit, e.g. to validate them in a CI pipeline. "+
+			"The controller exits with a non-zero status when the reconcile of any of them fails.")
+	flag.DurationVar(&runOnceTimeout, "run-once-timeout", 10*time.Minute,
+		"How long the reconcile of all objects may take with --run-once, objects not reconciled by then fail the run.")
+	flag.IntVar(&reconcileHistory, "reconcile-history", 0,
+		fmt.Sprintf("Number of last reconciles of every Gateway, route, ServiceExport and policy recorded in its %s annotation, "+
+			"with their time, result and error, e.g. to see why a resource fails to reconcile with kubectl. At most %d. Disabled when not set.",
+			k8s.ReconcileHistoryAnnotation, resync.MaxReconcileHistory))
 	flag.Parse()
 
 	logLevel := logLevel()
@@ -116,26 +280,115 @@ func main() {
 	if err != nil {
 		setupLog.Fatalf("init config failed: %s", err)
 	}
+	utils.LatticeServiceNameStrategy, err = utils.ParseServiceNameStrategy(serviceNameStrategy)
+	if err != nil {
+		setupLog.Fatalf("init config failed: %s", err)
+	}
+	config.EmptyEndpoints, err = config.ParseEmptyEndpointsPolicy(emptyEndpointsPolicy)
+	if err != nil {
+		setupLog.Fatalf("init config failed: %s", err)
+	}
+	config.PolicyRetention, err = config.ParsePolicyAnnotationRetention(policyAnnotationRetention)
+	if err != nil {
+		setupLog.Fatalf("init config failed: %s", err)
+	}
+	config.NoValidBackends, err = config.ParseNoValidBackendsPolicy(noValidBackendsPolicy)
+	if err != nil {
+		setupLog.Fatalf("init config failed: %s", err)
+	}
+	config.PolicyTargetRefChange, err = config.ParsePolicyTargetRefChangePolicy(policyTargetRefChange)
+	if err != nil {
+		setupLog.Fatalf("init config failed: %s", err)
+	}
+	config.UnknownAnnotations, err = config.ParseUnknownAnnotationsPolicy(unknownAnnotations)
+	if err != nil {
+		setupLog.Fatalf("init config failed: %s", err)
+	}
+	if config.QuotaBackpressureThreshold < 0 || config.QuotaBackpressureThreshold > 100 {
+		setupLog.Fatalf("init config failed: quota backpressure threshold %d is not a percentage", config.QuotaBackpressureThreshold)
+	}
+	if config.QuotaBackpressureThreshold != 0 && quotaUsagePollInterval == 0 {
+		setupLog.Fatalf("init config failed: quota backpressure threshold requires a quota usage poll interval")
+	}
+	if reconcileHistory < 0 || reconcileHistory > resync.MaxReconcileHistory {
+		setupLog.Fatalf("init config failed: reconcile history %d must be between 0 and %d", reconcileHistory, resync.MaxReconcileHistory)
+	}
+	apiTimeouts, err := services.ParseAPITimeouts(latticeAPITimeout, latticeAPIOperationTimeouts)
+	if err != nil {
+		setupLog.Fatalf("init config failed: %s", err)
+	}
+	apiErrorRetries, err := services.ParseErrorRetryPolicies(latticeAPIErrorRetries)
+	if err != nil {
+		setupLog.Fatalf("init config failed: %s", err)
+	}
 	setupLog.Infow("init config",
 		"VpcId", config.VpcID,
 		"Region", config.Region,
 		"AccountId", config.AccountID,
 		"DefaultServiceNetwork", config.DefaultServiceNetwork,
 		"ClusterName", config.ClusterName,
+		"ControllerId", config.ControllerID,
 		"LogLevel", logLevel,
 		"DisableTaggingServiceAPI", config.DisableTaggingServiceAPI,
+		"LatticeServiceNameStrategy", utils.LatticeServiceNameStrategy,
+		"FinalizerRemovalOnLatticeUnreachable", config.FinalizerRemovalOnLatticeUnreachable,
+		"ResyncPeriod", config.ResyncPeriod,
+		"ReconcileDebounce", config.ReconcileDebounce,
+		"ServiceNetworkCreationDisabled", config.ServiceNetworkCreationDisabled,
+		"EmptyEndpointsPolicy", config.EmptyEndpoints,
+		"NoValidBackendsPolicy", config.NoValidBackends,
+		"PolicyAnnotationRetention", config.PolicyRetention,
+		"PolicyTargetRefChange", config.PolicyTargetRefChange,
+		"DriftDetectionInterval", config.DriftDetectionInterval,
+		"RouteDNSConfigMap", config.RouteDNSConfigMap,
+		"SnapshotDir", config.SnapshotDir,
+		"LegacyFinalizers", legacyFinalizers,
+		"BackendHealthGatingInterval", config.BackendHealthGatingInterval,
+		"QuotaBackpressureThreshold", config.QuotaBackpressureThreshold,
+		"UnknownAnnotations", config.UnknownAnnotations,
+		"RunOnce", runOnce,
+		"ReconcileHistory", reconcileHistory,
+		"AssumeRoleArn", assumeRoleArns,
 	)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), otelEndpoint)
+	if err != nil {
+		setupLog.Fatalf("tracing setup failed: %s", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	// requests are always counted for the resync summary, and only logged per operation with --log-api-usage
+	apiUsage := services.NewAPIUsageCounter(log.Named("api-usage"), services.APIUsageLogInterval)
 	cloud, err := aws.NewCloud(log.Named("cloud"), aws.CloudConfig{
 		VpcId:                     config.VpcID,
 		AccountId:                 config.AccountID,
 		Region:                    config.Region,
 		ClusterName:               config.ClusterName,
+		ControllerId:              config.ControllerID,
 		TaggingServiceAPIDisabled: config.DisableTaggingServiceAPI,
-	}, metrics.Registry)
+		CredentialsExpiryWindow:   config.CredentialsExpiryWindow,
+		APITimeouts:               apiTimeouts,
+		APIErrorRetries:           apiErrorRetries,
+		AssumeRoleArns: utils.SliceFilter(utils.SliceMap(strings.Split(assumeRoleArns, ","), strings.TrimSpace),
+			func(arn string) bool { return arn != "" }),
+		AssumeRoleExternalId: assumeRoleExternalId,
+	}, metrics.Registry, apiUsage)
 	if err != nil {
 		setupLog.Fatal("cloud client setup failed: %s", err)
 	}
+	if assumeRoleArns != "" {
+		// fail fast on roles which cannot be assumed, instead of failing every reconcile
+		if _, err := cloud.Credentials().GetWithContext(context.Background()); err != nil {
+			setupLog.Fatalf("assume role %s failed: %s", assumeRoleArns, err)
+		}
+	}
+
+	if flag.Arg(0) == applySnapshotCommand {
+		if err := applySnapshot(context.Background(), log.Named(applySnapshotCommand), cloud, flag.Arg(1)); err != nil {
+			setupLog.Fatalf("%s failed: %s", applySnapshotCommand, err)
+		}
+		return
+	}
 
 	// do not create the webhook server when running locally
 	var webhookServer k8swebhook.Server
@@ -152,10 +405,28 @@ func main() {
 		setupLog.Infof("Webhook is disabled, value: '%s'", config.WebhookEnabled)
 	}
 
+	cacheOptions, err := managerCacheOptions(config.ResyncPeriod)
+	if err != nil {
+		setupLog.Fatalf("init config failed: %s", err)
+	}
+	eventBroadcaster := k8s.NewEventBroadcaster()
+	defer eventBroadcaster.Shutdown()
+
+	var newClient client.NewClientFunc
+	if statusUpdateWindow > 0 {
+		newClient = k8s.NewStatusCoalescingClientFunc(log.Named("status"), statusUpdateWindow)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
+		Scheme:           scheme,
+		Cache:            cacheOptions,
+		NewClient:        newClient,
+		EventBroadcaster: eventBroadcaster,
 		Metrics: metricsserver.Options{
 			BindAddress: metricsAddr,
+			ExtraHandlers: map[string]http.Handler{
+				config.EffectiveConfigPath: config.EffectiveConfigHandler(flag.CommandLine),
+			},
 		},
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -173,9 +444,104 @@ func main() {
 			logger,
 		)
 		webhook.NewPodMutator(logger, scheme, readinessGateInjector).SetupWithManager(logger, mgr)
+
+		validatorLogger := log.Named("iam-auth-policy-validator")
+		allowedActions := utils.SliceFilter(utils.SliceMap(strings.Split(iamAuthPolicyAllowedActions, ","), strings.TrimSpace),
+			func(action string) bool { return action != "" })
+		webhook.NewIAMAuthPolicyValidator(validatorLogger, scheme, allowedActions).SetupWithManager(validatorLogger, mgr)
+
+		gatewayValidatorLogger := log.Named("gateway-validator")
+		webhook.NewGatewayValidator(gatewayValidatorLogger, scheme, mgr.GetClient()).SetupWithManager(gatewayValidatorLogger, mgr)
 	}
 
-	finalizerManager := k8s.NewDefaultFinalizerManager(mgr.GetClient())
+	finalizerManager := k8s.NewDefaultFinalizerManager(mgr.GetClient(),
+		utils.SliceFilter(utils.SliceMap(strings.Split(legacyFinalizers, ","), strings.TrimSpace),
+			func(finalizer string) bool { return finalizer != "" })...)
+
+	if err := mgr.Add(aws.NewCredentialsMonitor(log.Named("credentials"), cloud.Credentials())); err != nil {
+		setupLog.Fatalf("credentials monitor setup failed: %s", err)
+	}
+
+	if logAPIUsage {
+		if err := mgr.Add(apiUsage); err != nil {
+			setupLog.Fatalf("api usage counter setup failed: %s", err)
+		}
+	}
+
+	if err := mgr.Add(lattice_metrics.NewResyncReporter(log.Named("resync-summary"), config.ResyncPeriod, apiUsage.Total)); err != nil {
+		setupLog.Fatalf("resync reporter setup failed: %s", err)
+	}
+
+	if quotaUsagePollInterval > 0 {
+		quotaUsagePoller := lattice_metrics.NewQuotaUsagePoller(log.Named("quota-usage"), cloud, quotaUsagePollInterval)
+		if err := mgr.Add(quotaUsagePoller); err != nil {
+			setupLog.Fatalf("quota usage poller setup failed: %s", err)
+		}
+	}
+
+	if ownershipCheckInterval > 0 {
+		ownershipWatcher := drift.NewOwnershipWatcher(log.Named("ownership"), cloud, ownershipCheckInterval)
+		if err := mgr.Add(ownershipWatcher); err != nil {
+			setupLog.Fatalf("ownership watcher setup failed: %s", err)
+		}
+	}
+
+	var driftPoller *drift.Poller
+	if driftSqsUrl != "" {
+		sess, err := session.NewSession(awssdk.NewConfig().WithRegion(config.Region))
+		if err != nil {
+			setupLog.Fatalf("drift sqs client setup failed: %s", err)
+		}
+		// the changes made with the VPC Lattice credentials of the controller are not drift
+		identity, err := sts.New(sess, awssdk.NewConfig().WithCredentials(cloud.Credentials())).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+		if err != nil {
+			setupLog.Fatalf("drift caller identity lookup failed: %s", err)
+		}
+		driftPoller = drift.NewPoller(log.Named("drift"), cloud, sqs.New(sess), driftSqsUrl, awssdk.StringValue(identity.Arn))
+		if err := mgr.Add(driftPoller); err != nil {
+			setupLog.Fatalf("drift poller setup failed: %s", err)
+		}
+	}
+
+	resyncer := resync.NewResyncer(log.Named("resync"), mgr.GetClient())
+	ctx, stop := context.WithCancel(ctrl.SetupSignalHandler())
+	defer stop()
+	if runOnce {
+		once := resync.NewRunOnce(log.Named("run-once"), resyncer, runOnceTimeout, stop)
+		lattice_metrics.AddReconcileObserver(once.Observe)
+		if err := mgr.Add(once); err != nil {
+			setupLog.Fatalf("run once setup failed: %s", err)
+		}
+	}
+	if reconcileHistory > 0 {
+		history := resync.NewReconcileHistory(log.Named("reconcile-history"), resyncer, mgr.GetClient(), mgr.GetAPIReader(), reconcileHistory)
+		lattice_metrics.AddReconcileObserver(history.Observe)
+	}
+	if resyncAddr != "0" {
+		if err := mgr.Add(resyncer.Server(resyncAddr)); err != nil {
+			setupLog.Fatalf("resync endpoint setup failed: %s", err)
+		}
+	}
+	if reconcileAddr != "0" {
+		if err := mgr.Add(resyncer.ReconcileServer(reconcileAddr)); err != nil {
+			setupLog.Fatalf("reconcile endpoint setup failed: %s", err)
+		}
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Fatalf("discovery client setup failed: %s", err)
+	}
+	missingKinds, err := k8s.WaitForKinds(context.Background(), log.Named("crds"), discoveryClient,
+		k8s.RequiredGatewayAPIKinds, gatewayAPICRDsWaitTimeout)
+	if err != nil {
+		setupLog.Fatalf("gateway api crds discovery failed: %s", err)
+	}
+	gatewayAPIInstalled := len(missingKinds) == 0
+	if !gatewayAPIInstalled {
+		setupLog.Errorf("%s CRDs are not installed, skipping the gateway class, gateway, route and access log policy controllers, %s",
+			k8s.KindNames(missingKinds), k8s.GatewayAPIInstallHint)
+	}
 
 	// parent logging scope for all controllers
 	ctrlLog := log.Named("controller")
@@ -190,19 +556,21 @@ func main() {
 		setupLog.Fatalf("service controller setup failed: %s", err)
 	}
 
-	err = controllers.RegisterGatewayClassController(ctrlLog.Named("gateway-class"), mgr)
-	if err != nil {
-		setupLog.Fatalf("gateway-class controller setup failed: %s", err)
-	}
-
-	err = controllers.RegisterGatewayController(ctrlLog.Named("gateway"), cloud, finalizerManager, mgr)
-	if err != nil {
-		setupLog.Fatalf("gateway controller setup failed: %s", err)
-	}
-
-	err = controllers.RegisterAllRouteControllers(ctrlLog.Named("route"), cloud, finalizerManager, mgr)
-	if err != nil {
-		setupLog.Fatalf("route controller setup failed: %s", err)
+	if gatewayAPIInstalled {
+		err = controllers.RegisterGatewayClassController(ctrlLog.Named("gateway-class"), mgr)
+		if err != nil {
+			setupLog.Fatalf("gateway-class controller setup failed: %s", err)
+		}
+
+		err = controllers.RegisterGatewayController(ctrlLog.Named("gateway"), cloud, finalizerManager, mgr, resyncer)
+		if err != nil {
+			setupLog.Fatalf("gateway controller setup failed: %s", err)
+		}
+
+		err = controllers.RegisterAllRouteControllers(ctrlLog.Named("route"), cloud, finalizerManager, mgr, driftPoller, resyncer)
+		if err != nil {
+			setupLog.Fatalf("route controller setup failed: %s", err)
+		}
 	}
 
 	err = controllers.RegisterServiceImportController(ctrlLog.Named("service-import"), mgr, finalizerManager)
@@ -210,30 +578,42 @@ func main() {
 		setupLog.Fatalf("serviceimport controller setup failed: %s", err)
 	}
 
-	err = controllers.RegisterServiceExportController(ctrlLog.Named("service-export"), cloud, finalizerManager, mgr)
+	err = controllers.RegisterServiceExportController(ctrlLog.Named("service-export"), cloud, finalizerManager, mgr, driftPoller, resyncer)
 	if err != nil {
 		setupLog.Fatalf("serviceexport controller setup failed: %s", err)
 	}
 
-	err = controllers.RegisterAccessLogPolicyController(ctrlLog.Named("access-log-policy"), cloud, finalizerManager, mgr)
-	if err != nil {
-		setupLog.Fatalf("accesslogpolicy controller setup failed: %s", err)
+	if gatewayAPIInstalled {
+		err = controllers.RegisterAccessLogPolicyController(ctrlLog.Named("access-log-policy"), cloud, finalizerManager, mgr, resyncer)
+		if err != nil {
+			setupLog.Fatalf("accesslogpolicy controller setup failed: %s", err)
+		}
 	}
 
-	err = controllers.RegisterIAMAuthPolicyController(ctrlLog.Named("iam-auth-policy"), mgr, cloud)
+	err = controllers.RegisterIAMAuthPolicyController(ctrlLog.Named("iam-auth-policy"), mgr, cloud, resyncer)
 	if err != nil {
 		setupLog.Fatalf("iam auth policy controller setup failed: %s", err)
 	}
 
-	err = controllers.RegisterTargetGroupPolicyController(ctrlLog.Named("target-group-policy"), mgr)
+	err = controllers.RegisterTargetGroupPolicyController(ctrlLog.Named("target-group-policy"), mgr, resyncer)
 	if err != nil {
 		setupLog.Fatalf("target group policy controller setup failed: %s", err)
 	}
 
-	err = controllers.RegisterVpcAssociationPolicyController(ctrlLog.Named("vpc-association-policy"), cloud, finalizerManager, mgr)
+	err = controllers.RegisterVpcAssociationPolicyController(ctrlLog.Named("vpc-association-policy"), cloud, finalizerManager, mgr, resyncer)
 	if err != nil {
 		setupLog.Fatalf("vpc association policy controller setup failed: %s", err)
 	}
+
+	err = controllers.RegisterServiceNetworkLogPolicyController(ctrlLog.Named("service-network-log-policy"), cloud, finalizerManager, mgr, resyncer)
+	if err != nil {
+		setupLog.Fatalf("service network log policy controller setup failed: %s", err)
+	}
+
+	err = controllers.RegisterServiceNetworkResourcePolicyController(ctrlLog.Named("service-network-resource-policy"), cloud, finalizerManager, mgr, resyncer)
+	if err != nil {
+		setupLog.Fatalf("service network resource policy controller setup failed: %s", err)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -246,13 +626,36 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 
 }
 
+// applySnapshot deploys the stacks saved into a --snapshot-dir, then exits. Every stack is deployed even when
+// others fail, deploying a snapshot again only retries what failed.
+func applySnapshot(ctx context.Context, log gwlog.Logger, cloud aws.Cloud, dir string) error {
+	if dir == "" {
+		return fmt.Errorf("usage: %s <snapshot dir>", applySnapshotCommand)
+	}
+	stacks, err := deploy.ReadSnapshot(dir)
+	if err != nil {
+		return err
+	}
+	deployer := deploy.NewSnapshotStackDeploy(log, cloud)
+	var deployErr error
+	for _, stack := range stacks {
+		if err := deployer.Deploy(ctx, stack); err != nil {
+			deployErr = errors.Join(deployErr, fmt.Errorf("failed to deploy stack %s due to %w", stack.StackID(), err))
+			continue
+		}
+		log.Infof(ctx, "Deployed stack %s", stack.StackID())
+	}
+	log.Infof(ctx, "Applied snapshot %s, %d stacks", dir, len(stacks))
+	return deployErr
+}
+
 func logLevel() zapcore.Level {
 	level := os.Getenv("LOG_LEVEL")
 	switch level {
@@ -266,3 +669,14 @@ func logLevel() zapcore.Level {
 		return zapcore.InfoLevel
 	}
 }
+
+func managerCacheOptions(resyncPeriod time.Duration) (cache.Options, error) {
+	if err := config.ValidateResyncPeriod(resyncPeriod); err != nil {
+		return cache.Options{}, err
+	}
+	// ConfigMaps are not filtered, the GatewayClass parameters watch needs ConfigMaps without any label. Only their
+	// metadata is cached, the route DNS ConfigMaps are read from the API server
+	return cache.Options{
+		SyncPeriod: &resyncPeriod,
+	}, nil
+}
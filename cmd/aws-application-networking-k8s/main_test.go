@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func Test_managerCacheOptions(t *testing.T) {
+	options, err := managerCacheOptions(30 * time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, *options.SyncPeriod)
+	// GatewayClass parameters ConfigMaps have no label, they must not be filtered out of the cache
+	parameters := labels.Set{}
+	for obj, byObject := range options.ByObject {
+		if _, ok := obj.(*corev1.ConfigMap); ok && byObject.Label != nil {
+			assert.True(t, byObject.Label.Matches(parameters), "ConfigMaps are filtered by %s", byObject.Label)
+		}
+	}
+
+	options, err = managerCacheOptions(time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, *options.SyncPeriod)
+
+	_, err = managerCacheOptions(59 * time.Second)
+	assert.EqualError(t, err, "invalid value for --resync-period: 59s, must be at least 1m0s")
+}
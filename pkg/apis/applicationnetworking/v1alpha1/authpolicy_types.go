@@ -9,6 +9,16 @@ const (
 	IAMAuthPolicyKind = "IAMAuthPolicy"
 )
 
+// +kubebuilder:validation:Enum=Exclusive;Merge
+type IAMAuthPolicyMode string
+
+const (
+	// The policy is the only policy applied to its target.
+	IAMAuthPolicyModeExclusive IAMAuthPolicyMode = "Exclusive"
+	// The statements of the policy are merged with the other Merge policies of its target.
+	IAMAuthPolicyModeMerge IAMAuthPolicyMode = "Merge"
+)
+
 // +genclient
 // +kubebuilder:object:root=true
 
@@ -44,6 +54,16 @@ type IAMAuthPolicySpec struct {
 	// IAM auth policy content. It is a JSON string that uses the same syntax as AWS IAM policies. Please check the VPC Lattice documentation to get [the common elements in an auth policy](https://docs.aws.amazon.com/vpc-lattice/latest/ug/auth-policies.html#auth-policies-common-elements)
 	Policy string `json:"policy"`
 
+	// Mode controls how the policy is combined with other IAMAuthPolicies of the same targetRef.
+	// With "Exclusive", only one policy can be attached to the targetRef and the others are Conflicted.
+	// With "Merge", the Statement arrays of all "Merge" policies of the targetRef are merged into a single
+	// auth policy, ordered by policy creation time. Policies of the targetRef that use a different mode
+	// than the oldest policy are Conflicted.
+	//
+	// This value will be considered "Exclusive" by default.
+	// +optional
+	Mode IAMAuthPolicyMode `json:"mode,omitempty"`
+
 	// TargetRef points to the Kubernetes Gateway, HTTPRoute, or GRPCRoute resource that will have this policy attached.
 	//
 	// This field is following the guidelines of Kubernetes Gateway API policy attachment.
@@ -70,6 +90,12 @@ type IAMAuthPolicyStatus struct {
 	// +kubebuilder:validation:MaxItems=8
 	// +kubebuilder:default={{type: "Accepted", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"},{type: "Programmed", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the metadata.generation of the IAMAuthPolicy the controller last reconciled.
+	// The status does not reflect the current spec yet while it is lower than metadata.generation.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 func (p *IAMAuthPolicy) GetTargetRef() *v1alpha2.PolicyTargetReference {
@@ -80,6 +106,14 @@ func (p *IAMAuthPolicy) GetStatusConditions() *[]metav1.Condition {
 	return &p.Status.Conditions
 }
 
+func (p *IAMAuthPolicy) SetObservedGeneration(generation int64) {
+	p.Status.ObservedGeneration = generation
+}
+
+func (p *IAMAuthPolicy) MergeEnabled() bool {
+	return p.Spec.Mode == IAMAuthPolicyModeMerge
+}
+
 func (pl *IAMAuthPolicyList) GetItems() []*IAMAuthPolicy {
 	return toPtrSlice(pl.Items)
 }
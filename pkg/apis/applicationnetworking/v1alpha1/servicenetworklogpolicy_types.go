@@ -0,0 +1,103 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+)
+
+const (
+	ServiceNetworkLogPolicyKind = "ServiceNetworkLogPolicy"
+
+	// Comma-separated ARNs of the VPC Lattice Access Log Subscriptions of the policy
+	ServiceNetworkLogSubscriptionsAnnotationKey = k8s.AnnotationPrefix + "accessLogSubscriptions"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+
+// +kubebuilder:resource:categories=gateway-api,shortName=snlp
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+type ServiceNetworkLogPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceNetworkLogPolicySpec `json:"spec"`
+
+	Status ServiceNetworkLogPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// ServiceNetworkLogPolicyList contains a list of ServiceNetworkLogPolicies.
+type ServiceNetworkLogPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceNetworkLogPolicy `json:"items"`
+}
+
+// +kubebuilder:validation:Pattern=`^arn(:[a-z0-9]+([.-][a-z0-9]+)*){2}(:([a-z0-9]+([.-][a-z0-9]+)*)?){2}:([^/].*)?`
+type LogDestinationArn string
+
+// ServiceNetworkLogPolicySpec defines the desired state of ServiceNetworkLogPolicy.
+type ServiceNetworkLogPolicySpec struct {
+	// DestinationArns are the Amazon Resource Names (ARNs) of the destinations that will store the
+	// service network's access logs. Supported values are S3 Bucket, CloudWatch Log Group, and Firehose
+	// Delivery Stream ARNs. A VPC Lattice Access Log Subscription is created for each destination,
+	// and VPC Lattice allows only one destination of each type per service network.
+	//
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=3
+	DestinationArns []LogDestinationArn `json:"destinationArns"`
+
+	// TargetRef points to the kubernetes Gateway resource that will have this policy attached.
+	//
+	// This field is following the guidelines of Kubernetes Gateway API policy attachment.
+	TargetRef *v1alpha2.PolicyTargetReference `json:"targetRef"`
+}
+
+// ServiceNetworkLogPolicyStatus defines the observed state of ServiceNetworkLogPolicy.
+type ServiceNetworkLogPolicyStatus struct {
+	// Conditions describe the current conditions of the ServiceNetworkLogPolicy.
+	//
+	// Implementations should prefer to express Policy conditions
+	// using the `PolicyConditionType` and `PolicyConditionReason`
+	// constants so that operators and tools can converge on a common
+	// vocabulary to describe ServiceNetworkLogPolicy state.
+	//
+	// Known condition types are:
+	//
+	// * "Accepted"
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:default={{type: "Accepted", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the metadata.generation of the ServiceNetworkLogPolicy the controller last reconciled.
+	// The status does not reflect the current spec yet while it is lower than metadata.generation.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+func (p *ServiceNetworkLogPolicy) GetTargetRef() *v1alpha2.PolicyTargetReference {
+	return p.Spec.TargetRef
+}
+
+func (p *ServiceNetworkLogPolicy) GetStatusConditions() *[]metav1.Condition {
+	return &p.Status.Conditions
+}
+
+func (p *ServiceNetworkLogPolicy) SetObservedGeneration(generation int64) {
+	p.Status.ObservedGeneration = generation
+}
+
+func (pl *ServiceNetworkLogPolicyList) GetItems() []*ServiceNetworkLogPolicy {
+	return toPtrSlice(pl.Items)
+}
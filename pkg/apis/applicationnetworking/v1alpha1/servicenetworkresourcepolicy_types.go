@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	ServiceNetworkResourcePolicyKind = "ServiceNetworkResourcePolicy"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+
+// +kubebuilder:resource:categories=gateway-api,shortName=snrp
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+type ServiceNetworkResourcePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceNetworkResourcePolicySpec `json:"spec"`
+
+	Status ServiceNetworkResourcePolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// ServiceNetworkResourcePolicyList contains a list of ServiceNetworkResourcePolicies.
+type ServiceNetworkResourcePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceNetworkResourcePolicy `json:"items"`
+}
+
+// ServiceNetworkResourcePolicySpec defines the desired state of ServiceNetworkResourcePolicy.
+// The resource policy controls which principals can access the service network resource itself, e.g. to associate
+// their VPCs and services with a service network shared through AWS RAM. It does not authorize requests to the
+// services of the service network, which is the purpose of IAMAuthPolicy.
+type ServiceNetworkResourcePolicySpec struct {
+	// Resource policy content. It is a JSON string that uses the same syntax as AWS IAM policies. Please check the VPC Lattice documentation for [the actions supported in a resource policy](https://docs.aws.amazon.com/vpc-lattice/latest/ug/sharing.html)
+	//
+	// +kubebuilder:validation:MinLength=1
+	Policy string `json:"policy"`
+
+	// TargetRef points to the kubernetes Gateway resource that will have this policy attached.
+	//
+	// This field is following the guidelines of Kubernetes Gateway API policy attachment.
+	TargetRef *v1alpha2.PolicyTargetReference `json:"targetRef"`
+}
+
+// ServiceNetworkResourcePolicyStatus defines the observed state of ServiceNetworkResourcePolicy.
+type ServiceNetworkResourcePolicyStatus struct {
+	// Conditions describe the current conditions of the ServiceNetworkResourcePolicy.
+	//
+	// Implementations should prefer to express Policy conditions
+	// using the `PolicyConditionType` and `PolicyConditionReason`
+	// constants so that operators and tools can converge on a common
+	// vocabulary to describe ServiceNetworkResourcePolicy state.
+	//
+	// Known condition types are:
+	//
+	// * "Accepted"
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:default={{type: "Accepted", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the metadata.generation of the ServiceNetworkResourcePolicy the controller last reconciled.
+	// The status does not reflect the current spec yet while it is lower than metadata.generation.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+func (p *ServiceNetworkResourcePolicy) GetTargetRef() *v1alpha2.PolicyTargetReference {
+	return p.Spec.TargetRef
+}
+
+func (p *ServiceNetworkResourcePolicy) GetStatusConditions() *[]metav1.Condition {
+	return &p.Status.Conditions
+}
+
+func (p *ServiceNetworkResourcePolicy) SetObservedGeneration(generation int64) {
+	p.Status.ObservedGeneration = generation
+}
+
+func (pl *ServiceNetworkResourcePolicyList) GetItems() []*ServiceNetworkResourcePolicy {
+	return toPtrSlice(pl.Items)
+}
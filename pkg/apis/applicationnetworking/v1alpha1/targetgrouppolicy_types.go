@@ -3,10 +3,16 @@ package v1alpha1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 )
 
 const (
 	TargetGroupPolicyKind = "TargetGroupPolicy"
+
+	// Either "strict", the default, or "additive". In additive mode, health check settings not set by the policy
+	// keep the value of the VPC Lattice target group instead of being reset to the defaults.
+	ReconcileModeAnnotationKey = k8s.AnnotationPrefix + "reconcile-mode"
 )
 
 // +genclient
@@ -52,6 +58,14 @@ type TargetGroupPolicySpec struct {
 	// +optional
 	ProtocolVersion *string `json:"protocolVersion,omitempty"`
 
+	// The type of IP addresses of the targets, IPV4 or IPV6. Must be one of the IP families of the Service.
+	// Defaults to the primary IP family of the Service, so only one family of dual-stack Services is registered.
+	//
+	// Changes to this value results in a replacement of VPC Lattice target group.
+	// +optional
+	// +kubebuilder:validation:Enum=IPV4;IPV6
+	IpAddressType *string `json:"ipAddressType,omitempty"`
+
 	// TargetRef points to the kubernetes Service resource that will have this policy attached.
 	//
 	// This field is following the guidelines of Kubernetes Gateway API policy attachment.
@@ -95,8 +109,10 @@ type HealthCheckConfig struct {
 	// +kubebuilder:validation:Maximum=10
 	UnhealthyThresholdCount *int64 `json:"unhealthyThresholdCount,omitempty"`
 
-	// A regular expression to match HTTP status codes when checking for successful response from a target.
+	// The HTTP status codes of a successful response from a target, a comma-separated list of codes
+	// and ranges of codes between 200 and 599, e.g. "200", "200,202" or "200-399".
 	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]{3}(-[0-9]{3})?(,[0-9]{3}(-[0-9]{3})?)*$`
 	StatusMatch *string `json:"statusMatch,omitempty"`
 
 	// The destination for health checks on the targets.
@@ -138,6 +154,12 @@ type TargetGroupPolicyStatus struct {
 	// +kubebuilder:validation:MaxItems=8
 	// +kubebuilder:default={{type: "Accepted", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"},{type: "Programmed", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the metadata.generation of the TargetGroupPolicy the controller last reconciled.
+	// The status does not reflect the current spec yet while it is lower than metadata.generation.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=HTTP;HTTPS
@@ -164,6 +186,10 @@ func (p *TargetGroupPolicy) GetStatusConditions() *[]metav1.Condition {
 	return &p.Status.Conditions
 }
 
+func (p *TargetGroupPolicy) SetObservedGeneration(generation int64) {
+	p.Status.ObservedGeneration = generation
+}
+
 func (pl *TargetGroupPolicyList) GetItems() []*TargetGroupPolicy {
 	return toPtrSlice(pl.Items)
 }
@@ -56,6 +56,13 @@ type VpcAssociationPolicySpec struct {
 	// +optional
 	AssociateWithVpc *bool `json:"associateWithVpc,omitempty"`
 
+	// Tags defines the tags applied to the VpcServiceNetworkAssociation.
+	// Tags owned by the controller, such as the ManagedBy tag, cannot be overridden.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxProperties=50
+	Tags map[string]string `json:"tags,omitempty"`
+
 	// TargetRef points to the kubernetes Gateway resource that will have this policy attached.
 	//
 	// This field is following the guidelines of Kubernetes Gateway API policy attachment.
@@ -81,6 +88,12 @@ type VpcAssociationPolicyStatus struct {
 	// +kubebuilder:validation:MaxItems=8
 	// +kubebuilder:default={{type: "Accepted", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the metadata.generation of the VpcAssociationPolicy the controller last reconciled.
+	// The status does not reflect the current spec yet while it is lower than metadata.generation.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 func (p *VpcAssociationPolicy) GetTargetRef() *v1alpha2.PolicyTargetReference {
@@ -91,6 +104,10 @@ func (p *VpcAssociationPolicy) GetStatusConditions() *[]metav1.Condition {
 	return &p.Status.Conditions
 }
 
+func (p *VpcAssociationPolicy) SetObservedGeneration(generation int64) {
+	p.Status.ObservedGeneration = generation
+}
+
 func (pl *VpcAssociationPolicyList) GetItems() []*VpcAssociationPolicy {
 	return toPtrSlice(pl.Items)
 }
@@ -518,6 +518,213 @@ func (in *ServiceImportStatus) DeepCopy() *ServiceImportStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceNetworkLogPolicy) DeepCopyInto(out *ServiceNetworkLogPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceNetworkLogPolicy.
+func (in *ServiceNetworkLogPolicy) DeepCopy() *ServiceNetworkLogPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceNetworkLogPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceNetworkLogPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceNetworkLogPolicyList) DeepCopyInto(out *ServiceNetworkLogPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServiceNetworkLogPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceNetworkLogPolicyList.
+func (in *ServiceNetworkLogPolicyList) DeepCopy() *ServiceNetworkLogPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceNetworkLogPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceNetworkLogPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceNetworkLogPolicySpec) DeepCopyInto(out *ServiceNetworkLogPolicySpec) {
+	*out = *in
+	if in.DestinationArns != nil {
+		in, out := &in.DestinationArns, &out.DestinationArns
+		*out = make([]LogDestinationArn, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(v1alpha2.PolicyTargetReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceNetworkLogPolicySpec.
+func (in *ServiceNetworkLogPolicySpec) DeepCopy() *ServiceNetworkLogPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceNetworkLogPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceNetworkLogPolicyStatus) DeepCopyInto(out *ServiceNetworkLogPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceNetworkLogPolicyStatus.
+func (in *ServiceNetworkLogPolicyStatus) DeepCopy() *ServiceNetworkLogPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceNetworkLogPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceNetworkResourcePolicy) DeepCopyInto(out *ServiceNetworkResourcePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceNetworkResourcePolicy.
+func (in *ServiceNetworkResourcePolicy) DeepCopy() *ServiceNetworkResourcePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceNetworkResourcePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceNetworkResourcePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceNetworkResourcePolicyList) DeepCopyInto(out *ServiceNetworkResourcePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServiceNetworkResourcePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceNetworkResourcePolicyList.
+func (in *ServiceNetworkResourcePolicyList) DeepCopy() *ServiceNetworkResourcePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceNetworkResourcePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceNetworkResourcePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceNetworkResourcePolicySpec) DeepCopyInto(out *ServiceNetworkResourcePolicySpec) {
+	*out = *in
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(v1alpha2.PolicyTargetReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceNetworkResourcePolicySpec.
+func (in *ServiceNetworkResourcePolicySpec) DeepCopy() *ServiceNetworkResourcePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceNetworkResourcePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceNetworkResourcePolicyStatus) DeepCopyInto(out *ServiceNetworkResourcePolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceNetworkResourcePolicyStatus.
+func (in *ServiceNetworkResourcePolicyStatus) DeepCopy() *ServiceNetworkResourcePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceNetworkResourcePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServicePort) DeepCopyInto(out *ServicePort) {
 	*out = *in
@@ -610,6 +817,11 @@ func (in *TargetGroupPolicySpec) DeepCopyInto(out *TargetGroupPolicySpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.IpAddressType != nil {
+		in, out := &in.IpAddressType, &out.IpAddressType
+		*out = new(string)
+		**out = **in
+	}
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
 		*out = new(v1alpha2.PolicyTargetReference)
@@ -726,6 +938,13 @@ func (in *VpcAssociationPolicySpec) DeepCopyInto(out *VpcAssociationPolicySpec)
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
 		*out = new(v1alpha2.PolicyTargetReference)
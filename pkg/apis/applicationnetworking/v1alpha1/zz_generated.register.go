@@ -65,6 +65,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ServiceExportList{},
 		&ServiceImport{},
 		&ServiceImportList{},
+		&ServiceNetworkLogPolicy{},
+		&ServiceNetworkLogPolicyList{},
+		&ServiceNetworkResourcePolicy{},
+		&ServiceNetworkResourcePolicyList{},
 		&TargetGroupPolicy{},
 		&TargetGroupPolicyList{},
 		&VpcAssociationPolicy{},
@@ -3,16 +3,18 @@ package aws
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/request"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	"golang.org/x/exp/maps"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/metrics"
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
@@ -24,11 +26,20 @@ const (
 //go:generate mockgen -destination cloud_mocks.go -package aws github.com/aws/aws-application-networking-k8s/pkg/aws Cloud
 
 type CloudConfig struct {
-	VpcId                     string
-	AccountId                 string
-	Region                    string
-	ClusterName               string
+	VpcId       string
+	AccountId   string
+	Region      string
+	ClusterName string
+	// Distinguishes the ownership tags of controllers running in the same cluster and VPC
+	ControllerId              string
 	TaggingServiceAPIDisabled bool
+	CredentialsExpiryWindow   time.Duration
+	APITimeouts               services.APITimeouts
+	APIErrorRetries           services.ErrorRetryPolicies
+	// Roles assumed in turn with STS AssumeRole, each with the credentials of the previous one, the clients use
+	// the credentials of the last one. The external ID is passed when assuming the last role
+	AssumeRoleArns       []string
+	AssumeRoleExternalId string
 }
 
 type Cloud interface {
@@ -36,6 +47,9 @@ type Cloud interface {
 	Lattice() services.Lattice
 	Tagging() services.Tagging
 
+	// AWS credentials used by the clients, nil if not created by NewCloud
+	Credentials() *credentials.Credentials
+
 	// creates lattice tags with default values populated
 	DefaultTags() services.Tags
 
@@ -50,12 +64,21 @@ type Cloud interface {
 	TryOwnFromTags(ctx context.Context, arn string, tags services.Tags) (bool, error)
 }
 
-// NewCloud constructs new Cloud implementation.
-func NewCloud(log gwlog.Logger, cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud, error) {
-	sess, err := session.NewSession()
+// NewCloud constructs new Cloud implementation. Requests are counted by apiUsage when it is not nil.
+func NewCloud(log gwlog.Logger, cfg CloudConfig, metricsRegisterer prometheus.Registerer, apiUsage *services.APIUsageCounter) (Cloud, error) {
+	if err := config.ValidateRegion(cfg.Region); err != nil {
+		return nil, err
+	}
+	sess, err := newSession(cfg.Region, cfg.CredentialsExpiryWindow)
 	if err != nil {
 		return nil, err
 	}
+	if len(cfg.AssumeRoleArns) > 0 {
+		sess, err = assumeRoles(sess, cfg.AssumeRoleArns, cfg.AssumeRoleExternalId, cfg.CredentialsExpiryWindow)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	sess.Handlers.Complete.PushFront(func(r *request.Request) {
 		if r.Error != nil {
@@ -81,6 +104,12 @@ func NewCloud(log gwlog.Logger, cfg CloudConfig, metricsRegisterer prometheus.Re
 		}
 		metricsCollector.InjectHandlers(&sess.Handlers)
 	}
+	if apiUsage != nil {
+		apiUsage.InjectHandlers(&sess.Handlers)
+	}
+	injectTracingHandlers(&sess.Handlers)
+	cfg.APITimeouts.InjectHandlers(&sess.Handlers)
+	cfg.APIErrorRetries.InjectHandlers(&sess.Handlers)
 
 	lattice := services.NewDefaultLattice(sess, cfg.AccountId, cfg.Region)
 	var tagging services.Tagging
@@ -91,7 +120,13 @@ func NewCloud(log gwlog.Logger, cfg CloudConfig, metricsRegisterer prometheus.Re
 		tagging = services.NewDefaultTagging(sess, cfg.Region)
 	}
 
-	cl := NewDefaultCloudWithTagging(lattice, tagging, cfg)
+	cl := &defaultCloud{
+		cfg:          cfg,
+		lattice:      lattice,
+		tagging:      tagging,
+		creds:        sess.Config.Credentials,
+		managedByTag: getManagedByTag(cfg),
+	}
 	return cl, nil
 }
 
@@ -117,6 +152,7 @@ type defaultCloud struct {
 	cfg          CloudConfig
 	lattice      services.Lattice
 	tagging      services.Tagging
+	creds        *credentials.Credentials
 	managedByTag string
 }
 
@@ -128,6 +164,10 @@ func (c *defaultCloud) Tagging() services.Tagging {
 	return c.tagging
 }
 
+func (c *defaultCloud) Credentials() *credentials.Credentials {
+	return c.creds
+}
+
 func (c *defaultCloud) Config() CloudConfig {
 	return c.cfg
 }
@@ -204,5 +244,9 @@ func (c *defaultCloud) isOwner(managedBy string) bool {
 }
 
 func getManagedByTag(cfg CloudConfig) string {
-	return fmt.Sprintf("%s/%s/%s", cfg.AccountId, cfg.ClusterName, cfg.VpcId)
+	managedBy := fmt.Sprintf("%s/%s/%s", cfg.AccountId, cfg.ClusterName, cfg.VpcId)
+	if cfg.ControllerId != "" {
+		managedBy += "/" + cfg.ControllerId
+	}
+	return managedBy
 }
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	services "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	credentials "github.com/aws/aws-sdk-go/aws/credentials"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -49,6 +50,20 @@ func (mr *MockCloudMockRecorder) Config() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockCloud)(nil).Config))
 }
 
+// Credentials mocks base method.
+func (m *MockCloud) Credentials() *credentials.Credentials {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Credentials")
+	ret0, _ := ret[0].(*credentials.Credentials)
+	return ret0
+}
+
+// Credentials indicates an expected call of Credentials.
+func (mr *MockCloudMockRecorder) Credentials() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Credentials", reflect.TypeOf((*MockCloud)(nil).Credentials))
+}
+
 // DefaultTags mocks base method.
 func (m *MockCloud) DefaultTags() map[string]*string {
 	m.ctrl.T.Helper()
@@ -13,6 +13,7 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
 func TestGetManagedByTag(t *testing.T) {
@@ -27,10 +28,50 @@ func TestGetManagedByTag(t *testing.T) {
 		assert.Equal(t, "acc/cluster/vpc", tag)
 	})
 
+	t.Run("account, cluster name, vpc and controller id", func(t *testing.T) {
+		cfg := CloudConfig{
+			AccountId:    "acc",
+			VpcId:        "vpc",
+			ClusterName:  "cluster",
+			ControllerId: "blue",
+		}
+		tag := getManagedByTag(cfg)
+		assert.Equal(t, "acc/cluster/vpc/blue", tag)
+	})
+
+}
+
+func TestNewCloud_Region(t *testing.T) {
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("LATTICE_ENDPOINT", "")
+
+	t.Run("explicit region", func(t *testing.T) {
+		cl, err := NewCloud(gwlog.FallbackLogger, CloudConfig{
+			VpcId:     "vpc-id",
+			AccountId: "account-id",
+			Region:    "eu-west-1",
+		}, nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "eu-west-1", cl.Config().Region)
+
+		req, _ := cl.Lattice().ListServiceNetworksRequest(&vpclattice.ListServiceNetworksInput{})
+		assert.Equal(t, "eu-west-1", aws.StringValue(req.Config.Region))
+		assert.Equal(t, "https://vpc-lattice.eu-west-1.amazonaws.com", req.ClientInfo.Endpoint)
+	})
+
+	t.Run("invalid region", func(t *testing.T) {
+		_, err := NewCloud(gwlog.FallbackLogger, CloudConfig{Region: "eu-west"}, nil, nil)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("missing region", func(t *testing.T) {
+		_, err := NewCloud(gwlog.FallbackLogger, CloudConfig{}, nil, nil)
+		assert.NotNil(t, err)
+	})
 }
 
 func TestDefaultTags(t *testing.T) {
-	cfg := CloudConfig{"acc", "vpc", "region", "cluster", false}
+	cfg := CloudConfig{"acc", "vpc", "region", "cluster", "", false, 0, services.APITimeouts{}, nil, nil, ""}
 	c := NewDefaultCloud(nil, cfg)
 	tags := c.DefaultTags()
 	tagWant := getManagedByTag(cfg)
@@ -195,3 +236,55 @@ func Test_TryOwnFromTags(t *testing.T) {
 		})
 	}
 }
+
+func Test_ControllerIdOwnership(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	mockLattice := services.NewMockLattice(c)
+	cfg := CloudConfig{VpcId: "vpc-id", AccountId: "account-id", ClusterName: "cluster"}
+	blueCfg, greenCfg := cfg, cfg
+	blueCfg.ControllerId = "blue"
+	greenCfg.ControllerId = "green"
+	blue := NewDefaultCloud(mockLattice, blueCfg)
+	green := NewDefaultCloud(mockLattice, greenCfg)
+	unscoped := NewDefaultCloud(mockLattice, cfg)
+
+	assert.Equal(t, "account-id/cluster/vpc-id/blue", *blue.DefaultTags()[TagManagedBy])
+
+	tcs := []struct {
+		name  string
+		tags  services.Tags
+		owned bool
+	}{
+		{
+			name:  "own resource",
+			tags:  blue.DefaultTags(),
+			owned: true,
+		},
+		{
+			name:  "resource of another controller id",
+			tags:  green.DefaultTags(),
+			owned: false,
+		},
+		{
+			name:  "resource of controller without id",
+			tags:  unscoped.DefaultTags(),
+			owned: false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			owned, err := blue.TryOwnFromTags(context.Background(), "arn", tc.tags)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.owned, owned)
+
+			mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
+				Return(&vpclattice.ListTagsForResourceOutput{Tags: tc.tags}, nil)
+			managed, err := blue.IsArnManaged(context.Background(), "arn")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.owned, managed)
+		})
+	}
+}
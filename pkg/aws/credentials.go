@@ -0,0 +1,123 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const credentialsCheckInterval = time.Minute
+
+// Creates the AWS session in the given region, instead of the one of the environment or shared config.
+// Web identity (IRSA) credentials are refreshed expiryWindow before they expire, instead of being used until
+// the last moment, so in-flight requests are not signed with expired credentials.
+func newSession(region string, expiryWindow time.Duration) (*session.Session, error) {
+	return session.NewSessionWithOptions(session.Options{
+		Config:            aws.Config{Region: aws.String(region)},
+		SharedConfigState: session.SharedConfigStateFromEnv,
+		CredentialsProviderOptions: &session.CredentialsProviderOptions{
+			WebIdentityRoleProviderOptions: func(p *stscreds.WebIdentityRoleProvider) {
+				p.ExpiryWindow = expiryWindow
+			},
+		},
+	})
+}
+
+// assumeRoles returns a copy of the session signing requests with the credentials of the last of the given roles,
+// assumed in turn with the credentials of the previous role, the first one with the credentials of the session.
+// Credentials of every role are refreshed expiryWindow before they expire.
+func assumeRoles(sess *session.Session, roleArns []string, externalId string, expiryWindow time.Duration) (*session.Session, error) {
+	if err := ValidateAssumeRoleArns(roleArns); err != nil {
+		return nil, err
+	}
+	creds := sess.Config.Credentials
+	for i, roleArn := range roleArns {
+		last := i == len(roleArns)-1
+		stsSess := sess.Copy(&aws.Config{Credentials: creds})
+		creds = stscreds.NewCredentials(stsSess, roleArn, func(p *stscreds.AssumeRoleProvider) {
+			p.ExpiryWindow = expiryWindow
+			if last && externalId != "" {
+				p.ExternalID = aws.String(externalId)
+			}
+		})
+	}
+	return sess.Copy(&aws.Config{Credentials: creds}), nil
+}
+
+// ValidateAssumeRoleArns checks that the given ARNs are IAM role ARNs
+func ValidateAssumeRoleArns(roleArns []string) error {
+	for _, roleArn := range roleArns {
+		parsed, err := arn.Parse(roleArn)
+		if err != nil {
+			return fmt.Errorf("invalid role ARN %s: %w", roleArn, err)
+		}
+		if parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+			return fmt.Errorf("invalid role ARN %s: not an IAM role", roleArn)
+		}
+	}
+	return nil
+}
+
+// CredentialsMonitor periodically retrieves the AWS credentials, refreshing them if they are about to expire,
+// and logs an error when they cannot be refreshed before they expire.
+type CredentialsMonitor struct {
+	log      gwlog.Logger
+	creds    *credentials.Credentials
+	interval time.Duration
+}
+
+func NewCredentialsMonitor(log gwlog.Logger, creds *credentials.Credentials) *CredentialsMonitor {
+	return &CredentialsMonitor{
+		log:      log,
+		creds:    creds,
+		interval: credentialsCheckInterval,
+	}
+}
+
+func (m *CredentialsMonitor) Start(ctx context.Context) error {
+	if m.creds == nil {
+		return nil
+	}
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		if err := m.check(ctx); err != nil {
+			m.log.Errorf(ctx, "AWS credentials check failed: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Credentials are checked on all replicas, so a replica taking over leadership has valid credentials.
+func (m *CredentialsMonitor) NeedLeaderElection() bool {
+	return false
+}
+
+func (m *CredentialsMonitor) check(ctx context.Context) error {
+	if _, err := m.creds.GetWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	expiresAt, err := m.creds.ExpiresAt()
+	if err != nil {
+		// credentials without expiry, e.g. static credentials
+		return nil
+	}
+	if remaining := time.Until(expiresAt); remaining < m.interval {
+		return fmt.Errorf("credentials were not refreshed and expire in %s", remaining.Round(time.Second))
+	}
+	m.log.Debugf(ctx, "AWS credentials valid until %s", expiresAt)
+	return nil
+}
@@ -0,0 +1,134 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// returns credentials expiring after validity, refreshed expiryWindow before expiry
+type expiringProvider struct {
+	credentials.Expiry
+	validity     time.Duration
+	expiryWindow time.Duration
+	retrieved    int
+	err          error
+}
+
+func (p *expiringProvider) Retrieve() (credentials.Value, error) {
+	if p.err != nil {
+		return credentials.Value{}, p.err
+	}
+	p.retrieved++
+	p.SetExpiration(time.Now().Add(p.validity), p.expiryWindow)
+	return credentials.Value{AccessKeyID: "key", SecretAccessKey: "secret", ProviderName: "test"}, nil
+}
+
+func TestCredentialsMonitor_RefreshesExpiringCredentials(t *testing.T) {
+	ctx := context.TODO()
+	provider := &expiringProvider{validity: time.Hour, expiryWindow: 5 * time.Minute}
+	m := NewCredentialsMonitor(gwlog.FallbackLogger, credentials.NewCredentials(provider))
+
+	assert.Nil(t, m.check(ctx))
+	assert.Nil(t, m.check(ctx))
+	assert.Equal(t, 1, provider.retrieved)
+
+	// credentials entered the expiry window
+	provider.SetExpiration(time.Now().Add(time.Minute), 5*time.Minute)
+	assert.Nil(t, m.check(ctx))
+	assert.Equal(t, 2, provider.retrieved)
+}
+
+func TestCredentialsMonitor_ReportsCredentialsNotRefreshed(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("refresh fails", func(t *testing.T) {
+		provider := &expiringProvider{validity: time.Hour}
+		m := NewCredentialsMonitor(gwlog.FallbackLogger, credentials.NewCredentials(provider))
+		assert.Nil(t, m.check(ctx))
+
+		provider.SetExpiration(time.Now().Add(-time.Second), 0)
+		provider.err = errors.New("ExpiredTokenException")
+		assert.ErrorContains(t, m.check(ctx), "ExpiredTokenException")
+	})
+
+	t.Run("refreshed credentials expire before next check", func(t *testing.T) {
+		provider := &expiringProvider{validity: 30 * time.Second}
+		m := NewCredentialsMonitor(gwlog.FallbackLogger, credentials.NewCredentials(provider))
+		assert.ErrorContains(t, m.check(ctx), "not refreshed")
+	})
+
+	t.Run("static credentials", func(t *testing.T) {
+		m := NewCredentialsMonitor(gwlog.FallbackLogger, credentials.NewStaticCredentials("key", "secret", ""))
+		assert.Nil(t, m.check(ctx))
+	})
+}
+
+// answers STS AssumeRole requests with credentials whose access key is made of the account of the role,
+// recording the role ARN, external ID and access key the requests are signed with
+type fakeSTS struct {
+	requests []url.Values
+	signedBy []string
+}
+
+func (s *fakeSTS) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	s.requests = append(s.requests, req.PostForm)
+	s.signedBy = append(s.signedBy, regexp.MustCompile(`Credential=([^/]+)/`).FindStringSubmatch(req.Header.Get("Authorization"))[1])
+	fmt.Fprintf(w, `<AssumeRoleResponse><AssumeRoleResult><Credentials>
+<AccessKeyId>%s</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken>
+<Expiration>%s</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`,
+		"key-"+strings.Split(req.PostForm.Get("RoleArn"), ":")[4], time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+}
+
+func TestAssumeRoles(t *testing.T) {
+	sts := &fakeSTS{}
+	srv := httptest.NewServer(sts)
+	defer srv.Close()
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-west-2"),
+		Endpoint:    aws.String(srv.URL),
+		Credentials: credentials.NewStaticCredentials("pod-key", "secret", ""),
+	})
+	assert.Nil(t, err)
+
+	roles := []string{"arn:aws:iam::111111111111:role/hub", "arn:aws:iam::222222222222:role/lattice"}
+	assumed, err := assumeRoles(sess, roles, "external-id", 5*time.Minute)
+	assert.Nil(t, err)
+
+	value, err := assumed.Config.Credentials.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "key-222222222222", value.AccessKeyID)
+
+	assert.Len(t, sts.requests, 2)
+	assert.Equal(t, roles[0], sts.requests[0].Get("RoleArn"))
+	assert.Equal(t, "", sts.requests[0].Get("ExternalId"))
+	assert.Equal(t, roles[1], sts.requests[1].Get("RoleArn"))
+	assert.Equal(t, "external-id", sts.requests[1].Get("ExternalId"))
+	// every role is assumed with the credentials of the previous one
+	assert.Equal(t, []string{"pod-key", "key-111111111111"}, sts.signedBy)
+}
+
+func TestValidateAssumeRoleArns(t *testing.T) {
+	assert.Nil(t, ValidateAssumeRoleArns([]string{"arn:aws:iam::111111111111:role/lattice", "arn:aws:iam::111111111111:role/path/lattice"}))
+	assert.NotNil(t, ValidateAssumeRoleArns([]string{"lattice"}))
+	assert.NotNil(t, ValidateAssumeRoleArns([]string{"arn:aws:iam::111111111111:user/lattice"}))
+	assert.NotNil(t, ValidateAssumeRoleArns([]string{"arn:aws:s3:::bucket"}))
+
+	_, err := NewCloud(gwlog.FallbackLogger, CloudConfig{Region: "us-west-2", AssumeRoleArns: []string{"lattice"}}, nil, nil)
+	assert.NotNil(t, err)
+}
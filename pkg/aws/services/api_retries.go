@@ -0,0 +1,140 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+)
+
+const (
+	sdkHandlerAPIErrorRetries = "apiErrorRetries"
+
+	// upper bound of the backoff between two attempts, whatever the retry policy
+	maxErrorRetryDelay = 20 * time.Second
+)
+
+// RetryPolicy decides how VPC Lattice requests failing with an error code are retried. Retries wait
+// Backoff, doubled on every retry, with jitter. A zero MaxRetries does not retry the error.
+type RetryPolicy struct {
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+// ErrorRetryPolicies are the retry policies of VPC Lattice error codes, e.g. ThrottlingException. Errors
+// without a policy are retried by the SDK retryer of the client.
+type ErrorRetryPolicies map[string]RetryPolicy
+
+// DefaultErrorRetryPolicies keeps retrying throttled requests, with a backoff long enough to let the
+// request rate recover, retries server errors a few times only as reconciles are requeued anyway, and
+// does not retry exceeded quotas, which only a quota increase can fix.
+func DefaultErrorRetryPolicies() ErrorRetryPolicies {
+	return ErrorRetryPolicies{
+		vpclattice.ErrCodeThrottlingException:           {MaxRetries: 20, Backoff: 500 * time.Millisecond},
+		vpclattice.ErrCodeInternalServerException:       {MaxRetries: 5, Backoff: 100 * time.Millisecond},
+		vpclattice.ErrCodeServiceQuotaExceededException: {MaxRetries: 0},
+	}
+}
+
+// ParseErrorRetryPolicies parses comma-separated error code retry policies, e.g.
+// ThrottlingException=10:1s,ConflictException=3:2s, layered over DefaultErrorRetryPolicies.
+func ParseErrorRetryPolicies(policies string) (ErrorRetryPolicies, error) {
+	retries := DefaultErrorRetryPolicies()
+	for _, entry := range strings.Split(policies, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		code, value, ok := strings.Cut(entry, "=")
+		code = strings.TrimSpace(code)
+		if !ok || code == "" {
+			return nil, fmt.Errorf("invalid error retry policy %s, must be <error code>=<max retries>:<backoff>", entry)
+		}
+		maxRetries, backoff, ok := strings.Cut(strings.TrimSpace(value), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid retry policy of error code %s: %s, must be <max retries>:<backoff>", code, value)
+		}
+		policy := RetryPolicy{}
+		var err error
+		if policy.MaxRetries, err = strconv.Atoi(maxRetries); err != nil || policy.MaxRetries < 0 {
+			return nil, fmt.Errorf("invalid max retries of error code %s: %s", code, maxRetries)
+		}
+		if policy.Backoff, err = time.ParseDuration(backoff); err != nil || policy.Backoff < 0 {
+			return nil, fmt.Errorf("invalid backoff of error code %s: %s", code, backoff)
+		}
+		retries[code] = policy
+	}
+	return retries, nil
+}
+
+// InjectHandlers applies the retry policies to VPC Lattice requests, by wrapping their retryer before they are sent.
+func (p ErrorRetryPolicies) InjectHandlers(handlers *request.Handlers) {
+	if len(p) == 0 {
+		return
+	}
+	handlers.Validate.PushBackNamed(request.NamedHandler{
+		Name: sdkHandlerAPIErrorRetries,
+		Fn: func(r *request.Request) {
+			if r.ClientInfo.ServiceID != vpclattice.ServiceID || r.Retryer == nil {
+				return
+			}
+			r.Retryer = errorCodeRetryer{Retryer: r.Retryer, policies: p}
+		},
+	})
+}
+
+type errorCodeRetryer struct {
+	request.Retryer
+	policies ErrorRetryPolicies
+}
+
+func (e errorCodeRetryer) policy(r *request.Request) (RetryPolicy, bool) {
+	aerr, ok := r.Error.(awserr.Error)
+	if !ok {
+		return RetryPolicy{}, false
+	}
+	policy, ok := e.policies[aerr.Code()]
+	return policy, ok
+}
+
+// MaxRetries is the most retries of any error, as the SDK stops retrying once a request exceeds it
+func (e errorCodeRetryer) MaxRetries() int {
+	maxRetries := e.Retryer.MaxRetries()
+	for _, policy := range e.policies {
+		if policy.MaxRetries > maxRetries {
+			maxRetries = policy.MaxRetries
+		}
+	}
+	return maxRetries
+}
+
+func (e errorCodeRetryer) ShouldRetry(r *request.Request) bool {
+	if policy, ok := e.policy(r); ok {
+		return r.RetryCount < policy.MaxRetries
+	}
+	return r.RetryCount < e.Retryer.MaxRetries() && e.Retryer.ShouldRetry(r)
+}
+
+func (e errorCodeRetryer) RetryRules(r *request.Request) time.Duration {
+	policy, ok := e.policy(r)
+	if !ok {
+		return e.Retryer.RetryRules(r)
+	}
+	delay := policy.Backoff
+	for i := 0; i < r.RetryCount && delay < maxErrorRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxErrorRetryDelay {
+		delay = maxErrorRetryDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// between half and the full delay, so throttled controllers do not retry in lockstep
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
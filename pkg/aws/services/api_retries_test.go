@@ -0,0 +1,161 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseErrorRetryPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies string
+		want     ErrorRetryPolicies
+		wantErr  string
+	}{
+		{
+			name: "defaults",
+			want: DefaultErrorRetryPolicies(),
+		},
+		{
+			name:     "policies override defaults",
+			policies: "ThrottlingException=10:1s, ConflictException=3:2s,",
+			want: ErrorRetryPolicies{
+				vpclattice.ErrCodeThrottlingException:           {MaxRetries: 10, Backoff: time.Second},
+				vpclattice.ErrCodeInternalServerException:       {MaxRetries: 5, Backoff: 100 * time.Millisecond},
+				vpclattice.ErrCodeServiceQuotaExceededException: {MaxRetries: 0},
+				vpclattice.ErrCodeConflictException:             {MaxRetries: 3, Backoff: 2 * time.Second},
+			},
+		},
+		{
+			name:     "missing policy",
+			policies: "ConflictException",
+			wantErr:  "invalid error retry policy ConflictException, must be <error code>=<max retries>:<backoff>",
+		},
+		{
+			name:     "missing backoff",
+			policies: "ConflictException=3",
+			wantErr:  "invalid retry policy of error code ConflictException: 3, must be <max retries>:<backoff>",
+		},
+		{
+			name:     "invalid max retries",
+			policies: "ConflictException=-1:1s",
+			wantErr:  "invalid max retries of error code ConflictException: -1",
+		},
+		{
+			name:     "invalid backoff",
+			policies: "ConflictException=3:soon",
+			wantErr:  "invalid backoff of error code ConflictException: soon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies, err := ParseErrorRetryPolicies(tt.policies)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, policies)
+		})
+	}
+}
+
+func TestErrorRetryPolicies_InjectHandlers(t *testing.T) {
+	policies := ErrorRetryPolicies{
+		vpclattice.ErrCodeThrottlingException: {MaxRetries: 4, Backoff: 100 * time.Millisecond},
+		vpclattice.ErrCodeConflictException:   {MaxRetries: 1, Backoff: time.Second},
+	}
+
+	tests := []struct {
+		name         string
+		status       int
+		errorCode    string
+		wantAttempts int
+		wantDelays   []time.Duration
+	}{
+		{
+			name:         "throttling is retried with its backoff",
+			status:       http.StatusTooManyRequests,
+			errorCode:    vpclattice.ErrCodeThrottlingException,
+			wantAttempts: 5,
+			wantDelays:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond},
+		},
+		{
+			name:         "conflict is retried once",
+			status:       http.StatusConflict,
+			errorCode:    vpclattice.ErrCodeConflictException,
+			wantAttempts: 2,
+			wantDelays:   []time.Duration{time.Second},
+		},
+		{
+			name:         "errors without a policy are retried by the client retryer",
+			status:       http.StatusBadRequest,
+			errorCode:    vpclattice.ErrCodeValidationException,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.Header().Set("X-Amzn-Errortype", tt.errorCode)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"message":"failed"}`))
+			}))
+			defer server.Close()
+
+			var delays []time.Duration
+			sess := session.Must(session.NewSession(&aws.Config{
+				Region:      aws.String("us-west-2"),
+				Endpoint:    aws.String(server.URL),
+				Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+				SleepDelay:  func(d time.Duration) { delays = append(delays, d) },
+			}))
+			policies.InjectHandlers(&sess.Handlers)
+			latticeClient := vpclattice.New(sess, aws.NewConfig().WithMaxRetries(2))
+
+			_, err := latticeClient.GetServiceNetwork(&vpclattice.GetServiceNetworkInput{
+				ServiceNetworkIdentifier: aws.String("sn-id"),
+			})
+			var aerr awserr.Error
+			assert.ErrorAs(t, err, &aerr)
+			assert.Equal(t, tt.errorCode, aerr.Code())
+			assert.Equal(t, tt.wantAttempts, attempts)
+			assert.Len(t, delays, len(tt.wantDelays))
+			for i, delay := range delays {
+				assert.GreaterOrEqual(t, delay, tt.wantDelays[i]/2)
+				assert.LessOrEqual(t, delay, tt.wantDelays[i])
+			}
+		})
+	}
+}
+
+func TestErrorCodeRetryer_BackoffIsBounded(t *testing.T) {
+	retryer := errorCodeRetryer{
+		Retryer: client.DefaultRetryer{NumMaxRetries: 3},
+		policies: ErrorRetryPolicies{
+			vpclattice.ErrCodeThrottlingException: {MaxRetries: 100, Backoff: time.Second},
+		},
+	}
+	r := &request.Request{
+		Error:      awserr.New(vpclattice.ErrCodeThrottlingException, "throttled", nil),
+		RetryCount: 60,
+	}
+	assert.LessOrEqual(t, retryer.RetryRules(r), maxErrorRetryDelay)
+	assert.True(t, retryer.ShouldRetry(r))
+	assert.Equal(t, 100, retryer.MaxRetries())
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+)
+
+const sdkHandlerAPITimeout = "apiTimeout"
+
+// APITimeouts bounds the duration of VPC Lattice API requests, retries included. Operations can override
+// the default timeout, e.g. RegisterTargets of large target groups takes longer than putting a policy.
+// A zero timeout does not bound requests.
+type APITimeouts struct {
+	Default    time.Duration
+	Operations map[string]time.Duration
+}
+
+// ParseAPITimeouts parses comma-separated operation timeouts, e.g. RegisterTargets=2m,PutAuthPolicy=10s,
+// layered over the default timeout.
+func ParseAPITimeouts(defaultTimeout time.Duration, operations string) (APITimeouts, error) {
+	timeouts := APITimeouts{
+		Default:    defaultTimeout,
+		Operations: make(map[string]time.Duration),
+	}
+	for _, entry := range strings.Split(operations, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		operation, value, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(operation) == "" {
+			return APITimeouts{}, fmt.Errorf("invalid operation timeout %s, must be <operation>=<duration>", entry)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || timeout < 0 {
+			return APITimeouts{}, fmt.Errorf("invalid timeout of operation %s: %s", operation, value)
+		}
+		timeouts.Operations[strings.TrimSpace(operation)] = timeout
+	}
+	return timeouts, nil
+}
+
+// Timeout returns the timeout of the given operation, e.g. RegisterTargets.
+func (t APITimeouts) Timeout(operation string) time.Duration {
+	if timeout, ok := t.Operations[operation]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// InjectHandlers sets the timeout of the operation on the request context before it is sent,
+// the timeout covers all attempts of the request.
+func (t APITimeouts) InjectHandlers(handlers *request.Handlers) {
+	handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: sdkHandlerAPITimeout,
+		Fn: func(r *request.Request) {
+			if r.ClientInfo.ServiceID != vpclattice.ServiceID || r.Operation == nil {
+				return
+			}
+			timeout := t.Timeout(r.Operation.Name)
+			if timeout <= 0 {
+				return
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			r.SetContext(ctx)
+			r.Handlers.Complete.PushBack(func(*request.Request) { cancel() })
+		},
+	})
+}
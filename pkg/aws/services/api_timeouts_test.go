@@ -0,0 +1,136 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAPITimeouts(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations string
+		want       map[string]time.Duration
+		wantErr    string
+	}{
+		{
+			name: "no operation timeouts",
+			want: map[string]time.Duration{},
+		},
+		{
+			name:       "operation timeouts",
+			operations: "RegisterTargets=2m, PutAuthPolicy=10s,",
+			want: map[string]time.Duration{
+				"RegisterTargets": 2 * time.Minute,
+				"PutAuthPolicy":   10 * time.Second,
+			},
+		},
+		{
+			name:       "missing duration",
+			operations: "RegisterTargets",
+			wantErr:    "invalid operation timeout RegisterTargets, must be <operation>=<duration>",
+		},
+		{
+			name:       "invalid duration",
+			operations: "RegisterTargets=2 minutes",
+			wantErr:    "invalid timeout of operation RegisterTargets: 2 minutes",
+		},
+		{
+			name:       "negative duration",
+			operations: "RegisterTargets=-1s",
+			wantErr:    "invalid timeout of operation RegisterTargets: -1s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeouts, err := ParseAPITimeouts(time.Minute, tt.operations)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, time.Minute, timeouts.Default)
+			assert.Equal(t, tt.want, timeouts.Operations)
+		})
+	}
+}
+
+func TestAPITimeouts_InjectHandlers(t *testing.T) {
+	timeouts := APITimeouts{
+		Default: 10 * time.Second,
+		Operations: map[string]time.Duration{
+			"RegisterTargets": 2 * time.Minute,
+			"PutAuthPolicy":   0,
+		},
+	}
+	handlers := request.Handlers{}
+	timeouts.InjectHandlers(&handlers)
+
+	newRequest := func(serviceId, op string) *request.Request {
+		httpReq, _ := http.NewRequest("POST", "https://vpc-lattice.us-west-2.amazonaws.com", nil)
+		return &request.Request{
+			ClientInfo:  metadata.ClientInfo{ServiceID: serviceId},
+			Operation:   &request.Operation{Name: op},
+			HTTPRequest: httpReq,
+			Handlers:    request.Handlers{},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		serviceId   string
+		op          string
+		wantTimeout time.Duration
+	}{
+		{
+			name:        "slow operation gets its longer timeout",
+			serviceId:   "VPC Lattice",
+			op:          "RegisterTargets",
+			wantTimeout: 2 * time.Minute,
+		},
+		{
+			name:        "other operations get the default timeout",
+			serviceId:   "VPC Lattice",
+			op:          "CreateRule",
+			wantTimeout: 10 * time.Second,
+		},
+		{
+			name:      "zero timeout does not bound the operation",
+			serviceId: "VPC Lattice",
+			op:        "PutAuthPolicy",
+		},
+		{
+			name:      "other services are not bounded",
+			serviceId: "Resource Groups Tagging API",
+			op:        "GetResources",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRequest(tt.serviceId, tt.op)
+			start := time.Now()
+			handlers.Validate.Run(r)
+
+			deadline, ok := r.Context().Deadline()
+			if tt.wantTimeout == 0 {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.WithinDuration(t, start.Add(tt.wantTimeout), deadline, time.Second)
+			httpDeadline, _ := r.HTTPRequest.Context().Deadline()
+			assert.Equal(t, deadline, httpDeadline)
+
+			// the context is released once the request completes
+			assert.Nil(t, r.Context().Err())
+			r.Handlers.Complete.Run(r)
+			assert.NotNil(t, r.Context().Err())
+		})
+	}
+}
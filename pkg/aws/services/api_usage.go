@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	APIUsageLogInterval = 15 * time.Minute
+
+	sdkHandlerCountAPIUsage = "countAPIUsage"
+)
+
+// APIUsageCounter counts the AWS API requests made by the controller per operation, and periodically
+// logs the counts of the last window, to help sizing service quota increase requests.
+type APIUsageCounter struct {
+	log      gwlog.Logger
+	interval time.Duration
+
+	lock        sync.Mutex
+	counts      map[string]int
+	windowStart time.Time
+	// requests counted since the counter was created, across windows
+	total int
+}
+
+func NewAPIUsageCounter(log gwlog.Logger, interval time.Duration) *APIUsageCounter {
+	return &APIUsageCounter{
+		log:         log,
+		interval:    interval,
+		counts:      make(map[string]int),
+		windowStart: time.Now(),
+	}
+}
+
+// Every request attempt is counted, retries included, as they count against the rate quotas too.
+func (c *APIUsageCounter) InjectHandlers(handlers *request.Handlers) {
+	handlers.CompleteAttempt.PushBackNamed(request.NamedHandler{
+		Name: sdkHandlerCountAPIUsage,
+		Fn: func(r *request.Request) {
+			c.Count(r.ClientInfo.ServiceID + "/" + r.Operation.Name)
+		},
+	})
+}
+
+func (c *APIUsageCounter) Count(operation string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.counts[operation]++
+	c.total++
+}
+
+// Total returns the number of requests counted since the counter was created.
+func (c *APIUsageCounter) Total() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.total
+}
+
+// Flush logs the counts of the current window and starts a new one.
+func (c *APIUsageCounter) Flush(ctx context.Context) {
+	c.lock.Lock()
+	counts, windowStart := c.counts, c.windowStart
+	c.counts = make(map[string]int)
+	c.windowStart = time.Now()
+	c.lock.Unlock()
+
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	c.log.Infow(ctx, "AWS API usage",
+		"window", time.Since(windowStart).Round(time.Second).String(),
+		"total", total,
+		"operations", counts,
+	)
+}
+
+func (c *APIUsageCounter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			c.Flush(context.Background())
+			return nil
+		case <-ticker.C:
+			c.Flush(ctx)
+		}
+	}
+}
+
+// Every replica counts its own requests, including the ones it makes while not leader.
+func (c *APIUsageCounter) NeedLeaderElection() bool {
+	return false
+}
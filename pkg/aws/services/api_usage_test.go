@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestAPIUsageCounter(t *testing.T) {
+	ctx := context.TODO()
+	core, logs := observer.New(zap.InfoLevel)
+	counter := NewAPIUsageCounter(&gwlog.TracedLogger{InnerLogger: zap.New(core).Sugar()}, time.Minute)
+
+	handlers := request.Handlers{}
+	counter.InjectHandlers(&handlers)
+	for _, op := range []string{"ListServices", "ListServices", "CreateService"} {
+		handlers.CompleteAttempt.Run(&request.Request{
+			ClientInfo: metadata.ClientInfo{ServiceID: "VPC Lattice"},
+			Operation:  &request.Operation{Name: op},
+		})
+	}
+	counter.Count("VPC Lattice/ListServices")
+
+	counter.Flush(ctx)
+	entries := logs.TakeAll()
+	assert.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.EqualValues(t, 4, fields["total"])
+	assert.Equal(t, map[string]int{
+		"VPC Lattice/ListServices":  3,
+		"VPC Lattice/CreateService": 1,
+	}, fields["operations"])
+
+	// counts are reset on flush
+	counter.Flush(ctx)
+	entries = logs.TakeAll()
+	assert.Len(t, entries, 1)
+	assert.EqualValues(t, 0, entries[0].ContextMap()["total"])
+	// unlike the window counts, the total is kept
+	assert.Equal(t, 4, counter.Total())
+}
@@ -0,0 +1,1203 @@
+// Package latticefake provides an in-memory implementation of services.Lattice for tests.
+//
+// The fake keeps services, service networks, service network service associations, listeners,
+// rules, target groups, targets and tags in memory, and records every call made through it.
+// Only the operations used by the controller are implemented, calling any other method panics.
+package latticefake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/aws/aws-sdk-go/service/vpclattice/vpclatticeiface"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+)
+
+// Call is a single recorded call to the fake.
+type Call struct {
+	Method string
+	Input  interface{}
+}
+
+type rule struct {
+	listenerId string
+	rule       *vpclattice.GetRuleOutput
+}
+
+// Lattice is an in-memory services.Lattice. The zero value is not usable, use New.
+type Lattice struct {
+	// unimplemented operations panic on the nil embedded interface
+	vpclatticeiface.VPCLatticeAPI
+
+	account string
+	region  string
+
+	mu              sync.Mutex
+	nextId          int
+	calls           []Call
+	errors          map[string]error
+	serviceNetworks map[string]*vpclattice.ServiceNetworkSummary
+	services        map[string]*vpclattice.GetServiceOutput
+	associations    map[string]*vpclattice.ServiceNetworkServiceAssociationSummary
+	listeners       map[string]*vpclattice.GetListenerOutput
+	rules           map[string]*rule
+	targetGroups    map[string]*vpclattice.GetTargetGroupOutput
+	targets         map[string][]*vpclattice.TargetSummary
+	tags            map[string]services.Tags
+}
+
+var _ services.Lattice = &Lattice{}
+
+func New(account string, region string) *Lattice {
+	return &Lattice{
+		account:         account,
+		region:          region,
+		errors:          make(map[string]error),
+		serviceNetworks: make(map[string]*vpclattice.ServiceNetworkSummary),
+		services:        make(map[string]*vpclattice.GetServiceOutput),
+		associations:    make(map[string]*vpclattice.ServiceNetworkServiceAssociationSummary),
+		listeners:       make(map[string]*vpclattice.GetListenerOutput),
+		rules:           make(map[string]*rule),
+		targetGroups:    make(map[string]*vpclattice.GetTargetGroupOutput),
+		targets:         make(map[string][]*vpclattice.TargetSummary),
+		tags:            make(map[string]services.Tags),
+	}
+}
+
+// SetError makes every following call to the given method fail with err, until cleared with a nil err.
+func (l *Lattice) SetError(method string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err == nil {
+		delete(l.errors, method)
+		return
+	}
+	l.errors[method] = err
+}
+
+// Calls returns the recorded calls, in order. When methods are given, only calls to them are returned.
+func (l *Lattice) Calls(methods ...string) []Call {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var result []Call
+	for _, c := range l.calls {
+		if len(methods) == 0 || contains(methods, c.Method) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// CallCount returns the number of recorded calls to the given method.
+func (l *Lattice) CallCount(method string) int {
+	return len(l.Calls(method))
+}
+
+func (l *Lattice) ResetCalls() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = nil
+}
+
+// AddServiceNetwork seeds a service network, which the controller expects to exist already.
+func (l *Lattice) AddServiceNetwork(name string, tags services.Tags) *vpclattice.ServiceNetworkSummary {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := l.newId("sn")
+	sn := &vpclattice.ServiceNetworkSummary{
+		Arn:  aws.String(l.arn("servicenetwork/" + id)),
+		Id:   aws.String(id),
+		Name: aws.String(name),
+	}
+	l.serviceNetworks[id] = sn
+	l.setTags(aws.StringValue(sn.Arn), tags)
+	return sn
+}
+
+// Services returns the services, sorted by name.
+func (l *Lattice) Services() []*vpclattice.GetServiceOutput {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	result := make([]*vpclattice.GetServiceOutput, 0, len(l.services))
+	for _, svc := range l.services {
+		result = append(result, svc)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return aws.StringValue(result[i].Name) < aws.StringValue(result[j].Name)
+	})
+	return result
+}
+
+// TargetGroups returns the target groups, sorted by name.
+func (l *Lattice) TargetGroups() []*vpclattice.GetTargetGroupOutput {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	result := make([]*vpclattice.GetTargetGroupOutput, 0, len(l.targetGroups))
+	for id, tg := range l.targetGroups {
+		tg.ServiceArns = l.serviceArns(id)
+		result = append(result, tg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return aws.StringValue(result[i].Name) < aws.StringValue(result[j].Name)
+	})
+	return result
+}
+
+// Targets returns the targets registered to the given target group.
+func (l *Lattice) Targets(tgIdentifier string) []*vpclattice.TargetSummary {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]*vpclattice.TargetSummary{}, l.targets[idOf(tgIdentifier)]...)
+}
+
+// Tags returns the tags of the given resource.
+func (l *Lattice) Tags(resourceArn string) services.Tags {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	tags := services.Tags{}
+	for k, v := range l.tags[resourceArn] {
+		tags[k] = v
+	}
+	return tags
+}
+
+func (l *Lattice) record(method string, input interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, Call{Method: method, Input: input})
+	return l.errors[method]
+}
+
+func (l *Lattice) newId(prefix string) string {
+	l.nextId++
+	return fmt.Sprintf("%s-%017x", prefix, l.nextId)
+}
+
+func (l *Lattice) arn(resource string) string {
+	return arn.ARN{
+		Partition: "aws",
+		Service:   "vpc-lattice",
+		Region:    l.region,
+		AccountID: l.account,
+		Resource:  resource,
+	}.String()
+}
+
+func (l *Lattice) setTags(resourceArn string, tags services.Tags) {
+	if len(tags) == 0 {
+		return
+	}
+	if l.tags[resourceArn] == nil {
+		l.tags[resourceArn] = services.Tags{}
+	}
+	for k, v := range tags {
+		l.tags[resourceArn][k] = aws.String(aws.StringValue(v))
+	}
+}
+
+// idOf accepts either an ID or an ARN, e.g. service/svc-0123/listener/listener-0123
+func idOf(identifier string) string {
+	if arn.IsARN(identifier) {
+		parts := strings.Split(identifier, "/")
+		return parts[len(parts)-1]
+	}
+	return identifier
+}
+
+func notFound(resourceType string, identifier *string) error {
+	return awserr.New(vpclattice.ErrCodeResourceNotFoundException,
+		fmt.Sprintf("%s %s not found", resourceType, aws.StringValue(identifier)), nil)
+}
+
+func conflict(message string) error {
+	return awserr.New(vpclattice.ErrCodeConflictException, message, nil)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Lattice) ListServiceNetworksAsList(ctx context.Context, input *vpclattice.ListServiceNetworksInput) ([]*vpclattice.ServiceNetworkSummary, error) {
+	if err := l.record("ListServiceNetworksAsList", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	result := []*vpclattice.ServiceNetworkSummary{}
+	for _, sn := range l.serviceNetworks {
+		result = append(result, sn)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return aws.StringValue(result[i].Id) < aws.StringValue(result[j].Id)
+	})
+	return result, nil
+}
+
+// ListServiceNetworkVpcAssociationsAsList always returns no associations, VPC associations are not kept by the fake.
+func (l *Lattice) ListServiceNetworkVpcAssociationsAsList(ctx context.Context, input *vpclattice.ListServiceNetworkVpcAssociationsInput) ([]*vpclattice.ServiceNetworkVpcAssociationSummary, error) {
+	if err := l.record("ListServiceNetworkVpcAssociationsAsList", input); err != nil {
+		return nil, err
+	}
+	return []*vpclattice.ServiceNetworkVpcAssociationSummary{}, nil
+}
+
+func (l *Lattice) FindServiceNetwork(ctx context.Context, nameOrId string) (*services.ServiceNetworkInfo, error) {
+	if err := l.record("FindServiceNetwork", nameOrId); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var match []*vpclattice.ServiceNetworkSummary
+	for _, sn := range l.serviceNetworks {
+		if aws.StringValue(sn.Name) == nameOrId || aws.StringValue(sn.Id) == nameOrId {
+			match = append(match, sn)
+		}
+	}
+	switch len(match) {
+	case 0:
+		return nil, services.NewNotFoundError("Service network", nameOrId)
+	case 1:
+		tags := services.Tags{}
+		for k, v := range l.tags[aws.StringValue(match[0].Arn)] {
+			tags[k] = v
+		}
+		return &services.ServiceNetworkInfo{SvcNetwork: *match[0], Tags: tags}, nil
+	default:
+		return nil, fmt.Errorf("%w, multiple SN found for %s", services.ErrNameConflict, nameOrId)
+	}
+}
+
+// FindServiceNetworkByTags matches the service networks on the tags they were created or tagged with.
+func (l *Lattice) FindServiceNetworkByTags(ctx context.Context, selector map[string]string) (*services.ServiceNetworkInfo, error) {
+	if err := l.record("FindServiceNetworkByTags", selector); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var match []*vpclattice.ServiceNetworkSummary
+	for _, sn := range l.serviceNetworks {
+		tags := l.tags[aws.StringValue(sn.Arn)]
+		matches := len(selector) > 0
+		for k, v := range selector {
+			if tag, ok := tags[k]; !ok || aws.StringValue(tag) != v {
+				matches = false
+			}
+		}
+		if matches {
+			match = append(match, sn)
+		}
+	}
+	switch len(match) {
+	case 0:
+		return nil, services.NewNotFoundError("Service network", fmt.Sprint(selector))
+	case 1:
+		tags := services.Tags{}
+		for k, v := range l.tags[aws.StringValue(match[0].Arn)] {
+			tags[k] = v
+		}
+		return &services.ServiceNetworkInfo{SvcNetwork: *match[0], Tags: tags}, nil
+	default:
+		return nil, fmt.Errorf("%w, multiple SN found for selector %v", services.ErrNameConflict, selector)
+	}
+}
+
+func (l *Lattice) CreateServiceWithContext(ctx context.Context, input *vpclattice.CreateServiceInput, opts ...request.Option) (*vpclattice.CreateServiceOutput, error) {
+	if err := l.record("CreateServiceWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, svc := range l.services {
+		if aws.StringValue(svc.Name) == aws.StringValue(input.Name) {
+			return nil, conflict(fmt.Sprintf("service %s already exists", aws.StringValue(input.Name)))
+		}
+	}
+	id := l.newId("svc")
+	now := time.Now()
+	svc := &vpclattice.GetServiceOutput{
+		Arn:              aws.String(l.arn("service/" + id)),
+		AuthType:         input.AuthType,
+		CertificateArn:   input.CertificateArn,
+		CreatedAt:        &now,
+		CustomDomainName: input.CustomDomainName,
+		DnsEntry: &vpclattice.DnsEntry{
+			DomainName:   aws.String(fmt.Sprintf("%s.%s.vpc-lattice-svcs.%s.on.aws", aws.StringValue(input.Name), id, l.region)),
+			HostedZoneId: aws.String("fake-hosted-zone"),
+		},
+		Id:            aws.String(id),
+		LastUpdatedAt: &now,
+		Name:          input.Name,
+		Status:        aws.String(vpclattice.ServiceStatusActive),
+	}
+	l.services[id] = svc
+	l.setTags(aws.StringValue(svc.Arn), input.Tags)
+	return &vpclattice.CreateServiceOutput{
+		Arn:              svc.Arn,
+		AuthType:         svc.AuthType,
+		CertificateArn:   svc.CertificateArn,
+		CustomDomainName: svc.CustomDomainName,
+		DnsEntry:         svc.DnsEntry,
+		Id:               svc.Id,
+		Name:             svc.Name,
+		Status:           svc.Status,
+	}, nil
+}
+
+func (l *Lattice) GetServiceWithContext(ctx context.Context, input *vpclattice.GetServiceInput, opts ...request.Option) (*vpclattice.GetServiceOutput, error) {
+	if err := l.record("GetServiceWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	svc, ok := l.services[idOf(aws.StringValue(input.ServiceIdentifier))]
+	if !ok {
+		return nil, notFound("service", input.ServiceIdentifier)
+	}
+	return svc, nil
+}
+
+func (l *Lattice) UpdateServiceWithContext(ctx context.Context, input *vpclattice.UpdateServiceInput, opts ...request.Option) (*vpclattice.UpdateServiceOutput, error) {
+	if err := l.record("UpdateServiceWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	svc, ok := l.services[idOf(aws.StringValue(input.ServiceIdentifier))]
+	if !ok {
+		return nil, notFound("service", input.ServiceIdentifier)
+	}
+	if input.AuthType != nil {
+		svc.AuthType = input.AuthType
+	}
+	if input.CertificateArn != nil {
+		svc.CertificateArn = input.CertificateArn
+	}
+	return &vpclattice.UpdateServiceOutput{
+		Arn:              svc.Arn,
+		AuthType:         svc.AuthType,
+		CertificateArn:   svc.CertificateArn,
+		CustomDomainName: svc.CustomDomainName,
+		Id:               svc.Id,
+		Name:             svc.Name,
+	}, nil
+}
+
+func (l *Lattice) DeleteServiceWithContext(ctx context.Context, input *vpclattice.DeleteServiceInput, opts ...request.Option) (*vpclattice.DeleteServiceOutput, error) {
+	if err := l.record("DeleteServiceWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := idOf(aws.StringValue(input.ServiceIdentifier))
+	svc, ok := l.services[id]
+	if !ok {
+		return nil, notFound("service", input.ServiceIdentifier)
+	}
+	for _, snsa := range l.associations {
+		if aws.StringValue(snsa.ServiceId) == id {
+			return nil, conflict(fmt.Sprintf("service %s has service network associations", id))
+		}
+	}
+	for listenerId, listener := range l.listeners {
+		if aws.StringValue(listener.ServiceId) == id {
+			l.deleteListener(listenerId)
+		}
+	}
+	delete(l.services, id)
+	delete(l.tags, aws.StringValue(svc.Arn))
+	return &vpclattice.DeleteServiceOutput{
+		Arn:    svc.Arn,
+		Id:     svc.Id,
+		Name:   svc.Name,
+		Status: aws.String(vpclattice.ServiceStatusDeleteInProgress),
+	}, nil
+}
+
+func (l *Lattice) ListServicesAsList(ctx context.Context, input *vpclattice.ListServicesInput) ([]*vpclattice.ServiceSummary, error) {
+	if err := l.record("ListServicesAsList", input); err != nil {
+		return nil, err
+	}
+	result := []*vpclattice.ServiceSummary{}
+	for _, svc := range l.Services() {
+		result = append(result, serviceSummary(svc))
+	}
+	return result, nil
+}
+
+func (l *Lattice) FindService(ctx context.Context, latticeServiceName string) (*vpclattice.ServiceSummary, error) {
+	if err := l.record("FindService", latticeServiceName); err != nil {
+		return nil, err
+	}
+	for _, svc := range l.Services() {
+		if aws.StringValue(svc.Name) == latticeServiceName {
+			return serviceSummary(svc), nil
+		}
+	}
+	return nil, services.NewNotFoundError("Service", latticeServiceName)
+}
+
+// FindServices returns the service with the name, service names are unique in the fake like in an account.
+func (l *Lattice) FindServices(ctx context.Context, latticeServiceName string) ([]*vpclattice.ServiceSummary, error) {
+	if err := l.record("FindServices", latticeServiceName); err != nil {
+		return nil, err
+	}
+	for _, svc := range l.Services() {
+		if aws.StringValue(svc.Name) == latticeServiceName {
+			return []*vpclattice.ServiceSummary{serviceSummary(svc)}, nil
+		}
+	}
+	return nil, services.NewNotFoundError("Service", latticeServiceName)
+}
+
+func serviceSummary(svc *vpclattice.GetServiceOutput) *vpclattice.ServiceSummary {
+	return &vpclattice.ServiceSummary{
+		Arn:              svc.Arn,
+		CreatedAt:        svc.CreatedAt,
+		CustomDomainName: svc.CustomDomainName,
+		DnsEntry:         svc.DnsEntry,
+		Id:               svc.Id,
+		LastUpdatedAt:    svc.LastUpdatedAt,
+		Name:             svc.Name,
+		Status:           svc.Status,
+	}
+}
+
+func (l *Lattice) CreateServiceNetworkServiceAssociationWithContext(ctx context.Context, input *vpclattice.CreateServiceNetworkServiceAssociationInput, opts ...request.Option) (*vpclattice.CreateServiceNetworkServiceAssociationOutput, error) {
+	if err := l.record("CreateServiceNetworkServiceAssociationWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	svc, ok := l.services[idOf(aws.StringValue(input.ServiceIdentifier))]
+	if !ok {
+		return nil, notFound("service", input.ServiceIdentifier)
+	}
+	sn, ok := l.serviceNetworks[idOf(aws.StringValue(input.ServiceNetworkIdentifier))]
+	if !ok {
+		return nil, notFound("service network", input.ServiceNetworkIdentifier)
+	}
+	for _, snsa := range l.associations {
+		if aws.StringValue(snsa.ServiceId) == aws.StringValue(svc.Id) &&
+			aws.StringValue(snsa.ServiceNetworkId) == aws.StringValue(sn.Id) {
+			return nil, conflict(fmt.Sprintf("service %s is already associated to %s",
+				aws.StringValue(svc.Id), aws.StringValue(sn.Id)))
+		}
+	}
+	id := l.newId("snsa")
+	now := time.Now()
+	snsa := &vpclattice.ServiceNetworkServiceAssociationSummary{
+		Arn:                aws.String(l.arn("servicenetworkserviceassociation/" + id)),
+		CreatedAt:          &now,
+		CreatedBy:          aws.String(l.account),
+		CustomDomainName:   svc.CustomDomainName,
+		DnsEntry:           svc.DnsEntry,
+		Id:                 aws.String(id),
+		ServiceArn:         svc.Arn,
+		ServiceId:          svc.Id,
+		ServiceName:        svc.Name,
+		ServiceNetworkArn:  sn.Arn,
+		ServiceNetworkId:   sn.Id,
+		ServiceNetworkName: sn.Name,
+		Status:             aws.String(vpclattice.ServiceNetworkServiceAssociationStatusActive),
+	}
+	l.associations[id] = snsa
+	l.setTags(aws.StringValue(snsa.Arn), input.Tags)
+	return &vpclattice.CreateServiceNetworkServiceAssociationOutput{
+		Arn:              snsa.Arn,
+		CreatedBy:        snsa.CreatedBy,
+		CustomDomainName: snsa.CustomDomainName,
+		DnsEntry:         snsa.DnsEntry,
+		Id:               snsa.Id,
+		Status:           snsa.Status,
+	}, nil
+}
+
+func (l *Lattice) DeleteServiceNetworkServiceAssociationWithContext(ctx context.Context, input *vpclattice.DeleteServiceNetworkServiceAssociationInput, opts ...request.Option) (*vpclattice.DeleteServiceNetworkServiceAssociationOutput, error) {
+	if err := l.record("DeleteServiceNetworkServiceAssociationWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := idOf(aws.StringValue(input.ServiceNetworkServiceAssociationIdentifier))
+	snsa, ok := l.associations[id]
+	if !ok {
+		return nil, notFound("service network service association", input.ServiceNetworkServiceAssociationIdentifier)
+	}
+	delete(l.associations, id)
+	delete(l.tags, aws.StringValue(snsa.Arn))
+	return &vpclattice.DeleteServiceNetworkServiceAssociationOutput{
+		Arn:    snsa.Arn,
+		Id:     snsa.Id,
+		Status: aws.String(vpclattice.ServiceNetworkServiceAssociationStatusDeleteInProgress),
+	}, nil
+}
+
+func (l *Lattice) ListServiceNetworkServiceAssociationsAsList(ctx context.Context, input *vpclattice.ListServiceNetworkServiceAssociationsInput) ([]*vpclattice.ServiceNetworkServiceAssociationSummary, error) {
+	if err := l.record("ListServiceNetworkServiceAssociationsAsList", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	svcId := idOf(aws.StringValue(input.ServiceIdentifier))
+	snId := idOf(aws.StringValue(input.ServiceNetworkIdentifier))
+	result := []*vpclattice.ServiceNetworkServiceAssociationSummary{}
+	for _, snsa := range l.associations {
+		if (svcId == "" || aws.StringValue(snsa.ServiceId) == svcId) &&
+			(snId == "" || aws.StringValue(snsa.ServiceNetworkId) == snId) {
+			result = append(result, snsa)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return aws.StringValue(result[i].Id) < aws.StringValue(result[j].Id)
+	})
+	return result, nil
+}
+
+func (l *Lattice) CreateListenerWithContext(ctx context.Context, input *vpclattice.CreateListenerInput, opts ...request.Option) (*vpclattice.CreateListenerOutput, error) {
+	if err := l.record("CreateListenerWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	svc, ok := l.services[idOf(aws.StringValue(input.ServiceIdentifier))]
+	if !ok {
+		return nil, notFound("service", input.ServiceIdentifier)
+	}
+	for _, listener := range l.listeners {
+		if aws.StringValue(listener.ServiceId) == aws.StringValue(svc.Id) &&
+			aws.Int64Value(listener.Port) == aws.Int64Value(input.Port) {
+			return nil, conflict(fmt.Sprintf("listener on port %d already exists", aws.Int64Value(input.Port)))
+		}
+	}
+	id := l.newId("listener")
+	now := time.Now()
+	listener := &vpclattice.GetListenerOutput{
+		Arn:           aws.String(aws.StringValue(svc.Arn) + "/listener/" + id),
+		CreatedAt:     &now,
+		DefaultAction: input.DefaultAction,
+		Id:            aws.String(id),
+		LastUpdatedAt: &now,
+		Name:          input.Name,
+		Port:          input.Port,
+		Protocol:      input.Protocol,
+		ServiceArn:    svc.Arn,
+		ServiceId:     svc.Id,
+	}
+	l.listeners[id] = listener
+	l.setTags(aws.StringValue(listener.Arn), input.Tags)
+
+	// like Lattice, every listener has a default rule
+	ruleId := l.newId("rule")
+	l.rules[ruleId] = &rule{
+		listenerId: id,
+		rule: &vpclattice.GetRuleOutput{
+			Action:        input.DefaultAction,
+			Arn:           aws.String(aws.StringValue(listener.Arn) + "/rule/" + ruleId),
+			CreatedAt:     &now,
+			Id:            aws.String(ruleId),
+			IsDefault:     aws.Bool(true),
+			LastUpdatedAt: &now,
+			Name:          aws.String("default"),
+		},
+	}
+	return &vpclattice.CreateListenerOutput{
+		Arn:           listener.Arn,
+		DefaultAction: listener.DefaultAction,
+		Id:            listener.Id,
+		Name:          listener.Name,
+		Port:          listener.Port,
+		Protocol:      listener.Protocol,
+		ServiceArn:    listener.ServiceArn,
+		ServiceId:     listener.ServiceId,
+	}, nil
+}
+
+func (l *Lattice) GetListenerWithContext(ctx context.Context, input *vpclattice.GetListenerInput, opts ...request.Option) (*vpclattice.GetListenerOutput, error) {
+	if err := l.record("GetListenerWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	listener, ok := l.findListener(input.ServiceIdentifier, input.ListenerIdentifier)
+	if !ok {
+		return nil, notFound("listener", input.ListenerIdentifier)
+	}
+	return listener, nil
+}
+
+func (l *Lattice) UpdateListenerWithContext(ctx context.Context, input *vpclattice.UpdateListenerInput, opts ...request.Option) (*vpclattice.UpdateListenerOutput, error) {
+	if err := l.record("UpdateListenerWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	listener, ok := l.findListener(input.ServiceIdentifier, input.ListenerIdentifier)
+	if !ok {
+		return nil, notFound("listener", input.ListenerIdentifier)
+	}
+	listener.DefaultAction = input.DefaultAction
+	for _, r := range l.rules {
+		if r.listenerId == aws.StringValue(listener.Id) && aws.BoolValue(r.rule.IsDefault) {
+			r.rule.Action = input.DefaultAction
+		}
+	}
+	return &vpclattice.UpdateListenerOutput{
+		Arn:           listener.Arn,
+		DefaultAction: listener.DefaultAction,
+		Id:            listener.Id,
+		Name:          listener.Name,
+		Port:          listener.Port,
+		Protocol:      listener.Protocol,
+		ServiceArn:    listener.ServiceArn,
+		ServiceId:     listener.ServiceId,
+	}, nil
+}
+
+func (l *Lattice) DeleteListenerWithContext(ctx context.Context, input *vpclattice.DeleteListenerInput, opts ...request.Option) (*vpclattice.DeleteListenerOutput, error) {
+	if err := l.record("DeleteListenerWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	listener, ok := l.findListener(input.ServiceIdentifier, input.ListenerIdentifier)
+	if !ok {
+		return nil, notFound("listener", input.ListenerIdentifier)
+	}
+	l.deleteListener(aws.StringValue(listener.Id))
+	return &vpclattice.DeleteListenerOutput{}, nil
+}
+
+func (l *Lattice) ListListenersWithContext(ctx context.Context, input *vpclattice.ListListenersInput, opts ...request.Option) (*vpclattice.ListListenersOutput, error) {
+	items, err := l.listListeners("ListListenersWithContext", input)
+	if err != nil {
+		return nil, err
+	}
+	return &vpclattice.ListListenersOutput{Items: items}, nil
+}
+
+func (l *Lattice) ListListenersAsList(ctx context.Context, input *vpclattice.ListListenersInput) ([]*vpclattice.ListenerSummary, error) {
+	return l.listListeners("ListListenersAsList", input)
+}
+
+func (l *Lattice) listListeners(method string, input *vpclattice.ListListenersInput) ([]*vpclattice.ListenerSummary, error) {
+	if err := l.record(method, input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	svcId := idOf(aws.StringValue(input.ServiceIdentifier))
+	if _, ok := l.services[svcId]; !ok {
+		return nil, notFound("service", input.ServiceIdentifier)
+	}
+	result := []*vpclattice.ListenerSummary{}
+	for _, listener := range l.listeners {
+		if aws.StringValue(listener.ServiceId) != svcId {
+			continue
+		}
+		result = append(result, &vpclattice.ListenerSummary{
+			Arn:           listener.Arn,
+			CreatedAt:     listener.CreatedAt,
+			Id:            listener.Id,
+			LastUpdatedAt: listener.LastUpdatedAt,
+			Name:          listener.Name,
+			Port:          listener.Port,
+			Protocol:      listener.Protocol,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return aws.StringValue(result[i].Id) < aws.StringValue(result[j].Id)
+	})
+	return result, nil
+}
+
+func (l *Lattice) findListener(serviceIdentifier *string, listenerIdentifier *string) (*vpclattice.GetListenerOutput, bool) {
+	listener, ok := l.listeners[idOf(aws.StringValue(listenerIdentifier))]
+	if !ok || aws.StringValue(listener.ServiceId) != idOf(aws.StringValue(serviceIdentifier)) {
+		return nil, false
+	}
+	return listener, true
+}
+
+func (l *Lattice) deleteListener(id string) {
+	for ruleId, r := range l.rules {
+		if r.listenerId == id {
+			delete(l.rules, ruleId)
+		}
+	}
+	delete(l.tags, aws.StringValue(l.listeners[id].Arn))
+	delete(l.listeners, id)
+}
+
+func (l *Lattice) CreateRuleWithContext(ctx context.Context, input *vpclattice.CreateRuleInput, opts ...request.Option) (*vpclattice.CreateRuleOutput, error) {
+	if err := l.record("CreateRuleWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	listener, ok := l.findListener(input.ServiceIdentifier, input.ListenerIdentifier)
+	if !ok {
+		return nil, notFound("listener", input.ListenerIdentifier)
+	}
+	for _, r := range l.rules {
+		if r.listenerId == aws.StringValue(listener.Id) && !aws.BoolValue(r.rule.IsDefault) &&
+			aws.Int64Value(r.rule.Priority) == aws.Int64Value(input.Priority) {
+			return nil, conflict(fmt.Sprintf("rule with priority %d already exists", aws.Int64Value(input.Priority)))
+		}
+	}
+	id := l.newId("rule")
+	now := time.Now()
+	r := &vpclattice.GetRuleOutput{
+		Action:        input.Action,
+		Arn:           aws.String(aws.StringValue(listener.Arn) + "/rule/" + id),
+		CreatedAt:     &now,
+		Id:            aws.String(id),
+		IsDefault:     aws.Bool(false),
+		LastUpdatedAt: &now,
+		Match:         input.Match,
+		Name:          input.Name,
+		Priority:      input.Priority,
+	}
+	l.rules[id] = &rule{listenerId: aws.StringValue(listener.Id), rule: r}
+	l.setTags(aws.StringValue(r.Arn), input.Tags)
+	return &vpclattice.CreateRuleOutput{
+		Action:   r.Action,
+		Arn:      r.Arn,
+		Id:       r.Id,
+		Match:    r.Match,
+		Name:     r.Name,
+		Priority: r.Priority,
+	}, nil
+}
+
+func (l *Lattice) GetRuleWithContext(ctx context.Context, input *vpclattice.GetRuleInput, opts ...request.Option) (*vpclattice.GetRuleOutput, error) {
+	if err := l.record("GetRuleWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r, ok := l.findRule(input.ServiceIdentifier, input.ListenerIdentifier, input.RuleIdentifier)
+	if !ok {
+		return nil, notFound("rule", input.RuleIdentifier)
+	}
+	return r, nil
+}
+
+func (l *Lattice) UpdateRuleWithContext(ctx context.Context, input *vpclattice.UpdateRuleInput, opts ...request.Option) (*vpclattice.UpdateRuleOutput, error) {
+	if err := l.record("UpdateRuleWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r, ok := l.findRule(input.ServiceIdentifier, input.ListenerIdentifier, input.RuleIdentifier)
+	if !ok {
+		return nil, notFound("rule", input.RuleIdentifier)
+	}
+	if input.Action != nil {
+		r.Action = input.Action
+	}
+	if input.Match != nil {
+		r.Match = input.Match
+	}
+	if input.Priority != nil {
+		r.Priority = input.Priority
+	}
+	return &vpclattice.UpdateRuleOutput{
+		Action:    r.Action,
+		Arn:       r.Arn,
+		Id:        r.Id,
+		IsDefault: r.IsDefault,
+		Match:     r.Match,
+		Name:      r.Name,
+		Priority:  r.Priority,
+	}, nil
+}
+
+func (l *Lattice) BatchUpdateRuleWithContext(ctx context.Context, input *vpclattice.BatchUpdateRuleInput, opts ...request.Option) (*vpclattice.BatchUpdateRuleOutput, error) {
+	if err := l.record("BatchUpdateRuleWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := &vpclattice.BatchUpdateRuleOutput{}
+	for _, update := range input.Rules {
+		r, ok := l.findRule(input.ServiceIdentifier, input.ListenerIdentifier, update.RuleIdentifier)
+		if !ok {
+			out.Unsuccessful = append(out.Unsuccessful, &vpclattice.RuleUpdateFailure{
+				FailureCode:    aws.String(vpclattice.ErrCodeResourceNotFoundException),
+				FailureMessage: aws.String("rule not found"),
+				RuleIdentifier: update.RuleIdentifier,
+			})
+			continue
+		}
+		if update.Action != nil {
+			r.Action = update.Action
+		}
+		if update.Match != nil {
+			r.Match = update.Match
+		}
+		if update.Priority != nil {
+			r.Priority = update.Priority
+		}
+		out.Successful = append(out.Successful, &vpclattice.RuleUpdateSuccess{
+			Action:    r.Action,
+			Arn:       r.Arn,
+			Id:        r.Id,
+			IsDefault: r.IsDefault,
+			Match:     r.Match,
+			Name:      r.Name,
+			Priority:  r.Priority,
+		})
+	}
+	return out, nil
+}
+
+func (l *Lattice) DeleteRuleWithContext(ctx context.Context, input *vpclattice.DeleteRuleInput, opts ...request.Option) (*vpclattice.DeleteRuleOutput, error) {
+	if err := l.record("DeleteRuleWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r, ok := l.findRule(input.ServiceIdentifier, input.ListenerIdentifier, input.RuleIdentifier)
+	if !ok {
+		return nil, notFound("rule", input.RuleIdentifier)
+	}
+	if aws.BoolValue(r.IsDefault) {
+		return nil, awserr.New(vpclattice.ErrCodeValidationException, "cannot delete the default rule", nil)
+	}
+	delete(l.rules, aws.StringValue(r.Id))
+	delete(l.tags, aws.StringValue(r.Arn))
+	return &vpclattice.DeleteRuleOutput{}, nil
+}
+
+func (l *Lattice) ListRulesAsList(ctx context.Context, input *vpclattice.ListRulesInput) ([]*vpclattice.RuleSummary, error) {
+	rules, err := l.listRules("ListRulesAsList", input)
+	if err != nil {
+		return nil, err
+	}
+	result := []*vpclattice.RuleSummary{}
+	for _, r := range rules {
+		result = append(result, &vpclattice.RuleSummary{
+			Arn:           r.Arn,
+			CreatedAt:     r.CreatedAt,
+			Id:            r.Id,
+			IsDefault:     r.IsDefault,
+			LastUpdatedAt: r.LastUpdatedAt,
+			Name:          r.Name,
+			Priority:      r.Priority,
+		})
+	}
+	return result, nil
+}
+
+func (l *Lattice) GetRulesAsList(ctx context.Context, input *vpclattice.ListRulesInput) ([]*vpclattice.GetRuleOutput, error) {
+	return l.listRules("GetRulesAsList", input)
+}
+
+func (l *Lattice) listRules(method string, input *vpclattice.ListRulesInput) ([]*vpclattice.GetRuleOutput, error) {
+	if err := l.record(method, input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	listener, ok := l.findListener(input.ServiceIdentifier, input.ListenerIdentifier)
+	if !ok {
+		return nil, notFound("listener", input.ListenerIdentifier)
+	}
+	result := []*vpclattice.GetRuleOutput{}
+	for _, r := range l.rules {
+		if r.listenerId == aws.StringValue(listener.Id) {
+			result = append(result, r.rule)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return aws.StringValue(result[i].Id) < aws.StringValue(result[j].Id)
+	})
+	return result, nil
+}
+
+func (l *Lattice) findRule(serviceIdentifier, listenerIdentifier, ruleIdentifier *string) (*vpclattice.GetRuleOutput, bool) {
+	listener, ok := l.findListener(serviceIdentifier, listenerIdentifier)
+	if !ok {
+		return nil, false
+	}
+	r, ok := l.rules[idOf(aws.StringValue(ruleIdentifier))]
+	if !ok || r.listenerId != aws.StringValue(listener.Id) {
+		return nil, false
+	}
+	return r.rule, true
+}
+
+func (l *Lattice) CreateTargetGroupWithContext(ctx context.Context, input *vpclattice.CreateTargetGroupInput, opts ...request.Option) (*vpclattice.CreateTargetGroupOutput, error) {
+	if err := l.record("CreateTargetGroupWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, tg := range l.targetGroups {
+		if aws.StringValue(tg.Name) == aws.StringValue(input.Name) {
+			return nil, conflict(fmt.Sprintf("target group %s already exists", aws.StringValue(input.Name)))
+		}
+	}
+	id := l.newId("tg")
+	now := time.Now()
+	tg := &vpclattice.GetTargetGroupOutput{
+		Arn:           aws.String(l.arn("targetgroup/" + id)),
+		Config:        input.Config,
+		CreatedAt:     &now,
+		Id:            aws.String(id),
+		LastUpdatedAt: &now,
+		Name:          input.Name,
+		Status:        aws.String(vpclattice.TargetGroupStatusActive),
+		Type:          input.Type,
+	}
+	l.targetGroups[id] = tg
+	l.setTags(aws.StringValue(tg.Arn), input.Tags)
+	return &vpclattice.CreateTargetGroupOutput{
+		Arn:    tg.Arn,
+		Config: tg.Config,
+		Id:     tg.Id,
+		Name:   tg.Name,
+		Status: tg.Status,
+		Type:   tg.Type,
+	}, nil
+}
+
+func (l *Lattice) GetTargetGroupWithContext(ctx context.Context, input *vpclattice.GetTargetGroupInput, opts ...request.Option) (*vpclattice.GetTargetGroupOutput, error) {
+	if err := l.record("GetTargetGroupWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	tg, ok := l.targetGroups[idOf(aws.StringValue(input.TargetGroupIdentifier))]
+	if !ok {
+		return nil, notFound("target group", input.TargetGroupIdentifier)
+	}
+	tg.ServiceArns = l.serviceArns(aws.StringValue(tg.Id))
+	return tg, nil
+}
+
+func (l *Lattice) UpdateTargetGroupWithContext(ctx context.Context, input *vpclattice.UpdateTargetGroupInput, opts ...request.Option) (*vpclattice.UpdateTargetGroupOutput, error) {
+	if err := l.record("UpdateTargetGroupWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	tg, ok := l.targetGroups[idOf(aws.StringValue(input.TargetGroupIdentifier))]
+	if !ok {
+		return nil, notFound("target group", input.TargetGroupIdentifier)
+	}
+	if tg.Config == nil {
+		tg.Config = &vpclattice.TargetGroupConfig{}
+	}
+	tg.Config.HealthCheck = input.HealthCheck
+	return &vpclattice.UpdateTargetGroupOutput{
+		Arn:    tg.Arn,
+		Config: tg.Config,
+		Id:     tg.Id,
+		Name:   tg.Name,
+		Status: tg.Status,
+		Type:   tg.Type,
+	}, nil
+}
+
+func (l *Lattice) DeleteTargetGroupWithContext(ctx context.Context, input *vpclattice.DeleteTargetGroupInput, opts ...request.Option) (*vpclattice.DeleteTargetGroupOutput, error) {
+	if err := l.record("DeleteTargetGroupWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := idOf(aws.StringValue(input.TargetGroupIdentifier))
+	tg, ok := l.targetGroups[id]
+	if !ok {
+		return nil, notFound("target group", input.TargetGroupIdentifier)
+	}
+	if len(l.targets[id]) > 0 {
+		return nil, conflict(fmt.Sprintf("target group %s has registered targets", id))
+	}
+	if len(l.serviceArns(id)) > 0 {
+		return nil, conflict(fmt.Sprintf("target group %s is in use by a service", id))
+	}
+	delete(l.targetGroups, id)
+	delete(l.targets, id)
+	delete(l.tags, aws.StringValue(tg.Arn))
+	return &vpclattice.DeleteTargetGroupOutput{
+		Arn:    tg.Arn,
+		Id:     tg.Id,
+		Status: aws.String(vpclattice.TargetGroupStatusDeleteInProgress),
+	}, nil
+}
+
+func (l *Lattice) ListTargetGroupsAsList(ctx context.Context, input *vpclattice.ListTargetGroupsInput) ([]*vpclattice.TargetGroupSummary, error) {
+	if err := l.record("ListTargetGroupsAsList", input); err != nil {
+		return nil, err
+	}
+	result := []*vpclattice.TargetGroupSummary{}
+	for _, tg := range l.TargetGroups() {
+		summary := &vpclattice.TargetGroupSummary{
+			Arn:           tg.Arn,
+			CreatedAt:     tg.CreatedAt,
+			Id:            tg.Id,
+			LastUpdatedAt: tg.LastUpdatedAt,
+			Name:          tg.Name,
+			ServiceArns:   tg.ServiceArns,
+			Status:        tg.Status,
+			Type:          tg.Type,
+		}
+		if tg.Config != nil {
+			summary.IpAddressType = tg.Config.IpAddressType
+			summary.Port = tg.Config.Port
+			summary.Protocol = tg.Config.Protocol
+			summary.VpcIdentifier = tg.Config.VpcIdentifier
+		}
+		if input.VpcIdentifier != nil && aws.StringValue(summary.VpcIdentifier) != aws.StringValue(input.VpcIdentifier) {
+			continue
+		}
+		if input.TargetGroupType != nil && aws.StringValue(summary.Type) != aws.StringValue(input.TargetGroupType) {
+			continue
+		}
+		result = append(result, summary)
+	}
+	return result, nil
+}
+
+func (l *Lattice) RegisterTargetsWithContext(ctx context.Context, input *vpclattice.RegisterTargetsInput, opts ...request.Option) (*vpclattice.RegisterTargetsOutput, error) {
+	if err := l.record("RegisterTargetsWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := idOf(aws.StringValue(input.TargetGroupIdentifier))
+	if _, ok := l.targetGroups[id]; !ok {
+		return nil, notFound("target group", input.TargetGroupIdentifier)
+	}
+	out := &vpclattice.RegisterTargetsOutput{}
+	for _, t := range input.Targets {
+		registered := false
+		for _, existing := range l.targets[id] {
+			if aws.StringValue(existing.Id) == aws.StringValue(t.Id) && aws.Int64Value(existing.Port) == aws.Int64Value(t.Port) {
+				registered = true
+				existing.Status = aws.String(vpclattice.TargetStatusHealthy)
+			}
+		}
+		if !registered {
+			l.targets[id] = append(l.targets[id], &vpclattice.TargetSummary{
+				Id:     t.Id,
+				Port:   t.Port,
+				Status: aws.String(vpclattice.TargetStatusHealthy),
+			})
+		}
+		out.Successful = append(out.Successful, t)
+	}
+	return out, nil
+}
+
+func (l *Lattice) DeregisterTargetsWithContext(ctx context.Context, input *vpclattice.DeregisterTargetsInput, opts ...request.Option) (*vpclattice.DeregisterTargetsOutput, error) {
+	if err := l.record("DeregisterTargetsWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := idOf(aws.StringValue(input.TargetGroupIdentifier))
+	if _, ok := l.targetGroups[id]; !ok {
+		return nil, notFound("target group", input.TargetGroupIdentifier)
+	}
+	out := &vpclattice.DeregisterTargetsOutput{}
+	for _, t := range input.Targets {
+		var remaining []*vpclattice.TargetSummary
+		for _, existing := range l.targets[id] {
+			if aws.StringValue(existing.Id) == aws.StringValue(t.Id) && aws.Int64Value(existing.Port) == aws.Int64Value(t.Port) {
+				continue
+			}
+			remaining = append(remaining, existing)
+		}
+		l.targets[id] = remaining
+		out.Successful = append(out.Successful, t)
+	}
+	return out, nil
+}
+
+func (l *Lattice) ListTargetsAsList(ctx context.Context, input *vpclattice.ListTargetsInput) ([]*vpclattice.TargetSummary, error) {
+	if err := l.record("ListTargetsAsList", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := idOf(aws.StringValue(input.TargetGroupIdentifier))
+	if _, ok := l.targetGroups[id]; !ok {
+		return nil, notFound("target group", input.TargetGroupIdentifier)
+	}
+	return append([]*vpclattice.TargetSummary{}, l.targets[id]...), nil
+}
+
+func (l *Lattice) ListTagsForResourceWithContext(ctx context.Context, input *vpclattice.ListTagsForResourceInput, opts ...request.Option) (*vpclattice.ListTagsForResourceOutput, error) {
+	if err := l.record("ListTagsForResourceWithContext", input); err != nil {
+		return nil, err
+	}
+	return &vpclattice.ListTagsForResourceOutput{Tags: l.Tags(aws.StringValue(input.ResourceArn))}, nil
+}
+
+func (l *Lattice) TagResourceWithContext(ctx context.Context, input *vpclattice.TagResourceInput, opts ...request.Option) (*vpclattice.TagResourceOutput, error) {
+	if err := l.record("TagResourceWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.setTags(aws.StringValue(input.ResourceArn), input.Tags)
+	return &vpclattice.TagResourceOutput{}, nil
+}
+
+func (l *Lattice) UntagResourceWithContext(ctx context.Context, input *vpclattice.UntagResourceInput, opts ...request.Option) (*vpclattice.UntagResourceOutput, error) {
+	if err := l.record("UntagResourceWithContext", input); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range input.TagKeys {
+		delete(l.tags[aws.StringValue(input.ResourceArn)], aws.StringValue(key))
+	}
+	return &vpclattice.UntagResourceOutput{}, nil
+}
+
+// serviceArns returns the services forwarding traffic to the target group, through a listener or a rule.
+func (l *Lattice) serviceArns(tgId string) []*string {
+	seen := make(map[string]bool)
+	var result []*string
+	add := func(listenerId string, action *vpclattice.RuleAction) {
+		listener, ok := l.listeners[listenerId]
+		if !ok || action == nil || action.Forward == nil {
+			return
+		}
+		for _, wtg := range action.Forward.TargetGroups {
+			svcArn := aws.StringValue(listener.ServiceArn)
+			if idOf(aws.StringValue(wtg.TargetGroupIdentifier)) == tgId && !seen[svcArn] {
+				seen[svcArn] = true
+				result = append(result, aws.String(svcArn))
+			}
+		}
+	}
+	for id, listener := range l.listeners {
+		add(id, listener.DefaultAction)
+	}
+	for _, r := range l.rules {
+		add(r.listenerId, r.rule.Action)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return aws.StringValue(result[i]) < aws.StringValue(result[j])
+	})
+	return result
+}
@@ -0,0 +1,270 @@
+package latticefake
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+)
+
+func Test_Service_RoundTrip(t *testing.T) {
+	ctx := context.TODO()
+	fake := New("account-id", "us-west-2")
+	sn := fake.AddServiceNetwork("sn", services.Tags{"owner": aws.String("test")})
+
+	created, err := fake.CreateServiceWithContext(ctx, &vpclattice.CreateServiceInput{
+		Name: aws.String("svc-name"),
+		Tags: services.Tags{"key": aws.String("value")},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "arn:aws:vpc-lattice:us-west-2:account-id:service/"+aws.StringValue(created.Id), aws.StringValue(created.Arn))
+	assert.NotEmpty(t, aws.StringValue(created.DnsEntry.DomainName))
+
+	_, err = fake.CreateServiceWithContext(ctx, &vpclattice.CreateServiceInput{Name: aws.String("svc-name")})
+	assert.NotNil(t, err, "service names are unique")
+
+	found, err := fake.FindService(ctx, "svc-name")
+	assert.Nil(t, err)
+	assert.Equal(t, created.Id, found.Id)
+	got, err := fake.GetServiceWithContext(ctx, &vpclattice.GetServiceInput{ServiceIdentifier: created.Arn})
+	assert.Nil(t, err)
+	assert.Equal(t, created.Id, got.Id)
+	assert.Equal(t, "value", aws.StringValue(fake.Tags(aws.StringValue(created.Arn))["key"]))
+
+	snInfo, err := fake.FindServiceNetwork(ctx, "sn")
+	assert.Nil(t, err)
+	assert.Equal(t, "test", aws.StringValue(snInfo.Tags["owner"]))
+	snsa, err := fake.CreateServiceNetworkServiceAssociationWithContext(ctx, &vpclattice.CreateServiceNetworkServiceAssociationInput{
+		ServiceIdentifier:        created.Id,
+		ServiceNetworkIdentifier: sn.Id,
+	})
+	assert.Nil(t, err)
+	associations, err := fake.ListServiceNetworkServiceAssociationsAsList(ctx, &vpclattice.ListServiceNetworkServiceAssociationsInput{
+		ServiceIdentifier: created.Id,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, associations, 1)
+	assert.Equal(t, "sn", aws.StringValue(associations[0].ServiceNetworkName))
+
+	_, err = fake.DeleteServiceWithContext(ctx, &vpclattice.DeleteServiceInput{ServiceIdentifier: created.Id})
+	assert.NotNil(t, err, "associated services cannot be deleted")
+	_, err = fake.DeleteServiceNetworkServiceAssociationWithContext(ctx, &vpclattice.DeleteServiceNetworkServiceAssociationInput{
+		ServiceNetworkServiceAssociationIdentifier: snsa.Id,
+	})
+	assert.Nil(t, err)
+	_, err = fake.DeleteServiceWithContext(ctx, &vpclattice.DeleteServiceInput{ServiceIdentifier: created.Id})
+	assert.Nil(t, err)
+
+	_, err = fake.FindService(ctx, "svc-name")
+	assert.True(t, services.IsNotFoundError(err))
+	_, err = fake.GetServiceWithContext(ctx, &vpclattice.GetServiceInput{ServiceIdentifier: created.Id})
+	assert.True(t, services.IsLatticeAPINotFoundErr(err))
+	assert.Empty(t, fake.Services())
+	assert.Empty(t, fake.Tags(aws.StringValue(created.Arn)))
+}
+
+func Test_TargetGroup_RoundTrip(t *testing.T) {
+	ctx := context.TODO()
+	fake := New("account-id", "us-west-2")
+
+	created, err := fake.CreateTargetGroupWithContext(ctx, &vpclattice.CreateTargetGroupInput{
+		Name: aws.String("tg-name"),
+		Type: aws.String(vpclattice.TargetGroupTypeIp),
+		Config: &vpclattice.TargetGroupConfig{
+			Port:          aws.Int64(80),
+			Protocol:      aws.String(vpclattice.TargetGroupProtocolHttp),
+			VpcIdentifier: aws.String("vpc-id"),
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, vpclattice.TargetGroupStatusActive, aws.StringValue(created.Status))
+
+	tgs, err := fake.ListTargetGroupsAsList(ctx, &vpclattice.ListTargetGroupsInput{VpcIdentifier: aws.String("vpc-id")})
+	assert.Nil(t, err)
+	if assert.Len(t, tgs, 1) {
+		assert.Equal(t, int64(80), aws.Int64Value(tgs[0].Port))
+	}
+	tgs, err = fake.ListTargetGroupsAsList(ctx, &vpclattice.ListTargetGroupsInput{VpcIdentifier: aws.String("other-vpc")})
+	assert.Nil(t, err)
+	assert.Empty(t, tgs)
+
+	targets := []*vpclattice.Target{
+		{Id: aws.String("10.0.0.1"), Port: aws.Int64(8080)},
+		{Id: aws.String("10.0.0.2"), Port: aws.Int64(8080)},
+	}
+	_, err = fake.RegisterTargetsWithContext(ctx, &vpclattice.RegisterTargetsInput{
+		TargetGroupIdentifier: created.Arn,
+		Targets:               targets,
+	})
+	assert.Nil(t, err)
+	listed, err := fake.ListTargetsAsList(ctx, &vpclattice.ListTargetsInput{TargetGroupIdentifier: created.Id})
+	assert.Nil(t, err)
+	assert.Len(t, listed, 2)
+
+	_, err = fake.DeleteTargetGroupWithContext(ctx, &vpclattice.DeleteTargetGroupInput{TargetGroupIdentifier: created.Id})
+	assert.NotNil(t, err, "target groups with targets cannot be deleted")
+
+	_, err = fake.DeregisterTargetsWithContext(ctx, &vpclattice.DeregisterTargetsInput{
+		TargetGroupIdentifier: created.Id,
+		Targets:               targets[:1],
+	})
+	assert.Nil(t, err)
+	assert.Len(t, fake.Targets(aws.StringValue(created.Id)), 1)
+	_, err = fake.DeregisterTargetsWithContext(ctx, &vpclattice.DeregisterTargetsInput{
+		TargetGroupIdentifier: created.Id,
+		Targets:               targets[1:],
+	})
+	assert.Nil(t, err)
+
+	_, err = fake.DeleteTargetGroupWithContext(ctx, &vpclattice.DeleteTargetGroupInput{TargetGroupIdentifier: created.Id})
+	assert.Nil(t, err)
+	_, err = fake.GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{TargetGroupIdentifier: created.Id})
+	assert.True(t, services.IsNotFoundError(err))
+	assert.Empty(t, fake.TargetGroups())
+}
+
+func Test_ListenerAndRule_RoundTrip(t *testing.T) {
+	ctx := context.TODO()
+	fake := New("account-id", "us-west-2")
+
+	svc, err := fake.CreateServiceWithContext(ctx, &vpclattice.CreateServiceInput{Name: aws.String("svc-name")})
+	assert.Nil(t, err)
+	tg, err := fake.CreateTargetGroupWithContext(ctx, &vpclattice.CreateTargetGroupInput{Name: aws.String("tg-name")})
+	assert.Nil(t, err)
+
+	listener, err := fake.CreateListenerWithContext(ctx, &vpclattice.CreateListenerInput{
+		ServiceIdentifier: svc.Id,
+		Name:              aws.String("listener"),
+		Port:              aws.Int64(80),
+		Protocol:          aws.String(vpclattice.ListenerProtocolHttp),
+		DefaultAction: &vpclattice.RuleAction{
+			FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(404)},
+		},
+	})
+	assert.Nil(t, err)
+	listeners, err := fake.ListListenersAsList(ctx, &vpclattice.ListListenersInput{ServiceIdentifier: svc.Id})
+	assert.Nil(t, err)
+	assert.Len(t, listeners, 1)
+
+	rule, err := fake.CreateRuleWithContext(ctx, &vpclattice.CreateRuleInput{
+		ServiceIdentifier:  svc.Id,
+		ListenerIdentifier: listener.Id,
+		Name:               aws.String("rule"),
+		Priority:           aws.Int64(1),
+		Action: &vpclattice.RuleAction{
+			Forward: &vpclattice.ForwardAction{
+				TargetGroups: []*vpclattice.WeightedTargetGroup{
+					{TargetGroupIdentifier: tg.Id, Weight: aws.Int64(1)},
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	rules, err := fake.GetRulesAsList(ctx, &vpclattice.ListRulesInput{
+		ServiceIdentifier:  svc.Id,
+		ListenerIdentifier: listener.Id,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, rules, 2, "includes the default rule")
+
+	// the target group is in use by the service through the rule
+	gotTg, err := fake.GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{TargetGroupIdentifier: tg.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, []*string{svc.Arn}, gotTg.ServiceArns)
+	_, err = fake.DeleteTargetGroupWithContext(ctx, &vpclattice.DeleteTargetGroupInput{TargetGroupIdentifier: tg.Id})
+	assert.NotNil(t, err)
+
+	_, err = fake.UpdateRuleWithContext(ctx, &vpclattice.UpdateRuleInput{
+		ServiceIdentifier:  svc.Id,
+		ListenerIdentifier: listener.Id,
+		RuleIdentifier:     rule.Arn,
+		Priority:           aws.Int64(2),
+	})
+	assert.Nil(t, err)
+	gotRule, err := fake.GetRuleWithContext(ctx, &vpclattice.GetRuleInput{
+		ServiceIdentifier:  svc.Id,
+		ListenerIdentifier: listener.Id,
+		RuleIdentifier:     rule.Id,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), aws.Int64Value(gotRule.Priority))
+
+	_, err = fake.DeleteRuleWithContext(ctx, &vpclattice.DeleteRuleInput{
+		ServiceIdentifier:  svc.Id,
+		ListenerIdentifier: listener.Id,
+		RuleIdentifier:     rule.Id,
+	})
+	assert.Nil(t, err)
+	_, err = fake.DeleteTargetGroupWithContext(ctx, &vpclattice.DeleteTargetGroupInput{TargetGroupIdentifier: tg.Id})
+	assert.Nil(t, err)
+
+	_, err = fake.DeleteListenerWithContext(ctx, &vpclattice.DeleteListenerInput{
+		ServiceIdentifier:  svc.Id,
+		ListenerIdentifier: listener.Id,
+	})
+	assert.Nil(t, err)
+	_, err = fake.GetListenerWithContext(ctx, &vpclattice.GetListenerInput{
+		ServiceIdentifier:  svc.Id,
+		ListenerIdentifier: listener.Id,
+	})
+	assert.True(t, services.IsNotFoundError(err))
+}
+
+func Test_Tags(t *testing.T) {
+	ctx := context.TODO()
+	fake := New("account-id", "us-west-2")
+	tg, err := fake.CreateTargetGroupWithContext(ctx, &vpclattice.CreateTargetGroupInput{
+		Name: aws.String("tg-name"),
+		Tags: services.Tags{"a": aws.String("1")},
+	})
+	assert.Nil(t, err)
+
+	_, err = fake.TagResourceWithContext(ctx, &vpclattice.TagResourceInput{
+		ResourceArn: tg.Arn,
+		Tags:        services.Tags{"b": aws.String("2")},
+	})
+	assert.Nil(t, err)
+	_, err = fake.UntagResourceWithContext(ctx, &vpclattice.UntagResourceInput{
+		ResourceArn: tg.Arn,
+		TagKeys:     []*string{aws.String("a")},
+	})
+	assert.Nil(t, err)
+
+	out, err := fake.ListTagsForResourceWithContext(ctx, &vpclattice.ListTagsForResourceInput{ResourceArn: tg.Arn})
+	assert.Nil(t, err)
+	assert.Equal(t, services.Tags{"b": aws.String("2")}, out.Tags)
+}
+
+func Test_CallRecordingAndErrors(t *testing.T) {
+	ctx := context.TODO()
+	fake := New("account-id", "us-west-2")
+
+	injected := errors.New("injected")
+	fake.SetError("CreateTargetGroupWithContext", injected)
+	_, err := fake.CreateTargetGroupWithContext(ctx, &vpclattice.CreateTargetGroupInput{Name: aws.String("tg-name")})
+	assert.Equal(t, injected, err)
+	assert.Empty(t, fake.TargetGroups())
+
+	fake.SetError("CreateTargetGroupWithContext", nil)
+	_, err = fake.CreateTargetGroupWithContext(ctx, &vpclattice.CreateTargetGroupInput{Name: aws.String("tg-name")})
+	assert.Nil(t, err)
+	_, err = fake.ListTargetGroupsAsList(ctx, &vpclattice.ListTargetGroupsInput{})
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, fake.CallCount("CreateTargetGroupWithContext"))
+	assert.Equal(t, 1, fake.CallCount("ListTargetGroupsAsList"))
+	calls := fake.Calls()
+	if assert.Len(t, calls, 3) {
+		assert.Equal(t, "ListTargetGroupsAsList", calls[2].Method)
+		assert.Equal(t, "tg-name", aws.StringValue(calls[1].Input.(*vpclattice.CreateTargetGroupInput).Name))
+	}
+
+	fake.ResetCalls()
+	assert.Empty(t, fake.Calls())
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,6 +20,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	"github.com/aws/aws-sdk-go/service/vpclattice/vpclatticeiface"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
@@ -48,6 +52,16 @@ func IsNotFoundError(err error) bool {
 	return errors.Is(err, ErrNotFound)
 }
 
+func IsServiceQuotaExceededError(err error) bool {
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && aerr.Code() == vpclattice.ErrCodeServiceQuotaExceededException
+}
+
+func IsAccessDeniedError(err error) bool {
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && aerr.Code() == vpclattice.ErrCodeAccessDeniedException
+}
+
 func IgnoreNotFound(err error) error {
 	if IsNotFoundError(err) {
 		return nil
@@ -91,6 +105,31 @@ func IsInvalidError(err error) bool {
 	return errors.As(err, &invalidErr)
 }
 
+// IsConnectivityError returns true when a request could not reach VPC Lattice or got no response,
+// as opposed to an error returned by the VPC Lattice API
+func IsConnectivityError(err error) bool {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		switch aerr.Code() {
+		case request.ErrCodeRequestError, request.ErrCodeResponseTimeout:
+			return true
+		}
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsServerError returns true when VPC Lattice failed to handle a request with a 5xx response, e.g.
+// during maintenance or a partial outage. Throttling errors are not server errors.
+func IsServerError(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) {
+		return reqErr.StatusCode() >= http.StatusInternalServerError && !request.IsErrorThrottle(reqErr)
+	}
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && aerr.Code() == vpclattice.ErrCodeInternalServerException
+}
+
 type Lattice interface {
 	vpclatticeiface.VPCLatticeAPI
 	ListListenersAsList(ctx context.Context, input *vpclattice.ListListenersInput) ([]*vpclattice.ListenerSummary, error)
@@ -103,7 +142,9 @@ type Lattice interface {
 	ListServiceNetworkVpcAssociationsAsList(ctx context.Context, input *vpclattice.ListServiceNetworkVpcAssociationsInput) ([]*vpclattice.ServiceNetworkVpcAssociationSummary, error)
 	ListServiceNetworkServiceAssociationsAsList(ctx context.Context, input *vpclattice.ListServiceNetworkServiceAssociationsInput) ([]*vpclattice.ServiceNetworkServiceAssociationSummary, error)
 	FindServiceNetwork(ctx context.Context, nameOrId string) (*ServiceNetworkInfo, error)
+	FindServiceNetworkByTags(ctx context.Context, selector map[string]string) (*ServiceNetworkInfo, error)
 	FindService(ctx context.Context, latticeServiceName string) (*vpclattice.ServiceSummary, error)
+	FindServices(ctx context.Context, latticeServiceName string) ([]*vpclattice.ServiceSummary, error)
 }
 
 type defaultLattice struct {
@@ -270,6 +311,14 @@ func (d *defaultLattice) TagResourceWithContext(ctx context.Context, input *vpcl
 	return d.VPCLatticeAPI.TagResourceWithContext(ctx, input, option...)
 }
 
+func (d *defaultLattice) UntagResourceWithContext(ctx context.Context, input *vpclattice.UntagResourceInput, option ...request.Option) (*vpclattice.UntagResourceOutput, error) {
+	if d.cache != nil {
+		key := tagCacheKey(*input.ResourceArn)
+		d.cache.Remove(key)
+	}
+	return d.VPCLatticeAPI.UntagResourceWithContext(ctx, input, option...)
+}
+
 func (d *defaultLattice) ListTargetsAsList(ctx context.Context, input *vpclattice.ListTargetsInput) ([]*vpclattice.TargetSummary, error) {
 	result := []*vpclattice.TargetSummary{}
 
@@ -377,14 +426,63 @@ func (d *defaultLattice) FindServiceNetwork(ctx context.Context, nameOrId string
 		return nil, err
 	}
 
-	// try to fetch tags only if SN in the same aws account with controller's config
+	tags, err := d.serviceNetworkTags(ctx, snMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceNetworkInfo{
+		SvcNetwork: *snMatch,
+		Tags:       tags,
+	}, nil
+}
+
+// FindServiceNetworkByTags finds the single service network whose tags include all the selector key-value pairs.
+// Only service networks whose tags can be read, i.e. the ones of the controller's account, can match. Returns a
+// ErrNameConflict error when several service networks match.
+func (d *defaultLattice) FindServiceNetworkByTags(ctx context.Context, selector map[string]string) (*ServiceNetworkInfo, error) {
+	input := &vpclattice.ListServiceNetworksInput{}
+	allSn, err := d.ListServiceNetworksAsList(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	selectorTags := aws.StringMap(selector)
+	var matches []*ServiceNetworkInfo
+	for _, sn := range allSn {
+		tags, err := d.serviceNetworkTags(ctx, sn)
+		if err != nil {
+			return nil, err
+		}
+		if containsTags(tags, selectorTags) {
+			matches = append(matches, &ServiceNetworkInfo{SvcNetwork: *sn, Tags: tags})
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, NewNotFoundError("Service network", labels.Set(selector).String())
+	case 1:
+		return matches[0], nil
+	default:
+		snMatch := make([]*vpclattice.ServiceNetworkSummary, len(matches))
+		for i, m := range matches {
+			snMatch[i] = &m.SvcNetwork
+		}
+		return nil, fmt.Errorf("%w, multiple SN found for selector %s: %s",
+			ErrNameConflict, labels.Set(selector).String(), d.snSummaryToLog(snMatch))
+	}
+}
+
+// try to fetch tags only if SN in the same aws account with controller's config
+func (d *defaultLattice) serviceNetworkTags(ctx context.Context, sn *vpclattice.ServiceNetworkSummary) (Tags, error) {
 	tags := Tags{}
-	isLocal, err := d.isLocalResource(aws.StringValue(snMatch.Arn))
+	isLocal, err := d.isLocalResource(aws.StringValue(sn.Arn))
 	if err != nil {
 		return nil, err
 	}
 	if isLocal {
-		tagsInput := vpclattice.ListTagsForResourceInput{ResourceArn: snMatch.Arn}
+		tagsInput := vpclattice.ListTagsForResourceInput{ResourceArn: sn.Arn}
 		tagsOutput, err := d.ListTagsForResourceWithContext(ctx, &tagsInput)
 		if err != nil {
 			aerr, ok := err.(awserr.Error)
@@ -397,23 +495,31 @@ func (d *defaultLattice) FindServiceNetwork(ctx context.Context, nameOrId string
 			tags = tagsOutput.Tags
 		}
 	}
-
-	return &ServiceNetworkInfo{
-		SvcNetwork: *snMatch,
-		Tags:       tags,
-	}, nil
+	return tags, nil
 }
 
-// see utils.LatticeServiceName
+// see utils.LatticeServiceName. When several services have the name, the first one of FindServices is returned.
 func (d *defaultLattice) FindService(ctx context.Context, latticeServiceName string) (*vpclattice.ServiceSummary, error) {
-	input := vpclattice.ListServicesInput{}
+	svcMatches, err := d.FindServices(ctx, latticeServiceName)
+	if err != nil {
+		return nil, err
+	}
+	return svcMatches[0], nil
+}
+
+// FindServices returns all the services with the name, or a not found error when there is none. Names are unique
+// within an account, but services shared by other accounts can have the same name. The service of the controller's
+// account comes first, then the others from the oldest, so that FindService always selects the same one.
+// ListServices cannot filter by name and a duplicate can be on any page, so every page of the services visible to
+// the account is listed: one ListServices call per page of up to 100 services, each time a service is looked up.
+func (d *defaultLattice) FindServices(ctx context.Context, latticeServiceName string) ([]*vpclattice.ServiceSummary, error) {
+	input := vpclattice.ListServicesInput{MaxResults: aws.Int64(100)}
 
-	var svcMatch *vpclattice.ServiceSummary
+	var svcMatches []*vpclattice.ServiceSummary
 	err := d.ListServicesPagesWithContext(ctx, &input, func(page *vpclattice.ListServicesOutput, lastPage bool) bool {
 		for _, svc := range page.Items {
 			if *svc.Name == latticeServiceName {
-				svcMatch = svc
-				return false
+				svcMatches = append(svcMatches, svc)
 			}
 		}
 		return true
@@ -422,11 +528,25 @@ func (d *defaultLattice) FindService(ctx context.Context, latticeServiceName str
 	if err != nil {
 		return nil, err
 	}
-	if svcMatch == nil {
+	if len(svcMatches) == 0 {
 		return nil, NewNotFoundError("Service", latticeServiceName)
 	}
 
-	return svcMatch, nil
+	isLocal := func(svc *vpclattice.ServiceSummary) bool {
+		local, err := d.isLocalResource(aws.StringValue(svc.Arn))
+		return err == nil && local
+	}
+	sort.SliceStable(svcMatches, func(i, j int) bool {
+		a, b := svcMatches[i], svcMatches[j]
+		if isLocal(a) != isLocal(b) {
+			return isLocal(a)
+		}
+		if !aws.TimeValue(a.CreatedAt).Equal(aws.TimeValue(b.CreatedAt)) {
+			return aws.TimeValue(a.CreatedAt).Before(aws.TimeValue(b.CreatedAt))
+		}
+		return aws.StringValue(a.Arn) < aws.StringValue(b.Arn)
+	})
+	return svcMatches, nil
 }
 
 func IsLatticeAPINotFoundErr(err error) bool {
@@ -1051,6 +1051,21 @@ func (mr *MockLatticeMockRecorder) FindService(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindService", reflect.TypeOf((*MockLattice)(nil).FindService), arg0, arg1)
 }
 
+// FindServices mocks base method.
+func (m *MockLattice) FindServices(arg0 context.Context, arg1 string) ([]*vpclattice.ServiceSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindServices", arg0, arg1)
+	ret0, _ := ret[0].([]*vpclattice.ServiceSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindServices indicates an expected call of FindServices.
+func (mr *MockLatticeMockRecorder) FindServices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindServices", reflect.TypeOf((*MockLattice)(nil).FindServices), arg0, arg1)
+}
+
 // FindServiceNetwork mocks base method.
 func (m *MockLattice) FindServiceNetwork(arg0 context.Context, arg1 string) (*ServiceNetworkInfo, error) {
 	m.ctrl.T.Helper()
@@ -1066,6 +1081,21 @@ func (mr *MockLatticeMockRecorder) FindServiceNetwork(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindServiceNetwork", reflect.TypeOf((*MockLattice)(nil).FindServiceNetwork), arg0, arg1)
 }
 
+// FindServiceNetworkByTags mocks base method.
+func (m *MockLattice) FindServiceNetworkByTags(arg0 context.Context, arg1 map[string]string) (*ServiceNetworkInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindServiceNetworkByTags", arg0, arg1)
+	ret0, _ := ret[0].(*ServiceNetworkInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindServiceNetworkByTags indicates an expected call of FindServiceNetworkByTags.
+func (mr *MockLatticeMockRecorder) FindServiceNetworkByTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindServiceNetworkByTags", reflect.TypeOf((*MockLattice)(nil).FindServiceNetworkByTags), arg0, arg1)
+}
+
 // GetAccessLogSubscription mocks base method.
 func (m *MockLattice) GetAccessLogSubscription(arg0 *vpclattice.GetAccessLogSubscriptionInput) (*vpclattice.GetAccessLogSubscriptionOutput, error) {
 	m.ctrl.T.Helper()
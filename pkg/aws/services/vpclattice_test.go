@@ -2,9 +2,13 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	"github.com/golang/mock/gomock"
@@ -23,6 +27,47 @@ func Test_IsNotFoundError(t *testing.T) {
 	assert.True(t, IsNotFoundError(blankNfEff))
 }
 
+func Test_IsServiceQuotaExceededError(t *testing.T) {
+	quotaErr := awserr.New(vpclattice.ErrCodeServiceQuotaExceededException, "quota exceeded", nil)
+
+	assert.True(t, IsServiceQuotaExceededError(quotaErr))
+	assert.True(t, IsServiceQuotaExceededError(fmt.Errorf("failed to create rule: %w", quotaErr)))
+	assert.False(t, IsServiceQuotaExceededError(awserr.New(vpclattice.ErrCodeConflictException, "conflict", nil)))
+	assert.False(t, IsServiceQuotaExceededError(errors.New("ERROR")))
+	assert.False(t, IsServiceQuotaExceededError(nil))
+}
+
+func Test_IsConnectivityError(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	requestErr := awserr.New(request.ErrCodeRequestError, "send request failed", dialErr)
+	notFoundErr := awserr.New(vpclattice.ErrCodeResourceNotFoundException, "not found", nil)
+
+	assert.True(t, IsConnectivityError(requestErr))
+	assert.True(t, IsConnectivityError(fmt.Errorf("failed to delete service: %w", requestErr)))
+	assert.True(t, IsConnectivityError(dialErr))
+	assert.False(t, IsConnectivityError(notFoundErr))
+	assert.False(t, IsConnectivityError(errors.New("ERROR")))
+	assert.False(t, IsConnectivityError(nil))
+}
+
+func Test_IsServerError(t *testing.T) {
+	internalErr := awserr.NewRequestFailure(
+		awserr.New(vpclattice.ErrCodeInternalServerException, "internal error", nil), 500, "req-id")
+	unavailableErr := awserr.NewRequestFailure(awserr.New("ServiceUnavailable", "unavailable", nil), 503, "req-id")
+	throttlingErr := awserr.NewRequestFailure(
+		awserr.New(vpclattice.ErrCodeThrottlingException, "slow down", nil), 503, "req-id")
+	notFoundErr := awserr.NewRequestFailure(
+		awserr.New(vpclattice.ErrCodeResourceNotFoundException, "not found", nil), 404, "req-id")
+
+	assert.True(t, IsServerError(internalErr))
+	assert.True(t, IsServerError(fmt.Errorf("failed to create service: %w", unavailableErr)))
+	assert.True(t, IsServerError(awserr.New(vpclattice.ErrCodeInternalServerException, "internal error", nil)))
+	assert.False(t, IsServerError(throttlingErr))
+	assert.False(t, IsServerError(notFoundErr))
+	assert.False(t, IsServerError(errors.New("ERROR")))
+	assert.False(t, IsServerError(nil))
+}
+
 func Test_defaultLattice_ListServiceNetworksAsList(t *testing.T) {
 	tests := []struct {
 		ctx        context.Context
@@ -426,6 +471,44 @@ func Test_defaultLattice_FindService_pagedResults(t *testing.T) {
 	assert.Nil(t, itemNotFound)
 }
 
+func Test_defaultLattice_FindServices_duplicates(t *testing.T) {
+	ctx := context.TODO()
+	c := gomock.NewController(t)
+	mockLattice := NewMockLattice(c)
+	d := &defaultLattice{VPCLatticeAPI: mockLattice, ownAccount: "111111111111"}
+
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	shared := func(account string, createdAt time.Time) *vpclattice.ServiceSummary {
+		return &vpclattice.ServiceSummary{
+			Name:      aws.String("s-name"),
+			Arn:       aws.String("arn:aws:vpc-lattice:us-west-2:" + account + ":service/svc-" + account),
+			CreatedAt: aws.Time(createdAt),
+		}
+	}
+	newerShared := shared("333333333333", newer)
+	olderShared := shared("222222222222", older)
+	// the service of the controller's account is the newest one, and on the last page
+	own := shared("111111111111", newer.Add(time.Hour))
+	other := &vpclattice.ServiceSummary{Name: aws.String("other-name"), Arn: aws.String("other-arn")}
+
+	mockLattice.EXPECT().ListServicesPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx aws.Context, input *vpclattice.ListServicesInput, f func(*vpclattice.ListServicesOutput, bool) bool, opts ...request.Option) error {
+			if f(&vpclattice.ListServicesOutput{Items: []*vpclattice.ServiceSummary{newerShared, other, olderShared}}, false) {
+				f(&vpclattice.ListServicesOutput{Items: []*vpclattice.ServiceSummary{own}}, true)
+			}
+			return nil
+		}).AnyTimes()
+
+	found, err := d.FindServices(ctx, "s-name")
+	assert.Nil(t, err)
+	assert.Equal(t, []*vpclattice.ServiceSummary{own, olderShared, newerShared}, found)
+
+	svc, err := d.FindService(ctx, "s-name")
+	assert.Nil(t, err)
+	assert.Equal(t, own, svc)
+}
+
 func Test_defaultLattice_FindService_errorsRaised(t *testing.T) {
 	ctx := context.TODO()
 	c := gomock.NewController(t)
@@ -530,6 +613,76 @@ func TestServiceNetworkMatch(t *testing.T) {
 
 }
 
+func Test_defaultLattice_FindServiceNetworkByTags(t *testing.T) {
+	const ownAccount = "123456789012"
+	snArn := func(account, id string) string {
+		return fmt.Sprintf("arn:aws:vpc-lattice:us-west-2:%s:servicenetwork/%s", account, id)
+	}
+	allSn := []*vpclattice.ServiceNetworkSummary{
+		{Name: aws.String("prod-a1b2"), Id: aws.String("sn-1"), Arn: aws.String(snArn(ownAccount, "sn-1"))},
+		{Name: aws.String("prod-c3d4"), Id: aws.String("sn-2"), Arn: aws.String(snArn(ownAccount, "sn-2"))},
+		{Name: aws.String("dev-e5f6"), Id: aws.String("sn-3"), Arn: aws.String(snArn(ownAccount, "sn-3"))},
+		{Name: aws.String("shared"), Id: aws.String("sn-4"), Arn: aws.String(snArn("111222333444", "sn-4"))},
+	}
+	snTags := map[string]Tags{
+		snArn(ownAccount, "sn-1"): {"environment": aws.String("prod"), "team": aws.String("payments")},
+		snArn(ownAccount, "sn-2"): {"environment": aws.String("prod"), "team": aws.String("orders")},
+		snArn(ownAccount, "sn-3"): {"environment": aws.String("dev"), "team": aws.String("payments")},
+	}
+
+	tests := []struct {
+		name       string
+		selector   map[string]string
+		outSnId    string
+		outErrType error
+	}{
+		{
+			name:     "unique match",
+			selector: map[string]string{"environment": "prod", "team": "payments"},
+			outSnId:  "sn-1",
+		},
+		{
+			name:       "no match",
+			selector:   map[string]string{"environment": "staging"},
+			outErrType: ErrNotFound,
+		},
+		{
+			name:       "ambiguous match",
+			selector:   map[string]string{"environment": "prod"},
+			outErrType: ErrNameConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			mockLattice := NewMockLattice(c)
+			d := &defaultLattice{VPCLatticeAPI: mockLattice, ownAccount: ownAccount}
+
+			mockLattice.EXPECT().ListServiceNetworksPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx aws.Context, input *vpclattice.ListServiceNetworksInput, f func(*vpclattice.ListServiceNetworksOutput, bool) bool, opts ...request.Option) error {
+					f(&vpclattice.ListServiceNetworksOutput{Items: allSn}, true)
+					return nil
+				})
+			// tags of the foreign service network are not read
+			mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx aws.Context, input *vpclattice.ListTagsForResourceInput, opts ...request.Option) (*vpclattice.ListTagsForResourceOutput, error) {
+					return &vpclattice.ListTagsForResourceOutput{Tags: snTags[*input.ResourceArn]}, nil
+				}).Times(3)
+
+			snInfo, err := d.FindServiceNetworkByTags(context.TODO(), tt.selector)
+			if tt.outErrType != nil {
+				assert.ErrorIs(t, err, tt.outErrType)
+				assert.Nil(t, snInfo)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.outSnId, aws.StringValue(snInfo.SvcNetwork.Id))
+				assert.Equal(t, "payments", aws.StringValue(snInfo.Tags["team"]))
+			}
+		})
+	}
+}
+
 func TestIsLocalResource(t *testing.T) {
 	type test struct {
 		name         string
@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/tracing"
+)
+
+const (
+	sdkHandlerStartSpan = "startTracingSpan"
+	sdkHandlerEndSpan   = "endTracingSpan"
+)
+
+type apiCallSpanKey struct{}
+
+// Every API call gets a span, child of the span of the calling reconcile when made with its context.
+// Retries of a call are part of its span.
+func injectTracingHandlers(handlers *request.Handlers) {
+	handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: sdkHandlerStartSpan,
+		Fn:   startSpan,
+	})
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: sdkHandlerEndSpan,
+		Fn:   endSpan,
+	})
+}
+
+func startSpan(r *request.Request) {
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", "aws-api"),
+		attribute.String("rpc.service", r.ClientInfo.ServiceID),
+		attribute.String("rpc.method", r.Operation.Name),
+	}
+	attrs = append(attrs, resourceAttributes(r.Params)...)
+	ctx, span := tracing.Tracer().Start(r.Context(), r.ClientInfo.ServiceID+"/"+r.Operation.Name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	r.SetContext(context.WithValue(ctx, apiCallSpanKey{}, span))
+}
+
+func endSpan(r *request.Request) {
+	// the context span is the caller's one if the call did not get to validation
+	span, ok := r.Context().Value(apiCallSpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	if r.RequestID != "" {
+		span.SetAttributes(attribute.String("aws.request_id", r.RequestID))
+	}
+	if r.HTTPResponse != nil {
+		span.SetAttributes(attribute.Int("http.status_code", r.HTTPResponse.StatusCode))
+	}
+	if r.Error != nil {
+		span.RecordError(r.Error)
+		span.SetStatus(codes.Error, r.Error.Error())
+	}
+	span.End()
+}
+
+// returns the identifiers, names and ARNs set in the request input, e.g. aws.request.ServiceIdentifier
+func resourceAttributes(params interface{}) []attribute.KeyValue {
+	v := reflect.ValueOf(params)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+	var attrs []attribute.KeyValue
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() || !isResourceField(field.Name) {
+			continue
+		}
+		value, ok := v.Field(i).Interface().(*string)
+		if !ok || value == nil {
+			continue
+		}
+		attrs = append(attrs, attribute.String("aws.request."+field.Name, *value))
+	}
+	return attrs
+}
+
+func isResourceField(name string) bool {
+	return strings.HasSuffix(name, "Identifier") || strings.HasSuffix(name, "Arn") || name == "Name"
+}
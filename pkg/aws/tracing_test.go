@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkaws "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestTracing_ReconcileSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Amzn-Requestid", "request-id")
+		if r.URL.Path == "/services/svc-0123456789abcdef" {
+			w.Write([]byte(`{"id": "svc-0123456789abcdef", "name": "svc"}`))
+			return
+		}
+		w.Header().Set("X-Amzn-Errortype", vpclattice.ErrCodeResourceNotFoundException)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "not found"}`))
+	}))
+	defer srv.Close()
+
+	sess := session.Must(session.NewSession(&sdkaws.Config{
+		Region:      sdkaws.String("us-west-2"),
+		Endpoint:    sdkaws.String(srv.URL),
+		Credentials: credentials.NewStaticCredentials("key", "secret", ""),
+		MaxRetries:  sdkaws.Int(0),
+	}))
+	injectTracingHandlers(&sess.Handlers)
+	lattice := vpclattice.New(sess)
+
+	ctx := gwlog.StartReconcileTrace(context.TODO(), gwlog.FallbackLogger, "route", "name", "ns")
+	_, err := lattice.GetServiceWithContext(ctx, &vpclattice.GetServiceInput{ServiceIdentifier: sdkaws.String("svc-0123456789abcdef")})
+	assert.Nil(t, err)
+	_, err = lattice.GetListenerWithContext(ctx, &vpclattice.GetListenerInput{
+		ServiceIdentifier:  sdkaws.String("svc-0123456789abcdef"),
+		ListenerIdentifier: sdkaws.String("listener-0123456789abcdef"),
+	})
+	assert.NotNil(t, err)
+	gwlog.EndReconcileTrace(ctx, gwlog.FallbackLogger)
+
+	spans := exporter.GetSpans()
+	if !assert.Len(t, spans, 3) {
+		return
+	}
+	getService, getListener, reconcile := spans[0], spans[1], spans[2]
+
+	assert.Equal(t, "reconcile route", reconcile.Name)
+	assert.False(t, reconcile.Parent.IsValid())
+	assert.Contains(t, reconcile.Attributes, attribute.String("k8s.resource.name", "name"))
+	assert.Contains(t, reconcile.Attributes, attribute.String("k8s.namespace.name", "ns"))
+
+	assert.Equal(t, "VPC Lattice/GetService", getService.Name)
+	assert.Equal(t, trace.SpanKindClient, getService.SpanKind)
+	assert.Equal(t, reconcile.SpanContext.SpanID(), getService.Parent.SpanID())
+	assert.Equal(t, reconcile.SpanContext.TraceID(), getService.SpanContext.TraceID())
+	assert.Contains(t, getService.Attributes, attribute.String("aws.request.ServiceIdentifier", "svc-0123456789abcdef"))
+	assert.Contains(t, getService.Attributes, attribute.String("aws.request_id", "request-id"))
+	assert.Equal(t, codes.Unset, getService.Status.Code)
+
+	assert.Equal(t, "VPC Lattice/GetListener", getListener.Name)
+	assert.Equal(t, reconcile.SpanContext.SpanID(), getListener.Parent.SpanID())
+	assert.Contains(t, getListener.Attributes, attribute.String("aws.request.ServiceIdentifier", "svc-0123456789abcdef"))
+	assert.Contains(t, getListener.Attributes, attribute.String("aws.request.ListenerIdentifier", "listener-0123456789abcdef"))
+	assert.Contains(t, getListener.Attributes, attribute.Int("http.status_code", http.StatusNotFound))
+	assert.Equal(t, codes.Error, getListener.Status.Code)
+}
+
+func TestTracing_NoopWithoutProvider(t *testing.T) {
+	ctx := gwlog.StartReconcileTrace(context.TODO(), gwlog.FallbackLogger, "route", "name", "ns")
+	assert.False(t, trace.SpanFromContext(ctx).IsRecording())
+	gwlog.EndReconcileTrace(ctx, gwlog.FallbackLogger)
+}
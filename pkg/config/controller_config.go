@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
+	"time"
 
 	"strings"
 
@@ -21,6 +23,7 @@ const (
 
 const (
 	REGION                          = "REGION"
+	AWS_REGION                      = "AWS_REGION"
 	CLUSTER_VPC_ID                  = "CLUSTER_VPC_ID"
 	CLUSTER_NAME                    = "CLUSTER_NAME"
 	DEFAULT_SERVICE_NETWORK         = "DEFAULT_SERVICE_NETWORK"
@@ -30,11 +33,21 @@ const (
 	DEV_MODE                        = "DEV_MODE"
 	WEBHOOK_ENABLED                 = "WEBHOOK_ENABLED"
 	ROUTE_MAX_CONCURRENT_RECONCILES = "ROUTE_MAX_CONCURRENT_RECONCILES"
+	UNSUPPORTED_KIND_REQUEUE        = "UNSUPPORTED_KIND_REQUEUE"
+	CREDENTIALS_EXPIRY_WINDOW       = "CREDENTIALS_EXPIRY_WINDOW"
+	LISTENER_RULE_LIMIT             = "LISTENER_RULE_LIMIT"
 )
 
 var VpcID = ""
 var AccountID = ""
 var Region = ""
+
+// Set with --aws-region, the region of the VPC Lattice endpoint. Takes precedence over the REGION and AWS_REGION
+// environment variables and the instance metadata, which is not available outside of EC2
+var RegionOverride = ""
+
+// e.g. us-west-2, us-gov-east-1, cn-north-1
+var regionPattern = regexp.MustCompile(`^[a-z]{2,4}(-[a-z]+)+-[0-9]+$`)
 var DefaultServiceNetwork = ""
 var ClusterName = ""
 var DevMode = ""
@@ -43,6 +56,199 @@ var WebhookEnabled = ""
 var DisableTaggingServiceAPI = false
 var ServiceNetworkOverrideMode = false
 var RouteMaxConcurrentReconciles = 1
+var UnsupportedKindRequeue = time.Minute
+var CredentialsExpiryWindow = 5 * time.Minute
+
+// Requeue delay of reconciles failing on VPC Lattice 5xx errors, longer than the rate limited requeue
+// of other errors so partial outages are not retried in a hot loop
+var ServerErrorRequeue = time.Minute
+
+// ListenerRuleLimit is the VPC Lattice quota of rules per listener, excluding the default rule
+var ListenerRuleLimit = 10
+
+// Lattice rule priorities range from 1 to 100, bounding the number of rules of a listener
+const maxListenerRuleLimit = 100
+
+// Set with --finalizer-removal-on-lattice-unreachable, finalizers of deleted resources are removed after
+// FinalizerRemovalMaxAttempts cleanup attempts failing because VPC Lattice is unreachable
+var FinalizerRemovalOnLatticeUnreachable = false
+var FinalizerRemovalMaxAttempts = 10
+
+// Default health check settings of target groups, for settings not set by a TargetGroupPolicy.
+// Set with --http-health-check-path, --grpc-health-check-path and --grpc-health-check-enabled
+var HTTPHealthCheckPath = "/"
+var GRPCHealthCheckPath = "/grpc.health.v1.Health/Check"
+var GRPCHealthCheckEnabled = false
+
+// Set with --resync-period, the period after which all cached objects are reconciled again to correct drift.
+// Shorter periods correct drift sooner at the cost of more VPC Lattice API requests
+var ResyncPeriod = 10 * time.Hour
+
+const MinResyncPeriod = time.Minute
+
+// Set with --controller-id, part of the ownership tag of VPC Lattice resources so controllers running
+// in the same cluster and VPC do not manage each other's resources
+var ControllerID = ""
+
+// Set with --disable-service-network-creation, the controller never creates service networks, e.g. when they
+// are provisioned with infrastructure as code. The default service network is only associated with the VPC if it exists
+var ServiceNetworkCreationDisabled = false
+
+// Set with --reconcile-debounce, the delay of reconciles triggered by spec edits. Edits made within the
+// delay are reconciled once, with the latest spec
+var ReconcileDebounce = time.Second
+
+// Set with --drift-detection-interval, the interval at which the VPC Lattice state of managed resources is
+// compared to their desired state. Drift detection is disabled when 0
+var DriftDetectionInterval time.Duration
+
+// Set with --route-dns-configmap, the name of the ConfigMap maintained in every namespace with routes, mapping
+// each route to the DNS name of its VPC Lattice service. Disabled when empty
+var RouteDNSConfigMap = ""
+
+// Set with --snapshot-dir, the directory the desired stack of every route is saved into once deployed, for
+// apply-snapshot to rebuild the VPC Lattice configuration after a loss. Disabled when empty
+var SnapshotDir = ""
+
+// Set with --backend-health-gating-interval, the interval at which routes with weighted backendRefs are reconciled
+// again, leaving backends without healthy targets out of the weighted target groups. Disabled when 0
+var BackendHealthGatingInterval time.Duration
+
+// Set with --quota-backpressure-threshold, the usage in percent of the services per service network quota from
+// which the VPC Lattice services of new routes are not created anymore, as of the last quota usage poll. Disabled when 0
+var QuotaBackpressureThreshold = 0
+
+// EmptyEndpointsPolicy decides how target groups of Services without endpoints are built, e.g. of Services
+// created moments ago whose EndpointSlices are not populated yet
+type EmptyEndpointsPolicy string
+
+const (
+	// Target groups are built without targets, the Service is reconciled again once it has endpoints.
+	EmptyEndpointsPolicyAccept EmptyEndpointsPolicy = "accept"
+	// Building target groups fails, and is retried until the Service has endpoints.
+	EmptyEndpointsPolicyRequeue EmptyEndpointsPolicy = "requeue"
+)
+
+// Set with --empty-endpoints-policy
+var EmptyEndpoints = EmptyEndpointsPolicyAccept
+
+func ParseEmptyEndpointsPolicy(s string) (EmptyEndpointsPolicy, error) {
+	switch policy := EmptyEndpointsPolicy(s); policy {
+	case EmptyEndpointsPolicyAccept, EmptyEndpointsPolicyRequeue:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid empty endpoints policy %q, must be one of %q, %q",
+			s, EmptyEndpointsPolicyAccept, EmptyEndpointsPolicyRequeue)
+	}
+}
+
+// NoValidBackendsPolicy decides what a route rule does once none of its backendRefs is valid, e.g. after all
+// its Services were deleted
+type NoValidBackendsPolicy string
+
+const (
+	// The rule stops forwarding and responds with a 404 fixed response, the route reports the missing backends.
+	NoValidBackendsPolicyTearDown NoValidBackendsPolicy = "teardown"
+	// The rule keeps forwarding to the target groups it was last deployed with, until a backendRef is valid again.
+	NoValidBackendsPolicyKeepLastKnownGood NoValidBackendsPolicy = "keep-last-known-good"
+)
+
+// Set with --no-valid-backends-policy
+var NoValidBackends = NoValidBackendsPolicyTearDown
+
+func ParseNoValidBackendsPolicy(s string) (NoValidBackendsPolicy, error) {
+	switch policy := NoValidBackendsPolicy(s); policy {
+	case NoValidBackendsPolicyTearDown, NoValidBackendsPolicyKeepLastKnownGood:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid no valid backends policy %q, must be one of %q, %q",
+			s, NoValidBackendsPolicyTearDown, NoValidBackendsPolicyKeepLastKnownGood)
+	}
+}
+
+// UnknownAnnotationsPolicy decides how Gateways and routes with unknown application-networking.k8s.aws/ annotation
+// keys are handled, e.g. typos of the keys of the controller
+type UnknownAnnotationsPolicy string
+
+const (
+	// Unknown annotation keys are ignored.
+	UnknownAnnotationsLenient UnknownAnnotationsPolicy = "lenient"
+	// Gateways and routes with unknown annotation keys are not accepted, their status names the unknown keys.
+	UnknownAnnotationsStrict UnknownAnnotationsPolicy = "strict"
+)
+
+// Set with --unknown-annotations
+var UnknownAnnotations = UnknownAnnotationsLenient
+
+func ParseUnknownAnnotationsPolicy(s string) (UnknownAnnotationsPolicy, error) {
+	switch policy := UnknownAnnotationsPolicy(s); policy {
+	case UnknownAnnotationsLenient, UnknownAnnotationsStrict:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid unknown annotations policy %q, must be one of %q, %q",
+			s, UnknownAnnotationsLenient, UnknownAnnotationsStrict)
+	}
+}
+
+// PolicyTargetRefChangePolicy decides what happens when the targetRef of an IAMAuthPolicy is edited
+type PolicyTargetRefChangePolicy string
+
+const (
+	// The validating webhook rejects targetRef changes, policies are deleted and recreated to move them.
+	PolicyTargetRefChangeReject PolicyTargetRefChangePolicy = "reject"
+	// targetRef changes are accepted, the policy is removed from the previous target and applied to the new one.
+	PolicyTargetRefChangeMove PolicyTargetRefChangePolicy = "move"
+)
+
+// Set with --policy-target-ref-change
+var PolicyTargetRefChange = PolicyTargetRefChangeReject
+
+func ParsePolicyTargetRefChangePolicy(s string) (PolicyTargetRefChangePolicy, error) {
+	switch policy := PolicyTargetRefChangePolicy(s); policy {
+	case PolicyTargetRefChangeReject, PolicyTargetRefChangeMove:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid policy targetRef change policy %q, must be one of %q, %q",
+			s, PolicyTargetRefChangeReject, PolicyTargetRefChangeMove)
+	}
+}
+
+// PolicyAnnotationRetention decides how much of the last applied IAMAuthPolicy document is kept in its annotations
+type PolicyAnnotationRetention string
+
+const (
+	// Only the hash of the last applied policy document is kept.
+	PolicyAnnotationRetentionHash PolicyAnnotationRetention = "hash"
+	// The full last applied policy document is kept next to its hash, e.g. to inspect what was applied to VPC Lattice.
+	PolicyAnnotationRetentionFull PolicyAnnotationRetention = "full"
+)
+
+// Set with --policy-annotation-retention
+var PolicyRetention = PolicyAnnotationRetentionHash
+
+func ParsePolicyAnnotationRetention(s string) (PolicyAnnotationRetention, error) {
+	switch retention := PolicyAnnotationRetention(s); retention {
+	case PolicyAnnotationRetentionHash, PolicyAnnotationRetentionFull:
+		return retention, nil
+	default:
+		return "", fmt.Errorf("invalid policy annotation retention %q, must be one of %q, %q",
+			s, PolicyAnnotationRetentionHash, PolicyAnnotationRetentionFull)
+	}
+}
+
+func ValidateResyncPeriod(period time.Duration) error {
+	if period < MinResyncPeriod {
+		return fmt.Errorf("invalid value for --resync-period: %s, must be at least %s", period, MinResyncPeriod)
+	}
+	return nil
+}
+
+func ValidateRegion(region string) error {
+	if !regionPattern.MatchString(region) {
+		return fmt.Errorf("invalid region %q, must be an AWS region name, e.g. us-west-2", region)
+	}
+	return nil
+}
 
 func ConfigInit() error {
 	sess, _ := session.NewSession()
@@ -64,13 +270,23 @@ func configInit(sess *session.Session, metadata EC2Metadata) error {
 		}
 	}
 
-	Region = os.Getenv(REGION)
+	Region = RegionOverride
+	if Region == "" {
+		Region = os.Getenv(REGION)
+	}
+	if Region == "" {
+		// set by EKS for pods using IAM roles for service accounts
+		Region = os.Getenv(AWS_REGION)
+	}
 	if Region == "" {
 		Region, err = metadata.Region()
 		if err != nil {
 			return fmt.Errorf("region is not specified: %s", err)
 		}
 	}
+	if err = ValidateRegion(Region); err != nil {
+		return err
+	}
 
 	AccountID = os.Getenv(AWS_ACCOUNT_ID)
 	if AccountID == "" {
@@ -107,6 +323,33 @@ func configInit(sess *session.Session, metadata EC2Metadata) error {
 		RouteMaxConcurrentReconciles = routeMaxConcurrentReconcilesInt
 	}
 
+	unsupportedKindRequeue := os.Getenv(UNSUPPORTED_KIND_REQUEUE)
+	if unsupportedKindRequeue != "" {
+		unsupportedKindRequeueDuration, err := time.ParseDuration(unsupportedKindRequeue)
+		if err != nil || unsupportedKindRequeueDuration <= 0 {
+			return fmt.Errorf("invalid value for UNSUPPORTED_KIND_REQUEUE: %s", unsupportedKindRequeue)
+		}
+		UnsupportedKindRequeue = unsupportedKindRequeueDuration
+	}
+
+	credentialsExpiryWindow := os.Getenv(CREDENTIALS_EXPIRY_WINDOW)
+	if credentialsExpiryWindow != "" {
+		credentialsExpiryWindowDuration, err := time.ParseDuration(credentialsExpiryWindow)
+		if err != nil || credentialsExpiryWindowDuration < 0 {
+			return fmt.Errorf("invalid value for CREDENTIALS_EXPIRY_WINDOW: %s", credentialsExpiryWindow)
+		}
+		CredentialsExpiryWindow = credentialsExpiryWindowDuration
+	}
+
+	listenerRuleLimit := os.Getenv(LISTENER_RULE_LIMIT)
+	if listenerRuleLimit != "" {
+		listenerRuleLimitInt, err := strconv.Atoi(listenerRuleLimit)
+		if err != nil || listenerRuleLimitInt < 1 || listenerRuleLimitInt > maxListenerRuleLimit {
+			return fmt.Errorf("invalid value for LISTENER_RULE_LIMIT: %s", listenerRuleLimit)
+		}
+		ListenerRuleLimit = listenerRuleLimitInt
+	}
+
 	return nil
 }
 
@@ -2,8 +2,8 @@ package config
 
 import (
 	"errors"
-	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -27,26 +27,35 @@ func ec2MetadataUnavailable() EC2Metadata {
 	return ec2MetadataUnavaialble{}
 }
 
+// sets the env vars configInit requires, so that a test fails on the value it checks
+func setRequiredEnv(t *testing.T) {
+	t.Setenv(REGION, "us-west-2")
+	t.Setenv(CLUSTER_VPC_ID, "vpc-123456")
+	t.Setenv(AWS_ACCOUNT_ID, "12345678")
+	t.Setenv(CLUSTER_NAME, "cluster-name")
+	t.Setenv(ROUTE_MAX_CONCURRENT_RECONCILES, "1")
+}
+
 func Test_config_init_with_partial_env_var(t *testing.T) {
 	// Test variable
 	testRegion := "us-west-2"
 	testClusterVpcId := "vpc-123456"
 	testClusterLocalGateway := "default"
 
-	os.Setenv(REGION, testRegion)
-	os.Setenv(CLUSTER_VPC_ID, testClusterVpcId)
-	os.Setenv(DEFAULT_SERVICE_NETWORK, testClusterLocalGateway)
-	os.Unsetenv(AWS_ACCOUNT_ID)
+	t.Setenv(REGION, testRegion)
+	t.Setenv(CLUSTER_VPC_ID, testClusterVpcId)
+	t.Setenv(DEFAULT_SERVICE_NETWORK, testClusterLocalGateway)
+	t.Setenv(AWS_ACCOUNT_ID, "")
 	err := configInit(nil, ec2MetadataUnavailable())
 	assert.NotNil(t, err)
 }
 
 func Test_config_init_no_env_var(t *testing.T) {
-	os.Unsetenv(REGION)
-	os.Unsetenv(CLUSTER_VPC_ID)
-	os.Unsetenv(DEFAULT_SERVICE_NETWORK)
-	os.Unsetenv(AWS_ACCOUNT_ID)
-	os.Unsetenv(ROUTE_MAX_CONCURRENT_RECONCILES)
+	t.Setenv(REGION, "")
+	t.Setenv(CLUSTER_VPC_ID, "")
+	t.Setenv(DEFAULT_SERVICE_NETWORK, "")
+	t.Setenv(AWS_ACCOUNT_ID, "")
+	t.Setenv(ROUTE_MAX_CONCURRENT_RECONCILES, "")
 	err := configInit(nil, ec2MetadataUnavailable())
 	assert.NotNil(t, err)
 
@@ -61,13 +70,19 @@ func Test_config_init_with_all_env_var(t *testing.T) {
 	testClusterName := "cluster-name"
 	testMaxRouteReconciles := "5"
 	testMaxRouteReconcilesInt := 5
+	testUnsupportedKindRequeue := "30s"
+	testCredentialsExpiryWindow := "10m"
+	testListenerRuleLimit := "50"
 
-	os.Setenv(REGION, testRegion)
-	os.Setenv(CLUSTER_VPC_ID, testClusterVpcId)
-	os.Setenv(DEFAULT_SERVICE_NETWORK, testClusterLocalGateway)
-	os.Setenv(AWS_ACCOUNT_ID, testAwsAccountId)
-	os.Setenv(CLUSTER_NAME, testClusterName)
-	os.Setenv(ROUTE_MAX_CONCURRENT_RECONCILES, testMaxRouteReconciles)
+	t.Setenv(REGION, testRegion)
+	t.Setenv(CLUSTER_VPC_ID, testClusterVpcId)
+	t.Setenv(DEFAULT_SERVICE_NETWORK, testClusterLocalGateway)
+	t.Setenv(AWS_ACCOUNT_ID, testAwsAccountId)
+	t.Setenv(CLUSTER_NAME, testClusterName)
+	t.Setenv(ROUTE_MAX_CONCURRENT_RECONCILES, testMaxRouteReconciles)
+	t.Setenv(UNSUPPORTED_KIND_REQUEUE, testUnsupportedKindRequeue)
+	t.Setenv(CREDENTIALS_EXPIRY_WINDOW, testCredentialsExpiryWindow)
+	t.Setenv(LISTENER_RULE_LIMIT, testListenerRuleLimit)
 	err := configInit(nil, ec2MetadataUnavailable())
 	assert.Nil(t, err)
 	assert.Equal(t, testRegion, Region)
@@ -76,13 +91,109 @@ func Test_config_init_with_all_env_var(t *testing.T) {
 	assert.Equal(t, testClusterLocalGateway, DefaultServiceNetwork)
 	assert.Equal(t, testClusterName, ClusterName)
 	assert.Equal(t, testMaxRouteReconcilesInt, RouteMaxConcurrentReconciles)
+	assert.Equal(t, 30*time.Second, UnsupportedKindRequeue)
+	assert.Equal(t, 10*time.Minute, CredentialsExpiryWindow)
+	assert.Equal(t, 50, ListenerRuleLimit)
 }
 
 func Test_bad_reconcile_value(t *testing.T) {
 	// Test variable
 	maxReconciles := "FOO"
 
-	os.Setenv(ROUTE_MAX_CONCURRENT_RECONCILES, maxReconciles)
+	setRequiredEnv(t)
+	t.Setenv(ROUTE_MAX_CONCURRENT_RECONCILES, maxReconciles)
 	err := configInit(nil, ec2MetadataUnavailable())
 	assert.NotNil(t, err)
 }
+
+func Test_bad_unsupported_kind_requeue_value(t *testing.T) {
+	setRequiredEnv(t)
+	for _, value := range []string{"FOO", "0s", "-1m"} {
+		t.Setenv(UNSUPPORTED_KIND_REQUEUE, value)
+		err := configInit(nil, ec2MetadataUnavailable())
+		assert.NotNil(t, err, value)
+	}
+}
+
+func Test_bad_credentials_expiry_window_value(t *testing.T) {
+	setRequiredEnv(t)
+	for _, value := range []string{"FOO", "-1m"} {
+		t.Setenv(CREDENTIALS_EXPIRY_WINDOW, value)
+		err := configInit(nil, ec2MetadataUnavailable())
+		assert.NotNil(t, err, value)
+	}
+}
+
+func Test_bad_listener_rule_limit_value(t *testing.T) {
+	setRequiredEnv(t)
+	for _, value := range []string{"FOO", "0", "101"} {
+		t.Setenv(LISTENER_RULE_LIMIT, value)
+		err := configInit(nil, ec2MetadataUnavailable())
+		assert.NotNil(t, err, value)
+	}
+}
+
+func Test_config_init_region(t *testing.T) {
+	defer func(override string) { RegionOverride = override }(RegionOverride)
+	t.Setenv(CLUSTER_VPC_ID, "vpc-123456")
+	t.Setenv(AWS_ACCOUNT_ID, "12345678")
+	t.Setenv(CLUSTER_NAME, "cluster-name")
+	t.Setenv(ROUTE_MAX_CONCURRENT_RECONCILES, "")
+
+	tests := []struct {
+		name      string
+		override  string
+		region    string
+		awsRegion string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "override takes precedence",
+			override:  "eu-west-1",
+			region:    "us-west-2",
+			awsRegion: "us-east-1",
+			want:      "eu-west-1",
+		},
+		{
+			name:      "REGION takes precedence over AWS_REGION",
+			region:    "us-west-2",
+			awsRegion: "us-east-1",
+			want:      "us-west-2",
+		},
+		{
+			name:      "AWS_REGION",
+			awsRegion: "us-gov-east-1",
+			want:      "us-gov-east-1",
+		},
+		{
+			name:    "no region and no metadata",
+			wantErr: true,
+		},
+		{
+			name:     "invalid override",
+			override: "us-west-2a",
+			region:   "us-west-2",
+			wantErr:  true,
+		},
+		{
+			name:    "invalid REGION",
+			region:  "US West",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegionOverride = tt.override
+			t.Setenv(REGION, tt.region)
+			t.Setenv(AWS_REGION, tt.awsRegion)
+			err := configInit(nil, ec2MetadataUnavailable())
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, Region)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+const (
+	EffectiveConfigPath = "/config"
+
+	redacted = "REDACTED"
+)
+
+// names of flags which could hold credentials, or values treated like them, e.g. the external ID of assumed roles
+var sensitiveFlagName = regexp.MustCompile(`(?i)(secret|password|token|credential|key|external-id)`)
+
+// EffectiveConfig returns the resolved configuration of the controller, all flags of the given flag set
+// with their defaults applied and the configuration read from the environment or instance metadata.
+// Values of sensitive flags and passwords in URLs are redacted.
+func EffectiveConfig(flags *flag.FlagSet) map[string]interface{} {
+	flagValues := map[string]string{}
+	flags.VisitAll(func(f *flag.Flag) {
+		flagValues[f.Name] = redact(f.Name, f.Value.String())
+	})
+	return map[string]interface{}{
+		"flags": flagValues,
+		"environment": map[string]interface{}{
+			REGION:                          Region,
+			CLUSTER_VPC_ID:                  VpcID,
+			CLUSTER_NAME:                    ClusterName,
+			AWS_ACCOUNT_ID:                  AccountID,
+			DEFAULT_SERVICE_NETWORK:         DefaultServiceNetwork,
+			ENABLE_SERVICE_NETWORK_OVERRIDE: ServiceNetworkOverrideMode,
+			DISABLE_TAGGING_SERVICE_API:     DisableTaggingServiceAPI,
+			DEV_MODE:                        DevMode,
+			WEBHOOK_ENABLED:                 WebhookEnabled,
+			ROUTE_MAX_CONCURRENT_RECONCILES: RouteMaxConcurrentReconciles,
+			UNSUPPORTED_KIND_REQUEUE:        UnsupportedKindRequeue.String(),
+			CREDENTIALS_EXPIRY_WINDOW:       CredentialsExpiryWindow.String(),
+			LISTENER_RULE_LIMIT:             ListenerRuleLimit,
+		},
+	}
+}
+
+func redact(name string, value string) string {
+	if value == "" {
+		return value
+	}
+	if sensitiveFlagName.MatchString(name) {
+		return redacted
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+			return u.String()
+		}
+	}
+	return value
+}
+
+// EffectiveConfigHandler serves the effective configuration as JSON.
+func EffectiveConfigHandler(flags *flag.FlagSet) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(EffectiveConfig(flags))
+	})
+}
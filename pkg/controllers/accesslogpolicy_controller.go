@@ -24,8 +24,8 @@ import (
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -41,11 +41,15 @@ import (
 	"github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
 	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
 	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/resync"
 	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
@@ -72,6 +76,7 @@ func RegisterAccessLogPolicyController(
 	cloud aws.Cloud,
 	finalizerManager k8s.FinalizerManager,
 	mgr ctrl.Manager,
+	resyncer *resync.Resyncer,
 ) error {
 	mgrClient := mgr.GetClient()
 	scheme := mgr.GetScheme()
@@ -93,14 +98,37 @@ func RegisterAccessLogPolicyController(
 		stackMarshaller:  stackMarshaller,
 	}
 
+	tracker := metrics.NewQueueTracker("AccessLogPolicy")
+	gate := eventhandlers.NewPriorityGate(1)
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&anv1alpha1.AccessLogPolicy{}, pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Watches(&gwv1beta1.Gateway{}, handler.EnqueueRequestsFromMapFunc(r.findImpactedAccessLogPolicies), pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Watches(&gwv1beta1.HTTPRoute{}, handler.EnqueueRequestsFromMapFunc(r.findImpactedAccessLogPolicies), pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Watches(&gwv1alpha2.GRPCRoute{}, handler.EnqueueRequestsFromMapFunc(r.findImpactedAccessLogPolicies), pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Watches(&gwv1alpha2.TLSRoute{}, handler.EnqueueRequestsFromMapFunc(r.findImpactedAccessLogPolicies), pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+		Named("accesslogpolicy").
+		Watches(&anv1alpha1.AccessLogPolicy{}, tracker.EventHandler(gate.EventHandler(eventhandlers.Debounce(&handler.EnqueueRequestForObject{}, config.ReconcileDebounce))), pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&gwv1beta1.Gateway{}, tracker.EventHandler(handler.EnqueueRequestsFromMapFunc(r.findImpactedAccessLogPolicies)), pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&gwv1beta1.HTTPRoute{}, tracker.EventHandler(handler.EnqueueRequestsFromMapFunc(r.findImpactedAccessLogPolicies)), pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	// GRPCRoute and TLSRoute are only installed with the experimental channel of the Gateway API CRDs
+	for _, route := range []struct {
+		gvk schema.GroupVersionKind
+		obj client.Object
+	}{
+		{k8s.GRPCRouteKind, &gwv1alpha2.GRPCRoute{}},
+		{k8s.TLSRouteKind, &gwv1alpha2.TLSRoute{}},
+	} {
+		if ok, err := k8s.IsGVKSupported(mgr, route.gvk.GroupVersion().String(), route.gvk.Kind); ok {
+			builder.Watches(route.obj, tracker.EventHandler(handler.EnqueueRequestsFromMapFunc(r.findImpactedAccessLogPolicies)), pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+		} else {
+			if err != nil {
+				return err
+			}
+			log.Infof(context.TODO(), "%s CRD is not installed, skipping watch", route.gvk.Kind)
+		}
+	}
+
+	if resyncer != nil {
+		builder.WatchesRawSource(resyncer.Source(&anv1alpha1.AccessLogPolicyList{}), tracker.EventHandler(gate.Resync(&handler.EnqueueRequestForObject{})))
+	}
 
-	return builder.Complete(r)
+	return builder.Complete(tracker.Reconciler(gate.Reconciler(r)))
 }
 
 func (r *accessLogPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -168,7 +196,7 @@ func (r *accessLogPolicyReconciler) reconcileUpsert(ctx context.Context, alp *an
 		message := fmt.Sprintf("The targetRef's Group must be \"%s\" but was \"%s\"",
 			gwv1beta1.GroupName, alp.Spec.TargetRef.Group)
 		r.eventRecorder.Event(alp, corev1.EventTypeWarning, k8s.FailedReconcileEvent, message)
-		return r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonInvalid, message)
+		return r.updateAccessLogPolicyStatus(ctx, alp, conditions.ReasonInvalid, message)
 	}
 
 	validKinds := []string{"Gateway", "HTTPRoute", "GRPCRoute"}
@@ -176,7 +204,10 @@ func (r *accessLogPolicyReconciler) reconcileUpsert(ctx context.Context, alp *an
 		message := fmt.Sprintf("The targetRef's Kind must be \"Gateway\", \"HTTPRoute\", or \"GRPCRoute\""+
 			" but was \"%s\"", alp.Spec.TargetRef.Kind)
 		r.eventRecorder.Event(alp, corev1.EventTypeWarning, k8s.FailedReconcileEvent, message)
-		return r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonInvalid, message)
+		if err := r.updateAccessLogPolicyStatus(ctx, alp, conditions.ReasonUnsupportedKind, message); err != nil {
+			return err
+		}
+		return lattice_runtime.NewRequeueNeededAfter(message, config.UnsupportedKindRequeue)
 	}
 
 	targetRefNamespace := k8s.NamespaceOrDefault(alp.Spec.TargetRef.Namespace)
@@ -184,7 +215,7 @@ func (r *accessLogPolicyReconciler) reconcileUpsert(ctx context.Context, alp *an
 		message := fmt.Sprintf("The targetRef's namespace, \"%s\", does not match the Access Log Policy's"+
 			" namespace, \"%s\"", string(*alp.Spec.TargetRef.Namespace), alp.Namespace)
 		r.eventRecorder.Event(alp, corev1.EventTypeWarning, k8s.FailedReconcileEvent, message)
-		return r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonInvalid, message)
+		return r.updateAccessLogPolicyStatus(ctx, alp, conditions.ReasonInvalid, message)
 	}
 
 	targetRefExists, err := r.targetRefExists(ctx, alp)
@@ -194,7 +225,7 @@ func (r *accessLogPolicyReconciler) reconcileUpsert(ctx context.Context, alp *an
 	if !targetRefExists {
 		message := fmt.Sprintf("%s target \"%s/%s\" could not be found", alp.Spec.TargetRef.Kind, targetRefNamespace, alp.Spec.TargetRef.Name)
 		r.eventRecorder.Event(alp, corev1.EventTypeWarning, k8s.FailedReconcileEvent, message)
-		return r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonTargetNotFound, message)
+		return r.updateAccessLogPolicyStatus(ctx, alp, conditions.ReasonTargetNotFound, message)
 	}
 
 	stack, err := r.buildAndDeployModel(ctx, alp)
@@ -202,11 +233,11 @@ func (r *accessLogPolicyReconciler) reconcileUpsert(ctx context.Context, alp *an
 		if services.IsConflictError(err) {
 			message := "An Access Log Policy with a Destination Arn for the same destination type already exists for this targetRef"
 			r.eventRecorder.Event(alp, corev1.EventTypeWarning, k8s.FailedReconcileEvent, message)
-			return r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonConflicted, message)
+			return r.updateAccessLogPolicyStatus(ctx, alp, conditions.ReasonConflicted, message)
 		} else if services.IsInvalidError(err) {
 			message := fmt.Sprintf("The AWS resource with Destination Arn \"%s\" could not be found", *alp.Spec.DestinationArn)
 			r.eventRecorder.Event(alp, corev1.EventTypeWarning, k8s.FailedReconcileEvent, message)
-			return r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonInvalid, message)
+			return r.updateAccessLogPolicyStatus(ctx, alp, conditions.ReasonInvalid, message)
 		}
 		r.eventRecorder.Event(alp, corev1.EventTypeWarning, k8s.FailedReconcileEvent,
 			"Failed to create or update due to "+err.Error())
@@ -218,7 +249,7 @@ func (r *accessLogPolicyReconciler) reconcileUpsert(ctx context.Context, alp *an
 		return err
 	}
 
-	err = r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonAccepted, config.LatticeGatewayControllerName)
+	err = r.updateAccessLogPolicyStatus(ctx, alp, conditions.ReasonAccepted, config.LatticeGatewayControllerName)
 	if err != nil {
 		return err
 	}
@@ -313,26 +344,17 @@ func (r *accessLogPolicyReconciler) updateAccessLogPolicyAnnotations(
 func (r *accessLogPolicyReconciler) updateAccessLogPolicyStatus(
 	ctx context.Context,
 	alp *anv1alpha1.AccessLogPolicy,
-	reason gwv1alpha2.PolicyConditionReason,
+	reason conditions.Reason,
 	message string,
 ) error {
-	status := metav1.ConditionTrue
-	if reason != gwv1alpha2.PolicyReasonAccepted {
-		status = metav1.ConditionFalse
-	}
-
-	alp.Status.Conditions = utils.GetNewConditions(alp.Status.Conditions, metav1.Condition{
-		Type:               string(gwv1alpha2.PolicyConditionAccepted),
-		ObservedGeneration: alp.Generation,
-		Message:            message,
-		Status:             status,
-		Reason:             string(reason),
-	})
+	cnd := conditions.New(conditions.TypeAccepted, alp.Generation, reason, message)
+	alp.Status.Conditions = utils.GetNewConditions(alp.Status.Conditions, cnd)
+	alp.Status.ObservedGeneration = alp.Generation
 
 	if err := r.client.Status().Update(ctx, alp); err != nil {
 		r.eventRecorder.Event(alp, corev1.EventTypeWarning, k8s.FailedReconcileEvent,
 			"Failed to update status due to "+err.Error())
-		return fmt.Errorf("failed to set Accepted status to %s and reason to %s due to %s", status, reason, err)
+		return fmt.Errorf("failed to set Accepted status to %s and reason to %s due to %s", cnd.Status, reason, err)
 	}
 
 	return nil
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+)
+
+// annotation keys the controller reads or writes on routes, other keys with the k8s.AnnotationPrefix are unknown
+var routeAnnotations = map[string]bool{
+	WeightModeAnnotation:               true,
+	k8s.MaintenanceWindowAnnotation:    true,
+	gateway.BackendProtocolsAnnotation: true,
+	LatticeAssignedDomainName:          true,
+	LatticeServiceArn:                  true,
+	k8s.ReconcileHistoryAnnotation:     true,
+}
+
+// annotation keys the controller reads or writes on Gateways
+var gatewayAnnotations = map[string]bool{
+	k8s.ServiceNetworkSelectorAnnotation: true,
+	ServiceNetworkSwitchoverAnnotation:   true,
+	k8s.ReconcileHistoryAnnotation:       true,
+}
+
+// validateAnnotations returns why the annotations of the object are invalid, or empty string. With
+// config.UnknownAnnotationsStrict, annotations with the k8s.AnnotationPrefix which are not known are invalid,
+// e.g. typos of known keys, which would otherwise have no effect.
+func validateAnnotations(obj client.Object, known map[string]bool) string {
+	if config.UnknownAnnotations != config.UnknownAnnotationsStrict {
+		return ""
+	}
+	var unknown []string
+	for key := range obj.GetAnnotations() {
+		if strings.HasPrefix(key, k8s.AnnotationPrefix) && !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return ""
+	}
+	sort.Strings(unknown)
+	return fmt.Sprintf("unknown annotations %s, check them for typos", strings.Join(unknown, ", "))
+}
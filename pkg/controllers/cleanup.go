@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
+)
+
+const unreachableCleanupRetryInterval = time.Minute
+
+// Counts consecutive cleanup attempts of deleted resources failing because VPC Lattice is unreachable.
+// Counts are kept in memory, so a controller restart starts over.
+type cleanupTracker struct {
+	lock     sync.Mutex
+	failures map[types.UID]int
+}
+
+func newCleanupTracker() *cleanupTracker {
+	return &cleanupTracker{
+		failures: make(map[types.UID]int),
+	}
+}
+
+// Handles a failed Lattice cleanup of a deleted resource. Returns nil when the finalizer should be removed
+// anyway, leaking the Lattice resources. This only happens with config.FinalizerRemovalOnLatticeUnreachable,
+// after config.FinalizerRemovalMaxAttempts consecutive connectivity failures one retry interval apart.
+// Otherwise returns the error to retry with.
+func (t *cleanupTracker) handleCleanupError(obj client.Object, err error) error {
+	if t == nil || !config.FinalizerRemovalOnLatticeUnreachable || !services.IsConnectivityError(err) {
+		t.reset(obj)
+		return err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.failures[obj.GetUID()]++
+	attempts := t.failures[obj.GetUID()]
+	if attempts < config.FinalizerRemovalMaxAttempts {
+		return lattice_runtime.NewRequeueNeededAfter(
+			fmt.Sprintf("VPC Lattice unreachable, cleanup attempt %d of %d failed: %s",
+				attempts, config.FinalizerRemovalMaxAttempts, err),
+			unreachableCleanupRetryInterval)
+	}
+	delete(t.failures, obj.GetUID())
+	return nil
+}
+
+func (t *cleanupTracker) reset(obj client.Object) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.failures, obj.GetUID())
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
+)
+
+func TestCleanupTracker_HandleCleanupError(t *testing.T) {
+	defer func(enabled bool, attempts int) {
+		config.FinalizerRemovalOnLatticeUnreachable = enabled
+		config.FinalizerRemovalMaxAttempts = attempts
+	}(config.FinalizerRemovalOnLatticeUnreachable, config.FinalizerRemovalMaxAttempts)
+
+	route := &gwv1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns", UID: "uid"}}
+	unreachable := awserr.New(request.ErrCodeRequestError, "send request failed", errors.New("dial tcp: i/o timeout"))
+	otherErr := errors.New("ConflictException")
+
+	config.FinalizerRemovalMaxAttempts = 3
+
+	t.Run("disabled", func(t *testing.T) {
+		config.FinalizerRemovalOnLatticeUnreachable = false
+		tracker := newCleanupTracker()
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, unreachable, tracker.handleCleanupError(route, unreachable))
+		}
+	})
+
+	t.Run("removes finalizer after max attempts", func(t *testing.T) {
+		config.FinalizerRemovalOnLatticeUnreachable = true
+		tracker := newCleanupTracker()
+		for i := 0; i < 2; i++ {
+			err := tracker.handleCleanupError(route, unreachable)
+			var requeueNeededAfter *lattice_runtime.RequeueNeededAfter
+			assert.True(t, errors.As(err, &requeueNeededAfter))
+			assert.Equal(t, time.Minute, requeueNeededAfter.Duration())
+		}
+		assert.Nil(t, tracker.handleCleanupError(route, unreachable))
+		assert.Empty(t, tracker.failures)
+	})
+
+	t.Run("other errors reset attempts", func(t *testing.T) {
+		config.FinalizerRemovalOnLatticeUnreachable = true
+		tracker := newCleanupTracker()
+		assert.NotNil(t, tracker.handleCleanupError(route, unreachable))
+		assert.NotNil(t, tracker.handleCleanupError(route, unreachable))
+		assert.Equal(t, otherErr, tracker.handleCleanupError(route, otherErr))
+		assert.NotNil(t, tracker.handleCleanupError(route, unreachable))
+		assert.NotNil(t, tracker.handleCleanupError(route, unreachable))
+		assert.Nil(t, tracker.handleCleanupError(route, unreachable))
+	})
+}
@@ -0,0 +1,62 @@
+package eventhandlers
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// Debounce wraps the given handler so that the requests it enqueues on update events are delayed.
+// The workqueue keeps a single request per object, so edits made within the delay are reconciled once,
+// and the reconcile reads the latest version of the object, skipping the intermediate ones.
+// Create, delete and generic events are enqueued without delay. A zero delay returns the handler as is.
+func Debounce(h handler.EventHandler, delay time.Duration) handler.EventHandler {
+	if delay <= 0 {
+		return h
+	}
+	return &debouncedEventHandler{
+		handler: h,
+		delay:   delay,
+	}
+}
+
+type debouncedEventHandler struct {
+	handler handler.EventHandler
+	delay   time.Duration
+}
+
+func (h *debouncedEventHandler) Create(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.handler.Create(ctx, e, q)
+}
+
+func (h *debouncedEventHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.handler.Update(ctx, e, &delayedQueue{RateLimitingInterface: q, delay: h.delay})
+}
+
+func (h *debouncedEventHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.handler.Delete(ctx, e, q)
+}
+
+func (h *debouncedEventHandler) Generic(ctx context.Context, e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.handler.Generic(ctx, e, q)
+}
+
+type delayedQueue struct {
+	workqueue.RateLimitingInterface
+	delay time.Duration
+}
+
+func (q *delayedQueue) Add(item interface{}) {
+	q.RateLimitingInterface.AddAfter(item, q.delay)
+}
+
+// the delaying queue keeps the earliest ready time of an item, a pending request is not pushed back
+func (q *delayedQueue) AddAfter(item interface{}, duration time.Duration) {
+	if duration < q.delay {
+		duration = q.delay
+	}
+	q.RateLimitingInterface.AddAfter(item, duration)
+}
@@ -0,0 +1,76 @@
+package eventhandlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	clocktesting "k8s.io/utils/clock/testing"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestDebounce_RapidEdits(t *testing.T) {
+	ctx := context.TODO()
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+
+	route := createHTTPRoute("route", "ns1", gwv1beta1.BackendObjectReference{Name: "svc-v0"})
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(&route).Build()
+
+	clock := clocktesting.NewFakeClock(time.Now())
+	queue := workqueue.NewRateLimitingQueueWithDelayingInterface(
+		workqueue.NewDelayingQueueWithCustomClock(clock, "test"), workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	h := Debounce(&handler.EnqueueRequestForObject{}, time.Second)
+
+	for _, backend := range []string{"svc-v1", "svc-v2", "svc-v3"} {
+		old := &gwv1beta1.HTTPRoute{}
+		assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "ns1", Name: "route"}, old))
+		updated := old.DeepCopy()
+		updated.Spec.Rules[0].BackendRefs[0].Name = gwv1beta1.ObjectName(backend)
+		assert.NoError(t, k8sClient.Update(ctx, updated))
+		h.Update(ctx, event.UpdateEvent{ObjectOld: old, ObjectNew: updated}, queue)
+		clock.Step(100 * time.Millisecond)
+	}
+	assert.Equal(t, 0, queue.Len(), "edits are not reconciled before the delay")
+
+	clock.Step(time.Second)
+	assert.Eventually(t, func() bool { return queue.Len() == 1 }, time.Second, 10*time.Millisecond)
+
+	// reconcile the queued requests as a controller would, reading the latest version of the route
+	var applied []string
+	for queue.Len() > 0 {
+		item, _ := queue.Get()
+		req := item.(reconcile.Request)
+		current := &gwv1beta1.HTTPRoute{}
+		assert.NoError(t, k8sClient.Get(ctx, req.NamespacedName, current))
+		applied = append(applied, string(current.Spec.Rules[0].BackendRefs[0].Name))
+		queue.Done(item)
+	}
+	assert.Equal(t, []string{"svc-v3"}, applied)
+}
+
+func TestDebounce_CreateIsNotDelayed(t *testing.T) {
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	h := Debounce(&handler.EnqueueRequestForObject{}, time.Hour)
+
+	route := createHTTPRoute("route", "ns1", gwv1beta1.BackendObjectReference{Name: "svc"})
+	h.Create(context.TODO(), event.CreateEvent{Object: &route}, queue)
+	assert.Equal(t, 1, queue.Len())
+}
+
+func TestDebounce_ZeroDelay(t *testing.T) {
+	h := &handler.EnqueueRequestForObject{}
+	assert.Same(t, h, Debounce(h, 0))
+}
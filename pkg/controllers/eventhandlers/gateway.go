@@ -55,6 +55,9 @@ func (h *enqueueRequestsForGatewayEvent) Update(ctx context.Context, e event.Upd
 		// initialize transition time
 		gwNew.Status.Conditions[0].LastTransitionTime = ZeroTransitionTime
 		h.enqueueImpactedRoutes(ctx, queue)
+	} else if !equality.Semantic.DeepEqual(gwOld.Status.Addresses, gwNew.Status.Addresses) {
+		// statuses and exports of the routes, e.g. route DNS ConfigMaps, follow the address of their Gateway
+		h.enqueueGatewayRoutes(ctx, gwNew, queue)
 	}
 }
 
@@ -66,6 +69,36 @@ func (h *enqueueRequestsForGatewayEvent) Generic(ctx context.Context, e event.Ge
 
 }
 
+// enqueueGatewayRoutes enqueues the routes with a parentRef to the given Gateway
+func (h *enqueueRequestsForGatewayEvent) enqueueGatewayRoutes(ctx context.Context, gw *gateway_api.Gateway, queue workqueue.RateLimitingInterface) {
+	routes, err := core.ListAllRoutes(ctx, h.client)
+	if err != nil {
+		h.log.Errorf(ctx, "Failed to list all routes, %s", err)
+		return
+	}
+
+	for _, route := range routes {
+		for _, parentRef := range route.Spec().ParentRefs() {
+			gwNamespace := route.Namespace()
+			if parentRef.Namespace != nil {
+				gwNamespace = string(*parentRef.Namespace)
+			}
+			if string(parentRef.Name) != gw.Name || gwNamespace != gw.Namespace {
+				continue
+			}
+			h.log.Debugf(ctx, "Adding Route %s-%s to queue due to address change of Gateway %s-%s",
+				route.Name(), route.Namespace(), gw.Name, gw.Namespace)
+			queue.Add(reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: route.Namespace(),
+					Name:      route.Name(),
+				},
+			})
+			break
+		}
+	}
+}
+
 func (h *enqueueRequestsForGatewayEvent) enqueueImpactedRoutes(ctx context.Context, queue workqueue.RateLimitingInterface) {
 	routes, err := core.ListAllRoutes(ctx, h.client)
 	if err != nil {
@@ -0,0 +1,113 @@
+package eventhandlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestGatewayEvent_AddressChangeEnqueuesRoutes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	gwv1beta1.AddToScheme(scheme)
+	gwv1alpha2.AddToScheme(scheme)
+
+	parentRef := func(namespace, name string) gwv1beta1.ParentReference {
+		ref := gwv1beta1.ParentReference{Name: gwv1beta1.ObjectName(name)}
+		if namespace != "" {
+			ns := gwv1beta1.Namespace(namespace)
+			ref.Namespace = &ns
+		}
+		return ref
+	}
+	httpRoute := func(namespace, name string, parentRefs ...gwv1beta1.ParentReference) *gwv1beta1.HTTPRoute {
+		return &gwv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: gwv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gwv1beta1.CommonRouteSpec{ParentRefs: parentRefs},
+			},
+		}
+	}
+	k8sClient := testclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		httpRoute("ns1", "same-namespace", parentRef("", "gw")),
+		httpRoute("ns2", "other-namespace", parentRef("ns1", "gw")),
+		httpRoute("ns1", "second-parent", parentRef("", "other-gw"), parentRef("", "gw")),
+		httpRoute("ns1", "other-gateway", parentRef("", "other-gw")),
+		httpRoute("ns2", "same-name-other-namespace", parentRef("", "gw")),
+		&gwv1alpha2.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "grpc", Namespace: "ns1"},
+			Spec: gwv1alpha2.GRPCRouteSpec{
+				CommonRouteSpec: gwv1beta1.CommonRouteSpec{ParentRefs: []gwv1beta1.ParentReference{parentRef("", "gw")}},
+			},
+		},
+	).Build()
+	h := NewEnqueueRequestGatewayEvent(gwlog.FallbackLogger, k8sClient)
+
+	addressType := gwv1beta1.HostnameAddressType
+	gw := func(address string) *gwv1beta1.Gateway {
+		gw := &gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns1"},
+			Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "amazon-vpc-lattice"},
+		}
+		if address != "" {
+			gw.Status.Addresses = []gwv1.GatewayStatusAddress{{Type: &addressType, Value: address}}
+		}
+		return gw
+	}
+	drain := func(queue workqueue.RateLimitingInterface) []types.NamespacedName {
+		var names []types.NamespacedName
+		for queue.Len() > 0 {
+			item, _ := queue.Get()
+			names = append(names, item.(reconcile.Request).NamespacedName)
+			queue.Done(item)
+		}
+		return names
+	}
+
+	t.Run("address changed", func(t *testing.T) {
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		h.Update(context.TODO(), event.UpdateEvent{
+			ObjectOld: gw("svc-1.7d67968.vpc-lattice-svcs.us-west-2.on.aws"),
+			ObjectNew: gw("svc-2.7d67968.vpc-lattice-svcs.us-west-2.on.aws"),
+		}, queue)
+		assert.ElementsMatch(t, []types.NamespacedName{
+			{Namespace: "ns1", Name: "same-namespace"},
+			{Namespace: "ns2", Name: "other-namespace"},
+			{Namespace: "ns1", Name: "second-parent"},
+			{Namespace: "ns1", Name: "grpc"},
+		}, drain(queue))
+	})
+
+	t.Run("address assigned", func(t *testing.T) {
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		h.Update(context.TODO(), event.UpdateEvent{
+			ObjectOld: gw(""),
+			ObjectNew: gw("svc-1.7d67968.vpc-lattice-svcs.us-west-2.on.aws"),
+		}, queue)
+		assert.Len(t, drain(queue), 4)
+	})
+
+	t.Run("address unchanged", func(t *testing.T) {
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		h.Update(context.TODO(), event.UpdateEvent{
+			ObjectOld: gw("svc-1.7d67968.vpc-lattice-svcs.us-west-2.on.aws"),
+			ObjectNew: gw("svc-1.7d67968.vpc-lattice-svcs.us-west-2.on.aws"),
+		}, queue)
+		assert.Empty(t, drain(queue))
+	})
+}
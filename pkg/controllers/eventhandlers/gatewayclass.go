@@ -3,11 +3,13 @@ package eventhandlers
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gateway_api "sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -27,12 +29,27 @@ type enqueueRequestsForGatewayClassEvent struct {
 	client client.Client
 }
 
+// NewEnqueueRequestsForGatewayClassParametersEvent enqueues the Gateways of the GatewayClasses whose parametersRef
+// points to the changed ConfigMap, for the Gateways to pick up the new parameters.
+func NewEnqueueRequestsForGatewayClassParametersEvent(log gwlog.Logger, client client.Client) handler.EventHandler {
+	h := &enqueueRequestsForGatewayClassEvent{
+		log:    log,
+		client: client,
+	}
+	return handler.EnqueueRequestsFromMapFunc(h.mapParametersToGateways)
+}
+
 func (h *enqueueRequestsForGatewayClassEvent) Create(ctx context.Context, e event.CreateEvent, queue workqueue.RateLimitingInterface) {
 	gwClassNew := e.Object.(*gateway_api.GatewayClass)
 	h.enqueueImpactedGateway(ctx, queue, gwClassNew)
 }
 
 func (h *enqueueRequestsForGatewayClassEvent) Update(ctx context.Context, e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	gwClassOld := e.ObjectOld.(*gateway_api.GatewayClass)
+	gwClassNew := e.ObjectNew.(*gateway_api.GatewayClass)
+	if !equality.Semantic.DeepEqual(gwClassOld.Spec.ParametersRef, gwClassNew.Spec.ParametersRef) {
+		h.enqueueImpactedGateway(ctx, queue, gwClassNew)
+	}
 }
 
 func (h *enqueueRequestsForGatewayClassEvent) Delete(ctx context.Context, e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
@@ -67,3 +84,61 @@ func (h *enqueueRequestsForGatewayClassEvent) enqueueImpactedGateway(
 		}
 	}
 }
+
+// NewGatewayClassParametersPredicate filters ConfigMap events to the ConfigMaps referenced by the parametersRef of a
+// GatewayClass of this controller, so that changes of other ConfigMaps of the cluster do not reach the event handler.
+func NewGatewayClassParametersPredicate(log gwlog.Logger, k8sClient client.Client) predicate.Predicate {
+	h := &enqueueRequestsForGatewayClassEvent{
+		log:    log,
+		client: k8sClient,
+	}
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return len(h.referencingGatewayClasses(context.TODO(), obj)) > 0
+	})
+}
+
+// returns the names of the GatewayClasses of this controller whose parametersRef points to the ConfigMap
+func (h *enqueueRequestsForGatewayClassEvent) referencingGatewayClasses(ctx context.Context, obj client.Object) map[string]bool {
+	gwClassList := &gateway_api.GatewayClassList{}
+	if err := h.client.List(ctx, gwClassList); err != nil {
+		h.log.Errorf(ctx, "Error listing GatewayClasses during ConfigMap event %s", err)
+		return nil
+	}
+	gwClassNames := make(map[string]bool)
+	for _, gwClass := range gwClassList.Items {
+		if gwClass.Spec.ControllerName == config.LatticeGatewayControllerName &&
+			isConfigMapParametersRef(gwClass.Spec.ParametersRef, obj) {
+			gwClassNames[gwClass.Name] = true
+		}
+	}
+	return gwClassNames
+}
+
+func (h *enqueueRequestsForGatewayClassEvent) mapParametersToGateways(ctx context.Context, obj client.Object) []reconcile.Request {
+	gwClassNames := h.referencingGatewayClasses(ctx, obj)
+	if len(gwClassNames) == 0 {
+		return nil
+	}
+
+	gwList := &gateway_api.GatewayList{}
+	if err := h.client.List(ctx, gwList); err != nil {
+		h.log.Errorf(ctx, "Error listing Gateways during ConfigMap event %s", err)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, gw := range gwList.Items {
+		if gwClassNames[string(gw.Spec.GatewayClassName)] {
+			gwName := types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}
+			requests = append(requests, reconcile.Request{NamespacedName: gwName})
+			h.log.Infow(ctx, "GatewayClass parameters change triggered Gateway update",
+				"parametersName", obj.GetNamespace()+"/"+obj.GetName(), "gatewayName", gwName)
+		}
+	}
+	return requests
+}
+
+// GatewayClass parameters are namespaced ConfigMaps, in the core API group
+func isConfigMapParametersRef(ref *gateway_api.ParametersReference, obj client.Object) bool {
+	return ref != nil && ref.Group == "" && ref.Kind == "ConfigMap" && ref.Name == obj.GetName() &&
+		ref.Namespace != nil && string(*ref.Namespace) == obj.GetNamespace()
+}
@@ -0,0 +1,123 @@
+package eventhandlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestGatewayClassParametersEvent_MapToGateways(t *testing.T) {
+	scheme := runtime.NewScheme()
+	gwv1beta1.AddToScheme(scheme)
+
+	parametersRef := func(namespace, name string) *gwv1beta1.ParametersReference {
+		ns := gwv1beta1.Namespace(namespace)
+		return &gwv1beta1.ParametersReference{Kind: "ConfigMap", Name: name, Namespace: &ns}
+	}
+	gwClass := func(name, controller string, ref *gwv1beta1.ParametersReference) client.Object {
+		return &gwv1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: gwv1beta1.GatewayClassSpec{
+				ControllerName: gwv1beta1.GatewayController(controller),
+				ParametersRef:  ref,
+			},
+		}
+	}
+	gw := func(namespace, name, class string) client.Object {
+		return &gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       gwv1beta1.GatewaySpec{GatewayClassName: gwv1beta1.ObjectName(class)},
+		}
+	}
+	k8sClient := testclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		gwClass("lattice", config.LatticeGatewayControllerName, parametersRef("system", "params")),
+		gwClass("lattice-other-params", config.LatticeGatewayControllerName, parametersRef("system", "other-params")),
+		gwClass("lattice-no-params", config.LatticeGatewayControllerName, nil),
+		gwClass("other-controller", "example.com/other-controller", parametersRef("system", "params")),
+		gw("ns1", "gw1", "lattice"),
+		gw("ns2", "gw2", "lattice"),
+		gw("ns1", "gw3", "lattice-other-params"),
+		gw("ns1", "gw4", "lattice-no-params"),
+		gw("ns1", "gw5", "other-controller"),
+	).Build()
+	h := &enqueueRequestsForGatewayClassEvent{log: gwlog.FallbackLogger, client: k8sClient}
+
+	tests := []struct {
+		name      string
+		namespace string
+		want      []types.NamespacedName
+	}{
+		{
+			name:      "params",
+			namespace: "system",
+			want: []types.NamespacedName{
+				{Namespace: "ns1", Name: "gw1"},
+				{Namespace: "ns2", Name: "gw2"},
+			},
+		},
+		{
+			name:      "other-params",
+			namespace: "system",
+			want:      []types.NamespacedName{{Namespace: "ns1", Name: "gw3"}},
+		},
+		{
+			name:      "params",
+			namespace: "ns1",
+		},
+		{
+			name:      "unreferenced",
+			namespace: "system",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.namespace+"/"+tt.name, func(t *testing.T) {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: tt.name, Namespace: tt.namespace}}
+			var got []types.NamespacedName
+			for _, req := range h.mapParametersToGateways(context.TODO(), cm) {
+				got = append(got, req.NamespacedName)
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+
+	t.Run("events of unreferenced ConfigMaps are filtered out", func(t *testing.T) {
+		p := NewGatewayClassParametersPredicate(gwlog.FallbackLogger, k8sClient)
+		cm := func(namespace, name string) client.Object {
+			return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		}
+
+		assert.True(t, p.Create(event.CreateEvent{Object: cm("system", "params")}))
+		assert.True(t, p.Update(event.UpdateEvent{ObjectOld: cm("system", "other-params"), ObjectNew: cm("system", "other-params")}))
+		assert.True(t, p.Delete(event.DeleteEvent{Object: cm("system", "params")}))
+		assert.False(t, p.Create(event.CreateEvent{Object: cm("ns1", "params")}))
+		assert.False(t, p.Update(event.UpdateEvent{ObjectOld: cm("system", "unreferenced"), ObjectNew: cm("system", "unreferenced")}))
+		assert.False(t, p.Delete(event.DeleteEvent{Object: cm("kube-system", "kube-proxy")}))
+	})
+
+	t.Run("parametersRef of GatewayClass changed", func(t *testing.T) {
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		oldClass := gwClass("lattice", config.LatticeGatewayControllerName, parametersRef("system", "old-params"))
+		newClass := gwClass("lattice", config.LatticeGatewayControllerName, parametersRef("system", "params"))
+
+		h.Update(context.TODO(), event.UpdateEvent{ObjectOld: newClass, ObjectNew: newClass}, queue)
+		assert.Equal(t, 0, queue.Len())
+
+		h.Update(context.TODO(), event.UpdateEvent{ObjectOld: oldClass, ObjectNew: newClass}, queue)
+		assert.Equal(t, 2, queue.Len())
+	})
+}
@@ -0,0 +1,101 @@
+package eventhandlers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// Enqueues the routes and service exports of the endpoints hosted on a node when it starts or stops
+// draining, so their targets are deregistered before the pods terminate, and registered again
+// when the node is back.
+type nodeEventHandler struct {
+	log        gwlog.Logger
+	client     client.Client
+	svcHandler *serviceEventHandler
+}
+
+func NewNodeEventHandler(log gwlog.Logger, client client.Client) *nodeEventHandler {
+	return &nodeEventHandler{log: log, client: client,
+		svcHandler: NewServiceEventHandler(log, client)}
+}
+
+func (h *nodeEventHandler) MapToRoute(routeType core.RouteType) handler.EventHandler {
+	return h.eventHandler(func(ctx context.Context, epSlice *discoveryv1.EndpointSlice) []reconcile.Request {
+		return h.svcHandler.mapToRoute(ctx, epSlice, routeType)
+	})
+}
+
+func (h *nodeEventHandler) MapToServiceExport() handler.EventHandler {
+	return h.eventHandler(func(ctx context.Context, epSlice *discoveryv1.EndpointSlice) []reconcile.Request {
+		return h.svcHandler.mapToServiceExport(ctx, epSlice)
+	})
+}
+
+// Only updates changing the draining state of the node are relevant. Deleted nodes are handled
+// through the removal of their endpoints.
+func (h *nodeEventHandler) eventHandler(mapFn func(context.Context, *discoveryv1.EndpointSlice) []reconcile.Request) handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok || k8s.IsNodeDraining(oldNode) == k8s.IsNodeDraining(newNode) {
+				return
+			}
+			for _, req := range h.mapToRequests(ctx, newNode, mapFn) {
+				queue.Add(req)
+			}
+		},
+	}
+}
+
+func (h *nodeEventHandler) mapToRequests(ctx context.Context, node *corev1.Node,
+	mapFn func(context.Context, *discoveryv1.EndpointSlice) []reconcile.Request) []reconcile.Request {
+	epSlices := &discoveryv1.EndpointSliceList{}
+	if err := h.client.List(ctx, epSlices); err != nil {
+		h.log.Errorf(ctx, "failed to list endpoint slices of node %s: %s", node.Name, err)
+		return nil
+	}
+
+	seen := make(map[reconcile.Request]struct{})
+	var requests []reconcile.Request
+	for i := range epSlices.Items {
+		epSlice := &epSlices.Items[i]
+		if !hostsEndpoint(epSlice, node.Name) {
+			continue
+		}
+		for _, req := range mapFn(ctx, epSlice) {
+			if _, ok := seen[req]; !ok {
+				seen[req] = struct{}{}
+				requests = append(requests, req)
+			}
+		}
+	}
+	if len(requests) > 0 {
+		h.log.Infow(ctx, "Node draining state change triggered update",
+			"nodeName", node.Name, "draining", k8s.IsNodeDraining(node), "requests", len(requests))
+	}
+	return requests
+}
+
+func hostsEndpoint(epSlice *discoveryv1.EndpointSlice, nodeName string) bool {
+	for _, ep := range epSlice.Endpoints {
+		if ep.NodeName != nil && *ep.NodeName == nodeName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package eventhandlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestNodeEventHandler_MapToRoute(t *testing.T) {
+	ctx := context.TODO()
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+
+	route := createHTTPRoute("route", "ns1", gwv1beta1.BackendObjectReference{
+		Kind: (*gwv1beta1.Kind)(ptr.To("Service")),
+		Name: "svc",
+	})
+	otherRoute := createHTTPRoute("other-route", "ns1", gwv1beta1.BackendObjectReference{
+		Kind: (*gwv1beta1.Kind)(ptr.To("Service")),
+		Name: "other-svc",
+	})
+	epSlice := func(name, svcName, nodeName string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1",
+				Name:      name,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: svcName},
+			},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, NodeName: aws.String(nodeName)},
+			},
+		}
+	}
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(
+		&route, &otherRoute,
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "svc"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "other-svc"}},
+		epSlice("svc-1", "svc", "node"),
+		epSlice("svc-2", "svc", "node"),
+		epSlice("other-svc-1", "other-svc", "other-node"),
+	).Build()
+
+	readyNode := func(status corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: status},
+			}},
+		}
+	}
+	cordonedNode := readyNode(corev1.ConditionTrue)
+	cordonedNode.Spec.Unschedulable = true
+
+	tests := []struct {
+		name     string
+		old      *corev1.Node
+		new      *corev1.Node
+		expected []reconcile.Request
+	}{
+		{
+			name:     "node becomes NotReady",
+			old:      readyNode(corev1.ConditionTrue),
+			new:      readyNode(corev1.ConditionFalse),
+			expected: []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "route"}}},
+		},
+		{
+			name:     "node is cordoned",
+			old:      readyNode(corev1.ConditionTrue),
+			new:      cordonedNode,
+			expected: []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "route"}}},
+		},
+		{
+			name:     "node becomes Ready",
+			old:      readyNode(corev1.ConditionUnknown),
+			new:      readyNode(corev1.ConditionTrue),
+			expected: []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "route"}}},
+		},
+		{
+			name: "draining state unchanged",
+			old:  readyNode(corev1.ConditionTrue),
+			new:  readyNode(corev1.ConditionTrue),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewNodeEventHandler(gwlog.FallbackLogger, k8sClient).MapToRoute(core.HttpRouteType)
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+
+			h.Update(ctx, event.UpdateEvent{ObjectOld: tt.old, ObjectNew: tt.new}, queue)
+
+			var requests []reconcile.Request
+			for queue.Len() > 0 {
+				item, _ := queue.Get()
+				requests = append(requests, item.(reconcile.Request))
+				queue.Done(item)
+			}
+			assert.Equal(t, tt.expected, requests)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package eventhandlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// PriorityGate reconciles user-initiated changes of a controller before the requests of bulk resyncs.
+// Resync requests, from a resync source or from the periodic resync of the informer, are held back while the
+// controller queue already has maxQueued requests waiting, and are released in order as reconciles complete.
+// Other requests are enqueued right away, so an edit made during a large resync only waits for the few resync
+// requests already in the queue.
+type PriorityGate struct {
+	maxQueued int
+
+	lock  sync.Mutex
+	queue workqueue.RateLimitingInterface
+	// held back resync requests, in order, and their set
+	held    []interface{}
+	heldSet map[interface{}]bool
+}
+
+// NewPriorityGate returns a gate for a controller queue, maxQueued is usually the number of concurrent reconciles
+// of the controller, so that resyncs still keep every worker busy.
+func NewPriorityGate(maxQueued int) *PriorityGate {
+	if maxQueued < 1 {
+		maxQueued = 1
+	}
+	return &PriorityGate{
+		maxQueued: maxQueued,
+		heldSet:   make(map[interface{}]bool),
+	}
+}
+
+// EventHandler wraps the handler of the watched objects. Requests of informer resyncs, update events which do not
+// change the object, are low priority, requests of other events are high priority.
+func (g *PriorityGate) EventHandler(h handler.EventHandler) handler.EventHandler {
+	return &prioritizedEventHandler{handler: h, gate: g}
+}
+
+// Resync wraps the handler of a resync source, its requests are low priority, except the ones of objects whose
+// reconcile is requested by a user, which implement userRequested.
+func (g *PriorityGate) Resync(h handler.EventHandler) handler.EventHandler {
+	return &prioritizedEventHandler{handler: h, gate: g, resync: true}
+}
+
+// Reconciler wraps the reconciler of the controller, so that held back requests are released as reconciles complete.
+func (g *PriorityGate) Reconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		res, err := r.Reconcile(ctx, req)
+		g.release()
+		return res, err
+	})
+}
+
+// addLow enqueues a resync request if the queue has room for it, holds it back otherwise
+func (g *PriorityGate) addLow(q workqueue.RateLimitingInterface, item interface{}) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.queue = q
+	if g.heldSet[item] {
+		return
+	}
+	if len(g.held) == 0 && q.Len() < g.maxQueued {
+		q.Add(item)
+		return
+	}
+	g.held = append(g.held, item)
+	g.heldSet[item] = true
+}
+
+// addHigh enqueues a request right away, the reconcile also covers a held back resync of the same object
+func (g *PriorityGate) addHigh(q workqueue.RateLimitingInterface, item interface{}) {
+	g.lock.Lock()
+	g.queue = q
+	if g.heldSet[item] {
+		delete(g.heldSet, item)
+		for i, held := range g.held {
+			if held == item {
+				g.held = append(g.held[:i], g.held[i+1:]...)
+				break
+			}
+		}
+	}
+	g.lock.Unlock()
+	q.Add(item)
+}
+
+// release enqueues held back requests while the queue has room for them
+func (g *PriorityGate) release() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for len(g.held) > 0 && g.queue.Len() < g.maxQueued {
+		item := g.held[0]
+		g.held = g.held[1:]
+		delete(g.heldSet, item)
+		g.queue.Add(item)
+	}
+}
+
+// userRequested is implemented by the objects of resync sources whose reconcile is requested by a user, e.g. through
+// the reconcile endpoint
+type userRequested interface {
+	UserRequested() bool
+}
+
+type prioritizedEventHandler struct {
+	handler handler.EventHandler
+	gate    *PriorityGate
+	resync  bool
+}
+
+func (h *prioritizedEventHandler) Create(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.handler.Create(ctx, e, h.wrap(q, h.resync))
+}
+
+func (h *prioritizedEventHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	informerResync := e.ObjectOld != nil && e.ObjectNew != nil &&
+		e.ObjectOld.GetResourceVersion() == e.ObjectNew.GetResourceVersion()
+	h.handler.Update(ctx, e, h.wrap(q, h.resync || informerResync))
+}
+
+func (h *prioritizedEventHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.handler.Delete(ctx, e, h.wrap(q, h.resync))
+}
+
+func (h *prioritizedEventHandler) Generic(ctx context.Context, e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	requested, ok := e.Object.(userRequested)
+	h.handler.Generic(ctx, e, h.wrap(q, h.resync && !(ok && requested.UserRequested())))
+}
+
+func (h *prioritizedEventHandler) wrap(q workqueue.RateLimitingInterface, low bool) workqueue.RateLimitingInterface {
+	return &prioritizedQueue{RateLimitingInterface: q, gate: h.gate, low: low}
+}
+
+type prioritizedQueue struct {
+	workqueue.RateLimitingInterface
+	gate *PriorityGate
+	low  bool
+}
+
+func (q *prioritizedQueue) Add(item interface{}) {
+	if q.low {
+		q.gate.addLow(q.RateLimitingInterface, item)
+		return
+	}
+	q.gate.addHigh(q.RateLimitingInterface, item)
+}
+
+// delayed requests, e.g. debounced edits, are not ready yet and do not compete with the queued ones
+func (q *prioritizedQueue) AddAfter(item interface{}, duration time.Duration) {
+	if duration <= 0 {
+		q.Add(item)
+		return
+	}
+	q.RateLimitingInterface.AddAfter(item, duration)
+}
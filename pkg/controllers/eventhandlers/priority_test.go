@@ -0,0 +1,85 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestPriorityGate_UserEditBeforeQueuedResync(t *testing.T) {
+	ctx := context.TODO()
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+
+	gate := NewPriorityGate(1)
+	resyncHandler := gate.Resync(&handler.EnqueueRequestForObject{})
+	objectHandler := gate.EventHandler(&handler.EnqueueRequestForObject{})
+
+	route := func(name string, resourceVersion string, generation int64) *gwv1beta1.HTTPRoute {
+		return &gwv1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "ns1",
+			Name:            name,
+			ResourceVersion: resourceVersion,
+			Generation:      generation,
+		}}
+	}
+
+	// a resync enqueues every route
+	for i := 0; i < 5; i++ {
+		resyncHandler.Generic(ctx, event.GenericEvent{Object: route(fmt.Sprintf("route-%d", i), "1", 1)}, queue)
+	}
+	// the periodic resync of the informer does not change the route
+	objectHandler.Update(ctx, event.UpdateEvent{ObjectOld: route("route-5", "1", 1), ObjectNew: route("route-5", "1", 1)}, queue)
+	// then a user edits a route
+	objectHandler.Update(ctx, event.UpdateEvent{ObjectOld: route("edited", "1", 1), ObjectNew: route("edited", "2", 2)}, queue)
+	// and the resynced route-3, its resync is covered by the reconcile of the edit
+	objectHandler.Update(ctx, event.UpdateEvent{ObjectOld: route("route-3", "1", 1), ObjectNew: route("route-3", "2", 2)}, queue)
+	// and requests the reconcile of another route through the reconcile endpoint
+	resyncHandler.Generic(ctx, event.GenericEvent{Object: &requestedRoute{route("requested", "1", 1)}}, queue)
+
+	// reconcile the queued requests as a controller would
+	var reconciled []string
+	r := gate.Reconciler(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		reconciled = append(reconciled, req.Name)
+		return reconcile.Result{}, nil
+	}))
+	for queue.Len() > 0 {
+		item, _ := queue.Get()
+		r.Reconcile(ctx, item.(reconcile.Request))
+		queue.Done(item)
+	}
+
+	assert.Equal(t, []string{"route-0", "edited", "route-3", "requested", "route-1", "route-2", "route-4", "route-5"}, reconciled)
+}
+
+func TestPriorityGate_ResyncWithoutBacklog(t *testing.T) {
+	ctx := context.TODO()
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+
+	gate := NewPriorityGate(3)
+	h := gate.Resync(&handler.EnqueueRequestForObject{})
+	for i := 0; i < 5; i++ {
+		h.Generic(ctx, event.GenericEvent{Object: &gwv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: fmt.Sprintf("route-%d", i)},
+		}}, queue)
+	}
+	// resync requests fill the queue up to the number of workers
+	assert.Equal(t, 3, queue.Len())
+}
+
+type requestedRoute struct {
+	*gwv1beta1.HTTPRoute
+}
+
+func (r *requestedRoute) UserRequested() bool {
+	return true
+}
@@ -8,9 +8,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -82,6 +88,36 @@ func TestServiceEventHandler_MapToRoute(t *testing.T) {
 	}
 }
 
+func TestServiceEventHandler_SelectorChangeEnqueuesRoute(t *testing.T) {
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	gwv1beta1.AddToScheme(scheme)
+	route := createHTTPRoute("route", "ns1", gwv1beta1.BackendObjectReference{
+		Kind: (*gwv1beta1.Kind)(ptr.To("Service")),
+		Name: "svc",
+	})
+	k8sClient := testclient.NewClientBuilder().WithScheme(scheme).WithObjects(&route).Build()
+	h := NewServiceEventHandler(gwlog.FallbackLogger, k8sClient)
+
+	svc := func(app string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "svc"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": app}},
+		}
+	}
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	// the targets of the route are resolved again from the endpoint slices of the new selector
+	h.MapToRoute(core.HttpRouteType).Update(context.TODO(), event.UpdateEvent{
+		ObjectOld: svc("v1"),
+		ObjectNew: svc("v2"),
+	}, queue)
+
+	assert.Equal(t, 1, queue.Len())
+	item, _ := queue.Get()
+	assert.Equal(t, types.NamespacedName{Namespace: "ns1", Name: "route"}, item.(reconcile.Request).NamespacedName)
+}
+
 func TestServiceEventHandler_MapToServiceExport(t *testing.T) {
 	c := gomock.NewController(t)
 	defer c.Finish()
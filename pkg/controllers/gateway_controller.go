@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
@@ -27,7 +28,10 @@ import (
 	"github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/resync"
 	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
@@ -35,6 +39,7 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -43,8 +48,10 @@ import (
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	pkg_builder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -52,6 +59,14 @@ import (
 const (
 	gatewayFinalizer = "gateway.k8s.aws/resources"
 	defaultNamespace = "default"
+
+	// ServiceNetworkSwitchoverAnnotation names the service network the cluster VPC is migrated away from.
+	// The VPC is associated with the Gateway's service network before the named one is disassociated.
+	ServiceNetworkSwitchoverAnnotation = k8s.AnnotationPrefix + "switchover-from-service-network"
+
+	GatewayConditionServiceNetworkSwitchover = "ServiceNetworkSwitchover"
+
+	serviceNetworkSwitchoverRequeueInterval = 10 * time.Second
 )
 
 type gatewayReconciler struct {
@@ -61,6 +76,7 @@ type gatewayReconciler struct {
 	finalizerManager k8s.FinalizerManager
 	eventRecorder    record.EventRecorder
 	cloud            aws.Cloud
+	snManager        deploy.ServiceNetworkManager
 }
 
 func RegisterGatewayController(
@@ -68,10 +84,12 @@ func RegisterGatewayController(
 	cloud aws.Cloud,
 	finalizerManager k8s.FinalizerManager,
 	mgr ctrl.Manager,
+	resyncer *resync.Resyncer,
 ) error {
 	mgrClient := mgr.GetClient()
 	scheme := mgr.GetScheme()
 	evtRec := mgr.GetEventRecorderFor("gateway")
+	snManager := deploy.NewDefaultServiceNetworkManager(log, cloud)
 
 	r := &gatewayReconciler{
 		log:              log,
@@ -80,11 +98,11 @@ func RegisterGatewayController(
 		finalizerManager: finalizerManager,
 		eventRecorder:    evtRec,
 		cloud:            cloud,
+		snManager:        snManager,
 	}
 
 	if config.DefaultServiceNetwork != "" {
 		// Attempt creation of default service network, move gracefully even if it fails.
-		snManager := deploy.NewDefaultServiceNetworkManager(log, cloud)
 		_, err := snManager.CreateOrUpdate(context.Background(), &model.ServiceNetwork{
 			Spec: model.ServiceNetworkSpec{
 				Name: config.DefaultServiceNetwork,
@@ -98,9 +116,20 @@ func RegisterGatewayController(
 
 	gwClassEventHandler := eventhandlers.NewEnqueueRequestsForGatewayClassEvent(log, mgrClient)
 	vpcAssociationPolicyEventHandler := eventhandlers.NewVpcAssociationPolicyEventHandler(log, mgrClient)
+	tracker := metrics.NewQueueTracker("Gateway")
+	gate := eventhandlers.NewPriorityGate(1)
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&gwv1beta1.Gateway{}, pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{}))
-	builder.Watches(&gwv1beta1.GatewayClass{}, gwClassEventHandler)
+		Named("gateway").
+		Watches(&gwv1beta1.Gateway{}, tracker.EventHandler(gate.EventHandler(eventhandlers.Debounce(&handler.EnqueueRequestForObject{}, config.ReconcileDebounce))), pkg_builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})))
+	builder.Watches(&gwv1beta1.GatewayClass{}, tracker.EventHandler(gwClassEventHandler))
+	// only metadata of ConfigMaps is cached, changes of the GatewayClass parameters are mapped to Gateways by name
+	builder.Watches(&corev1.ConfigMap{}, tracker.EventHandler(eventhandlers.NewEnqueueRequestsForGatewayClassParametersEvent(log, mgrClient)),
+		pkg_builder.OnlyMetadata, pkg_builder.WithPredicates(eventhandlers.NewGatewayClassParametersPredicate(log, mgrClient)))
+
+	if resyncer != nil {
+		builder.WatchesRawSource(resyncer.Source(&gwv1beta1.GatewayList{}), tracker.EventHandler(gate.Resync(&handler.EnqueueRequestForObject{})))
+	}
 
 	//Watch VpcAssociationPolicy CRD if it is installed
 	ok, err := k8s.IsGVKSupported(mgr, anv1alpha1.GroupVersion.String(), anv1alpha1.VpcAssociationPolicyKind)
@@ -108,11 +137,11 @@ func RegisterGatewayController(
 		return err
 	}
 	if ok {
-		builder.Watches(&anv1alpha1.VpcAssociationPolicy{}, vpcAssociationPolicyEventHandler.MapToGateway())
+		builder.Watches(&anv1alpha1.VpcAssociationPolicy{}, tracker.EventHandler(vpcAssociationPolicyEventHandler.MapToGateway()))
 	} else {
 		log.Infof(context.TODO(), "VpcAssociationPolicy CRD is not installed, skipping watch")
 	}
-	return builder.Complete(r)
+	return builder.Complete(tracker.Reconciler(gate.Reconciler(r)))
 }
 
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;create;update;patch;delete
@@ -228,16 +257,45 @@ func (r *gatewayReconciler) reconcileUpsert(ctx context.Context, gw *gwv1beta1.G
 		return err
 	}
 
-	snInfo, err := r.cloud.Lattice().FindServiceNetwork(ctx, gw.Name)
+	if invalidAnnotations := validateAnnotations(gw, gatewayAnnotations); invalidAnnotations != "" {
+		if err = r.updateGatewayProgrammedStatus(ctx, gw, conditions.ReasonInvalid, invalidAnnotations); err != nil {
+			return lattice_runtime.NewRetryError()
+		}
+		return nil
+	}
+
+	selector, err := k8s.ServiceNetworkSelector(gw)
+	if err != nil {
+		if err = r.updateGatewayProgrammedStatus(ctx, gw, conditions.ReasonInvalid, err.Error()); err != nil {
+			return lattice_runtime.NewRetryError()
+		}
+		return nil
+	}
+
+	snInfo, err := r.findServiceNetwork(ctx, gw, selector)
 	if err != nil {
 		if services.IsNotFoundError(err) {
-			if err = r.updateGatewayProgrammedStatus(ctx, gw, gwv1.GatewayReasonPending, "VPC Lattice Service Network not found"); err != nil {
+			msg := "VPC Lattice Service Network not found"
+			if selector != nil {
+				msg = fmt.Sprintf("No VPC Lattice Service Network has the tags %s selected by the Gateway", labels.Set(selector))
+			}
+			if config.ServiceNetworkCreationDisabled {
+				msg += ", service network creation is disabled, it must be created outside of the controller"
+			}
+			if err = r.updateGatewayProgrammedStatus(ctx, gw, conditions.ReasonPending, msg); err != nil {
+				return lattice_runtime.NewRetryError()
+			}
+			return nil
+		}
+		if errors.Is(err, services.ErrNameConflict) && selector != nil {
+			msg := fmt.Sprintf("Found multiple VPC Lattice Service Networks with the tags %s selected by the Gateway. Ensure only one Service Network has the selected tags.", labels.Set(selector))
+			if err = r.updateGatewayProgrammedStatus(ctx, gw, conditions.ReasonInvalid, msg); err != nil {
 				return lattice_runtime.NewRetryError()
 			}
 			return nil
 		}
 		if errors.Is(err, services.ErrNameConflict) {
-			if err = r.updateGatewayProgrammedStatus(ctx, gw, gwv1.GatewayReasonInvalid, "Found multiple VPC Lattice Service Networks matching Gateway name. Either ensure only one Service Network has a matching name, or use the Service Network's id as the Gateway name."); err != nil {
+			if err = r.updateGatewayProgrammedStatus(ctx, gw, conditions.ReasonInvalid, "Found multiple VPC Lattice Service Networks matching Gateway name. Either ensure only one Service Network has a matching name, or use the Service Network's id as the Gateway name."); err != nil {
 				return lattice_runtime.NewRetryError()
 			}
 			return nil
@@ -245,39 +303,109 @@ func (r *gatewayReconciler) reconcileUpsert(ctx context.Context, gw *gwv1beta1.G
 		return err
 	}
 
-	err = r.updateGatewayProgrammedStatus(ctx, gw, gwv1.GatewayReasonProgrammed, fmt.Sprintf("aws-service-network-arn: %s", *snInfo.SvcNetwork.Arn))
+	err = r.updateGatewayProgrammedStatus(ctx, gw, conditions.ReasonProgrammed, fmt.Sprintf("aws-service-network-arn: %s", *snInfo.SvcNetwork.Arn))
 	if err != nil {
 		return err
 	}
 
+	if !config.ServiceNetworkOverrideMode {
+		// in override mode all gateways share the default service network
+		err = r.snManager.UpsertInfrastructureTags(ctx, snInfo, model.GatewayInfrastructureTags(gw.Spec.Infrastructure))
+		if err != nil {
+			return err
+		}
+	}
+
+	if fromSnName, ok := gw.Annotations[ServiceNetworkSwitchoverAnnotation]; ok && fromSnName != "" {
+		return r.reconcileServiceNetworkSwitchover(ctx, gw, fromSnName)
+	}
+
 	return nil
 }
 
-func (r *gatewayReconciler) updateGatewayProgrammedStatus(
+// findServiceNetwork finds the service network of the gateway, the one with the selector tags if the gateway has
+// a ServiceNetworkSelectorAnnotation, the one named after the gateway otherwise
+func (r *gatewayReconciler) findServiceNetwork(ctx context.Context, gw *gwv1beta1.Gateway, selector map[string]string) (*services.ServiceNetworkInfo, error) {
+	if selector == nil || config.ServiceNetworkOverrideMode {
+		return r.cloud.Lattice().FindServiceNetwork(ctx, gw.Name)
+	}
+	return r.cloud.Lattice().FindServiceNetworkByTags(ctx, selector)
+}
+
+func (r *gatewayReconciler) reconcileServiceNetworkSwitchover(ctx context.Context, gw *gwv1beta1.Gateway, fromSnName string) error {
+	if fromSnName == gw.Name {
+		return r.updateGatewaySwitchoverStatus(ctx, gw, metav1.ConditionFalse, string(conditions.ReasonInvalid),
+			"Service network to switch over from must differ from the Gateway's service network")
+	}
+
+	// the new association gets the settings of the policy, as if it was created by the policy
+	var sgIds []*string
+	var tags services.Tags
+	vap, err := policy.NewVpcAssociationPolicyHandler(r.log, r.client).ObjResolvedPolicy(ctx, gw)
+	if err != nil {
+		return err
+	}
+	if vap != nil {
+		sgIds, tags = vpcAssociationSettings(vap)
+	}
+
+	phase, err := r.snManager.SwitchVpcAssociation(ctx, fromSnName, gw.Name, sgIds, tags)
+	if err != nil {
+		if err2 := r.updateGatewaySwitchoverStatus(ctx, gw, metav1.ConditionFalse, "Failed", err.Error()); err2 != nil {
+			return errors.Wrap(err2, err.Error())
+		}
+		return err
+	}
+
+	var message string
+	switch phase {
+	case model.VpcAssociationSwitchoverAssociating:
+		message = fmt.Sprintf("Waiting for VPC association with service network %s to become active", gw.Name)
+	case model.VpcAssociationSwitchoverDisassociating:
+		message = fmt.Sprintf("Disassociating VPC from service network %s", fromSnName)
+	case model.VpcAssociationSwitchoverCompleted:
+		message = fmt.Sprintf("Switched over from service network %s", fromSnName)
+		return r.updateGatewaySwitchoverStatus(ctx, gw, metav1.ConditionTrue, string(phase), message)
+	}
+	if err = r.updateGatewaySwitchoverStatus(ctx, gw, metav1.ConditionFalse, string(phase), message); err != nil {
+		return err
+	}
+	return lattice_runtime.NewRequeueNeededAfter(message, serviceNetworkSwitchoverRequeueInterval)
+}
+
+func (r *gatewayReconciler) updateGatewaySwitchoverStatus(
 	ctx context.Context,
 	gw *gwv1beta1.Gateway,
-	reason gwv1.GatewayConditionReason,
+	status metav1.ConditionStatus,
+	reason string,
 	message string,
 ) error {
 	gwOld := gw.DeepCopy()
 
-	if reason == gwv1.GatewayReasonProgrammed {
-		gw.Status.Conditions = utils.GetNewConditions(gw.Status.Conditions, metav1.Condition{
-			Type:               string(gwv1.GatewayConditionProgrammed),
-			Status:             metav1.ConditionTrue,
-			ObservedGeneration: gw.Generation,
-			Reason:             string(gwv1.GatewayReasonProgrammed),
-			Message:            message,
-		})
-	} else {
-		gw.Status.Conditions = utils.GetNewConditions(gw.Status.Conditions, metav1.Condition{
-			Type:               string(gwv1.GatewayConditionProgrammed),
-			Status:             metav1.ConditionFalse,
-			ObservedGeneration: gw.Generation,
-			Reason:             string(reason),
-			Message:            message,
-		})
+	gw.Status.Conditions = utils.GetNewConditions(gw.Status.Conditions, metav1.Condition{
+		Type:               GatewayConditionServiceNetworkSwitchover,
+		Status:             status,
+		ObservedGeneration: gw.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+
+	if err := r.client.Status().Patch(ctx, gw, client.MergeFrom(gwOld)); err != nil {
+		return fmt.Errorf("update gw switchover status error, gw: %s, err: %w", gw.Name, err)
 	}
+	return nil
+}
+
+func (r *gatewayReconciler) updateGatewayProgrammedStatus(
+	ctx context.Context,
+	gw *gwv1beta1.Gateway,
+	reason conditions.Reason,
+	message string,
+) error {
+	gwOld := gw.DeepCopy()
+
+	gw.Status.Conditions = utils.GetNewConditions(gw.Status.Conditions,
+		conditions.New(conditions.TypeProgrammed, gw.Generation, reason, message))
 
 	if err := r.client.Status().Patch(ctx, gw, client.MergeFrom(gwOld)); err != nil {
 		return fmt.Errorf("update gw status error, gw: %s, err: %w", gw.Name, err)
@@ -288,24 +416,11 @@ func (r *gatewayReconciler) updateGatewayProgrammedStatus(
 func (r *gatewayReconciler) updateGatewayAcceptStatus(ctx context.Context, gw *gwv1beta1.Gateway, accepted bool) error {
 	gwOld := gw.DeepCopy()
 
-	var cond metav1.Condition
+	reason := conditions.ReasonInvalid
 	if accepted {
-		cond = metav1.Condition{
-			Type:               string(gwv1.GatewayConditionAccepted),
-			ObservedGeneration: gw.Generation,
-			Message:            config.LatticeGatewayControllerName,
-			Status:             metav1.ConditionTrue,
-			Reason:             string(gwv1.GatewayReasonAccepted),
-		}
-	} else {
-		cond = metav1.Condition{
-			Type:               string(gwv1.GatewayConditionAccepted),
-			ObservedGeneration: gw.Generation,
-			Message:            config.LatticeGatewayControllerName,
-			Status:             metav1.ConditionFalse,
-			Reason:             string(gwv1.GatewayReasonInvalid),
-		}
+		reason = conditions.ReasonAccepted
 	}
+	cond := conditions.New(conditions.TypeAccepted, gw.Generation, reason, config.LatticeGatewayControllerName)
 	gw.Status.Conditions = utils.GetNewConditions(gw.Status.Conditions, cond)
 
 	if err := r.client.Status().Patch(ctx, gw, client.MergeFrom(gwOld)); err != nil {
@@ -348,8 +463,6 @@ func UpdateGWListenerStatus(ctx context.Context, k8sClient client.Client, gw *gw
 		return fmt.Errorf("failed to find gateway listener")
 	}
 
-	defaultListener := gw.Spec.Listeners[0]
-
 	// go through each section of gw
 	for _, listener := range gw.Spec.Listeners {
 		listenerStatus := gwv1beta1.ListenerStatus{
@@ -361,26 +474,22 @@ func UpdateGWListenerStatus(ctx context.Context, k8sClient client.Client, gw *gw
 
 		//Check if RouteGroupKind in listener spec is supported
 		validListener, supportedKinds := listenerRouteGroupKindSupported(listener)
-		if !validListener {
-			condition := metav1.Condition{
-				Type:               string(gwv1.ListenerConditionResolvedRefs),
-				Status:             metav1.ConditionFalse,
-				Reason:             string(gwv1.ListenerReasonInvalidRouteKinds),
-				ObservedGeneration: gw.Generation,
-				LastTransitionTime: metav1.Now(),
-			}
+		if !model.IsValidListenerPort(int64(listener.Port)) {
+			condition := conditions.New(conditions.TypeAccepted, gw.Generation, conditions.ReasonPortUnavailable,
+				fmt.Sprintf("port %d is outside of the allowed range %d-%d", listener.Port, model.MinListenerPort, model.MaxListenerPort))
+			condition.LastTransitionTime = metav1.Now()
+			listenerStatus.SupportedKinds = supportedKinds
+			listenerStatus.Conditions = append(listenerStatus.Conditions, condition)
+		} else if !validListener {
+			condition := conditions.New(conditions.TypeResolvedRefs, gw.Generation, conditions.ReasonInvalidRouteKinds, "")
+			condition.LastTransitionTime = metav1.Now()
 			listenerStatus.SupportedKinds = supportedKinds
 			listenerStatus.Conditions = append(listenerStatus.Conditions, condition)
 		} else {
 			hasValidListener = true
 
-			condition := metav1.Condition{
-				Type:               string(gwv1.ListenerConditionAccepted),
-				Status:             metav1.ConditionTrue,
-				Reason:             string(gwv1.ListenerReasonAccepted),
-				ObservedGeneration: gw.Generation,
-				LastTransitionTime: metav1.Now(),
-			}
+			condition := conditions.New(conditions.TypeAccepted, gw.Generation, conditions.ReasonAccepted, "")
+			condition.LastTransitionTime = metav1.Now()
 
 			for _, route := range routes {
 				if !route.DeletionTimestamp().IsZero() {
@@ -398,14 +507,11 @@ func UpdateGWListenerStatus(ctx context.Context, k8sClient client.Client, gw *gw
 						continue
 					}
 
-					var sectionName string
-					if parentRef.SectionName == nil {
-						sectionName = string(defaultListener.Name)
-					} else {
-						sectionName = string(*parentRef.SectionName)
+					// without sectionName, the route attaches to every listener serving its kind
+					if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+						continue
 					}
-
-					if sectionName != string(listener.Name) {
+					if parentRef.SectionName == nil && !k8s.IsRouteKindServedByListener(listener, route.GroupKind().Kind) {
 						continue
 					}
 
@@ -413,6 +519,14 @@ func UpdateGWListenerStatus(ctx context.Context, k8sClient client.Client, gw *gw
 						continue
 					}
 
+					allowed, err := k8s.IsNamespaceAllowedByListener(ctx, k8sClient, gw, listener, route.Namespace())
+					if err != nil {
+						return err
+					}
+					if !allowed {
+						continue
+					}
+
 					listenerStatus.AttachedRoutes++
 				}
 			}
@@ -0,0 +1,640 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	aws2 "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestGatewayReconciler_ServiceNetworkSwitchover(t *testing.T) {
+	config.VpcID = "my-vpc"
+	config.ClusterName = "my-cluster"
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	gwv1alpha2.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&gwv1beta1.Gateway{}).
+		Build()
+
+	gwClass := &gwv1beta1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "amazon-vpc-lattice",
+			Namespace: defaultNamespace,
+		},
+		Spec: gwv1beta1.GatewayClassSpec{
+			ControllerName: config.LatticeGatewayControllerName,
+		},
+	}
+	k8sClient.Create(ctx, gwClass.DeepCopy())
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "green",
+			Namespace: "ns1",
+			Annotations: map[string]string{
+				ServiceNetworkSwitchoverAnnotation: "blue",
+			},
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:     "http",
+					Protocol: "HTTP",
+					Port:     80,
+					AllowedRoutes: &gwv1beta1.AllowedRoutes{
+						Kinds: []gwv1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+					},
+				},
+			},
+		},
+	}
+	k8sClient.Create(ctx, gw.DeepCopy())
+
+	vap := &anv1alpha1.VpcAssociationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "green-vap",
+			Namespace: "ns1",
+		},
+		Spec: anv1alpha1.VpcAssociationPolicySpec{
+			TargetRef: &gwv1alpha2.PolicyTargetReference{
+				Group: gwv1beta1.GroupName,
+				Kind:  "Gateway",
+				Name:  "green",
+			},
+			SecurityGroupIds: []anv1alpha1.SecurityGroupId{"sg-1"},
+			Tags:             map[string]string{"team": "finance"},
+		},
+	}
+	k8sClient.Create(ctx, vap.DeepCopy())
+
+	// association status of the cluster VPC keyed by service network id, missing means not associated
+	snvaStatus := map[string]string{
+		"blue-id": vpclattice.ServiceNetworkVpcAssociationStatusActive,
+	}
+
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{
+		VpcId:       config.VpcID,
+		AccountId:   "account-id",
+		Region:      "ep-imagine-1",
+		ClusterName: config.ClusterName,
+	})
+	mockLattice.EXPECT().FindServiceNetwork(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, name string) (*mocks.ServiceNetworkInfo, error) {
+			return &mocks.ServiceNetworkInfo{
+				SvcNetwork: vpclattice.ServiceNetworkSummary{
+					Arn:  aws.String(name + "-arn"),
+					Id:   aws.String(name + "-id"),
+					Name: aws.String(name),
+				},
+			}, nil
+		}).AnyTimes()
+	mockLattice.EXPECT().ListServiceNetworkVpcAssociationsAsList(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.ListServiceNetworkVpcAssociationsInput) ([]*vpclattice.ServiceNetworkVpcAssociationSummary, error) {
+			snId := aws.StringValue(input.ServiceNetworkIdentifier)
+			status, ok := snvaStatus[snId]
+			if !ok {
+				return nil, nil
+			}
+			return []*vpclattice.ServiceNetworkVpcAssociationSummary{
+				{
+					Arn:    aws.String(snId + "-snva-arn"),
+					Id:     aws.String(snId + "-snva-id"),
+					Status: aws.String(status),
+				},
+			}, nil
+		}).AnyTimes()
+	mockLattice.EXPECT().CreateServiceNetworkVpcAssociationWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.CreateServiceNetworkVpcAssociationInput, opts ...request.Option) (*vpclattice.CreateServiceNetworkVpcAssociationOutput, error) {
+			// the new association gets the settings of the VpcAssociationPolicy
+			assert.Equal(t, []string{"sg-1"}, aws.StringValueSlice(input.SecurityGroupIds))
+			assert.Equal(t, "finance", aws.StringValue(input.Tags["team"]))
+			assert.Equal(t, cloud.DefaultTags()[aws2.TagManagedBy], input.Tags[aws2.TagManagedBy])
+			snvaStatus[aws.StringValue(input.ServiceNetworkIdentifier)] = vpclattice.ServiceNetworkVpcAssociationStatusCreateInProgress
+			return &vpclattice.CreateServiceNetworkVpcAssociationOutput{
+				Status: aws.String(vpclattice.ServiceNetworkVpcAssociationStatusCreateInProgress),
+			}, nil
+		}).Times(1)
+	mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).Return(
+		&vpclattice.ListTagsForResourceOutput{Tags: cloud.DefaultTags()}, nil).AnyTimes()
+	mockLattice.EXPECT().DeleteServiceNetworkVpcAssociationWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.DeleteServiceNetworkVpcAssociationInput, opts ...request.Option) (*vpclattice.DeleteServiceNetworkVpcAssociationOutput, error) {
+			assert.Equal(t, "blue-id-snva-id", aws.StringValue(input.ServiceNetworkVpcAssociationIdentifier))
+			snvaStatus["blue-id"] = vpclattice.ServiceNetworkVpcAssociationStatusDeleteInProgress
+			return &vpclattice.DeleteServiceNetworkVpcAssociationOutput{}, nil
+		}).Times(1)
+
+	mockEventRecorder := mock_client.NewMockEventRecorder(c)
+	mockEventRecorder.EXPECT().Event(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockFinalizer := k8s.NewMockFinalizerManager(c)
+	mockFinalizer.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	r := gatewayReconciler{
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		scheme:           k8sScheme,
+		finalizerManager: mockFinalizer,
+		eventRecorder:    mockEventRecorder,
+		cloud:            cloud,
+		snManager:        deploy.NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud),
+	}
+
+	reconcileAndCheck := func(expectedStatus metav1.ConditionStatus, expectedReason model.VpcAssociationSwitchoverPhase, expectRequeue bool) {
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: k8s.NamespacedName(gw)})
+		assert.Nil(t, err)
+		assert.Equal(t, expectRequeue, result.RequeueAfter != 0)
+
+		current := &gwv1beta1.Gateway{}
+		assert.Nil(t, k8sClient.Get(ctx, k8s.NamespacedName(gw), current))
+		var cond *metav1.Condition
+		for i := range current.Status.Conditions {
+			if current.Status.Conditions[i].Type == GatewayConditionServiceNetworkSwitchover {
+				cond = &current.Status.Conditions[i]
+			}
+		}
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, expectedStatus, cond.Status)
+			assert.Equal(t, string(expectedReason), cond.Reason)
+		}
+	}
+
+	// new association is created while the old one stays in place
+	reconcileAndCheck(metav1.ConditionFalse, model.VpcAssociationSwitchoverAssociating, true)
+	assert.Equal(t, vpclattice.ServiceNetworkVpcAssociationStatusActive, snvaStatus["blue-id"])
+
+	// old association is kept until the new one is active
+	reconcileAndCheck(metav1.ConditionFalse, model.VpcAssociationSwitchoverAssociating, true)
+	assert.Equal(t, vpclattice.ServiceNetworkVpcAssociationStatusActive, snvaStatus["blue-id"])
+
+	// new association is active, old one gets deleted
+	snvaStatus["green-id"] = vpclattice.ServiceNetworkVpcAssociationStatusActive
+	reconcileAndCheck(metav1.ConditionFalse, model.VpcAssociationSwitchoverDisassociating, true)
+	reconcileAndCheck(metav1.ConditionFalse, model.VpcAssociationSwitchoverDisassociating, true)
+
+	// old association is gone
+	delete(snvaStatus, "blue-id")
+	reconcileAndCheck(metav1.ConditionTrue, model.VpcAssociationSwitchoverCompleted, false)
+	assert.Equal(t, vpclattice.ServiceNetworkVpcAssociationStatusActive, snvaStatus["green-id"])
+}
+
+func TestUpdateGWListenerStatus_ListenerPorts(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	gwv1alpha2.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&gwv1beta1.Gateway{}).
+		Build()
+
+	allowedRoutes := &gwv1beta1.AllowedRoutes{
+		Kinds: []gwv1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+	}
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:          "https-8443",
+					Protocol:      "HTTPS",
+					Port:          8443,
+					AllowedRoutes: allowedRoutes,
+				},
+				{
+					Name:          "out-of-range",
+					Protocol:      "HTTP",
+					Port:          0,
+					AllowedRoutes: allowedRoutes,
+				},
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, gw))
+
+	assert.Nil(t, UpdateGWListenerStatus(ctx, k8sClient, gw))
+
+	current := &gwv1beta1.Gateway{}
+	assert.Nil(t, k8sClient.Get(ctx, k8s.NamespacedName(gw), current))
+	assert.Len(t, current.Status.Listeners, 2)
+	for _, listener := range current.Status.Listeners {
+		if !assert.Len(t, listener.Conditions, 1) {
+			continue
+		}
+		cond := listener.Conditions[0]
+		assert.Equal(t, string(gwv1.ListenerConditionAccepted), cond.Type)
+		switch listener.Name {
+		case "https-8443":
+			assert.Equal(t, metav1.ConditionTrue, cond.Status)
+			assert.Equal(t, string(gwv1.ListenerReasonAccepted), cond.Reason)
+		case "out-of-range":
+			assert.Equal(t, metav1.ConditionFalse, cond.Status)
+			assert.Equal(t, string(gwv1.ListenerReasonPortUnavailable), cond.Reason)
+		}
+	}
+
+	// a gateway without any listener on a valid port is rejected
+	gw.Spec.Listeners = gw.Spec.Listeners[1:]
+	assert.NotNil(t, UpdateGWListenerStatus(ctx, k8sClient, gw))
+}
+
+func TestUpdateGWListenerStatus_AttachedRoutesAllowedNamespaces(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	gwv1alpha2.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&gwv1beta1.Gateway{}).
+		Build()
+
+	fromSame := gwv1.NamespacesFromSame
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:     "http",
+					Protocol: "HTTP",
+					Port:     80,
+					AllowedRoutes: &gwv1beta1.AllowedRoutes{
+						Namespaces: &gwv1beta1.RouteNamespaces{From: &fromSame},
+						Kinds:      []gwv1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+					},
+				},
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, gw))
+
+	gwNamespace := gwv1beta1.Namespace("ns1")
+	for _, ns := range []string{"ns1", "ns2"} {
+		assert.Nil(t, k8sClient.Create(ctx, &gwv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "route",
+				Namespace: ns,
+			},
+			Spec: gwv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+					ParentRefs: []gwv1beta1.ParentReference{{Name: "gw", Namespace: &gwNamespace}},
+				},
+			},
+		}))
+	}
+
+	assert.Nil(t, UpdateGWListenerStatus(ctx, k8sClient, gw))
+
+	// the route of ns2 is not allowed by the listener and does not count as attached
+	current := &gwv1beta1.Gateway{}
+	assert.Nil(t, k8sClient.Get(ctx, k8s.NamespacedName(gw), current))
+	assert.Len(t, current.Status.Listeners, 1)
+	assert.EqualValues(t, 1, current.Status.Listeners[0].AttachedRoutes)
+}
+
+func TestUpdateGWListenerStatus_AttachedRoutesWithoutSectionName(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	gwv1alpha2.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&gwv1beta1.Gateway{}).
+		Build()
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:          "http",
+					Protocol:      "HTTP",
+					Port:          80,
+					AllowedRoutes: &gwv1beta1.AllowedRoutes{},
+				},
+				{
+					Name:          "https",
+					Protocol:      "HTTPS",
+					Port:          443,
+					AllowedRoutes: &gwv1beta1.AllowedRoutes{},
+				},
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, gw))
+
+	assert.Nil(t, k8sClient.Create(ctx, &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "http-route",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "gw"}},
+			},
+		},
+	}))
+	assert.Nil(t, k8sClient.Create(ctx, &gwv1alpha2.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grpc-route",
+			Namespace: "ns1",
+		},
+		Spec: gwv1alpha2.GRPCRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "gw"}},
+			},
+		},
+	}))
+
+	assert.Nil(t, UpdateGWListenerStatus(ctx, k8sClient, gw))
+
+	// the HTTPRoute attaches to both listeners, the GRPCRoute to the HTTPS listener only
+	current := &gwv1beta1.Gateway{}
+	assert.Nil(t, k8sClient.Get(ctx, k8s.NamespacedName(gw), current))
+	assert.Len(t, current.Status.Listeners, 2)
+	assert.EqualValues(t, 1, current.Status.Listeners[0].AttachedRoutes)
+	assert.EqualValues(t, 2, current.Status.Listeners[1].AttachedRoutes)
+}
+
+func TestGatewayReconciler_ServiceNetworkCreationDisabled(t *testing.T) {
+	config.ServiceNetworkCreationDisabled = true
+	defer func() { config.ServiceNetworkCreationDisabled = false }()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	gwv1alpha2.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&gwv1beta1.Gateway{}).
+		Build()
+	assert.Nil(t, k8sClient.Create(ctx, &gwv1beta1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "amazon-vpc-lattice",
+			Namespace: defaultNamespace,
+		},
+		Spec: gwv1beta1.GatewayClassSpec{
+			ControllerName: config.LatticeGatewayControllerName,
+		},
+	}))
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "missing",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:     "http",
+					Protocol: "HTTP",
+					Port:     80,
+					AllowedRoutes: &gwv1beta1.AllowedRoutes{
+						Kinds: []gwv1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+					},
+				},
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, gw.DeepCopy()))
+
+	// no CreateServiceNetwork call is expected
+	mockLattice := mocks.NewMockLattice(c)
+	mockLattice.EXPECT().FindServiceNetwork(gomock.Any(), "missing").
+		Return(nil, mocks.NewNotFoundError("Service network", "missing"))
+	cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{})
+
+	mockFinalizer := k8s.NewMockFinalizerManager(c)
+	mockFinalizer.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	r := gatewayReconciler{
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		scheme:           k8sScheme,
+		finalizerManager: mockFinalizer,
+		cloud:            cloud,
+		snManager:        deploy.NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud),
+	}
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: k8s.NamespacedName(gw)})
+	assert.Nil(t, err)
+
+	current := &gwv1beta1.Gateway{}
+	assert.Nil(t, k8sClient.Get(ctx, k8s.NamespacedName(gw), current))
+	cond := meta.FindStatusCondition(current.Status.Conditions, string(gwv1.GatewayConditionProgrammed))
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, string(gwv1.GatewayReasonPending), cond.Reason)
+		assert.Contains(t, cond.Message, "service network creation is disabled")
+	}
+}
+
+func TestGatewayReconciler_ServiceNetworkSelector(t *testing.T) {
+	prodSn := vpclattice.ServiceNetworkSummary{
+		Arn:  aws.String("prod-a1b2-arn"),
+		Id:   aws.String("prod-a1b2-id"),
+		Name: aws.String("prod-a1b2"),
+	}
+
+	tests := []struct {
+		name     string
+		selector string
+		// key of the selector annotation, k8s.ServiceNetworkSelectorAnnotation when empty
+		selectorKey string
+		strict      bool
+		snInfo      *mocks.ServiceNetworkInfo
+		snErr       error
+		wantReason  gwv1.GatewayConditionReason
+		wantMessage string
+	}{
+		{
+			name:        "unique match",
+			selector:    "environment=prod",
+			snInfo:      &mocks.ServiceNetworkInfo{SvcNetwork: prodSn},
+			wantReason:  gwv1.GatewayReasonProgrammed,
+			wantMessage: "aws-service-network-arn: prod-a1b2-arn",
+		},
+		{
+			name:        "no match",
+			selector:    "environment=prod",
+			snErr:       mocks.NewNotFoundError("Service network", "environment=prod"),
+			wantReason:  gwv1.GatewayReasonPending,
+			wantMessage: "No VPC Lattice Service Network has the tags environment=prod",
+		},
+		{
+			name:        "ambiguous match",
+			selector:    "environment=prod",
+			snErr:       fmt.Errorf("%w, multiple SN found", mocks.ErrNameConflict),
+			wantReason:  gwv1.GatewayReasonInvalid,
+			wantMessage: "Found multiple VPC Lattice Service Networks with the tags environment=prod",
+		},
+		{
+			name:        "invalid selector",
+			selector:    "environment",
+			wantReason:  gwv1.GatewayReasonInvalid,
+			wantMessage: "invalid application-networking.k8s.aws/service-network-selector annotation",
+		},
+		{
+			name:        "typo of the selector with strict unknown annotations",
+			selector:    "environment=prod",
+			selectorKey: k8s.AnnotationPrefix + "service-network-selecter",
+			strict:      true,
+			wantReason:  gwv1.GatewayReasonInvalid,
+			wantMessage: "unknown annotations application-networking.k8s.aws/service-network-selecter, check them for typos",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+			if tt.strict {
+				config.UnknownAnnotations = config.UnknownAnnotationsStrict
+				defer func() { config.UnknownAnnotations = config.UnknownAnnotationsLenient }()
+			}
+			selectorKey := k8s.ServiceNetworkSelectorAnnotation
+			if tt.selectorKey != "" {
+				selectorKey = tt.selectorKey
+			}
+
+			k8sScheme := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sScheme)
+			gwv1beta1.AddToScheme(k8sScheme)
+			gwv1alpha2.AddToScheme(k8sScheme)
+			addOptionalCRDs(k8sScheme)
+
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&gwv1beta1.Gateway{}).
+				Build()
+			assert.Nil(t, k8sClient.Create(ctx, &gwv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "amazon-vpc-lattice",
+					Namespace: defaultNamespace,
+				},
+				Spec: gwv1beta1.GatewayClassSpec{
+					ControllerName: config.LatticeGatewayControllerName,
+				},
+			}))
+			gw := &gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "prod",
+					Namespace:   "ns1",
+					Annotations: map[string]string{selectorKey: tt.selector},
+				},
+				Spec: gwv1beta1.GatewaySpec{
+					GatewayClassName: "amazon-vpc-lattice",
+					Listeners: []gwv1beta1.Listener{
+						{
+							Name:     "http",
+							Protocol: "HTTP",
+							Port:     80,
+							AllowedRoutes: &gwv1beta1.AllowedRoutes{
+								Kinds: []gwv1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+							},
+						},
+					},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, gw.DeepCopy()))
+
+			// the service network is never looked up by the gateway name
+			mockLattice := mocks.NewMockLattice(c)
+			if tt.snInfo != nil || tt.snErr != nil {
+				mockLattice.EXPECT().FindServiceNetworkByTags(gomock.Any(), map[string]string{"environment": "prod"}).
+					Return(tt.snInfo, tt.snErr)
+			}
+			cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{})
+
+			mockFinalizer := k8s.NewMockFinalizerManager(c)
+			mockFinalizer.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+			r := gatewayReconciler{
+				log:              gwlog.FallbackLogger,
+				client:           k8sClient,
+				scheme:           k8sScheme,
+				finalizerManager: mockFinalizer,
+				cloud:            cloud,
+				snManager:        deploy.NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud),
+			}
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: k8s.NamespacedName(gw)})
+			assert.Nil(t, err)
+
+			current := &gwv1beta1.Gateway{}
+			assert.Nil(t, k8sClient.Get(ctx, k8s.NamespacedName(gw), current))
+			cond := meta.FindStatusCondition(current.Status.Conditions, string(gwv1.GatewayConditionProgrammed))
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, string(tt.wantReason), cond.Reason)
+				assert.Contains(t, cond.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 	"github.com/pkg/errors"
 
@@ -28,7 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -46,9 +48,11 @@ func RegisterGatewayClassController(log gwlog.Logger, mgr ctrl.Manager) error {
 		scheme:                   mgr.GetScheme(),
 		latticeControllerEnabled: false,
 	}
+	tracker := metrics.NewQueueTracker("GatewayClass")
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&gwv1beta1.GatewayClass{}).
-		Complete(r)
+		Named("gatewayclass").
+		Watches(&gwv1beta1.GatewayClass{}, tracker.EventHandler(&handler.EnqueueRequestForObject{})).
+		Complete(tracker.Reconciler(r))
 }
 
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses,verbs=get;list;watch;create;update;patch;delete
@@ -82,8 +86,8 @@ func (r *gatewayClassReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	gwClass.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now())
 	gwClass.Status.Conditions[0].ObservedGeneration = gwClass.Generation
 	gwClass.Status.Conditions[0].Status = "True"
-	gwClass.Status.Conditions[0].Message = string(gwv1.GatewayClassReasonAccepted)
-	gwClass.Status.Conditions[0].Reason = string(gwv1.GatewayClassReasonAccepted)
+	gwClass.Status.Conditions[0].Message = string(conditions.ReasonAccepted)
+	gwClass.Status.Conditions[0].Reason = string(conditions.ReasonAccepted)
 
 	if err := r.client.Status().Patch(ctx, gwClass, client.MergeFrom(gwClassOld)); err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to update gatewayclass status")
@@ -2,20 +2,29 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
 	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
 	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/resync"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -26,7 +35,10 @@ const (
 	IAMAuthPolicyAnnotation      = "iam-auth-policy"
 	IAMAuthPolicyAnnotationResId = k8s.AnnotationPrefix + IAMAuthPolicyAnnotation + "-resource-id"
 	IAMAuthPolicyAnnotationType  = k8s.AnnotationPrefix + IAMAuthPolicyAnnotation + "-resource-type"
-	IAMAuthPolicyFinalizer       = k8s.AnnotationPrefix + IAMAuthPolicyAnnotation
+	// Hash of the last applied policy document, and the document itself with --policy-annotation-retention=full
+	IAMAuthPolicyAnnotationHash        = k8s.AnnotationPrefix + IAMAuthPolicyAnnotation + "-hash"
+	IAMAuthPolicyAnnotationLastApplied = k8s.AnnotationPrefix + IAMAuthPolicyAnnotation + "-last-applied"
+	IAMAuthPolicyFinalizer             = k8s.AnnotationPrefix + IAMAuthPolicyAnnotation
 )
 
 type (
@@ -41,7 +53,7 @@ type IAMAuthPolicyController struct {
 	cloud  pkg_aws.Cloud
 }
 
-func RegisterIAMAuthPolicyController(log gwlog.Logger, mgr ctrl.Manager, cloud pkg_aws.Cloud) error {
+func RegisterIAMAuthPolicyController(log gwlog.Logger, mgr ctrl.Manager, cloud pkg_aws.Cloud, resyncer *resync.Resyncer) error {
 	ph := policy.NewIAMAuthPolicyHandler(log, mgr.GetClient())
 
 	controller := &IAMAuthPolicyController{
@@ -52,24 +64,43 @@ func RegisterIAMAuthPolicyController(log gwlog.Logger, mgr ctrl.Manager, cloud p
 		cloud:  cloud,
 	}
 
+	tracker := metrics.NewQueueTracker(anv1alpha1.IAMAuthPolicyKind)
+	gate := eventhandlers.NewPriorityGate(1)
 	b := ctrl.
 		NewControllerManagedBy(mgr).
-		For(&anv1alpha1.IAMAuthPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
-	ph.AddWatchers(b, &gwv1beta1.Gateway{}, &gwv1beta1.HTTPRoute{}, &gwv1alpha2.GRPCRoute{})
-	err := b.Complete(controller)
+		Named("iamauthpolicy").
+		Watches(&anv1alpha1.IAMAuthPolicy{}, tracker.EventHandler(gate.EventHandler(eventhandlers.Debounce(&handler.EnqueueRequestForObject{}, config.ReconcileDebounce))), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	ph.AddWatchers(b, tracker, &gwv1beta1.Gateway{}, &gwv1beta1.HTTPRoute{}, &gwv1alpha2.GRPCRoute{})
+	if resyncer != nil {
+		b.WatchesRawSource(resyncer.Source(&anv1alpha1.IAMAuthPolicyList{}), tracker.EventHandler(gate.Resync(&handler.EnqueueRequestForObject{})))
+	}
+	scanner := newStaleResourceIdScanner(log.Named("stale-resource-id"), mgr.GetClient(), cloud)
+	b.WatchesRawSource(scanner.Source(), tracker.EventHandler(&handler.EnqueueRequestForObject{}))
+	if err := mgr.Add(scanner); err != nil {
+		return err
+	}
+	if config.DriftDetectionInterval > 0 {
+		detector := newIAMAuthPolicyDriftDetector(log.Named("drift"), mgr.GetClient(), cloud, ph, config.DriftDetectionInterval)
+		if err := mgr.Add(detector); err != nil {
+			return err
+		}
+	}
+	err := b.Complete(tracker.Reconciler(gate.Reconciler(controller)))
 	return err
 }
 
 // Reconciles IAMAuthPolicy CRD.
 //
-// IAMAuthPolicy has a plain text policy field and targetRef.Content of policy is not validated by
-// controller, but Lattice API.
+// IAMAuthPolicy has a plain text policy field and targetRef. Content of policy is validated against the
+// schema of VPC Lattice auth policies, see model.ValidateIAMAuthPolicyDocument, and by the Lattice API.
 //
 // TargetRef Kind can be Gatbeway, HTTPRoute, or GRPCRoute. Other Kinds will result in Invalid
 // status.  Policy can be attached to single targetRef only. Attempt to attach more than 1 policy
 // will result in Policy Conflict.  If policies created in sequence, the first one will be in
 // Accepted status, and second in Conflict.  Any following updates to accepted policy will put it
-// into conflicting status, and requires manual resolution - delete conflicting policy.
+// into conflicting status, and requires manual resolution - delete conflicting policy. Policies
+// with Merge mode are the exception, their statements are merged into a single Lattice policy
+// when all policies of the targetRef use Merge mode.
 //
 // Lattice side. Gateway attaches to Lattice ServiceNetwork, and HTTP/GRPCRoute to Service.  Policy
 // attachment changes ServiceNetowrk and Service auth-type to IAM, and detachment to
@@ -98,6 +129,10 @@ func (c *IAMAuthPolicyController) Reconcile(ctx context.Context, req ctrl.Reques
 	} else {
 		res, err = c.reconcileUpsert(ctx, k8sPolicy)
 	}
+	if errors.Is(err, deploy.RetryErr) {
+		c.log.Infof(ctx, "Requeue IAM policy %s, %s", req.NamespacedName, err)
+		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -116,15 +151,35 @@ func (c *IAMAuthPolicyController) Reconcile(ctx context.Context, req ctrl.Reques
 }
 
 func (c *IAMAuthPolicyController) reconcileDelete(ctx context.Context, k8sPolicy *anv1alpha1.IAMAuthPolicy) (ctrl.Result, error) {
+	statusPolicy := model.IAMAuthPolicyStatus{}
 	err := c.ph.ValidateTargetRef(ctx, k8sPolicy)
 	if err == nil {
-		modelPolicy := model.NewIAMAuthPolicy(k8sPolicy)
-		_, err := c.pm.Delete(ctx, modelPolicy)
-		if err != nil {
-			return ctrl.Result{}, services.IgnoreNotFound(err)
+		var mergedPolicies []*IAP
+		if k8sPolicy.MergeEnabled() {
+			mergedPolicies, err = c.ph.MergedPolicies(ctx, k8sPolicy)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if len(mergedPolicies) > 0 {
+			// other policies are still merged into the Lattice resource policy, keep their statements
+			modelPolicy, err := model.NewMergedIAMAuthPolicy(mergedPolicies)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			statusPolicy, err = c.pm.Put(ctx, modelPolicy)
+			if err != nil {
+				return ctrl.Result{}, services.IgnoreNotFound(err)
+			}
+		} else {
+			modelPolicy := model.NewIAMAuthPolicy(k8sPolicy)
+			_, err := c.pm.Delete(ctx, modelPolicy)
+			if err != nil {
+				return ctrl.Result{}, services.IgnoreNotFound(err)
+			}
 		}
 	}
-	err = c.handleLatticeResourceChange(ctx, k8sPolicy, model.IAMAuthPolicyStatus{})
+	err = c.handleLatticeResourceChange(ctx, k8sPolicy, statusPolicy)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -133,28 +188,61 @@ func (c *IAMAuthPolicyController) reconcileDelete(ctx context.Context, k8sPolicy
 }
 
 func (c *IAMAuthPolicyController) reconcileUpsert(ctx context.Context, k8sPolicy *anv1alpha1.IAMAuthPolicy) (ctrl.Result, error) {
+	if err := c.detachFromPreviousKind(ctx, k8sPolicy); err != nil {
+		return ctrl.Result{}, err
+	}
 	reason, err := c.ph.ValidateAndUpdateCondition(ctx, k8sPolicy)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	if reason != policy.ReasonAccepted {
-		return ctrl.Result{}, nil
+	if reason != conditions.ReasonAccepted {
+		return policy.ResultForReason(reason), nil
+	}
+	if err := model.ValidateIAMAuthPolicyDocument(k8sPolicy.Spec.Policy); err != nil {
+		// retrying would not help until the policy is fixed
+		err = c.ph.UpdateAcceptedCondition(ctx, k8sPolicy, conditions.ReasonInvalid, err.Error())
+		return ctrl.Result{}, err
 	}
 	modelPolicy := model.NewIAMAuthPolicy(k8sPolicy)
+	if prevModel, ok := c.getLatticeAnnotation(k8sPolicy); ok && !k8sPolicy.MergeEnabled() &&
+		!meta.IsStatusConditionTrue(k8sPolicy.Status.Conditions, conditions.TypeDriftDetected) {
+		// merged documents are also put by the other policies of the targetRef, only exclusive ones are skipped.
+		// Drifted policies are put again to correct the drift
+		modelPolicy.ResourceId = prevModel.ResourceId
+		modelPolicy.LastAppliedHash = k8sPolicy.Annotations[IAMAuthPolicyAnnotationHash]
+	}
+	if k8sPolicy.MergeEnabled() {
+		mergedPolicies, err := c.ph.MergedPolicies(ctx, k8sPolicy)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		modelPolicy, err = model.NewMergedIAMAuthPolicy(mergedPolicies)
+		if err != nil {
+			err = c.ph.UpdateAcceptedCondition(ctx, k8sPolicy, conditions.ReasonInvalid, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
 	c.addFinalizer(k8sPolicy)
 	err = c.client.Update(ctx, k8sPolicy)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	// Put looks up the Lattice resource by name, so the policy is applied to the current resource even when
+	// the annotated one was deleted and recreated with a new id
 	statusPolicy, err := c.pm.Put(ctx, modelPolicy)
 	if err != nil {
 		return reconcile.Result{}, services.IgnoreNotFound(err)
 	}
-	c.updateLatticeAnnotaion(k8sPolicy, statusPolicy.ResourceId, modelPolicy.Type)
+	if prevModel, ok := c.getLatticeAnnotation(k8sPolicy); ok && prevModel.ResourceId != statusPolicy.ResourceId {
+		c.log.Infof(ctx, "Lattice resource of policy %s/%s changed from %s to %s",
+			k8sPolicy.Namespace, k8sPolicy.Name, prevModel.ResourceId, statusPolicy.ResourceId)
+	}
+	// previous resource is read from the annotation, so clean it up before updating the annotation
 	err = c.handleLatticeResourceChange(ctx, k8sPolicy, statusPolicy)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	c.updateLatticeAnnotaion(k8sPolicy, statusPolicy.ResourceId, modelPolicy.Type, modelPolicy.Policy)
 	return ctrl.Result{}, nil
 }
 
@@ -185,12 +273,43 @@ func (c *IAMAuthPolicyController) handleLatticeResourceChange(ctx context.Contex
 	return nil
 }
 
-func (c *IAMAuthPolicyController) updateLatticeAnnotaion(k8sPolicy *anv1alpha1.IAMAuthPolicy, resId, resType string) {
+// Once the targetRef changes kind, e.g. from Gateway to HTTPRoute, the policy applied to the Lattice resource of the
+// previous kind is removed before the policy is applied to the new target. It is removed even when the new target is
+// not accepted, e.g. not found yet, so that the previous resource does not keep enforcing an orphaned policy.
+func (c *IAMAuthPolicyController) detachFromPreviousKind(ctx context.Context, k8sPolicy *anv1alpha1.IAMAuthPolicy) error {
+	prevModel, ok := c.getLatticeAnnotation(k8sPolicy)
+	if !ok || k8sPolicy.Spec.TargetRef == nil {
+		return nil
+	}
+	resourceType := model.IAMAuthPolicyResourceType(string(k8sPolicy.Spec.TargetRef.Kind))
+	if resourceType == prevModel.Type {
+		return nil
+	}
+	c.log.Infof(ctx, "targetRef of policy %s/%s changed kind to %s, removing policy from previous %s %s",
+		k8sPolicy.Namespace, k8sPolicy.Name, k8sPolicy.Spec.TargetRef.Kind, prevModel.Type, prevModel.ResourceId)
+	if _, err := c.pm.Delete(ctx, prevModel); services.IgnoreNotFound(err) != nil {
+		return err
+	}
+	delete(k8sPolicy.Annotations, IAMAuthPolicyAnnotationResId)
+	delete(k8sPolicy.Annotations, IAMAuthPolicyAnnotationType)
+	delete(k8sPolicy.Annotations, IAMAuthPolicyAnnotationHash)
+	delete(k8sPolicy.Annotations, IAMAuthPolicyAnnotationLastApplied)
+	// persisted right away, as the status update of the validation reloads the policy
+	return c.client.Update(ctx, k8sPolicy)
+}
+
+func (c *IAMAuthPolicyController) updateLatticeAnnotaion(k8sPolicy *anv1alpha1.IAMAuthPolicy, resId, resType, doc string) {
 	if k8sPolicy.Annotations == nil {
 		k8sPolicy.Annotations = make(map[string]string)
 	}
 	k8sPolicy.Annotations[IAMAuthPolicyAnnotationResId] = resId
 	k8sPolicy.Annotations[IAMAuthPolicyAnnotationType] = resType
+	k8sPolicy.Annotations[IAMAuthPolicyAnnotationHash] = model.IAMAuthPolicyHash(doc)
+	if config.PolicyRetention == config.PolicyAnnotationRetentionFull {
+		k8sPolicy.Annotations[IAMAuthPolicyAnnotationLastApplied] = doc
+	} else {
+		delete(k8sPolicy.Annotations, IAMAuthPolicyAnnotationLastApplied)
+	}
 }
 
 func (c *IAMAuthPolicyController) getLatticeAnnotation(k8sPolicy *anv1alpha1.IAMAuthPolicy) (model.IAMAuthPolicy, bool) {
@@ -1 +1,711 @@
 package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	aws2 "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestIAMAuthPolicyController_ValidateTargetRef(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	otherClass := &gwv1beta1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-class"},
+		Spec:       gwv1beta1.GatewayClassSpec{ControllerName: "example.com/other-controller"},
+	}
+
+	tests := []struct {
+		name           string
+		objs           []client.Object
+		group          gwv1beta1.Group
+		kind           gwv1beta1.Kind
+		expectedReason conditions.Reason
+	}{
+		{
+			name:           "gateway not found",
+			expectedReason: conditions.ReasonTargetNotFound,
+		},
+		{
+			name: "gateway of another group",
+			objs: []client.Object{
+				&gwv1beta1.Gateway{
+					ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"},
+					Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "amazon-vpc-lattice"},
+				},
+			},
+			group:          "example.com",
+			expectedReason: conditions.ReasonInvalid,
+		},
+		{
+			name: "route of another group",
+			objs: []client.Object{
+				&gwv1beta1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"},
+				},
+			},
+			group:          "example.com",
+			kind:           "HTTPRoute",
+			expectedReason: conditions.ReasonInvalid,
+		},
+		{
+			name: "gateway of another controller",
+			objs: []client.Object{
+				otherClass,
+				&gwv1beta1.Gateway{
+					ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"},
+					Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "other-class"},
+				},
+			},
+			expectedReason: conditions.ReasonNotOurClass,
+		},
+		{
+			name: "gateway class not found",
+			objs: []client.Object{
+				&gwv1beta1.Gateway{
+					ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"},
+					Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "amazon-vpc-lattice"},
+				},
+			},
+			expectedReason: conditions.ReasonNotOurClass,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&anv1alpha1.IAMAuthPolicy{}).
+				WithObjects(tt.objs...).
+				Build()
+
+			group, kind := tt.group, tt.kind
+			if group == "" {
+				group = gwv1beta1.GroupName
+			}
+			if kind == "" {
+				kind = "Gateway"
+			}
+			iap := &anv1alpha1.IAMAuthPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "ns"},
+				Spec: anv1alpha1.IAMAuthPolicySpec{
+					Policy: "{}",
+					TargetRef: &gwv1alpha2.PolicyTargetReference{
+						Group: group,
+						Kind:  kind,
+						Name:  "gw",
+					},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, iap))
+
+			controller := &IAMAuthPolicyController{
+				log:    gwlog.FallbackLogger,
+				client: k8sClient,
+				ph:     policy.NewIAMAuthPolicyHandler(gwlog.FallbackLogger, k8sClient),
+			}
+			nsname := types.NamespacedName{Name: "policy", Namespace: "ns"}
+			_, err := controller.Reconcile(ctx, ctrl.Request{NamespacedName: nsname})
+			assert.Nil(t, err)
+
+			assert.Nil(t, k8sClient.Get(ctx, nsname, iap))
+			cnd := meta.FindStatusCondition(iap.Status.Conditions, conditions.TypeAccepted)
+			assert.NotNil(t, cnd)
+			assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+			assert.Equal(t, string(tt.expectedReason), cnd.Reason)
+			assert.NotContains(t, iap.Finalizers, IAMAuthPolicyFinalizer)
+		})
+	}
+}
+
+func TestIAMAuthPolicyController_ServiceRecreated(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&anv1alpha1.IAMAuthPolicy{}).
+		WithObjects(&gwv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns"},
+		}).
+		Build()
+
+	// the policy was applied to the Lattice service before it was recreated
+	iap := &anv1alpha1.IAMAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "policy",
+			Namespace: "ns",
+			Annotations: map[string]string{
+				IAMAuthPolicyAnnotationResId: "svc-old",
+				IAMAuthPolicyAnnotationType:  model.ServiceType,
+			},
+			Finalizers: []string{IAMAuthPolicyFinalizer},
+		},
+		Spec: anv1alpha1.IAMAuthPolicySpec{
+			Policy: "{}",
+			TargetRef: &gwv1alpha2.PolicyTargetReference{
+				Group: gwv1beta1.GroupName,
+				Kind:  "HTTPRoute",
+				Name:  "route",
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, iap))
+
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{})
+
+	mockLattice.EXPECT().FindService(gomock.Any(), utils.LatticeServiceName("route", "ns")).
+		Return(&vpclattice.ServiceSummary{Id: aws.String("svc-new")}, nil)
+	// the policy is re-applied to the recreated service
+	mockLattice.EXPECT().PutAuthPolicyWithContext(gomock.Any(), &vpclattice.PutAuthPolicyInput{
+		Policy:             aws.String("{}"),
+		ResourceIdentifier: aws.String("svc-new"),
+	}).Return(&vpclattice.PutAuthPolicyOutput{}, nil)
+	mockLattice.EXPECT().UpdateServiceWithContext(gomock.Any(), &vpclattice.UpdateServiceInput{
+		AuthType:          aws.String(vpclattice.AuthTypeAwsIam),
+		ServiceIdentifier: aws.String("svc-new"),
+	}).Return(&vpclattice.UpdateServiceOutput{}, nil)
+	// cleanup of the deleted service does not fail the reconcile
+	mockLattice.EXPECT().UpdateServiceWithContext(gomock.Any(), &vpclattice.UpdateServiceInput{
+		AuthType:          aws.String(vpclattice.AuthTypeNone),
+		ServiceIdentifier: aws.String("svc-old"),
+	}).Return(nil, awserr.New(vpclattice.ErrCodeResourceNotFoundException, "not found", nil))
+
+	controller := &IAMAuthPolicyController{
+		log:    gwlog.FallbackLogger,
+		client: k8sClient,
+		pm:     deploy.NewIAMAuthPolicyManager(cloud),
+		ph:     policy.NewIAMAuthPolicyHandler(gwlog.FallbackLogger, k8sClient),
+		cloud:  cloud,
+	}
+	nsname := types.NamespacedName{Name: "policy", Namespace: "ns"}
+	_, err := controller.Reconcile(ctx, ctrl.Request{NamespacedName: nsname})
+	assert.Nil(t, err)
+
+	assert.Nil(t, k8sClient.Get(ctx, nsname, iap))
+	assert.Equal(t, "svc-new", iap.Annotations[IAMAuthPolicyAnnotationResId])
+	assert.Equal(t, model.ServiceType, iap.Annotations[IAMAuthPolicyAnnotationType])
+	cnd := meta.FindStatusCondition(iap.Status.Conditions, conditions.TypeAccepted)
+	assert.NotNil(t, cnd)
+	assert.Equal(t, metav1.ConditionTrue, cnd.Status)
+}
+
+func TestIAMAuthPolicyController_SchemaViolation(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&anv1alpha1.IAMAuthPolicy{}).
+		WithObjects(&gwv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns"},
+		}).
+		Build()
+
+	iap := &anv1alpha1.IAMAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "ns"},
+		Spec: anv1alpha1.IAMAuthPolicySpec{
+			Policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"vpc-lattice:Invoke","Resource":"*"}]}`,
+			TargetRef: &gwv1alpha2.PolicyTargetReference{
+				Group: gwv1beta1.GroupName,
+				Kind:  "HTTPRoute",
+				Name:  "route",
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, iap))
+
+	// the policy is not put
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{})
+
+	controller := &IAMAuthPolicyController{
+		log:    gwlog.FallbackLogger,
+		client: k8sClient,
+		pm:     deploy.NewIAMAuthPolicyManager(cloud),
+		ph:     policy.NewIAMAuthPolicyHandler(gwlog.FallbackLogger, k8sClient),
+		cloud:  cloud,
+	}
+	nsname := types.NamespacedName{Name: "policy", Namespace: "ns"}
+	_, err := controller.Reconcile(ctx, ctrl.Request{NamespacedName: nsname})
+	assert.Nil(t, err)
+
+	assert.Nil(t, k8sClient.Get(ctx, nsname, iap))
+	cnd := meta.FindStatusCondition(iap.Status.Conditions, conditions.TypeAccepted)
+	assert.NotNil(t, cnd)
+	assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+	assert.Equal(t, string(conditions.ReasonInvalid), cnd.Reason)
+	assert.Equal(t, `invalid policy document: Statement[0].Action "vpc-lattice:Invoke" must be a VPC Lattice action, e.g. vpc-lattice-svcs:Invoke, or *`,
+		cnd.Message)
+}
+
+func TestIAMAuthPolicyController_TargetRefKindChanged(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&anv1alpha1.IAMAuthPolicy{}).
+		WithObjects(&gwv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns"},
+		}).
+		Build()
+
+	// the policy was applied to the service network of a Gateway, then its targetRef was edited to an HTTPRoute
+	iap := &anv1alpha1.IAMAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "policy",
+			Namespace: "ns",
+			Annotations: map[string]string{
+				IAMAuthPolicyAnnotationResId: "sn-1",
+				IAMAuthPolicyAnnotationType:  model.ServiceNetworkType,
+				IAMAuthPolicyAnnotationHash:  model.IAMAuthPolicyHash("{}"),
+			},
+			Finalizers: []string{IAMAuthPolicyFinalizer},
+		},
+		Spec: anv1alpha1.IAMAuthPolicySpec{
+			Policy: "{}",
+			TargetRef: &gwv1alpha2.PolicyTargetReference{
+				Group: gwv1beta1.GroupName,
+				Kind:  "HTTPRoute",
+				Name:  "route",
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, iap))
+
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{})
+
+	gomock.InOrder(
+		// the service network of the Gateway is cleaned up first
+		mockLattice.EXPECT().UpdateServiceNetworkWithContext(gomock.Any(), &vpclattice.UpdateServiceNetworkInput{
+			AuthType:                 aws.String(vpclattice.AuthTypeNone),
+			ServiceNetworkIdentifier: aws.String("sn-1"),
+		}).Return(&vpclattice.UpdateServiceNetworkOutput{}, nil),
+		mockLattice.EXPECT().DeleteAuthPolicy(&vpclattice.DeleteAuthPolicyInput{
+			ResourceIdentifier: aws.String("sn-1"),
+		}).Return(&vpclattice.DeleteAuthPolicyOutput{}, nil),
+		// then the policy is applied to the service of the HTTPRoute
+		mockLattice.EXPECT().FindService(gomock.Any(), utils.LatticeServiceName("route", "ns")).
+			Return(&vpclattice.ServiceSummary{Id: aws.String("svc-1")}, nil),
+		mockLattice.EXPECT().PutAuthPolicyWithContext(gomock.Any(), &vpclattice.PutAuthPolicyInput{
+			Policy:             aws.String("{}"),
+			ResourceIdentifier: aws.String("svc-1"),
+		}).Return(&vpclattice.PutAuthPolicyOutput{}, nil),
+		mockLattice.EXPECT().UpdateServiceWithContext(gomock.Any(), &vpclattice.UpdateServiceInput{
+			AuthType:          aws.String(vpclattice.AuthTypeAwsIam),
+			ServiceIdentifier: aws.String("svc-1"),
+		}).Return(&vpclattice.UpdateServiceOutput{}, nil),
+	)
+
+	controller := &IAMAuthPolicyController{
+		log:    gwlog.FallbackLogger,
+		client: k8sClient,
+		pm:     deploy.NewIAMAuthPolicyManager(cloud),
+		ph:     policy.NewIAMAuthPolicyHandler(gwlog.FallbackLogger, k8sClient),
+		cloud:  cloud,
+	}
+	nsname := types.NamespacedName{Name: "policy", Namespace: "ns"}
+	_, err := controller.Reconcile(ctx, ctrl.Request{NamespacedName: nsname})
+	assert.Nil(t, err)
+
+	assert.Nil(t, k8sClient.Get(ctx, nsname, iap))
+	assert.Equal(t, "svc-1", iap.Annotations[IAMAuthPolicyAnnotationResId])
+	assert.Equal(t, model.ServiceType, iap.Annotations[IAMAuthPolicyAnnotationType])
+	cnd := meta.FindStatusCondition(iap.Status.Conditions, conditions.TypeAccepted)
+	assert.NotNil(t, cnd)
+	assert.Equal(t, metav1.ConditionTrue, cnd.Status)
+}
+
+func TestIAMAuthPolicyController_MergeMode(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	statement := func(sid string) string {
+		return `{"Statement":[{"Sid":"` + sid + `","Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`
+	}
+	newPolicy := func(name string, created int64, mode anv1alpha1.IAMAuthPolicyMode) *anv1alpha1.IAMAuthPolicy {
+		return &anv1alpha1.IAMAuthPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "ns",
+				CreationTimestamp: metav1.Unix(created, 0),
+			},
+			Spec: anv1alpha1.IAMAuthPolicySpec{
+				Policy: statement(name),
+				Mode:   mode,
+				TargetRef: &gwv1alpha2.PolicyTargetReference{
+					Group: gwv1beta1.GroupName,
+					Kind:  "HTTPRoute",
+					Name:  "route",
+				},
+			},
+		}
+	}
+	setup := func(t *testing.T, mockLattice *mocks.MockLattice, policies ...*anv1alpha1.IAMAuthPolicy) (client.Client, *IAMAuthPolicyController) {
+		k8sClient := testclient.
+			NewClientBuilder().
+			WithScheme(k8sScheme).
+			WithStatusSubresource(&anv1alpha1.IAMAuthPolicy{}).
+			WithObjects(&gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns"},
+			}).
+			Build()
+		for _, p := range policies {
+			assert.Nil(t, k8sClient.Create(ctx, p))
+		}
+		cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{})
+		controller := &IAMAuthPolicyController{
+			log:    gwlog.FallbackLogger,
+			client: k8sClient,
+			pm:     deploy.NewIAMAuthPolicyManager(cloud),
+			ph:     policy.NewIAMAuthPolicyHandler(gwlog.FallbackLogger, k8sClient),
+			cloud:  cloud,
+		}
+		return k8sClient, controller
+	}
+	acceptedReason := func(t *testing.T, k8sClient client.Client, name string) string {
+		iap := &anv1alpha1.IAMAuthPolicy{}
+		assert.Nil(t, k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "ns"}, iap))
+		cnd := meta.FindStatusCondition(iap.Status.Conditions, conditions.TypeAccepted)
+		assert.NotNil(t, cnd)
+		return cnd.Reason
+	}
+	expectPut := func(mockLattice *mocks.MockLattice, doc string) {
+		mockLattice.EXPECT().FindService(gomock.Any(), utils.LatticeServiceName("route", "ns")).
+			Return(&vpclattice.ServiceSummary{Id: aws.String("svc-id")}, nil)
+		mockLattice.EXPECT().PutAuthPolicyWithContext(gomock.Any(), &vpclattice.PutAuthPolicyInput{
+			Policy:             aws.String(doc),
+			ResourceIdentifier: aws.String("svc-id"),
+		}).Return(&vpclattice.PutAuthPolicyOutput{}, nil)
+		mockLattice.EXPECT().UpdateServiceWithContext(gomock.Any(), &vpclattice.UpdateServiceInput{
+			AuthType:          aws.String(vpclattice.AuthTypeAwsIam),
+			ServiceIdentifier: aws.String("svc-id"),
+		}).Return(&vpclattice.UpdateServiceOutput{}, nil)
+	}
+
+	t.Run("merge policies are merged", func(t *testing.T) {
+		c := gomock.NewController(t)
+		defer c.Finish()
+		mockLattice := mocks.NewMockLattice(c)
+		k8sClient, controller := setup(t, mockLattice,
+			newPolicy("p1", 1, anv1alpha1.IAMAuthPolicyModeMerge),
+			newPolicy("p2", 2, anv1alpha1.IAMAuthPolicyModeMerge))
+
+		merged, err := model.MergeIAMAuthPolicyDocuments([]string{statement("p1"), statement("p2")})
+		assert.Nil(t, err)
+		expectPut(mockLattice, merged)
+
+		_, err = controller.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "p2", Namespace: "ns"}})
+		assert.Nil(t, err)
+		assert.Equal(t, string(conditions.ReasonAccepted), acceptedReason(t, k8sClient, "p2"))
+	})
+
+	t.Run("exclusive policy conflicts with merge policy", func(t *testing.T) {
+		c := gomock.NewController(t)
+		defer c.Finish()
+		mockLattice := mocks.NewMockLattice(c)
+		k8sClient, controller := setup(t, mockLattice,
+			newPolicy("p1", 1, anv1alpha1.IAMAuthPolicyModeMerge),
+			newPolicy("p2", 2, anv1alpha1.IAMAuthPolicyModeExclusive))
+
+		_, err := controller.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "p2", Namespace: "ns"}})
+		assert.Nil(t, err)
+		assert.Equal(t, string(conditions.ReasonConflicted), acceptedReason(t, k8sClient, "p2"))
+	})
+
+	t.Run("deleted merge policy keeps statements of other policies", func(t *testing.T) {
+		c := gomock.NewController(t)
+		defer c.Finish()
+		mockLattice := mocks.NewMockLattice(c)
+		p1 := newPolicy("p1", 1, anv1alpha1.IAMAuthPolicyModeMerge)
+		p1.Finalizers = []string{IAMAuthPolicyFinalizer}
+		p1.Annotations = map[string]string{
+			IAMAuthPolicyAnnotationResId: "svc-id",
+			IAMAuthPolicyAnnotationType:  model.ServiceType,
+		}
+		k8sClient, controller := setup(t, mockLattice, p1, newPolicy("p2", 2, anv1alpha1.IAMAuthPolicyModeMerge))
+		assert.Nil(t, k8sClient.Delete(ctx, p1))
+
+		merged, err := model.MergeIAMAuthPolicyDocuments([]string{statement("p2")})
+		assert.Nil(t, err)
+		expectPut(mockLattice, merged)
+
+		_, err = controller.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "p1", Namespace: "ns"}})
+		assert.Nil(t, err)
+		err = k8sClient.Get(ctx, types.NamespacedName{Name: "p1", Namespace: "ns"}, p1)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestIAMAuthPolicyController_ServiceNetworkUpdating(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	tests := []struct {
+		name          string
+		updateErr     error
+		expectedRes   ctrl.Result
+		expectedErr   bool
+		expectedResId string
+	}{
+		{
+			name:          "service network accepts auth type",
+			expectedResId: "sn-id",
+		},
+		{
+			name:        "service network is being updated",
+			updateErr:   awserr.New(vpclattice.ErrCodeConflictException, "service network is being updated", nil),
+			expectedRes: ctrl.Result{RequeueAfter: time.Second * 30},
+		},
+		{
+			name:        "other error",
+			updateErr:   awserr.New(vpclattice.ErrCodeInternalServerException, "internal error", nil),
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&anv1alpha1.IAMAuthPolicy{}).
+				WithObjects(
+					&gwv1beta1.GatewayClass{
+						ObjectMeta: metav1.ObjectMeta{Name: "amazon-vpc-lattice"},
+						Spec:       gwv1beta1.GatewayClassSpec{ControllerName: config.LatticeGatewayControllerName},
+					},
+					&gwv1beta1.Gateway{
+						ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"},
+						Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "amazon-vpc-lattice"},
+					},
+				).
+				Build()
+			iap := &anv1alpha1.IAMAuthPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "ns"},
+				Spec: anv1alpha1.IAMAuthPolicySpec{
+					Policy: "{}",
+					TargetRef: &gwv1alpha2.PolicyTargetReference{
+						Group: gwv1beta1.GroupName,
+						Kind:  "Gateway",
+						Name:  "gw",
+					},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, iap))
+
+			mockLattice := mocks.NewMockLattice(c)
+			cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{})
+			mockLattice.EXPECT().FindServiceNetwork(gomock.Any(), "gw").
+				Return(&mocks.ServiceNetworkInfo{SvcNetwork: vpclattice.ServiceNetworkSummary{Id: aws.String("sn-id")}}, nil)
+			mockLattice.EXPECT().PutAuthPolicyWithContext(gomock.Any(), gomock.Any()).
+				Return(&vpclattice.PutAuthPolicyOutput{}, nil)
+			mockLattice.EXPECT().UpdateServiceNetworkWithContext(gomock.Any(), &vpclattice.UpdateServiceNetworkInput{
+				AuthType:                 aws.String(vpclattice.AuthTypeAwsIam),
+				ServiceNetworkIdentifier: aws.String("sn-id"),
+			}).Return(&vpclattice.UpdateServiceNetworkOutput{}, tt.updateErr)
+
+			controller := &IAMAuthPolicyController{
+				log:    gwlog.FallbackLogger,
+				client: k8sClient,
+				pm:     deploy.NewIAMAuthPolicyManager(cloud),
+				ph:     policy.NewIAMAuthPolicyHandler(gwlog.FallbackLogger, k8sClient),
+				cloud:  cloud,
+			}
+			nsname := types.NamespacedName{Name: "policy", Namespace: "ns"}
+			res, err := controller.Reconcile(ctx, ctrl.Request{NamespacedName: nsname})
+			if tt.expectedErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.expectedRes, res)
+
+			assert.Nil(t, k8sClient.Get(ctx, nsname, iap))
+			assert.Equal(t, tt.expectedResId, iap.Annotations[IAMAuthPolicyAnnotationResId])
+		})
+	}
+}
+
+func TestIAMAuthPolicyController_PolicyAnnotationRetention(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	doc := `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`
+	updatedDoc := `{"Statement":[{"Effect":"Deny","Principal":"*","Action":"*","Resource":"*"}]}`
+
+	tests := []struct {
+		retention       config.PolicyAnnotationRetention
+		wantLastApplied func(doc string) map[string]string
+	}{
+		{
+			retention: config.PolicyAnnotationRetentionHash,
+			wantLastApplied: func(doc string) map[string]string {
+				return map[string]string{}
+			},
+		},
+		{
+			retention: config.PolicyAnnotationRetentionFull,
+			wantLastApplied: func(doc string) map[string]string {
+				return map[string]string{IAMAuthPolicyAnnotationLastApplied: doc}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.retention), func(t *testing.T) {
+			defer func(retention config.PolicyAnnotationRetention) { config.PolicyRetention = retention }(config.PolicyRetention)
+			config.PolicyRetention = tt.retention
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+			mockLattice := mocks.NewMockLattice(c)
+			cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{})
+
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&anv1alpha1.IAMAuthPolicy{}).
+				WithObjects(&gwv1beta1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns"},
+				}).
+				Build()
+			iap := &anv1alpha1.IAMAuthPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "ns"},
+				Spec: anv1alpha1.IAMAuthPolicySpec{
+					Policy: doc,
+					TargetRef: &gwv1alpha2.PolicyTargetReference{
+						Group: gwv1beta1.GroupName,
+						Kind:  "HTTPRoute",
+						Name:  "route",
+					},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, iap))
+
+			controller := &IAMAuthPolicyController{
+				log:    gwlog.FallbackLogger,
+				client: k8sClient,
+				pm:     deploy.NewIAMAuthPolicyManager(cloud),
+				ph:     policy.NewIAMAuthPolicyHandler(gwlog.FallbackLogger, k8sClient),
+				cloud:  cloud,
+			}
+			nsname := types.NamespacedName{Name: "policy", Namespace: "ns"}
+			reconcile := func(putDoc string) {
+				mockLattice.EXPECT().FindService(gomock.Any(), utils.LatticeServiceName("route", "ns")).
+					Return(&vpclattice.ServiceSummary{Id: aws.String("svc-id")}, nil)
+				if putDoc != "" {
+					mockLattice.EXPECT().PutAuthPolicyWithContext(gomock.Any(), &vpclattice.PutAuthPolicyInput{
+						Policy:             aws.String(putDoc),
+						ResourceIdentifier: aws.String("svc-id"),
+					}).Return(&vpclattice.PutAuthPolicyOutput{}, nil)
+				}
+				mockLattice.EXPECT().UpdateServiceWithContext(gomock.Any(), gomock.Any()).Return(&vpclattice.UpdateServiceOutput{}, nil)
+
+				_, err := controller.Reconcile(ctx, ctrl.Request{NamespacedName: nsname})
+				assert.Nil(t, err)
+				assert.Nil(t, k8sClient.Get(ctx, nsname, iap))
+			}
+			lastApplied := func() map[string]string {
+				annotations := map[string]string{}
+				if v, ok := iap.Annotations[IAMAuthPolicyAnnotationLastApplied]; ok {
+					annotations[IAMAuthPolicyAnnotationLastApplied] = v
+				}
+				return annotations
+			}
+
+			reconcile(doc)
+			assert.Equal(t, model.IAMAuthPolicyHash(doc), iap.Annotations[IAMAuthPolicyAnnotationHash])
+			assert.Equal(t, tt.wantLastApplied(doc), lastApplied())
+
+			// the document is applied already, it is not put again
+			reconcile("")
+			assert.Equal(t, model.IAMAuthPolicyHash(doc), iap.Annotations[IAMAuthPolicyAnnotationHash])
+
+			iap.Spec.Policy = updatedDoc
+			assert.Nil(t, k8sClient.Update(ctx, iap))
+			reconcile(updatedDoc)
+			assert.Equal(t, model.IAMAuthPolicyHash(updatedDoc), iap.Annotations[IAMAuthPolicyAnnotationHash])
+			assert.Equal(t, tt.wantLastApplied(updatedDoc), lastApplied())
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// Compares the Lattice auth policies of accepted IAMAuthPolicies to their desired policy documents, and records
+// the result in their DriftDetected condition and the drifted resources metric. Drift is not corrected here,
+// drifted policies are put again on their next reconcile.
+// Runs only on the elected leader, like the other runnables updating policy status.
+type iamAuthPolicyDriftDetector struct {
+	log      gwlog.Logger
+	client   client.Client
+	cloud    pkg_aws.Cloud
+	ph       *policy.PolicyHandler[*IAP]
+	interval time.Duration
+}
+
+func newIAMAuthPolicyDriftDetector(log gwlog.Logger, client client.Client, cloud pkg_aws.Cloud,
+	ph *policy.PolicyHandler[*IAP], interval time.Duration) *iamAuthPolicyDriftDetector {
+	return &iamAuthPolicyDriftDetector{
+		log:      log,
+		client:   client,
+		cloud:    cloud,
+		ph:       ph,
+		interval: interval,
+	}
+}
+
+// Start detects drift every interval until the context is cancelled, implements manager.Runnable.
+func (d *iamAuthPolicyDriftDetector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := d.Detect(ctx); err != nil {
+				d.log.Warnf(ctx, "Failed to detect drift of IAMAuthPolicies: %s", err)
+			}
+		}
+	}
+}
+
+// Detect updates the DriftDetected condition of the applied policies. Returns the number of drifted policies.
+func (d *iamAuthPolicyDriftDetector) Detect(ctx context.Context) (int, error) {
+	policies := &anv1alpha1.IAMAuthPolicyList{}
+	if err := d.client.List(ctx, policies); err != nil {
+		return 0, err
+	}
+	drifted := 0
+	for i := range policies.Items {
+		k8sPolicy := &policies.Items[i]
+		if !k8sPolicy.DeletionTimestamp.IsZero() ||
+			!conditions.HasReason(k8sPolicy.Status.Conditions, conditions.TypeAccepted, conditions.ReasonAccepted) {
+			continue
+		}
+		resId := k8sPolicy.Annotations[IAMAuthPolicyAnnotationResId]
+		if resId == "" {
+			continue
+		}
+		msg, err := d.drift(ctx, k8sPolicy, resId)
+		if err != nil {
+			d.log.Debugf(ctx, "Unable to detect drift of policy %s, %s", k8s.NamespacedName(k8sPolicy), err)
+			continue
+		}
+		cnd := conditions.New(conditions.TypeDriftDetected, k8sPolicy.Generation, conditions.ReasonInSync, "")
+		if msg != "" {
+			drifted++
+			d.log.Infof(ctx, "Drift detected for policy %s: %s", k8s.NamespacedName(k8sPolicy), msg)
+			cnd = conditions.New(conditions.TypeDriftDetected, k8sPolicy.Generation, conditions.ReasonDrifted, msg)
+		}
+		if !conditions.Changed(k8sPolicy.Status.Conditions, cnd) {
+			continue
+		}
+		meta.SetStatusCondition(&k8sPolicy.Status.Conditions, cnd)
+		if err := d.client.Status().Update(ctx, k8sPolicy); err != nil {
+			return drifted, err
+		}
+	}
+	metrics.SetDriftedResources(anv1alpha1.IAMAuthPolicyKind, drifted)
+	return drifted, nil
+}
+
+// Describes how the Lattice auth policy of the resource differs from the desired one, or empty when it does not.
+func (d *iamAuthPolicyDriftDetector) drift(ctx context.Context, k8sPolicy *IAP, resId string) (string, error) {
+	desired := model.NewIAMAuthPolicy(k8sPolicy)
+	if k8sPolicy.MergeEnabled() {
+		mergedPolicies, err := d.ph.MergedPolicies(ctx, k8sPolicy)
+		if err != nil {
+			return "", err
+		}
+		desired, err = model.NewMergedIAMAuthPolicy(mergedPolicies)
+		if err != nil {
+			return "", err
+		}
+	}
+	resp, err := d.cloud.Lattice().GetAuthPolicyWithContext(ctx, &vpclattice.GetAuthPolicyInput{
+		ResourceIdentifier: aws.String(resId),
+	})
+	if services.IsNotFoundError(err) {
+		return fmt.Sprintf("Lattice resource %s has no auth policy", resId), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if !equalPolicyDocuments(desired.Policy, aws.StringValue(resp.Policy)) {
+		return fmt.Sprintf("auth policy of Lattice resource %s differs from the desired policy", resId), nil
+	}
+	return "", nil
+}
+
+// Lattice may format the returned document differently, documents are compared as JSON values
+func equalPolicyDocuments(a, b string) bool {
+	var aDoc, bDoc interface{}
+	if json.Unmarshal([]byte(a), &aDoc) != nil || json.Unmarshal([]byte(b), &bDoc) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(aDoc, bDoc)
+}
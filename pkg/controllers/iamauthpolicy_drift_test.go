@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	aws2 "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestIAMAuthPolicyDriftDetector(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+
+	doc := `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`
+	appliedPolicy := func(name, resId string, reason conditions.Reason) *anv1alpha1.IAMAuthPolicy {
+		return &anv1alpha1.IAMAuthPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "ns",
+				Annotations: map[string]string{IAMAuthPolicyAnnotationResId: resId},
+			},
+			Spec: anv1alpha1.IAMAuthPolicySpec{
+				Policy: doc,
+				TargetRef: &gwv1alpha2.PolicyTargetReference{
+					Group: gwv1beta1.GroupName,
+					Kind:  "HTTPRoute",
+					Name:  gwv1alpha2.ObjectName(name),
+				},
+			},
+			Status: anv1alpha1.IAMAuthPolicyStatus{
+				Conditions: []metav1.Condition{{
+					Type:   conditions.TypeAccepted,
+					Status: metav1.ConditionTrue,
+					Reason: string(reason),
+				}},
+			},
+		}
+	}
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&anv1alpha1.IAMAuthPolicy{}).
+		WithObjects(
+			appliedPolicy("in-sync", "svc-in-sync", conditions.ReasonAccepted),
+			appliedPolicy("modified", "svc-modified", conditions.ReasonAccepted),
+			appliedPolicy("removed", "svc-removed", conditions.ReasonAccepted),
+			appliedPolicy("lookup-error", "svc-error", conditions.ReasonAccepted),
+			appliedPolicy("conflicted", "svc-conflicted", conditions.ReasonConflicted),
+		).
+		Build()
+
+	mockLattice := mocks.NewMockLattice(c)
+	getAuthPolicy := func(resId string) *gomock.Call {
+		return mockLattice.EXPECT().GetAuthPolicyWithContext(ctx, &vpclattice.GetAuthPolicyInput{
+			ResourceIdentifier: aws.String(resId),
+		})
+	}
+	// Lattice formats the document differently, it is still in sync
+	getAuthPolicy("svc-in-sync").Return(&vpclattice.GetAuthPolicyOutput{
+		Policy: aws.String(`{ "Statement": [ { "Action": "*", "Effect": "Allow", "Principal": "*", "Resource": "*" } ] }`),
+	}, nil)
+	getAuthPolicy("svc-modified").Return(&vpclattice.GetAuthPolicyOutput{
+		Policy: aws.String(`{"Statement":[{"Effect":"Deny","Principal":"*","Action":"*","Resource":"*"}]}`),
+	}, nil)
+	getAuthPolicy("svc-removed").Return(nil, awserr.New(vpclattice.ErrCodeResourceNotFoundException, "not found", nil))
+	getAuthPolicy("svc-error").Return(nil, errors.New("throttled"))
+
+	cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{})
+	d := newIAMAuthPolicyDriftDetector(gwlog.FallbackLogger, k8sClient, cloud,
+		policy.NewIAMAuthPolicyHandler(gwlog.FallbackLogger, k8sClient), 0)
+	drifted, err := d.Detect(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, drifted)
+
+	driftCondition := func(name string) *metav1.Condition {
+		iap := &anv1alpha1.IAMAuthPolicy{}
+		assert.Nil(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "ns", Name: name}, iap))
+		return meta.FindStatusCondition(iap.Status.Conditions, conditions.TypeDriftDetected)
+	}
+	cnd := driftCondition("in-sync")
+	assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+	assert.Equal(t, string(conditions.ReasonInSync), cnd.Reason)
+
+	cnd = driftCondition("modified")
+	assert.Equal(t, metav1.ConditionTrue, cnd.Status)
+	assert.Equal(t, string(conditions.ReasonDrifted), cnd.Reason)
+	assert.Equal(t, "auth policy of Lattice resource svc-modified differs from the desired policy", cnd.Message)
+
+	cnd = driftCondition("removed")
+	assert.Equal(t, metav1.ConditionTrue, cnd.Status)
+	assert.Equal(t, "Lattice resource svc-removed has no auth policy", cnd.Message)
+
+	assert.Nil(t, driftCondition("lookup-error"))
+	assert.Nil(t, driftCondition("conflicted"))
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	staleResourceIdScanInterval = 30 * time.Minute
+	staleResourceIdBufferLength = 100
+)
+
+// Finds IAMAuthPolicies whose resource id annotation points at a Lattice resource which no longer exists,
+// e.g. after it was deleted out of band. Their annotations are cleared and they are enqueued for reconcile,
+// which applies them to the current Lattice resource of their targetRef, if any.
+// Runs only on the elected leader, as other replicas do not run controllers to consume the events.
+type staleResourceIdScanner struct {
+	log      gwlog.Logger
+	client   client.Client
+	cloud    pkg_aws.Cloud
+	interval time.Duration
+	events   chan event.GenericEvent
+}
+
+func newStaleResourceIdScanner(log gwlog.Logger, client client.Client, cloud pkg_aws.Cloud) *staleResourceIdScanner {
+	return &staleResourceIdScanner{
+		log:      log,
+		client:   client,
+		cloud:    cloud,
+		interval: staleResourceIdScanInterval,
+		events:   make(chan event.GenericEvent, staleResourceIdBufferLength),
+	}
+}
+
+// Source returns the source of reconcile events for policies with stale annotations.
+func (s *staleResourceIdScanner) Source() source.Source {
+	return &source.Channel{Source: s.events}
+}
+
+// Start scans the policies every interval until the context is cancelled, implements manager.Runnable.
+func (s *staleResourceIdScanner) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := s.Scan(ctx); err != nil {
+				s.log.Warnf(ctx, "Failed to scan IAMAuthPolicies for stale resource ids: %s", err)
+			}
+		}
+	}
+}
+
+// Scan clears the annotations of policies pointing at deleted Lattice resources and enqueues them.
+// Returns the number of corrected policies.
+func (s *staleResourceIdScanner) Scan(ctx context.Context) (int, error) {
+	policies := &anv1alpha1.IAMAuthPolicyList{}
+	if err := s.client.List(ctx, policies); err != nil {
+		return 0, err
+	}
+	corrected := 0
+	for i := range policies.Items {
+		k8sPolicy := &policies.Items[i]
+		if !k8sPolicy.DeletionTimestamp.IsZero() {
+			continue
+		}
+		resId := k8sPolicy.Annotations[IAMAuthPolicyAnnotationResId]
+		resType := k8sPolicy.Annotations[IAMAuthPolicyAnnotationType]
+		if resId == "" || resType == "" {
+			continue
+		}
+		exists, err := s.resourceExists(ctx, resType, resId)
+		if err != nil {
+			s.log.Debugf(ctx, "Unable to look up Lattice resource %s of policy %s, %s",
+				resId, k8s.NamespacedName(k8sPolicy), err)
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		s.log.Infof(ctx, "Lattice resource %s of policy %s no longer exists, reconciling policy",
+			resId, k8s.NamespacedName(k8sPolicy))
+		delete(k8sPolicy.Annotations, IAMAuthPolicyAnnotationResId)
+		delete(k8sPolicy.Annotations, IAMAuthPolicyAnnotationType)
+		if err := s.client.Update(ctx, k8sPolicy); err != nil {
+			return corrected, err
+		}
+		corrected++
+		select {
+		case s.events <- event.GenericEvent{Object: k8sPolicy}:
+		case <-ctx.Done():
+			return corrected, ctx.Err()
+		}
+	}
+	return corrected, nil
+}
+
+func (s *staleResourceIdScanner) resourceExists(ctx context.Context, resType, resId string) (bool, error) {
+	var err error
+	switch resType {
+	case model.ServiceNetworkType:
+		_, err = s.cloud.Lattice().GetServiceNetworkWithContext(ctx, &vpclattice.GetServiceNetworkInput{
+			ServiceNetworkIdentifier: aws.String(resId),
+		})
+	case model.ServiceType:
+		_, err = s.cloud.Lattice().GetServiceWithContext(ctx, &vpclattice.GetServiceInput{
+			ServiceIdentifier: aws.String(resId),
+		})
+	default:
+		return true, nil
+	}
+	if services.IsNotFoundError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	aws2 "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestStaleResourceIdScanner(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+
+	annotatedPolicy := func(name, resType, resId string) *anv1alpha1.IAMAuthPolicy {
+		iap := &anv1alpha1.IAMAuthPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec:       anv1alpha1.IAMAuthPolicySpec{Policy: "{}"},
+		}
+		if resId != "" {
+			iap.Annotations = map[string]string{
+				IAMAuthPolicyAnnotationResId: resId,
+				IAMAuthPolicyAnnotationType:  resType,
+			}
+		}
+		return iap
+	}
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithObjects(
+			annotatedPolicy("deleted-svc", model.ServiceType, "svc-deleted"),
+			annotatedPolicy("live-svc", model.ServiceType, "svc-live"),
+			annotatedPolicy("deleted-sn", model.ServiceNetworkType, "sn-deleted"),
+			annotatedPolicy("lookup-error", model.ServiceType, "svc-error"),
+			annotatedPolicy("not-applied", "", ""),
+		).
+		Build()
+
+	notFound := awserr.New(vpclattice.ErrCodeResourceNotFoundException, "not found", nil)
+	mockLattice := mocks.NewMockLattice(c)
+	mockLattice.EXPECT().GetServiceWithContext(gomock.Any(), &vpclattice.GetServiceInput{ServiceIdentifier: aws.String("svc-deleted")}).
+		Return(nil, notFound)
+	mockLattice.EXPECT().GetServiceWithContext(gomock.Any(), &vpclattice.GetServiceInput{ServiceIdentifier: aws.String("svc-live")}).
+		Return(&vpclattice.GetServiceOutput{Id: aws.String("svc-live")}, nil)
+	mockLattice.EXPECT().GetServiceWithContext(gomock.Any(), &vpclattice.GetServiceInput{ServiceIdentifier: aws.String("svc-error")}).
+		Return(nil, errors.New("throttled"))
+	mockLattice.EXPECT().GetServiceNetworkWithContext(gomock.Any(), &vpclattice.GetServiceNetworkInput{ServiceNetworkIdentifier: aws.String("sn-deleted")}).
+		Return(nil, notFound)
+
+	scanner := newStaleResourceIdScanner(gwlog.FallbackLogger, k8sClient, aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{}))
+	corrected, err := scanner.Scan(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, corrected)
+
+	var enqueued []string
+	for len(scanner.events) > 0 {
+		enqueued = append(enqueued, (<-scanner.events).Object.GetName())
+	}
+	assert.ElementsMatch(t, []string{"deleted-svc", "deleted-sn"}, enqueued)
+
+	for name, wantResId := range map[string]string{
+		"deleted-svc":  "",
+		"deleted-sn":   "",
+		"live-svc":     "svc-live",
+		"lookup-error": "svc-error",
+	} {
+		iap := &anv1alpha1.IAMAuthPolicy{}
+		assert.Nil(t, k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "ns"}, iap))
+		assert.Equal(t, wantResId, iap.Annotations[IAMAuthPolicyAnnotationResId], name)
+		if wantResId == "" {
+			assert.NotContains(t, iap.Annotations, IAMAuthPolicyAnnotationType, name)
+		}
+	}
+}
@@ -19,11 +19,13 @@ package controllers
 import (
 	"context"
 
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 )
 
 type podReconciler struct {
@@ -38,9 +40,11 @@ func RegisterPodController(log gwlog.Logger, mgr ctrl.Manager) error {
 		client: mgr.GetClient(),
 		scheme: mgr.GetScheme(),
 	}
+	tracker := metrics.NewQueueTracker("Pod")
 	err := ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Pod{}).
-		Complete(pr)
+		Named("pod").
+		Watches(&corev1.Pod{}, tracker.EventHandler(&handler.EnqueueRequestForObject{})).
+		Complete(tracker.Reconciler(pr))
 	return err
 }
 
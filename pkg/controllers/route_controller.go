@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -27,11 +29,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/external-dns/endpoint"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -47,9 +51,14 @@ import (
 	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
 	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
 	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/drift"
 	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/resync"
 	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	k8sutils "github.com/aws/aws-application-networking-k8s/pkg/utils"
@@ -66,6 +75,7 @@ type routeReconciler struct {
 	routeType        core.RouteType
 	log              gwlog.Logger
 	client           client.Client
+	apiReader        client.Reader // reads objects which are not cached, e.g. route DNS ConfigMaps
 	scheme           *runtime.Scheme
 	finalizerManager k8s.FinalizerManager
 	eventRecorder    record.EventRecorder
@@ -73,10 +83,23 @@ type routeReconciler struct {
 	stackDeployer    deploy.StackDeployer
 	stackMarshaller  deploy.StackMarshaller
 	cloud            aws.Cloud
+	cleanupTracker   *cleanupTracker
 }
 
 const (
 	LatticeAssignedDomainName = "application-networking.k8s.aws/lattice-assigned-domain-name"
+	LatticeServiceArn         = "application-networking.k8s.aws/lattice-service-arn"
+
+	// WeightModeAnnotation decides how the backendRef weights of a route are interpreted. With "relative", the
+	// default, they are proportions as in the Gateway API. With "percentage", the weights of every rule must sum to 100
+	WeightModeAnnotation = k8s.AnnotationPrefix + "weight-mode"
+	WeightModeRelative   = "relative"
+	WeightModePercentage = "percentage"
+
+	// requeue interval of routes outside of a maintenance window which has no next start within a year
+	maintenanceWindowRequeueInterval = 24 * time.Hour
+	// requeue interval of new routes whose service creation is deferred by the quota backpressure
+	quotaBackpressureRequeueInterval = time.Minute
 )
 
 func RegisterAllRouteControllers(
@@ -84,26 +107,42 @@ func RegisterAllRouteControllers(
 	cloud aws.Cloud,
 	finalizerManager k8s.FinalizerManager,
 	mgr ctrl.Manager,
+	driftPoller *drift.Poller,
+	resyncer *resync.Resyncer,
 ) error {
 	mgrClient := mgr.GetClient()
 	gwEventHandler := eventhandlers.NewEnqueueRequestGatewayEvent(log, mgrClient)
 	svcEventHandler := eventhandlers.NewServiceEventHandler(log, mgrClient)
+	nodeEventHandler := eventhandlers.NewNodeEventHandler(log, mgrClient)
 
 	routeInfos := []struct {
 		routeType      core.RouteType
 		gatewayApiType client.Object
+		gatewayApiList client.ObjectList
+		gvk            schema.GroupVersionKind
+		sourceType     model.K8SSourceType
 	}{
-		{core.HttpRouteType, &gwv1beta1.HTTPRoute{}},
-		{core.GrpcRouteType, &gwv1alpha2.GRPCRoute{}},
-		{core.TlsRouteType, &gwv1alpha2.TLSRoute{}},
+		{core.HttpRouteType, &gwv1beta1.HTTPRoute{}, &gwv1beta1.HTTPRouteList{}, k8s.HTTPRouteKind, model.SourceTypeHTTPRoute},
+		{core.GrpcRouteType, &gwv1alpha2.GRPCRoute{}, &gwv1alpha2.GRPCRouteList{}, k8s.GRPCRouteKind, model.SourceTypeGRPCRoute},
+		{core.TlsRouteType, &gwv1alpha2.TLSRoute{}, &gwv1alpha2.TLSRouteList{}, k8s.TLSRouteKind, model.SourceTypeTLSRoute},
 	}
 
 	for _, routeInfo := range routeInfos {
+		// GRPCRoute and TLSRoute are only installed with the experimental channel of the Gateway API CRDs
+		if ok, err := k8s.IsGVKSupported(mgr, routeInfo.gvk.GroupVersion().String(), routeInfo.gvk.Kind); !ok {
+			if err != nil {
+				return err
+			}
+			log.Infof(context.TODO(), "%s CRD is not installed, skipping controller, %s", routeInfo.gvk.Kind, k8s.GatewayAPIInstallHint)
+			continue
+		}
+
 		brTgBuilder := gateway.NewBackendRefTargetGroupBuilder(log, mgrClient)
 		reconciler := routeReconciler{
 			routeType:        routeInfo.routeType,
 			log:              log,
 			client:           mgrClient,
+			apiReader:        mgr.GetAPIReader(),
 			scheme:           mgr.GetScheme(),
 			finalizerManager: finalizerManager,
 			eventRecorder:    mgr.GetEventRecorderFor(string(routeInfo.routeType) + "route"),
@@ -111,22 +150,27 @@ func RegisterAllRouteControllers(
 			stackDeployer:    deploy.NewLatticeServiceStackDeploy(log, cloud, mgrClient),
 			stackMarshaller:  deploy.NewDefaultStackMarshaller(),
 			cloud:            cloud,
+			cleanupTracker:   newCleanupTracker(),
 		}
 
 		svcImportEventHandler := eventhandlers.NewServiceImportEventHandler(log, mgrClient)
 
+		tracker := metrics.NewQueueTracker(routeInfo.gvk.Kind)
+		gate := eventhandlers.NewPriorityGate(config.RouteMaxConcurrentReconciles)
 		builder := ctrl.NewControllerManagedBy(mgr).
-			For(routeInfo.gatewayApiType, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-			Watches(&gwv1beta1.Gateway{}, gwEventHandler).
-			Watches(&corev1.Service{}, svcEventHandler.MapToRoute(routeInfo.routeType)).
-			Watches(&anv1alpha1.ServiceImport{}, svcImportEventHandler.MapToRoute(routeInfo.routeType)).
-			Watches(&discoveryv1.EndpointSlice{}, svcEventHandler.MapToRoute(routeInfo.routeType)).
+			Named(string(routeInfo.routeType)+"route").
+			Watches(routeInfo.gatewayApiType, tracker.EventHandler(gate.EventHandler(eventhandlers.Debounce(&handler.EnqueueRequestForObject{}, config.ReconcileDebounce))), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			Watches(&gwv1beta1.Gateway{}, tracker.EventHandler(gwEventHandler)).
+			Watches(&corev1.Service{}, tracker.EventHandler(svcEventHandler.MapToRoute(routeInfo.routeType))).
+			Watches(&anv1alpha1.ServiceImport{}, tracker.EventHandler(svcImportEventHandler.MapToRoute(routeInfo.routeType))).
+			Watches(&discoveryv1.EndpointSlice{}, tracker.EventHandler(svcEventHandler.MapToRoute(routeInfo.routeType))).
+			Watches(&corev1.Node{}, tracker.EventHandler(nodeEventHandler.MapToRoute(routeInfo.routeType))).
 			WithOptions(controller.Options{
 				MaxConcurrentReconciles: config.RouteMaxConcurrentReconciles,
 			})
 
 		if ok, err := k8s.IsGVKSupported(mgr, anv1alpha1.GroupVersion.String(), anv1alpha1.TargetGroupPolicyKind); ok {
-			builder.Watches(&anv1alpha1.TargetGroupPolicy{}, svcEventHandler.MapToRoute(routeInfo.routeType))
+			builder.Watches(&anv1alpha1.TargetGroupPolicy{}, tracker.EventHandler(svcEventHandler.MapToRoute(routeInfo.routeType)))
 		} else {
 			if err != nil {
 				return err
@@ -135,7 +179,8 @@ func RegisterAllRouteControllers(
 		}
 
 		if ok, err := k8s.IsGVKSupported(mgr, "externaldns.k8s.io/v1alpha1", "DNSEndpoint"); ok {
-			builder.Owns(&endpoint.DNSEndpoint{})
+			builder.Watches(&endpoint.DNSEndpoint{}, tracker.EventHandler(handler.EnqueueRequestForOwner(
+				mgr.GetScheme(), mgr.GetRESTMapper(), routeInfo.gatewayApiType, handler.OnlyControllerOwner())))
 		} else {
 			if err != nil {
 				return err
@@ -143,7 +188,15 @@ func RegisterAllRouteControllers(
 			log.Infof(context.TODO(), "DNSEndpoint CRD is not installed, skipping watch")
 		}
 
-		err := builder.Complete(&reconciler)
+		if driftPoller != nil {
+			builder.WatchesRawSource(driftPoller.Source(routeInfo.sourceType), tracker.EventHandler(&handler.EnqueueRequestForObject{}))
+		}
+
+		if resyncer != nil {
+			builder.WatchesRawSource(resyncer.Source(routeInfo.gatewayApiList), tracker.EventHandler(gate.Resync(&handler.EnqueueRequestForObject{})))
+		}
+
+		err := builder.Complete(tracker.Reconciler(gate.Reconciler(&reconciler)))
 		if err != nil {
 			return err
 		}
@@ -152,6 +205,9 @@ func RegisterAllRouteControllers(
 	return nil
 }
 
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
 func (r *routeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	ctx = gwlog.StartReconcileTrace(ctx, r.log, "route", req.Name, req.Namespace)
 	defer func() {
@@ -175,6 +231,13 @@ func (r *routeReconciler) reconcile(ctx context.Context, req ctrl.Request) error
 		return client.IgnoreNotFound(err)
 	}
 
+	// the finalizer was removed by an earlier reconcile or another process, so there is nothing left to clean up
+	if !route.DeletionTimestamp().IsZero() && !k8s.HasFinalizer(route.K8sObject(), routeTypeToFinalizer[r.routeType]) {
+		r.log.Debugf(ctx, "Route %s-%s is being deleted and has no finalizer, skipping cleanup",
+			route.Name(), route.Namespace())
+		return nil
+	}
+
 	if !r.isRouteRelevant(ctx, route) {
 		return nil
 	}
@@ -192,12 +255,38 @@ func (r *routeReconciler) reconcileDelete(ctx context.Context, req ctrl.Request,
 		k8s.RouteEventReasonReconcile, "Deleting Reconcile")
 
 	if _, err := r.buildAndDeployModel(ctx, route); err != nil {
-		return fmt.Errorf("failed to cleanup route %s, %s: %w", route.Name(), route.Namespace(), err)
+		if err = r.cleanupTracker.handleCleanupError(route.K8sObject(), err); err != nil {
+			return fmt.Errorf("failed to cleanup route %s, %s: %w", route.Name(), route.Namespace(), err)
+		}
+		svcName := k8sutils.LatticeServiceName(route.Name(), route.Namespace())
+		// set by the last successful reconcile, empty if the service was never created
+		svcArn := route.K8sObject().GetAnnotations()[LatticeServiceArn]
+		r.log.Warnw(ctx, "VPC Lattice is unreachable, removing finalizer without cleanup. Delete leaked resources manually",
+			"name", req.Name,
+			"namespace", req.Namespace,
+			"latticeServiceName", svcName,
+			"latticeServiceArn", svcArn,
+			"targetGroupTags", map[string]string{
+				model.K8SRouteNameKey:      route.Name(),
+				model.K8SRouteNamespaceKey: route.Namespace(),
+			},
+		)
+		leaked := svcName
+		if svcArn != "" {
+			leaked = svcArn
+		}
+		r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeWarning, k8s.LeakedResourcesEvent,
+			fmt.Sprintf("VPC Lattice is unreachable, service %s and its target groups were not deleted", leaked))
+		return r.finalizerManager.RemoveFinalizers(ctx, route.K8sObject(), routeTypeToFinalizer[r.routeType])
 	}
+	r.cleanupTracker.reset(route.K8sObject())
 
 	if err := updateRouteListenerStatus(ctx, r.client, route); err != nil {
 		return err
 	}
+	if err := r.removeRouteDNS(ctx, route); err != nil {
+		return err
+	}
 
 	r.log.Infow(ctx, "reconciled", "name", req.Name)
 	return r.finalizerManager.RemoveFinalizers(ctx, route.K8sObject(), routeTypeToFinalizer[r.routeType])
@@ -313,10 +402,28 @@ func (r *routeReconciler) buildAndDeployModel(
 		}
 		return nil, err
 	}
+	r.updateSnapshot(ctx, route, stack, json)
 
 	return stack, err
 }
 
+// updateSnapshot saves the deployed stack of the route into config.SnapshotDir, or removes it once the route is
+// deleted. Failures are only logged, the snapshot is a backup which must not block reconciles.
+func (r *routeReconciler) updateSnapshot(ctx context.Context, route core.Route, stack core.Stack, stackJSON string) {
+	if config.SnapshotDir == "" || stackJSON == "" {
+		return
+	}
+	var err error
+	if route.DeletionTimestamp().IsZero() {
+		err = deploy.WriteSnapshot(config.SnapshotDir, stack.StackID(), stackJSON)
+	} else {
+		err = deploy.RemoveSnapshot(config.SnapshotDir, stack.StackID())
+	}
+	if err != nil {
+		r.log.Warnf(ctx, "Failed to update snapshot of route %s-%s due to %s", route.Name(), route.Namespace(), err)
+	}
+}
+
 func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request, route core.Route) error {
 	r.log.Infow(ctx, "reconcile, adding or updating", "name", req.Name)
 	r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeNormal,
@@ -327,6 +434,11 @@ func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request,
 	}
 
 	if err := r.validateRoute(ctx, route); err != nil {
+		if errors.Is(err, ErrUnsupportedValue) {
+			// retrying would not help until the route is fixed, its VPC Lattice resources are left as they are
+			r.log.Infof(ctx, "Route %s-%s is not deployed: %s", route.Name(), route.Namespace(), err)
+			return nil
+		}
 		// TODO: we suppose to stop reconciliation here, but that will create problem when
 		// we delete Service and we suppose to delete TargetGroup, this validation will
 		// throw error if Service is not found.  For now just update route status and log
@@ -334,44 +446,70 @@ func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request,
 		r.log.Infof(ctx, "route: %s: %s", route.Name(), err)
 	}
 
-	backendRefIPFamiliesErr := r.validateBackendRefsIpFamilies(ctx, route)
-
-	if backendRefIPFamiliesErr != nil {
+	window, err := k8s.MaintenanceWindow(route.K8sObject())
+	if err != nil {
+		// retrying would not help until the annotation is fixed
 		httpRouteOld := route.DeepCopy()
-
 		route.Status().UpdateParentRefs(route.Spec().ParentRefs()[0], config.LatticeGatewayControllerName)
-
-		route.Status().UpdateRouteCondition(metav1.Condition{
-			Type:               string(gwv1beta1.RouteConditionAccepted),
-			Status:             metav1.ConditionFalse,
-			ObservedGeneration: route.K8sObject().GetGeneration(),
-			Reason:             string(gwv1beta1.RouteReasonUnsupportedValue),
-			Message:            "Dual stack Service is not supported",
-		})
-
+		route.Status().UpdateRouteCondition(r.newCondition(route, conditions.TypeAccepted, conditions.ReasonUnsupportedValue, err.Error()))
 		if err := r.client.Status().Patch(ctx, route.K8sObject(), client.MergeFrom(httpRouteOld.K8sObject())); err != nil {
-			return errors.Wrapf(err, "failed to update httproute status")
+			return errors.Wrapf(err, "failed to update route status")
 		}
+		return nil
+	}
 
-		return backendRefIPFamiliesErr
+	if msg, err := r.deferServiceCreation(ctx, route); err != nil {
+		return err
+	} else if msg != "" {
+		r.log.Infof(ctx, "Route %s-%s is not deployed: %s", route.Name(), route.Namespace(), msg)
+		route.Status().UpdateParentRefs(route.Spec().ParentRefs()[0], config.LatticeGatewayControllerName)
+		route.Status().UpdateRouteCondition(r.newCondition(route, conditions.TypeAccepted, conditions.ReasonPending, msg))
+		if err := r.client.Status().Update(ctx, route.K8sObject()); err != nil {
+			return fmt.Errorf("failed to update route status for quota backpressure due to err %w", err)
+		}
+		return lattice_runtime.NewRequeueNeededAfter(msg, quotaBackpressureRequeueInterval)
 	}
 
 	if _, err := r.buildAndDeployModel(ctx, route); err != nil {
 		if services.IsConflictError(err) {
 			// Stop reconciliation of this route if the route cannot be owned / has conflict
 			route.Status().UpdateParentRefs(route.Spec().ParentRefs()[0], config.LatticeGatewayControllerName)
-			route.Status().UpdateRouteCondition(metav1.Condition{
-				Type:               string(gwv1beta1.RouteConditionAccepted),
-				Status:             metav1.ConditionFalse,
-				ObservedGeneration: route.K8sObject().GetGeneration(),
-				Reason:             "Conflicted",
-				Message:            err.Error(),
-			})
+			route.Status().UpdateRouteCondition(r.newCondition(route, conditions.TypeAccepted, conditions.ReasonConflicted, err.Error()))
 			if err = r.client.Status().Update(ctx, route.K8sObject()); err != nil {
 				return fmt.Errorf("failed to update route status for conflict due to err %w", err)
 			}
 			return nil
 		}
+		rle := &model.RuleLimitExceededError{}
+		if errors.As(err, &rle) {
+			// the route needs fewer rules or the quota an increase, retrying would not help
+			route.Status().UpdateParentRefs(route.Spec().ParentRefs()[0], config.LatticeGatewayControllerName)
+			route.Status().UpdateRouteCondition(r.newCondition(route, conditions.TypeAccepted, conditions.ReasonRuleLimitExceeded, rle.Error()))
+			if err = r.client.Status().Update(ctx, route.K8sObject()); err != nil {
+				return fmt.Errorf("failed to update route status for rule limit due to err %w", err)
+			}
+			return nil
+		}
+		pme := &gateway.ProtocolMismatchError{}
+		if errors.As(err, &pme) {
+			// the route or its TargetGroupPolicy needs to change, retrying would not help
+			route.Status().UpdateParentRefs(route.Spec().ParentRefs()[0], config.LatticeGatewayControllerName)
+			route.Status().UpdateRouteCondition(r.newCondition(route, conditions.TypeResolvedRefs, conditions.ReasonUnsupportedProtocol, pme.Error()))
+			if err = r.client.Status().Update(ctx, route.K8sObject()); err != nil {
+				return fmt.Errorf("failed to update route status for protocol mismatch due to err %w", err)
+			}
+			return nil
+		}
+		sitgnfe := &lattice.ServiceImportTargetGroupNotFoundError{}
+		if errors.As(err, &sitgnfe) {
+			// retried, as the service can be exported from another cluster at any time
+			route.Status().UpdateParentRefs(route.Spec().ParentRefs()[0], config.LatticeGatewayControllerName)
+			route.Status().UpdateRouteCondition(r.newCondition(route, conditions.TypeResolvedRefs, conditions.ReasonBackendNotFound, sitgnfe.Error()))
+			if statusErr := r.client.Status().Update(ctx, route.K8sObject()); statusErr != nil {
+				return fmt.Errorf("failed to update route status for missing service export due to err %w", statusErr)
+			}
+			return err
+		}
 		return err
 	}
 
@@ -384,21 +522,58 @@ func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request,
 		return err
 	}
 
-	if svc == nil || svc.DnsEntry == nil || svc.DnsEntry.DomainName == nil {
+	if svc == nil || svc.Arn == nil || svc.DnsEntry == nil || svc.DnsEntry.DomainName == nil {
 		r.log.Infof(ctx, "Either service, dns entry, or domain name is not available. Will Retry")
 		return errors.New(lattice.LATTICE_RETRY)
 	}
 
-	if err := r.updateRouteAnnotation(ctx, *svc.DnsEntry.DomainName, route); err != nil {
+	if err := r.updateRouteAnnotation(ctx, *svc.Arn, *svc.DnsEntry.DomainName, route); err != nil {
+		return err
+	}
+	if err := r.updateRouteDNS(ctx, route, *svc.DnsEntry.DomainName); err != nil {
 		return err
 	}
 
 	r.log.Infow(ctx, "reconciled", "name", req.Name)
+	if now := time.Now(); window != nil && !window.Contains(now) {
+		// disruptive changes were deferred, if any, they are applied in the next window
+		wait := maintenanceWindowRequeueInterval
+		if next := window.Next(now); !next.IsZero() {
+			wait = next.Sub(now)
+		}
+		if config.BackendHealthGatingInterval != 0 && hasWeightedBackendRefs(route) && config.BackendHealthGatingInterval < wait {
+			wait = config.BackendHealthGatingInterval
+		}
+		return lattice_runtime.NewRequeueNeededAfter(
+			fmt.Sprintf("outside of the maintenance window %s", window), wait)
+	}
+	if config.BackendHealthGatingInterval != 0 && hasWeightedBackendRefs(route) {
+		// target health changes trigger no reconcile, the weighted target groups follow them at the interval
+		return lattice_runtime.NewRequeueNeededAfter("re-evaluating backend health", config.BackendHealthGatingInterval)
+	}
 	return nil
 }
 
-func (r *routeReconciler) updateRouteAnnotation(ctx context.Context, dns string, route core.Route) error {
-	r.log.Debugf(ctx, "Updating route %s-%s with DNS %s", route.Name(), route.Namespace(), dns)
+// deferServiceCreation returns why the VPC Lattice service of a new route is not created, or empty string. Routes
+// whose service exists are always deployed, so updates go on while new services are held back.
+func (r *routeReconciler) deferServiceCreation(ctx context.Context, route core.Route) (string, error) {
+	quotaMsg := metrics.QuotaBackpressure(metrics.QuotaServicesPerServiceNetwork)
+	if quotaMsg == "" {
+		return "", nil
+	}
+	_, err := r.cloud.Lattice().FindService(ctx, k8sutils.LatticeServiceName(route.Name(), route.Namespace()))
+	if err == nil {
+		return "", nil
+	}
+	if !services.IsNotFoundError(err) {
+		return "", err
+	}
+	return "creation of the VPC Lattice service is deferred, the " + quotaMsg, nil
+}
+
+// Sets the Lattice service ARN and DNS name on the route, updated on every reconcile in case the service was recreated
+func (r *routeReconciler) updateRouteAnnotation(ctx context.Context, arn string, dns string, route core.Route) error {
+	r.log.Debugf(ctx, "Updating route %s-%s with service %s and DNS %s", route.Name(), route.Namespace(), arn, dns)
 	routeOld := route.DeepCopy()
 
 	if len(route.K8sObject().GetAnnotations()) == 0 {
@@ -406,43 +581,18 @@ func (r *routeReconciler) updateRouteAnnotation(ctx context.Context, dns string,
 	}
 
 	route.K8sObject().GetAnnotations()[LatticeAssignedDomainName] = dns
+	route.K8sObject().GetAnnotations()[LatticeServiceArn] = arn
 	if err := r.client.Patch(ctx, route.K8sObject(), client.MergeFrom(routeOld.K8sObject())); err != nil {
 		return fmt.Errorf("failed to update route status due to err %w", err)
 	}
 
-	r.log.Debugf(ctx, "Successfully updated route %s-%s with DNS %s", route.Name(), route.Namespace(), dns)
-	return nil
-}
-
-func (r *routeReconciler) validateBackendRefsIpFamilies(ctx context.Context, route core.Route) error {
-	rules := route.Spec().Rules()
-
-	for _, rule := range rules {
-		backendRefs := rule.BackendRefs()
-
-		for _, backendRef := range backendRefs {
-			// For now we skip checking service import
-			if *backendRef.Kind() == "ServiceImport" {
-				continue
-			}
-
-			svc, err := gateway.GetServiceForBackendRef(ctx, r.client, route, backendRef)
-			if err != nil {
-				// Ignore error since Service might not be created yet
-				continue
-			}
-
-			if len(svc.Spec.IPFamilies) > 1 {
-				return errors.New("Invalid IpFamilies, Lattice Target Group doesn't support dual stack ip addresses")
-			}
-		}
-	}
-
+	r.log.Debugf(ctx, "Successfully updated route %s-%s with service %s and DNS %s", route.Name(), route.Namespace(), arn, dns)
 	return nil
 }
 
 var (
 	ErrValidation          = errors.New("validation")
+	ErrUnsupportedValue    = errors.New("route has unsupported values")
 	ErrParentRefsNotFound  = errors.New("parentRefs are not found")
 	ErrRouteGKNotSupported = errors.New("route GroupKind is not supported")
 )
@@ -466,6 +616,25 @@ func (r *routeReconciler) validateRoute(ctx context.Context, route core.Route) e
 		return err
 	}
 
+	unsupportedMsg := ""
+	if invalidAnnotations := validateAnnotations(route.K8sObject(), routeAnnotations); invalidAnnotations != "" {
+		unsupportedMsg = invalidAnnotations
+	} else if unsupported := r.findUnsupportedFilter(route); unsupported != "" {
+		unsupportedMsg = fmt.Sprintf("filter type %s is not supported by VPC Lattice", unsupported)
+	} else if unsupported := r.findUnsupportedMethod(route); unsupported != "" {
+		unsupportedMsg = fmt.Sprintf("HTTP method %s is not supported by VPC Lattice", unsupported)
+	} else if invalidWeights := validateWeights(route); invalidWeights != "" {
+		unsupportedMsg = invalidWeights
+	} else if invalidProtocols := validateBackendProtocols(route); invalidProtocols != "" {
+		unsupportedMsg = invalidProtocols
+	}
+	if unsupportedMsg != "" {
+		for i := range parentRefsAccepted {
+			cnd := r.newCondition(route, conditions.TypeAccepted, conditions.ReasonUnsupportedValue, unsupportedMsg)
+			meta.SetStatusCondition(&parentRefsAccepted[i].Conditions, cnd)
+		}
+	}
+
 	// we need to update each parentRef with backendRef status
 	parentRefsAcceptedResolvedRefs := make([]gwv1.RouteParentStatus, len(parentRefsAccepted))
 	for i, rps := range parentRefsAccepted {
@@ -480,6 +649,9 @@ func (r *routeReconciler) validateRoute(ctx context.Context, route core.Route) e
 		return fmt.Errorf("validate route: %w", err)
 	}
 
+	if unsupportedMsg != "" {
+		return fmt.Errorf("%w: %s", ErrUnsupportedValue, unsupportedMsg)
+	}
 	if r.hasNotAcceptedCondition(route) {
 		return fmt.Errorf("%w: route has validation errors, see status", ErrValidation)
 	}
@@ -526,6 +698,7 @@ func (r *routeReconciler) findRouteParentGw(ctx context.Context, route core.Rout
 // If parent GW exists will check:
 // - NoMatchingParent: parentRef sectionName and port matches Listener name and port
 // - TODO: NoMatchingListenerHostname: listener hostname matches one of route hostnames
+// - NotAllowedByListeners: allowedRoutes of a matching listener permit the route namespace
 // - TODO: NotAllowedByListeners: listener allowedRoutes contains route GroupKind
 func (r *routeReconciler) validateRouteParentRefs(ctx context.Context, route core.Route) ([]gwv1beta1.RouteParentStatus, error) {
 	if len(route.Spec().ParentRefs()) == 0 {
@@ -543,6 +716,7 @@ func (r *routeReconciler) validateRouteParentRefs(ctx context.Context, route cor
 		}
 
 		noMatchingParent := true
+		allowedByListeners := false
 		for _, listener := range gw.Spec.Listeners {
 			if parentRef.Port != nil && *parentRef.Port != listener.Port {
 				continue
@@ -551,6 +725,11 @@ func (r *routeReconciler) validateRouteParentRefs(ctx context.Context, route cor
 				continue
 			}
 			noMatchingParent = false
+			allowed, err := k8s.IsNamespaceAllowedByListener(ctx, r.client, gw, listener, route.Namespace())
+			if err != nil {
+				return nil, err
+			}
+			allowedByListeners = allowedByListeners || allowed
 		}
 
 		parentStatus := gwv1beta1.RouteParentStatus{
@@ -562,9 +741,12 @@ func (r *routeReconciler) validateRouteParentRefs(ctx context.Context, route cor
 		var cnd metav1.Condition
 		switch {
 		case noMatchingParent:
-			cnd = r.newCondition(route, gwv1beta1.RouteConditionAccepted, gwv1.RouteReasonNoMatchingParent, "")
+			cnd = r.newCondition(route, conditions.TypeAccepted, conditions.ReasonNoMatchingParent, noMatchingParentMessage(gw, parentRef))
+		case !allowedByListeners:
+			msg := fmt.Sprintf("routes of namespace %s are not allowed by the listeners of gateway %s", route.Namespace(), gw.Name)
+			cnd = r.newCondition(route, conditions.TypeAccepted, conditions.ReasonNotAllowedByListeners, msg)
 		default:
-			cnd = r.newCondition(route, gwv1beta1.RouteConditionAccepted, gwv1beta1.RouteReasonAccepted, "")
+			cnd = r.newCondition(route, conditions.TypeAccepted, conditions.ReasonAccepted, "")
 		}
 		meta.SetStatusCondition(&parentStatus.Conditions, cnd)
 		parentStatuses = append(parentStatuses, parentStatus)
@@ -573,6 +755,136 @@ func (r *routeReconciler) validateRouteParentRefs(ctx context.Context, route cor
 	return parentStatuses, nil
 }
 
+// Routes are not attached to another listener of the gateway when none matches the parentRef
+func noMatchingParentMessage(gw *gwv1beta1.Gateway, parentRef gwv1beta1.ParentReference) string {
+	var match []string
+	if parentRef.SectionName != nil {
+		match = append(match, fmt.Sprintf("sectionName %s", *parentRef.SectionName))
+	}
+	if parentRef.Port != nil {
+		match = append(match, fmt.Sprintf("port %d", *parentRef.Port))
+	}
+	if len(match) == 0 {
+		return fmt.Sprintf("gateway %s has no listeners", gw.Name)
+	}
+	return fmt.Sprintf("gateway %s has no listener matching %s", gw.Name, strings.Join(match, " and "))
+}
+
+// returns why the backendRef weights of the route are invalid for its weight mode, or empty string
+func validateWeights(route core.Route) string {
+	mode, ok := route.K8sObject().GetAnnotations()[WeightModeAnnotation]
+	if !ok || mode == WeightModeRelative {
+		return ""
+	}
+	if mode != WeightModePercentage {
+		return fmt.Sprintf("invalid %s %s, must be %s or %s", WeightModeAnnotation, mode, WeightModeRelative, WeightModePercentage)
+	}
+	for i, rule := range route.Spec().Rules() {
+		if len(rule.BackendRefs()) == 0 {
+			continue
+		}
+		sum := int64(0)
+		for _, backendRef := range rule.BackendRefs() {
+			weight := int64(1) // default value according to spec
+			if backendRef.Weight() != nil {
+				weight = int64(*backendRef.Weight())
+			}
+			sum += weight
+		}
+		if sum != 100 {
+			return fmt.Sprintf("backendRef weights of rule %d sum to %d, they must sum to 100 with %s %s",
+				i+1, sum, WeightModeAnnotation, WeightModePercentage)
+		}
+	}
+	return ""
+}
+
+// returns true when a rule of the route forwards to more than one backendRef, whose weights depend on backend health
+// with config.BackendHealthGatingInterval
+func hasWeightedBackendRefs(route core.Route) bool {
+	for _, rule := range route.Spec().Rules() {
+		if len(rule.BackendRefs()) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// returns why the backend protocol overrides of the route cannot be applied, or empty string
+func validateBackendProtocols(route core.Route) string {
+	protocols, err := gateway.ParseBackendProtocols(route)
+	if err != nil {
+		return err.Error()
+	}
+	if len(protocols) == 0 {
+		return ""
+	}
+	// TLSRoutes are attached to TLS passthrough listeners, which only forward to TCP target groups
+	if _, ok := route.(*core.TLSRoute); ok {
+		return fmt.Sprintf("%s is not supported on TLSRoutes, TLS passthrough listeners forward to TCP target groups",
+			gateway.BackendProtocolsAnnotation)
+	}
+	services := make(map[types.NamespacedName]bool)
+	for _, rule := range route.Spec().Rules() {
+		for _, ref := range rule.BackendRefs() {
+			if ref.Kind() != nil && string(*ref.Kind()) != "Service" {
+				continue
+			}
+			namespace := route.Namespace()
+			if ref.Namespace() != nil {
+				namespace = string(*ref.Namespace())
+			}
+			services[types.NamespacedName{Namespace: namespace, Name: string(ref.Name())}] = true
+		}
+	}
+	for nsName := range protocols {
+		if !services[nsName] {
+			return fmt.Sprintf("%s names service %s which is not a Service backendRef of the route",
+				gateway.BackendProtocolsAnnotation, nsName)
+		}
+	}
+	return ""
+}
+
+// VPC Lattice rules have no equivalent of traffic shadowing, so routes using
+// the RequestMirror filter are not accepted instead of silently dropping mirrored traffic.
+var unsupportedFilterTypes = utils.NewSet(gwv1.HTTPRouteFilterRequestMirror)
+
+// returns the first filter type of the route which cannot be translated to Lattice, or empty string
+func (r *routeReconciler) findUnsupportedFilter(route core.Route) gwv1beta1.HTTPRouteFilterType {
+	for _, rule := range route.Spec().Rules() {
+		httpRule, ok := rule.(*core.HTTPRouteRule)
+		if !ok {
+			continue
+		}
+		for _, filter := range httpRule.Filters() {
+			if unsupportedFilterTypes.Contains(filter.Type) {
+				return filter.Type
+			}
+		}
+	}
+	return ""
+}
+
+// VPC Lattice rules can match all HTTP methods except CONNECT and TRACE
+var unsupportedHTTPMethods = utils.NewSet(gwv1.HTTPMethodConnect, gwv1.HTTPMethodTrace)
+
+// returns the first method match of the route which cannot be translated to Lattice, or empty string
+func (r *routeReconciler) findUnsupportedMethod(route core.Route) gwv1beta1.HTTPMethod {
+	for _, rule := range route.Spec().Rules() {
+		for _, match := range rule.Matches() {
+			httpMatch, ok := match.(*core.HTTPRouteMatch)
+			if !ok || httpMatch.Method() == nil {
+				continue
+			}
+			if unsupportedHTTPMethods.Contains(*httpMatch.Method()) {
+				return *httpMatch.Method()
+			}
+		}
+	}
+	return ""
+}
+
 // set of valid Kinds for Route Backend References
 var validBackendKinds = utils.NewSet("Service", "ServiceImport")
 
@@ -580,14 +892,16 @@ var validBackendKinds = utils.NewSet("Service", "ServiceImport")
 // condition if at least one backendRef not in a valid state
 func (r *routeReconciler) validateBackedRefs(ctx context.Context, route core.Route) (metav1.Condition, error) {
 	var empty metav1.Condition
-	for _, rule := range route.Spec().Rules() {
+	notFoundMsg := ""
+	for i, rule := range route.Spec().Rules() {
+		notFound := 0
 		for _, ref := range rule.BackendRefs() {
 			kind := "Service"
 			if ref.Kind() != nil {
 				kind = string(*ref.Kind())
 			}
 			if !validBackendKinds.Contains(kind) {
-				return r.newCondition(route, gwv1beta1.RouteConditionResolvedRefs, gwv1beta1.RouteReasonInvalidKind, kind), nil
+				return r.newCondition(route, conditions.TypeResolvedRefs, conditions.ReasonInvalidKind, kind), nil
 			}
 
 			namespace := route.Namespace()
@@ -611,25 +925,39 @@ func (r *routeReconciler) validateBackedRefs(ctx context.Context, route core.Rou
 			err := r.client.Get(ctx, objKey, obj)
 			if err != nil {
 				if apierrors.IsNotFound(err) {
-					msg := fmt.Sprintf("backendRef name: %s", ref.Name())
-					return r.newCondition(route, gwv1beta1.RouteConditionResolvedRefs, gwv1beta1.RouteReasonBackendNotFound, msg), nil
+					notFound++
+					if notFoundMsg == "" {
+						notFoundMsg = fmt.Sprintf("backendRef name: %s", ref.Name())
+					}
 				}
+				continue
 			}
+			// without a port of the service, the target group would register no target
+			if svc, ok := obj.(*corev1.Service); ok && ref.Port() != nil && !k8s.HasServicePort(svc, int32(*ref.Port())) {
+				notFound++
+				if notFoundMsg == "" {
+					notFoundMsg = fmt.Sprintf("backendRef name: %s, port: %d is not a port of the service", ref.Name(), *ref.Port())
+				}
+			}
+		}
+		if notFound > 0 && notFound == len(rule.BackendRefs()) {
+			return r.newCondition(route, conditions.TypeResolvedRefs, conditions.ReasonBackendNotFound, noValidBackendRefsMessage(i)), nil
 		}
 	}
-	return r.newCondition(route, gwv1beta1.RouteConditionResolvedRefs, gwv1beta1.RouteReasonResolvedRefs, ""), nil
+	if notFoundMsg != "" {
+		return r.newCondition(route, conditions.TypeResolvedRefs, conditions.ReasonBackendNotFound, notFoundMsg), nil
+	}
+	return r.newCondition(route, conditions.TypeResolvedRefs, conditions.ReasonResolvedRefs, ""), nil
 }
 
-func (r *routeReconciler) newCondition(route core.Route, t gwv1beta1.RouteConditionType, reason gwv1beta1.RouteConditionReason, msg string) metav1.Condition {
-	status := metav1.ConditionTrue
-	if reason != gwv1beta1.RouteReasonAccepted && reason != gwv1beta1.RouteReasonResolvedRefs {
-		status = metav1.ConditionFalse
-	}
-	return metav1.Condition{
-		Type:               string(t),
-		Status:             status,
-		ObservedGeneration: route.K8sObject().GetGeneration(),
-		Reason:             string(reason),
-		Message:            msg,
+// describes what the rule does without valid backendRefs, according to config.NoValidBackends
+func noValidBackendRefsMessage(ruleIndex int) string {
+	if config.NoValidBackends == config.NoValidBackendsPolicyKeepLastKnownGood {
+		return fmt.Sprintf("no backendRef of rule %d is found, the rule keeps forwarding to its last known good target groups", ruleIndex+1)
 	}
+	return fmt.Sprintf("no backendRef of rule %d is found, the rule responds with 404", ruleIndex+1)
+}
+
+func (r *routeReconciler) newCondition(route core.Route, conditionType string, reason conditions.Reason, msg string) metav1.Condition {
+	return conditions.New(conditionType, route.K8sObject().GetGeneration(), reason, msg)
 }
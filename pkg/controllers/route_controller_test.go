@@ -2,22 +2,30 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	aws2 "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -26,11 +34,24 @@ import (
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/external-dns/endpoint"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"testing"
 )
 
 func TestRouteReconciler_ReconcileCreates(t *testing.T) {
+	registered := reconcileCreates(t, []corev1.IPFamily{corev1.IPv4Protocol})
+	assert.ElementsMatch(t, []string{"192.0.2.22", "192.0.2.33"}, registered)
+}
+
+// target groups of dual-stack Services have the primary IP family of the Service, only its endpoints are registered
+func TestRouteReconciler_ReconcileCreatesDualStack(t *testing.T) {
+	registered := reconcileCreates(t, []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol})
+	assert.ElementsMatch(t, []string{"192.0.2.22", "192.0.2.33"}, registered)
+}
+
+// reconcileCreates reconciles a new route to a Service with the given IP families, and returns the registered targets
+func reconcileCreates(t *testing.T, ipFamilies []corev1.IPFamily) []string {
 	config.VpcID = "my-vpc"
 	config.ClusterName = "my-cluster"
 
@@ -87,9 +108,7 @@ func TestRouteReconciler_ReconcileCreates(t *testing.T) {
 			Namespace: "ns1",
 		},
 		Spec: corev1.ServiceSpec{
-			IPFamilies: []corev1.IPFamily{
-				"IPv4",
-			},
+			IPFamilies: ipFamilies,
 			Ports: []corev1.ServicePort{
 				{
 					Protocol:   "TCP",
@@ -107,6 +126,7 @@ func TestRouteReconciler_ReconcileCreates(t *testing.T) {
 			Namespace: "ns1",
 			Labels:    map[string]string{discoveryv1.LabelServiceName: "my-service"},
 		},
+		AddressType: discoveryv1.AddressTypeIPv4,
 		Ports: []discoveryv1.EndpointPort{
 			{Port: aws.Int32(8090)},
 		},
@@ -120,6 +140,27 @@ func TestRouteReconciler_ReconcileCreates(t *testing.T) {
 		},
 	}
 	k8sClient.Create(ctx, epSlice.DeepCopy())
+	if len(ipFamilies) > 1 {
+		k8sClient.Create(ctx, &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-service-v6",
+				Namespace: "ns1",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "my-service"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv6,
+			Ports: []discoveryv1.EndpointPort{
+				{Port: aws.Int32(8090)},
+			},
+			Endpoints: []discoveryv1.Endpoint{
+				{
+					Addresses: []string{"2001:db8::22"},
+					Conditions: discoveryv1.EndpointConditions{
+						Ready: aws.Bool(true),
+					},
+				},
+			},
+		})
+	}
 
 	kind := gwv1beta1.Kind("Service")
 	port := gwv1beta1.PortNumber(80)
@@ -185,7 +226,13 @@ func TestRouteReconciler_ReconcileCreates(t *testing.T) {
 				Port: aws.Int64(8090),
 			},
 		}, nil)
-	mockLattice.EXPECT().RegisterTargetsWithContext(gomock.Any(), gomock.Any()).Return(
+	var registered []string
+	mockLattice.EXPECT().RegisterTargetsWithContext(gomock.Any(), gomock.Any()).Do(
+		func(ctx context.Context, input *vpclattice.RegisterTargetsInput, opts ...request.Option) {
+			for _, target := range input.Targets {
+				registered = append(registered, aws.StringValue(target.Id))
+			}
+		}).Return(
 		&vpclattice.RegisterTargetsOutput{
 			Successful: []*vpclattice.Target{
 				{
@@ -206,7 +253,7 @@ func TestRouteReconciler_ReconcileCreates(t *testing.T) {
 				Name: aws.String("sn-name"),
 			},
 		}, nil)
-	mockLattice.EXPECT().FindService(gomock.Any(), gomock.Any()).Return(
+	mockLattice.EXPECT().FindServices(gomock.Any(), gomock.Any()).Return(
 		nil, mocks.NewNotFoundError("Service", "svc-name")) // will trigger create
 	mockLattice.EXPECT().CreateServiceWithContext(gomock.Any(), gomock.Any()).Return(
 		&vpclattice.CreateServiceOutput{
@@ -233,7 +280,7 @@ func TestRouteReconciler_ReconcileCreates(t *testing.T) {
 			},
 		}, nil) // will trigger DNS Update
 
-	mockTagging.EXPECT().FindResourcesByTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockTagging.EXPECT().FindResourcesByTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2) // no prewarmed target group either
 	mockLattice.EXPECT().ListTargetGroupsAsList(gomock.Any(), gomock.Any()).Return(
 		[]*vpclattice.TargetGroupSummary{}, nil).AnyTimes() // this will cause us to skip "unused delete" step
 	mockLattice.EXPECT().CreateTargetGroupWithContext(gomock.Any(), gomock.Any()).Return(
@@ -304,6 +351,144 @@ func TestRouteReconciler_ReconcileCreates(t *testing.T) {
 	assert.Nil(t, err)
 	assert.False(t, result.Requeue)
 
+	reconciledRoute := &gwv1beta1.HTTPRoute{}
+	assert.Nil(t, k8sClient.Get(ctx, routeName, reconciledRoute))
+	assert.Equal(t, "svc-arn", reconciledRoute.Annotations[LatticeServiceArn])
+	assert.Equal(t, "my-fqdn.lattice.on.aws", reconciledRoute.Annotations[LatticeAssignedDomainName])
+	return registered
+}
+
+func TestRouteReconciler_UpdateRouteAnnotation(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+
+	route := &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-route",
+			Namespace:   "ns1",
+			Annotations: map[string]string{"other": "value"},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, route))
+
+	rc := routeReconciler{
+		routeType: core.HttpRouteType,
+		log:       gwlog.FallbackLogger,
+		client:    k8sClient,
+		scheme:    k8sScheme,
+	}
+	routeName := k8s.NamespacedName(route)
+	assert.Nil(t, rc.updateRouteAnnotation(ctx, "svc-arn", "svc.lattice.on.aws", core.NewHTTPRoute(*route)))
+
+	// the service was recreated
+	assert.Nil(t, k8sClient.Get(ctx, routeName, route))
+	assert.Nil(t, rc.updateRouteAnnotation(ctx, "new-svc-arn", "new-svc.lattice.on.aws", core.NewHTTPRoute(*route)))
+
+	assert.Nil(t, k8sClient.Get(ctx, routeName, route))
+	assert.Equal(t, map[string]string{
+		"other":                   "value",
+		LatticeServiceArn:         "new-svc-arn",
+		LatticeAssignedDomainName: "new-svc.lattice.on.aws",
+	}, route.Annotations)
+}
+
+func TestRouteReconciler_DeletingRouteWithoutFinalizer(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+
+	assert.Nil(t, k8sClient.Create(ctx, &gwv1beta1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "amazon-vpc-lattice", Namespace: defaultNamespace},
+		Spec:       gwv1beta1.GatewayClassSpec{ControllerName: config.LatticeGatewayControllerName},
+	}))
+	assert.Nil(t, k8sClient.Create(ctx, &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "ns1"},
+		Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "amazon-vpc-lattice"},
+	}))
+
+	route := &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-route",
+			Namespace: "ns1",
+			// another controller still holds the route, ours was already removed
+			Finalizers: []string{"example.com/other"},
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, route))
+	assert.Nil(t, k8sClient.Delete(ctx, route))
+
+	// no Lattice, finalizer, or event calls are expected
+	mockLattice := mocks.NewMockLattice(c)
+	rc := routeReconciler{
+		routeType:        core.HttpRouteType,
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		scheme:           k8sScheme,
+		finalizerManager: k8s.NewMockFinalizerManager(c),
+		eventRecorder:    mock_client.NewMockEventRecorder(c),
+		cloud:            aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{}),
+	}
+
+	result, err := rc.Reconcile(ctx, reconcile.Request{NamespacedName: k8s.NamespacedName(route)})
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+}
+
+func TestRouteReconciler_LeakedServiceArnOnUnreachableCleanup(t *testing.T) {
+	defer func(enabled bool, attempts int) {
+		config.FinalizerRemovalOnLatticeUnreachable = enabled
+		config.FinalizerRemovalMaxAttempts = attempts
+	}(config.FinalizerRemovalOnLatticeUnreachable, config.FinalizerRemovalMaxAttempts)
+	config.FinalizerRemovalOnLatticeUnreachable = true
+	config.FinalizerRemovalMaxAttempts = 1
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	route := &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-route",
+			Namespace:   "ns1",
+			Annotations: map[string]string{LatticeServiceArn: "svc-arn"},
+		},
+	}
+	unreachable := awserr.New(request.ErrCodeRequestError, "send request failed", errors.New("dial tcp: i/o timeout"))
+
+	mockModelBuilder := gateway.NewMockLatticeServiceBuilder(c)
+	mockModelBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).Return(nil, unreachable)
+	mockEventRecorder := mock_client.NewMockEventRecorder(c)
+	mockEventRecorder.EXPECT().Event(gomock.Any(), corev1.EventTypeWarning, k8s.LeakedResourcesEvent,
+		"VPC Lattice is unreachable, service svc-arn and its target groups were not deleted")
+	mockEventRecorder.EXPECT().Event(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockFinalizer := k8s.NewMockFinalizerManager(c)
+	mockFinalizer.EXPECT().RemoveFinalizers(gomock.Any(), gomock.Any(), gomock.Any())
+
+	rc := routeReconciler{
+		routeType:        core.HttpRouteType,
+		log:              gwlog.FallbackLogger,
+		finalizerManager: mockFinalizer,
+		eventRecorder:    mockEventRecorder,
+		modelBuilder:     mockModelBuilder,
+		cleanupTracker:   newCleanupTracker(),
+	}
+
+	err := rc.reconcileDelete(ctx, reconcile.Request{NamespacedName: k8s.NamespacedName(route)}, core.NewHTTPRoute(*route))
+	assert.Nil(t, err)
 }
 
 func addOptionalCRDs(scheme *runtime.Scheme) {
@@ -324,3 +509,1351 @@ func addOptionalCRDs(scheme *runtime.Scheme) {
 	scheme.AddKnownTypes(awsGatewayControllerCRDGroupVersion, &anv1alpha1.VpcAssociationPolicy{}, &anv1alpha1.VpcAssociationPolicyList{})
 	metav1.AddToGroupVersion(scheme, awsGatewayControllerCRDGroupVersion)
 }
+
+func TestRouteReconciler_ValidateRouteUnsupportedValues(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-gateway",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:     "http",
+					Protocol: "HTTP",
+					Port:     80,
+				},
+			},
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "ns1",
+		},
+	}
+	mirrorFilter := gwv1beta1.HTTPRouteFilter{
+		Type: gwv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: &gwv1beta1.HTTPRequestMirrorFilter{
+			BackendRef: gwv1beta1.BackendObjectReference{Name: "my-service"},
+		},
+	}
+
+	methodGet := gwv1.HTTPMethodGet
+	methodConnect := gwv1.HTTPMethodConnect
+
+	tests := []struct {
+		name            string
+		ruleFilters     []gwv1beta1.HTTPRouteFilter
+		backendFilters  []gwv1beta1.HTTPRouteFilter
+		method          *gwv1beta1.HTTPMethod
+		expectedReason  gwv1beta1.RouteConditionReason
+		expectValidated bool
+	}{
+		{
+			name:            "no filters",
+			expectedReason:  gwv1beta1.RouteReasonAccepted,
+			expectValidated: true,
+		},
+		{
+			name:           "mirror filter on rule",
+			ruleFilters:    []gwv1beta1.HTTPRouteFilter{mirrorFilter},
+			expectedReason: gwv1beta1.RouteReasonUnsupportedValue,
+		},
+		{
+			name:           "mirror filter on backendRef",
+			backendFilters: []gwv1beta1.HTTPRouteFilter{mirrorFilter},
+			expectedReason: gwv1beta1.RouteReasonUnsupportedValue,
+		},
+		{
+			name:            "supported method match",
+			method:          &methodGet,
+			expectedReason:  gwv1beta1.RouteReasonAccepted,
+			expectValidated: true,
+		},
+		{
+			name:           "unsupported method match",
+			method:         &methodConnect,
+			expectedReason: gwv1beta1.RouteReasonUnsupportedValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+				Build()
+			assert.Nil(t, k8sClient.Create(ctx, gw.DeepCopy()))
+			assert.Nil(t, k8sClient.Create(ctx, svc.DeepCopy()))
+
+			route := &gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-route",
+					Namespace: "ns1",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							Matches: []gwv1beta1.HTTPRouteMatch{{Method: tt.method}},
+							Filters: tt.ruleFilters,
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: gwv1beta1.BackendRef{
+										BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "my-service"},
+									},
+									Filters: tt.backendFilters,
+								},
+							},
+						},
+					},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, route))
+
+			rc := routeReconciler{
+				routeType: core.HttpRouteType,
+				log:       gwlog.FallbackLogger,
+				client:    k8sClient,
+				scheme:    k8sScheme,
+			}
+			coreRoute := core.NewHTTPRoute(*route)
+			err := rc.validateRoute(ctx, coreRoute)
+			assert.Equal(t, tt.expectValidated, err == nil)
+
+			parents := coreRoute.Status().Parents()
+			assert.Len(t, parents, 1)
+			cnd := meta.FindStatusCondition(parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, string(tt.expectedReason), cnd.Reason)
+
+			// backendRefs are resolved regardless of unsupported values
+			cnd = meta.FindStatusCondition(parents[0].Conditions, string(gwv1beta1.RouteConditionResolvedRefs))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, metav1.ConditionTrue, cnd.Status)
+		})
+	}
+}
+
+func TestRouteReconciler_ValidateRouteWeightMode(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-gateway",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:     "http",
+					Protocol: "HTTP",
+					Port:     80,
+				},
+			},
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "ns1",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mode           string
+		weights        []*int32
+		expectedReason gwv1beta1.RouteConditionReason
+		expectedMsg    string
+	}{
+		{
+			name:           "relative weights by default",
+			weights:        []*int32{aws.Int32(30), aws.Int32(30)},
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name:           "relative weights",
+			mode:           WeightModeRelative,
+			weights:        []*int32{aws.Int32(1), aws.Int32(2)},
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name:           "percentages summing to 100",
+			mode:           WeightModePercentage,
+			weights:        []*int32{aws.Int32(70), aws.Int32(30)},
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name:           "percentages counting unset weights as 1",
+			mode:           WeightModePercentage,
+			weights:        []*int32{aws.Int32(99), nil},
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name:           "percentages not summing to 100",
+			mode:           WeightModePercentage,
+			weights:        []*int32{aws.Int32(70), aws.Int32(20)},
+			expectedReason: gwv1beta1.RouteReasonUnsupportedValue,
+			expectedMsg: "backendRef weights of rule 1 sum to 90, they must sum to 100 with " +
+				"application-networking.k8s.aws/weight-mode percentage",
+		},
+		{
+			name:           "percentages exceeding 100",
+			mode:           WeightModePercentage,
+			weights:        []*int32{aws.Int32(100), aws.Int32(10)},
+			expectedReason: gwv1beta1.RouteReasonUnsupportedValue,
+			expectedMsg: "backendRef weights of rule 1 sum to 110, they must sum to 100 with " +
+				"application-networking.k8s.aws/weight-mode percentage",
+		},
+		{
+			name:           "unknown weight mode",
+			mode:           "ratio",
+			weights:        []*int32{aws.Int32(70), aws.Int32(30)},
+			expectedReason: gwv1beta1.RouteReasonUnsupportedValue,
+			expectedMsg:    "invalid application-networking.k8s.aws/weight-mode ratio, must be relative or percentage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+				Build()
+			assert.Nil(t, k8sClient.Create(ctx, gw.DeepCopy()))
+			assert.Nil(t, k8sClient.Create(ctx, svc.DeepCopy()))
+
+			var backendRefs []gwv1beta1.HTTPBackendRef
+			for _, weight := range tt.weights {
+				backendRefs = append(backendRefs, gwv1beta1.HTTPBackendRef{
+					BackendRef: gwv1beta1.BackendRef{
+						BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "my-service"},
+						Weight:                 weight,
+					},
+				})
+			}
+			route := &gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-route",
+					Namespace: "ns1",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{{BackendRefs: backendRefs}},
+				},
+			}
+			if tt.mode != "" {
+				route.Annotations = map[string]string{WeightModeAnnotation: tt.mode}
+			}
+			assert.Nil(t, k8sClient.Create(ctx, route))
+
+			rc := routeReconciler{
+				routeType: core.HttpRouteType,
+				log:       gwlog.FallbackLogger,
+				client:    k8sClient,
+				scheme:    k8sScheme,
+			}
+			coreRoute := core.NewHTTPRoute(*route)
+			err := rc.validateRoute(ctx, coreRoute)
+			assert.Equal(t, tt.expectedReason == gwv1beta1.RouteReasonAccepted, err == nil)
+
+			parents := coreRoute.Status().Parents()
+			assert.Len(t, parents, 1)
+			cnd := meta.FindStatusCondition(parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, string(tt.expectedReason), cnd.Reason)
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, cnd.Message)
+			}
+		})
+	}
+}
+
+func TestRouteReconciler_ValidateRouteUnknownAnnotations(t *testing.T) {
+	ctx := context.TODO()
+	defer func() { config.UnknownAnnotations = config.UnknownAnnotationsLenient }()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-gateway",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:     "http",
+					Protocol: "HTTP",
+					Port:     80,
+				},
+			},
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "ns1",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		policy         config.UnknownAnnotationsPolicy
+		annotations    map[string]string
+		expectedReason gwv1beta1.RouteConditionReason
+		expectedMsg    string
+	}{
+		{
+			name:           "typo ignored when lenient",
+			policy:         config.UnknownAnnotationsLenient,
+			annotations:    map[string]string{k8s.AnnotationPrefix + "weigth-mode": WeightModePercentage},
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name:           "typo rejected when strict",
+			policy:         config.UnknownAnnotationsStrict,
+			annotations:    map[string]string{k8s.AnnotationPrefix + "weigth-mode": WeightModePercentage},
+			expectedReason: gwv1beta1.RouteReasonUnsupportedValue,
+			expectedMsg:    "unknown annotations application-networking.k8s.aws/weigth-mode, check them for typos",
+		},
+		{
+			name:   "unknown annotations listed when strict",
+			policy: config.UnknownAnnotationsStrict,
+			annotations: map[string]string{
+				k8s.AnnotationPrefix + "maintenance-windows": "0 2 * * 6 4h",
+				k8s.AnnotationPrefix + "reconcile-mode":      "additive",
+			},
+			expectedReason: gwv1beta1.RouteReasonUnsupportedValue,
+			expectedMsg: "unknown annotations application-networking.k8s.aws/maintenance-windows, " +
+				"application-networking.k8s.aws/reconcile-mode, check them for typos",
+		},
+		{
+			name:   "known and other annotations accepted when strict",
+			policy: config.UnknownAnnotationsStrict,
+			annotations: map[string]string{
+				WeightModeAnnotation:                               WeightModeRelative,
+				k8s.MaintenanceWindowAnnotation:                    "0 2 * * 6 4h",
+				LatticeServiceArn:                                  "arn:aws:vpc-lattice:us-west-2:123456789012:service/svc-123",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.UnknownAnnotations = tt.policy
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+				Build()
+			assert.Nil(t, k8sClient.Create(ctx, gw.DeepCopy()))
+			assert.Nil(t, k8sClient.Create(ctx, svc.DeepCopy()))
+
+			route := &gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-route",
+					Namespace:   "ns1",
+					Annotations: tt.annotations,
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{{BackendRefs: []gwv1beta1.HTTPBackendRef{{
+						BackendRef: gwv1beta1.BackendRef{
+							BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "my-service"},
+						},
+					}}}},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, route))
+
+			rc := routeReconciler{
+				routeType: core.HttpRouteType,
+				log:       gwlog.FallbackLogger,
+				client:    k8sClient,
+				scheme:    k8sScheme,
+			}
+			coreRoute := core.NewHTTPRoute(*route)
+			err := rc.validateRoute(ctx, coreRoute)
+			assert.Equal(t, tt.expectedReason == gwv1beta1.RouteReasonAccepted, err == nil)
+
+			parents := coreRoute.Status().Parents()
+			assert.Len(t, parents, 1)
+			cnd := meta.FindStatusCondition(parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, string(tt.expectedReason), cnd.Reason)
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, cnd.Message)
+			}
+		})
+	}
+}
+
+func TestRouteReconciler_ValidateRouteBackendProtocols(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-gateway",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:     "http",
+					Protocol: "HTTP",
+					Port:     80,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		protocols      string
+		expectedReason gwv1beta1.RouteConditionReason
+		expectedMsg    string
+	}{
+		{
+			name:           "mixed HTTP and HTTPS backends",
+			protocols:      "secure-service=HTTPS,ns1/plain-service=HTTP",
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name:           "unsupported protocol",
+			protocols:      "secure-service=TCP",
+			expectedReason: gwv1beta1.RouteReasonUnsupportedValue,
+			expectedMsg: "invalid application-networking.k8s.aws/backend-protocols protocol TCP of secure-service, " +
+				"must be HTTP or HTTPS",
+		},
+		{
+			name:           "service not referenced by the route",
+			protocols:      "other-service=HTTPS",
+			expectedReason: gwv1beta1.RouteReasonUnsupportedValue,
+			expectedMsg: "application-networking.k8s.aws/backend-protocols names service ns1/other-service " +
+				"which is not a Service backendRef of the route",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+				Build()
+			assert.Nil(t, k8sClient.Create(ctx, gw.DeepCopy()))
+
+			var backendRefs []gwv1beta1.HTTPBackendRef
+			for _, name := range []string{"secure-service", "plain-service"} {
+				assert.Nil(t, k8sClient.Create(ctx, &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: "ns1",
+					},
+				}))
+				backendRefs = append(backendRefs, gwv1beta1.HTTPBackendRef{
+					BackendRef: gwv1beta1.BackendRef{
+						BackendObjectReference: gwv1beta1.BackendObjectReference{Name: gwv1beta1.ObjectName(name)},
+					},
+				})
+			}
+			route := &gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-route",
+					Namespace:   "ns1",
+					Annotations: map[string]string{gateway.BackendProtocolsAnnotation: tt.protocols},
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{{BackendRefs: backendRefs}},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, route))
+
+			rc := routeReconciler{
+				routeType: core.HttpRouteType,
+				log:       gwlog.FallbackLogger,
+				client:    k8sClient,
+				scheme:    k8sScheme,
+			}
+			coreRoute := core.NewHTTPRoute(*route)
+			err := rc.validateRoute(ctx, coreRoute)
+			assert.Equal(t, tt.expectedReason == gwv1beta1.RouteReasonAccepted, err == nil)
+
+			parents := coreRoute.Status().Parents()
+			assert.Len(t, parents, 1)
+			cnd := meta.FindStatusCondition(parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, string(tt.expectedReason), cnd.Reason)
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, cnd.Message)
+			}
+		})
+	}
+}
+
+func TestRouteReconciler_ValidateRouteNoValidBackendRefs(t *testing.T) {
+	ctx := context.TODO()
+	defer func() { config.NoValidBackends = config.NoValidBackendsPolicyTearDown }()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-gateway",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:     "http",
+					Protocol: "HTTP",
+					Port:     80,
+				},
+			},
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "ns1",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		policy      config.NoValidBackendsPolicy
+		backends    []string
+		expectedMsg string
+	}{
+		{
+			name:        "some backendRefs are not found",
+			policy:      config.NoValidBackendsPolicyTearDown,
+			backends:    []string{"my-service", "deleted-service"},
+			expectedMsg: "backendRef name: deleted-service",
+		},
+		{
+			name:        "no backendRef is found with teardown",
+			policy:      config.NoValidBackendsPolicyTearDown,
+			backends:    []string{"deleted-service", "other-deleted-service"},
+			expectedMsg: "no backendRef of rule 1 is found, the rule responds with 404",
+		},
+		{
+			name:        "no backendRef is found with keep last known good",
+			policy:      config.NoValidBackendsPolicyKeepLastKnownGood,
+			backends:    []string{"deleted-service", "other-deleted-service"},
+			expectedMsg: "no backendRef of rule 1 is found, the rule keeps forwarding to its last known good target groups",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.NoValidBackends = tt.policy
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+				Build()
+			assert.Nil(t, k8sClient.Create(ctx, gw.DeepCopy()))
+			assert.Nil(t, k8sClient.Create(ctx, svc.DeepCopy()))
+
+			var backendRefs []gwv1beta1.HTTPBackendRef
+			for _, backend := range tt.backends {
+				backendRefs = append(backendRefs, gwv1beta1.HTTPBackendRef{
+					BackendRef: gwv1beta1.BackendRef{
+						BackendObjectReference: gwv1beta1.BackendObjectReference{Name: gwv1beta1.ObjectName(backend)},
+					},
+				})
+			}
+			route := &gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-route",
+					Namespace: "ns1",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{{BackendRefs: backendRefs}},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, route))
+
+			rc := routeReconciler{
+				routeType: core.HttpRouteType,
+				log:       gwlog.FallbackLogger,
+				client:    k8sClient,
+				scheme:    k8sScheme,
+			}
+			coreRoute := core.NewHTTPRoute(*route)
+			assert.Error(t, rc.validateRoute(ctx, coreRoute))
+
+			parents := coreRoute.Status().Parents()
+			assert.Len(t, parents, 1)
+			cnd := meta.FindStatusCondition(parents[0].Conditions, string(gwv1beta1.RouteConditionResolvedRefs))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+			assert.Equal(t, string(gwv1beta1.RouteReasonBackendNotFound), cnd.Reason)
+			assert.Equal(t, tt.expectedMsg, cnd.Message)
+		})
+	}
+}
+
+func TestRouteReconciler_ValidateRouteBackendRefPort(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-gateway",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:     "http",
+					Protocol: "HTTP",
+					Port:     80,
+				},
+			},
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "ns1",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		port           gwv1beta1.PortNumber
+		expectedReason gwv1beta1.RouteConditionReason
+		expectedMsg    string
+	}{
+		{
+			name:           "port of the service",
+			port:           8080,
+			expectedReason: gwv1beta1.RouteReasonResolvedRefs,
+		},
+		{
+			name:           "port the service does not expose",
+			port:           9090,
+			expectedReason: gwv1beta1.RouteReasonBackendNotFound,
+			expectedMsg:    "no backendRef of rule 1 is found, the rule responds with 404",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+				Build()
+			assert.Nil(t, k8sClient.Create(ctx, gw.DeepCopy()))
+			assert.Nil(t, k8sClient.Create(ctx, svc.DeepCopy()))
+
+			route := &gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-route",
+					Namespace: "ns1",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{{
+						BackendRefs: []gwv1beta1.HTTPBackendRef{{
+							BackendRef: gwv1beta1.BackendRef{
+								BackendObjectReference: gwv1beta1.BackendObjectReference{
+									Name: "my-service",
+									Port: &tt.port,
+								},
+							},
+						}},
+					}},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, route))
+
+			rc := routeReconciler{
+				routeType: core.HttpRouteType,
+				log:       gwlog.FallbackLogger,
+				client:    k8sClient,
+				scheme:    k8sScheme,
+			}
+			coreRoute := core.NewHTTPRoute(*route)
+			err := rc.validateRoute(ctx, coreRoute)
+			if tt.expectedReason == gwv1beta1.RouteReasonResolvedRefs {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+
+			parents := coreRoute.Status().Parents()
+			assert.Len(t, parents, 1)
+			cnd := meta.FindStatusCondition(parents[0].Conditions, string(gwv1beta1.RouteConditionResolvedRefs))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, string(tt.expectedReason), cnd.Reason)
+			assert.Equal(t, tt.expectedMsg, cnd.Message)
+		})
+	}
+}
+
+type fakeStackDeployer func(ctx context.Context, stack core.Stack) error
+
+func (f fakeStackDeployer) Deploy(ctx context.Context, stack core.Stack) error {
+	return f(ctx, stack)
+}
+
+func TestRouteReconciler_ServiceImportNotExported(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+		WithObjects(
+			&gwv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "amazon-vpc-lattice"},
+				Spec:       gwv1beta1.GatewayClassSpec{ControllerName: config.LatticeGatewayControllerName},
+			},
+			&gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "ns1"},
+				Spec: gwv1beta1.GatewaySpec{
+					GatewayClassName: "amazon-vpc-lattice",
+					Listeners:        []gwv1beta1.Listener{{Name: "http", Protocol: "HTTP", Port: 80}},
+				},
+			},
+			&anv1alpha1.ServiceImport{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "ns1"},
+			},
+		).
+		Build()
+
+	kind := gwv1beta1.Kind("ServiceImport")
+	route := &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-route", Namespace: "ns1"},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+			},
+			Rules: []gwv1beta1.HTTPRouteRule{
+				{
+					BackendRefs: []gwv1beta1.HTTPBackendRef{
+						{
+							BackendRef: gwv1beta1.BackendRef{
+								BackendObjectReference: gwv1beta1.BackendObjectReference{Kind: &kind, Name: "my-service"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, route))
+
+	mockEventRecorder := mock_client.NewMockEventRecorder(c)
+	mockEventRecorder.EXPECT().Event(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockFinalizer := k8s.NewMockFinalizerManager(c)
+	mockFinalizer.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockBuilder := gateway.NewMockLatticeServiceBuilder(c)
+	mockBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).
+		Return(core.NewDefaultStack(core.StackID{Name: "my-route", Namespace: "ns1"}), nil)
+
+	rc := routeReconciler{
+		routeType:        core.HttpRouteType,
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		scheme:           k8sScheme,
+		finalizerManager: mockFinalizer,
+		eventRecorder:    mockEventRecorder,
+		modelBuilder:     mockBuilder,
+		stackDeployer: fakeStackDeployer(func(ctx context.Context, stack core.Stack) error {
+			return fmt.Errorf("error during rule synthesis %w", &lattice.ServiceImportTargetGroupNotFoundError{
+				K8SServiceName:      "my-service",
+				K8SServiceNamespace: "ns1",
+			})
+		}),
+		stackMarshaller: deploy.NewDefaultStackMarshaller(),
+	}
+
+	routeName := k8s.NamespacedName(route)
+	err := rc.reconcileUpsert(ctx, reconcile.Request{NamespacedName: routeName}, core.NewHTTPRoute(*route))
+	// retried until the service is exported
+	assert.Error(t, err)
+
+	reconciledRoute := &gwv1beta1.HTTPRoute{}
+	assert.Nil(t, k8sClient.Get(ctx, routeName, reconciledRoute))
+	assert.Len(t, reconciledRoute.Status.Parents, 1)
+	cnd := meta.FindStatusCondition(reconciledRoute.Status.Parents[0].Conditions, string(gwv1beta1.RouteConditionResolvedRefs))
+	assert.NotNil(t, cnd)
+	assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+	assert.Equal(t, string(gwv1beta1.RouteReasonBackendNotFound), cnd.Reason)
+	assert.Equal(t, "no exported target group found for ServiceImport ns1/my-service, the service must be exported with a ServiceExport", cnd.Message)
+}
+
+func TestRouteReconciler_QuotaBackpressure(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	config.QuotaBackpressureThreshold = 90
+	defer func() { config.QuotaBackpressureThreshold = 0 }()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+		WithObjects(
+			&gwv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "amazon-vpc-lattice"},
+				Spec:       gwv1beta1.GatewayClassSpec{ControllerName: config.LatticeGatewayControllerName},
+			},
+			&gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "ns1"},
+				Spec: gwv1beta1.GatewaySpec{
+					GatewayClassName: "amazon-vpc-lattice",
+					Listeners:        []gwv1beta1.Listener{{Name: "http", Protocol: "HTTP", Port: 80}},
+				},
+			},
+		).
+		Build()
+	route := &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-route", Namespace: "ns1"},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, route))
+
+	// the last poll found a service network with 460 of its 500 services
+	mockLattice := mocks.NewMockLattice(c)
+	mockLattice.EXPECT().ListServiceNetworksAsList(ctx, gomock.Any()).Return([]*vpclattice.ServiceNetworkSummary{
+		{Id: aws.String("sn-1"), NumberOfAssociatedServices: aws.Int64(460)},
+	}, nil)
+	mockLattice.EXPECT().ListServicesAsList(ctx, gomock.Any()).Return(nil, nil)
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return(nil, nil)
+	cloud := aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{})
+	assert.Nil(t, metrics.NewQuotaUsagePoller(gwlog.FallbackLogger, cloud, 0).Poll(ctx))
+
+	mockEventRecorder := mock_client.NewMockEventRecorder(c)
+	mockEventRecorder.EXPECT().Event(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockFinalizer := k8s.NewMockFinalizerManager(c)
+	mockFinalizer.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockBuilder := gateway.NewMockLatticeServiceBuilder(c)
+	deployed := 0
+	rc := routeReconciler{
+		routeType:        core.HttpRouteType,
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		scheme:           k8sScheme,
+		finalizerManager: mockFinalizer,
+		eventRecorder:    mockEventRecorder,
+		modelBuilder:     mockBuilder,
+		stackDeployer: fakeStackDeployer(func(ctx context.Context, stack core.Stack) error {
+			deployed++
+			return errors.New("stop after deploy")
+		}),
+		stackMarshaller: deploy.NewDefaultStackMarshaller(),
+		cloud:           cloud,
+	}
+	routeName := k8s.NamespacedName(route)
+
+	t.Run("creation of new services is deferred", func(t *testing.T) {
+		mockLattice.EXPECT().FindService(ctx, gomock.Any()).Return(nil, mocks.NewNotFoundError("Service", "my-route-ns1"))
+
+		err := rc.reconcileUpsert(ctx, reconcile.Request{NamespacedName: routeName}, core.NewHTTPRoute(*route))
+		var requeue *lattice_runtime.RequeueNeededAfter
+		assert.ErrorAs(t, err, &requeue)
+		assert.Equal(t, quotaBackpressureRequeueInterval, requeue.Duration())
+		assert.Equal(t, 0, deployed)
+
+		reconciledRoute := &gwv1beta1.HTTPRoute{}
+		assert.Nil(t, k8sClient.Get(ctx, routeName, reconciledRoute))
+		cnd := meta.FindStatusCondition(reconciledRoute.Status.Parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+		assert.NotNil(t, cnd)
+		assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+		assert.Equal(t, string(gwv1beta1.RouteReasonPending), cnd.Reason)
+		assert.Equal(t, "creation of the VPC Lattice service is deferred, the services_per_service_network quota usage is 460 of 500, at least 90% of its limit", cnd.Message)
+	})
+
+	t.Run("existing services are updated", func(t *testing.T) {
+		mockLattice.EXPECT().FindService(ctx, gomock.Any()).Return(&vpclattice.ServiceSummary{Id: aws.String("svc-id")}, nil)
+		mockBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).
+			Return(core.NewDefaultStack(core.StackID{Name: "my-route", Namespace: "ns1"}), nil)
+
+		assert.Nil(t, k8sClient.Get(ctx, routeName, route))
+		err := rc.reconcileUpsert(ctx, reconcile.Request{NamespacedName: routeName}, core.NewHTTPRoute(*route))
+		assert.EqualError(t, err, "stop after deploy")
+		assert.Equal(t, 1, deployed)
+	})
+}
+
+func TestRouteReconciler_ValidateRouteAllowedRoutes(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	fromSame := gwv1.NamespacesFromSame
+	fromAll := gwv1.NamespacesFromAll
+	fromSelector := gwv1.NamespacesFromSelector
+	teamSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}
+
+	tests := []struct {
+		name           string
+		allowedRoutes  *gwv1beta1.AllowedRoutes
+		routeNamespace string
+		expectedReason gwv1beta1.RouteConditionReason
+	}{
+		{
+			name:           "default allows the gateway namespace",
+			routeNamespace: "gw-ns",
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name:           "default rejects other namespaces",
+			routeNamespace: "payments",
+			expectedReason: gwv1beta1.RouteReasonNotAllowedByListeners,
+		},
+		{
+			name:           "Same allows the gateway namespace",
+			allowedRoutes:  &gwv1beta1.AllowedRoutes{Namespaces: &gwv1beta1.RouteNamespaces{From: &fromSame}},
+			routeNamespace: "gw-ns",
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name:           "Same rejects other namespaces",
+			allowedRoutes:  &gwv1beta1.AllowedRoutes{Namespaces: &gwv1beta1.RouteNamespaces{From: &fromSame}},
+			routeNamespace: "payments",
+			expectedReason: gwv1beta1.RouteReasonNotAllowedByListeners,
+		},
+		{
+			name:           "All allows other namespaces",
+			allowedRoutes:  &gwv1beta1.AllowedRoutes{Namespaces: &gwv1beta1.RouteNamespaces{From: &fromAll}},
+			routeNamespace: "payments",
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name: "Selector allows matching namespaces",
+			allowedRoutes: &gwv1beta1.AllowedRoutes{Namespaces: &gwv1beta1.RouteNamespaces{
+				From: &fromSelector, Selector: teamSelector}},
+			routeNamespace: "payments",
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name: "Selector rejects other namespaces",
+			allowedRoutes: &gwv1beta1.AllowedRoutes{Namespaces: &gwv1beta1.RouteNamespaces{
+				From: &fromSelector, Selector: teamSelector}},
+			routeNamespace: "orders",
+			expectedReason: gwv1beta1.RouteReasonNotAllowedByListeners,
+		},
+		{
+			name: "Selector rejects the gateway namespace when not matching",
+			allowedRoutes: &gwv1beta1.AllowedRoutes{Namespaces: &gwv1beta1.RouteNamespaces{
+				From: &fromSelector, Selector: teamSelector}},
+			routeNamespace: "gw-ns",
+			expectedReason: gwv1beta1.RouteReasonNotAllowedByListeners,
+		},
+		{
+			name:           "Selector without selector rejects all namespaces",
+			allowedRoutes:  &gwv1beta1.AllowedRoutes{Namespaces: &gwv1beta1.RouteNamespaces{From: &fromSelector}},
+			routeNamespace: "payments",
+			expectedReason: gwv1beta1.RouteReasonNotAllowedByListeners,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+				Build()
+			for ns, nsLabels := range map[string]map[string]string{
+				"gw-ns":    nil,
+				"payments": {"team": "payments"},
+				"orders":   {"team": "orders"},
+			} {
+				assert.Nil(t, k8sClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: ns, Labels: nsLabels},
+				}))
+			}
+			assert.Nil(t, k8sClient.Create(ctx, &gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-gateway",
+					Namespace: "gw-ns",
+				},
+				Spec: gwv1beta1.GatewaySpec{
+					GatewayClassName: "amazon-vpc-lattice",
+					Listeners: []gwv1beta1.Listener{
+						{
+							Name:          "http",
+							Protocol:      "HTTP",
+							Port:          80,
+							AllowedRoutes: tt.allowedRoutes,
+						},
+					},
+				},
+			}))
+			assert.Nil(t, k8sClient.Create(ctx, &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-service",
+					Namespace: tt.routeNamespace,
+				},
+			}))
+
+			gwNamespace := gwv1beta1.Namespace("gw-ns")
+			route := &gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-route",
+					Namespace: tt.routeNamespace,
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway", Namespace: &gwNamespace}},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: gwv1beta1.BackendRef{
+										BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "my-service"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, route))
+
+			rc := routeReconciler{
+				routeType: core.HttpRouteType,
+				log:       gwlog.FallbackLogger,
+				client:    k8sClient,
+				scheme:    k8sScheme,
+			}
+			coreRoute := core.NewHTTPRoute(*route)
+			err := rc.validateRoute(ctx, coreRoute)
+			assert.Equal(t, tt.expectedReason == gwv1beta1.RouteReasonAccepted, err == nil)
+
+			parents := coreRoute.Status().Parents()
+			assert.Len(t, parents, 1)
+			cnd := meta.FindStatusCondition(parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, string(tt.expectedReason), cnd.Reason)
+		})
+	}
+}
+
+func TestRouteReconciler_ValidateRouteSectionName(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	tests := []struct {
+		name            string
+		sectionName     gwv1beta1.SectionName
+		port            gwv1beta1.PortNumber
+		expectedReason  gwv1beta1.RouteConditionReason
+		expectedMessage string
+	}{
+		{
+			name:           "sectionName of a listener",
+			sectionName:    "https",
+			expectedReason: gwv1beta1.RouteReasonAccepted,
+		},
+		{
+			name:            "sectionName of no listener",
+			sectionName:     "grpc",
+			expectedReason:  gwv1.RouteReasonNoMatchingParent,
+			expectedMessage: "gateway my-gateway has no listener matching sectionName grpc",
+		},
+		{
+			name:            "sectionName of a listener with another port",
+			sectionName:     "https",
+			port:            80,
+			expectedReason:  gwv1.RouteReasonNoMatchingParent,
+			expectedMessage: "gateway my-gateway has no listener matching sectionName https and port 80",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+				Build()
+			assert.Nil(t, k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}))
+			assert.Nil(t, k8sClient.Create(ctx, &gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-gateway",
+					Namespace: "ns",
+				},
+				Spec: gwv1beta1.GatewaySpec{
+					GatewayClassName: "amazon-vpc-lattice",
+					Listeners: []gwv1beta1.Listener{
+						{Name: "http", Protocol: "HTTP", Port: 80},
+						{Name: "https", Protocol: "HTTPS", Port: 443},
+					},
+				},
+			}))
+			assert.Nil(t, k8sClient.Create(ctx, &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-service",
+					Namespace: "ns",
+				},
+			}))
+
+			parentRef := gwv1beta1.ParentReference{Name: "my-gateway", SectionName: &tt.sectionName}
+			if tt.port != 0 {
+				parentRef.Port = &tt.port
+			}
+			route := &gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-route",
+					Namespace: "ns",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{parentRef},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: gwv1beta1.BackendRef{
+										BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "my-service"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, route))
+
+			rc := routeReconciler{
+				routeType: core.HttpRouteType,
+				log:       gwlog.FallbackLogger,
+				client:    k8sClient,
+				scheme:    k8sScheme,
+			}
+			coreRoute := core.NewHTTPRoute(*route)
+			err := rc.validateRoute(ctx, coreRoute)
+			if tt.expectedReason == gwv1beta1.RouteReasonAccepted {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrValidation)
+			}
+
+			parents := coreRoute.Status().Parents()
+			assert.Len(t, parents, 1)
+			cnd := meta.FindStatusCondition(parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, string(tt.expectedReason), cnd.Reason)
+			assert.Equal(t, tt.expectedMessage, cnd.Message)
+		})
+	}
+}
+
+func TestRouteReconciler_UnsupportedValuesAreNotDeployed(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	backendRef := func(weight int32) gwv1beta1.HTTPBackendRef {
+		return gwv1beta1.HTTPBackendRef{
+			BackendRef: gwv1beta1.BackendRef{
+				BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "my-service"},
+				Weight:                 &weight,
+			},
+		}
+	}
+
+	methodTrace := gwv1.HTTPMethodTrace
+
+	tests := []struct {
+		name               string
+		unknownAnnotations config.UnknownAnnotationsPolicy
+		annotations        map[string]string
+		rule               gwv1beta1.HTTPRouteRule
+	}{
+		{
+			name:        "invalid weights",
+			annotations: map[string]string{WeightModeAnnotation: WeightModePercentage},
+			rule:        gwv1beta1.HTTPRouteRule{BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef(70), backendRef(20)}},
+		},
+		{
+			name: "mirror filter",
+			rule: gwv1beta1.HTTPRouteRule{
+				Filters: []gwv1beta1.HTTPRouteFilter{{
+					Type: gwv1.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gwv1beta1.HTTPRequestMirrorFilter{
+						BackendRef: gwv1beta1.BackendObjectReference{Name: "my-service"},
+					},
+				}},
+				BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef(1)},
+			},
+		},
+		{
+			name: "unsupported method match",
+			rule: gwv1beta1.HTTPRouteRule{
+				Matches:     []gwv1beta1.HTTPRouteMatch{{Method: &methodTrace}},
+				BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef(1)},
+			},
+		},
+		{
+			name:               "unknown annotations",
+			unknownAnnotations: config.UnknownAnnotationsStrict,
+			annotations:        map[string]string{k8s.AnnotationPrefix + "weigth-mode": WeightModePercentage},
+			rule:               gwv1beta1.HTTPRouteRule{BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef(1)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			if tt.unknownAnnotations != "" {
+				config.UnknownAnnotations = tt.unknownAnnotations
+				defer func() { config.UnknownAnnotations = config.UnknownAnnotationsLenient }()
+			}
+
+			k8sClient := testclient.
+				NewClientBuilder().
+				WithScheme(k8sScheme).
+				WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+				WithObjects(
+					&gwv1beta1.Gateway{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "ns1"},
+						Spec: gwv1beta1.GatewaySpec{
+							GatewayClassName: "amazon-vpc-lattice",
+							Listeners:        []gwv1beta1.Listener{{Name: "http", Protocol: "HTTP", Port: 80}},
+						},
+					},
+					&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "ns1"}},
+				).
+				Build()
+			route := &gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-route", Namespace: "ns1", Annotations: tt.annotations},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{tt.rule},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, route))
+
+			// no call to VPC Lattice is expected
+			mockLattice := mocks.NewMockLattice(c)
+			mockEventRecorder := mock_client.NewMockEventRecorder(c)
+			mockEventRecorder.EXPECT().Event(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockFinalizer := k8s.NewMockFinalizerManager(c)
+			mockFinalizer.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			rc := routeReconciler{
+				routeType:        core.HttpRouteType,
+				log:              gwlog.FallbackLogger,
+				client:           k8sClient,
+				scheme:           k8sScheme,
+				finalizerManager: mockFinalizer,
+				eventRecorder:    mockEventRecorder,
+				modelBuilder:     gateway.NewMockLatticeServiceBuilder(c),
+				stackDeployer: fakeStackDeployer(func(ctx context.Context, stack core.Stack) error {
+					t.Fatal("route with unsupported values is deployed")
+					return nil
+				}),
+				stackMarshaller: deploy.NewDefaultStackMarshaller(),
+				cloud:           aws2.NewDefaultCloud(mockLattice, aws2.CloudConfig{}),
+			}
+
+			routeName := k8s.NamespacedName(route)
+			err := rc.reconcileUpsert(ctx, reconcile.Request{NamespacedName: routeName}, core.NewHTTPRoute(*route))
+			assert.Nil(t, err)
+
+			reconciledRoute := &gwv1beta1.HTTPRoute{}
+			assert.Nil(t, k8sClient.Get(ctx, routeName, reconciledRoute))
+			cnd := meta.FindStatusCondition(reconciledRoute.Status.Parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, string(gwv1beta1.RouteReasonUnsupportedValue), cnd.Reason)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+)
+
+// RouteDNSConfigMapLabel is set on the route DNS ConfigMaps maintained by the controller. The ConfigMaps are read
+// from the API server rather than the cache, which only holds the metadata of ConfigMaps
+const RouteDNSConfigMapLabel = k8s.AnnotationPrefix + "route-dns"
+
+// routeDNSKey is the key of a route in the route DNS ConfigMap of its namespace, e.g. httproute.inventory
+func routeDNSKey(route core.Route) string {
+	return strings.ToLower(route.GroupKind().Kind) + "." + route.Name()
+}
+
+// updateRouteDNS sets the VPC Lattice DNS name of the route in the route DNS ConfigMap of its namespace,
+// config.RouteDNSConfigMap, creating it if needed. Routes are owners of the ConfigMap, which is garbage collected
+// along with the last of them.
+func (r *routeReconciler) updateRouteDNS(ctx context.Context, route core.Route, dns string) error {
+	if config.RouteDNSConfigMap == "" {
+		return nil
+	}
+	key := types.NamespacedName{Namespace: route.Namespace(), Name: config.RouteDNSConfigMap}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		if err := r.apiReader.Get(ctx, key, cm); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: key.Namespace,
+					Name:      key.Name,
+					Labels:    map[string]string{RouteDNSConfigMapLabel: "true"},
+				},
+				Data: map[string]string{routeDNSKey(route): dns},
+			}
+			if err := controllerutil.SetOwnerReference(route.K8sObject(), cm, r.scheme); err != nil {
+				return err
+			}
+			return r.client.Create(ctx, cm)
+		}
+		if cm.Labels[RouteDNSConfigMapLabel] != "true" {
+			return fmt.Errorf("ConfigMap %s exists without the %s label, it is not managed by the controller",
+				key, RouteDNSConfigMapLabel)
+		}
+
+		cmOld := cm.DeepCopy()
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[routeDNSKey(route)] = dns
+		if err := controllerutil.SetOwnerReference(route.K8sObject(), cm, r.scheme); err != nil {
+			return err
+		}
+		if reflect.DeepEqual(cm, cmOld) {
+			return nil
+		}
+		// owner references are replaced as a whole, the lock keeps concurrent reconciles from dropping each other's
+		return r.client.Patch(ctx, cm, client.MergeFromWithOptions(cmOld, client.MergeFromWithOptimisticLock{}))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update route DNS ConfigMap %s due to err %w", key, err)
+	}
+	return nil
+}
+
+// removeRouteDNS removes the route from the route DNS ConfigMap of its namespace, which is deleted once empty
+func (r *routeReconciler) removeRouteDNS(ctx context.Context, route core.Route) error {
+	if config.RouteDNSConfigMap == "" {
+		return nil
+	}
+	key := types.NamespacedName{Namespace: route.Namespace(), Name: config.RouteDNSConfigMap}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		if err := r.apiReader.Get(ctx, key, cm); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if cm.Labels[RouteDNSConfigMapLabel] != "true" {
+			return nil
+		}
+
+		_, found := cm.Data[routeDNSKey(route)]
+		var owners []metav1.OwnerReference
+		for _, owner := range cm.OwnerReferences {
+			if owner.UID != route.K8sObject().GetUID() {
+				owners = append(owners, owner)
+			}
+		}
+		if !found && len(owners) == len(cm.OwnerReferences) {
+			return nil
+		}
+
+		if found && len(cm.Data) == 1 {
+			return client.IgnoreNotFound(r.client.Delete(ctx, cm, client.Preconditions{
+				ResourceVersion: &cm.ResourceVersion,
+			}))
+		}
+		cmOld := cm.DeepCopy()
+		delete(cm.Data, routeDNSKey(route))
+		cm.OwnerReferences = owners
+		return r.client.Patch(ctx, cm, client.MergeFromWithOptions(cmOld, client.MergeFromWithOptimisticLock{}))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update route DNS ConfigMap %s due to err %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestRouteReconciler_RouteDNSConfigMap(t *testing.T) {
+	config.RouteDNSConfigMap = "lattice-routes"
+	defer func() { config.RouteDNSConfigMap = "" }()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	gwv1alpha2.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+
+	rc := routeReconciler{
+		log:       gwlog.FallbackLogger,
+		client:    k8sClient,
+		apiReader: k8sClient,
+		scheme:    k8sScheme,
+	}
+
+	inventory := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "ns1", UID: "inventory-uid"},
+	})
+	greeter := core.NewGRPCRoute(gwv1alpha2.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "greeter", Namespace: "ns1", UID: "greeter-uid"},
+	})
+	other := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "ns2", UID: "other-uid"},
+	})
+
+	assert.NoError(t, rc.updateRouteDNS(ctx, inventory, "inventory.lattice.on.aws"))
+	assert.NoError(t, rc.updateRouteDNS(ctx, greeter, "greeter.lattice.on.aws"))
+	assert.NoError(t, rc.updateRouteDNS(ctx, other, "other.lattice.on.aws"))
+	// the service of the route was recreated
+	assert.NoError(t, rc.updateRouteDNS(ctx, inventory, "new-inventory.lattice.on.aws"))
+
+	getConfigMap := func(namespace string) (*corev1.ConfigMap, error) {
+		cm := &corev1.ConfigMap{}
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "lattice-routes"}, cm)
+		return cm, err
+	}
+	owners := func(cm *corev1.ConfigMap) []string {
+		var names []string
+		for _, owner := range cm.OwnerReferences {
+			names = append(names, owner.Kind+"/"+owner.Name)
+		}
+		return names
+	}
+
+	cm, err := getConfigMap("ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, "true", cm.Labels[RouteDNSConfigMapLabel])
+	assert.Equal(t, map[string]string{
+		"httproute.inventory": "new-inventory.lattice.on.aws",
+		"grpcroute.greeter":   "greeter.lattice.on.aws",
+	}, cm.Data)
+	assert.Equal(t, []string{"HTTPRoute/inventory", "GRPCRoute/greeter"}, owners(cm))
+
+	cm, err = getConfigMap("ns2")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"httproute.inventory": "other.lattice.on.aws"}, cm.Data)
+	assert.Equal(t, []string{"HTTPRoute/inventory"}, owners(cm))
+
+	// deleted routes are removed
+	assert.NoError(t, rc.removeRouteDNS(ctx, inventory))
+	cm, err = getConfigMap("ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"grpcroute.greeter": "greeter.lattice.on.aws"}, cm.Data)
+	assert.Equal(t, []string{"GRPCRoute/greeter"}, owners(cm))
+
+	// along with the ConfigMap of the last one
+	assert.NoError(t, rc.removeRouteDNS(ctx, greeter))
+	_, err = getConfigMap("ns1")
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.NoError(t, rc.removeRouteDNS(ctx, greeter))
+
+	_, err = getConfigMap("ns2")
+	assert.NoError(t, err)
+}
+
+func TestRouteReconciler_RouteDNSConfigMapDisabled(t *testing.T) {
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+
+	rc := routeReconciler{
+		log:       gwlog.FallbackLogger,
+		client:    k8sClient,
+		apiReader: k8sClient,
+		scheme:    k8sScheme,
+	}
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "ns1", UID: "inventory-uid"},
+	})
+	assert.NoError(t, rc.updateRouteDNS(ctx, route, "inventory.lattice.on.aws"))
+
+	cms := &corev1.ConfigMapList{}
+	assert.NoError(t, k8sClient.List(ctx, cms))
+	assert.Empty(t, cms.Items)
+}
+
+func TestRouteReconciler_RouteDNSConfigMapNotManaged(t *testing.T) {
+	config.RouteDNSConfigMap = "lattice-routes"
+	defer func() { config.RouteDNSConfigMap = "" }()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "lattice-routes", Namespace: "ns1"},
+		Data:       map[string]string{"owner": "someone-else"},
+	}
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(existing).Build()
+
+	rc := routeReconciler{
+		log:       gwlog.FallbackLogger,
+		client:    k8sClient,
+		apiReader: k8sClient,
+		scheme:    k8sScheme,
+	}
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "ns1", UID: "inventory-uid"},
+	})
+
+	// ConfigMaps without the label are left untouched
+	assert.ErrorContains(t, rc.updateRouteDNS(ctx, route, "inventory.lattice.on.aws"), "not managed by the controller")
+	assert.NoError(t, rc.removeRouteDNS(ctx, route))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "ns1", Name: "lattice-routes"}, cm))
+	assert.Equal(t, map[string]string{"owner": "someone-else"}, cm.Data)
+	assert.Empty(t, cm.OwnerReferences)
+}
@@ -18,21 +18,32 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
 	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
 const (
 	serviceFinalizer = "service.ki8s.aws/resources"
+
+	// PrewarmTargetGroupTTLAnnotation requests a target group to be created for the service before
+	// any route refers to it. The value is a duration, counted from the service creation, after which
+	// the target group is deleted if no route has adopted it.
+	PrewarmTargetGroupTTLAnnotation = k8s.AnnotationPrefix + "prewarm-target-group-ttl"
 )
 
 type serviceReconciler struct {
@@ -41,6 +52,8 @@ type serviceReconciler struct {
 	scheme           *runtime.Scheme
 	finalizerManager k8s.FinalizerManager
 	eventRecorder    record.EventRecorder
+	prewarmManager   lattice.TargetGroupPrewarmManager
+	prewarmBuilder   *gateway.PrewarmTargetGroupBuilder
 }
 
 func RegisterServiceController(
@@ -58,10 +71,14 @@ func RegisterServiceController(
 		scheme:           scheme,
 		finalizerManager: finalizerManager,
 		eventRecorder:    evtRec,
+		prewarmManager:   lattice.NewTargetGroupPrewarmManager(log, cloud),
+		prewarmBuilder:   gateway.NewPrewarmTargetGroupBuilder(log, client),
 	}
+	tracker := metrics.NewQueueTracker("Service")
 	err := ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Service{}).
-		Complete(sr)
+		Named("service").
+		Watches(&corev1.Service{}, tracker.EventHandler(&handler.EnqueueRequestForObject{})).
+		Complete(tracker.Reconciler(sr))
 	return err
 }
 
@@ -95,9 +112,72 @@ func (r *serviceReconciler) reconcile(ctx context.Context, req ctrl.Request) err
 		return client.IgnoreNotFound(err)
 	}
 	if !svc.DeletionTimestamp.IsZero() {
+		if k8s.HasFinalizer(svc, serviceFinalizer) {
+			if err := r.prewarmManager.DeletePrewarmed(ctx, k8s.NamespacedName(svc)); err != nil {
+				return err
+			}
+		}
 		r.finalizerManager.RemoveFinalizers(ctx, svc, serviceFinalizer)
+		return nil
+	}
+
+	if err := r.reconcilePrewarm(ctx, svc); err != nil {
+		return err
 	}
 
 	r.log.Infow(ctx, "reconciled", "name", req.Name)
 	return nil
 }
+
+func (r *serviceReconciler) reconcilePrewarm(ctx context.Context, svc *corev1.Service) error {
+	ttlValue, ok := svc.Annotations[PrewarmTargetGroupTTLAnnotation]
+	if !ok {
+		// annotation removed, clean up anything left from an earlier prewarm
+		if k8s.HasFinalizer(svc, serviceFinalizer) {
+			if err := r.prewarmManager.DeletePrewarmed(ctx, k8s.NamespacedName(svc)); err != nil {
+				return err
+			}
+			return r.finalizerManager.RemoveFinalizers(ctx, svc, serviceFinalizer)
+		}
+		return nil
+	}
+
+	ttl, err := time.ParseDuration(ttlValue)
+	if err != nil || ttl <= 0 {
+		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel,
+			fmt.Sprintf("Invalid %s value %q, expected a positive duration", PrewarmTargetGroupTTLAnnotation, ttlValue))
+		return nil
+	}
+
+	remaining := time.Until(svc.CreationTimestamp.Add(ttl))
+	if remaining <= 0 {
+		if !k8s.HasFinalizer(svc, serviceFinalizer) {
+			return nil
+		}
+		r.log.Infof(ctx, "Prewarm TTL of service %s expired", k8s.NamespacedName(svc))
+		if err := r.prewarmManager.DeletePrewarmed(ctx, k8s.NamespacedName(svc)); err != nil {
+			return err
+		}
+		return r.finalizerManager.RemoveFinalizers(ctx, svc, serviceFinalizer)
+	}
+
+	if err := r.finalizerManager.AddFinalizers(ctx, svc, serviceFinalizer); err != nil {
+		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedAddFinalizer,
+			fmt.Sprintf("Failed add finalizer due to %s", err))
+		return err
+	}
+
+	tg, err := r.prewarmBuilder.BuildTargetGroup(ctx, svc)
+	if err != nil {
+		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel,
+			fmt.Sprintf("Failed to build prewarm target group due to %s", err))
+		return err
+	}
+	if _, err := r.prewarmManager.Prewarm(ctx, tg); err != nil {
+		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedDeployModel,
+			fmt.Sprintf("Failed to prewarm target group due to %s", err))
+		return err
+	}
+
+	return lattice_runtime.NewRequeueNeededAfter("waiting for prewarm TTL to expire", remaining)
+}
@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestServiceReconciler_Prewarm(t *testing.T) {
+	config.VpcID = "my-vpc"
+	config.ClusterName = "my-cluster"
+
+	tests := []struct {
+		name              string
+		annotations       map[string]string
+		createdAgo        time.Duration
+		finalizers        []string
+		expectPrewarm     bool
+		expectDelete      bool
+		expectFinalizer   bool
+		expectRequeueWait bool
+	}{
+		{
+			name:              "prewarm within ttl",
+			annotations:       map[string]string{PrewarmTargetGroupTTLAnnotation: "10m"},
+			createdAgo:        time.Minute,
+			expectPrewarm:     true,
+			expectFinalizer:   true,
+			expectRequeueWait: true,
+		},
+		{
+			name:            "ttl expired deletes prewarmed target group",
+			annotations:     map[string]string{PrewarmTargetGroupTTLAnnotation: "10m"},
+			createdAgo:      time.Hour,
+			finalizers:      []string{serviceFinalizer},
+			expectDelete:    true,
+			expectFinalizer: false,
+		},
+		{
+			name:        "ttl expired without finalizer does nothing",
+			annotations: map[string]string{PrewarmTargetGroupTTLAnnotation: "10m"},
+			createdAgo:  time.Hour,
+		},
+		{
+			name:         "annotation removed deletes prewarmed target group",
+			createdAgo:   time.Minute,
+			finalizers:   []string{serviceFinalizer},
+			expectDelete: true,
+		},
+		{
+			name:        "invalid ttl is ignored",
+			annotations: map[string]string{PrewarmTargetGroupTTLAnnotation: "soon"},
+			createdAgo:  time.Minute,
+		},
+		{
+			name:       "no annotation",
+			createdAgo: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+
+			k8sScheme := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sScheme)
+			addOptionalCRDs(k8sScheme)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "my-service",
+					Namespace:         "ns1",
+					Annotations:       tt.annotations,
+					Finalizers:        tt.finalizers,
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-tt.createdAgo)),
+				},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+				},
+			}
+			assert.Nil(t, k8sClient.Create(ctx, svc))
+
+			mockPrewarmManager := lattice.NewMockTargetGroupPrewarmManager(c)
+			if tt.expectPrewarm {
+				mockPrewarmManager.EXPECT().Prewarm(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tg *model.TargetGroup) (model.TargetGroupStatus, error) {
+						assert.Equal(t, model.SourceTypePrewarm, tg.Spec.K8SSourceType)
+						assert.Equal(t, "my-service", tg.Spec.K8SServiceName)
+						assert.Equal(t, "ns1", tg.Spec.K8SServiceNamespace)
+						assert.Equal(t, "IPV4", tg.Spec.IpAddressType)
+						assert.Equal(t, "my-vpc", tg.Spec.VpcId)
+						return model.TargetGroupStatus{Arn: "tg-arn"}, nil
+					})
+			}
+			if tt.expectDelete {
+				mockPrewarmManager.EXPECT().DeletePrewarmed(gomock.Any(), types.NamespacedName{
+					Namespace: "ns1",
+					Name:      "my-service",
+				}).Return(nil)
+			}
+
+			rec := &serviceReconciler{
+				log:              gwlog.FallbackLogger,
+				client:           k8sClient,
+				scheme:           k8sScheme,
+				finalizerManager: k8s.NewDefaultFinalizerManager(k8sClient),
+				eventRecorder:    record.NewFakeRecorder(10),
+				prewarmManager:   mockPrewarmManager,
+				prewarmBuilder:   gateway.NewPrewarmTargetGroupBuilder(gwlog.FallbackLogger, k8sClient),
+			}
+
+			result, err := rec.Reconcile(ctx, reconcile.Request{NamespacedName: k8s.NamespacedName(svc)})
+			assert.Nil(t, err)
+			if tt.expectRequeueWait {
+				assert.True(t, result.RequeueAfter > 0 && result.RequeueAfter <= 9*time.Minute)
+			} else {
+				assert.Equal(t, time.Duration(0), result.RequeueAfter)
+			}
+
+			updated := &corev1.Service{}
+			assert.Nil(t, k8sClient.Get(ctx, k8s.NamespacedName(svc), updated))
+			assert.Equal(t, tt.expectFinalizer, k8s.HasFinalizer(updated, serviceFinalizer))
+		})
+	}
+}
+
+func TestServiceReconciler_DeletesPrewarmedOnServiceDeletion(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-service",
+			Namespace:   "ns1",
+			Annotations: map[string]string{PrewarmTargetGroupTTLAnnotation: "10m"},
+			Finalizers:  []string{serviceFinalizer},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, svc))
+	assert.Nil(t, k8sClient.Delete(ctx, svc))
+
+	mockPrewarmManager := lattice.NewMockTargetGroupPrewarmManager(c)
+	mockPrewarmManager.EXPECT().DeletePrewarmed(gomock.Any(), k8s.NamespacedName(svc)).Return(nil)
+
+	rec := &serviceReconciler{
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		scheme:           k8sScheme,
+		finalizerManager: k8s.NewDefaultFinalizerManager(k8sClient),
+		eventRecorder:    record.NewFakeRecorder(10),
+		prewarmManager:   mockPrewarmManager,
+		prewarmBuilder:   gateway.NewPrewarmTargetGroupBuilder(gwlog.FallbackLogger, k8sClient),
+	}
+
+	_, err := rec.Reconcile(ctx, reconcile.Request{NamespacedName: k8s.NamespacedName(svc)})
+	assert.Nil(t, err)
+
+	// the service is gone once the finalizer is removed
+	err = k8sClient.Get(ctx, k8s.NamespacedName(svc), &corev1.Service{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
 	corev1 "k8s.io/api/core/v1"
@@ -27,12 +29,18 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	"github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
+	"github.com/aws/aws-application-networking-k8s/pkg/drift"
 	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/resync"
 	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 	discoveryv1 "k8s.io/api/discovery/v1"
@@ -47,10 +55,14 @@ type serviceExportReconciler struct {
 	modelBuilder     gateway.SvcExportTargetGroupModelBuilder
 	stackDeployer    deploy.StackDeployer
 	stackMarshaller  deploy.StackMarshaller
+	cleanupTracker   *cleanupTracker
 }
 
 const (
 	serviceExportFinalizer = "serviceexport.k8s.aws/resources"
+
+	// ARNs of the target groups of the service export, comma separated, so they can be identified once leaked
+	LatticeTargetGroupArns = "application-networking.k8s.aws/lattice-target-group-arns"
 )
 
 func RegisterServiceExportController(
@@ -58,6 +70,8 @@ func RegisterServiceExportController(
 	cloud aws.Cloud,
 	finalizerManager k8s.FinalizerManager,
 	mgr ctrl.Manager,
+	driftPoller *drift.Poller,
+	resyncer *resync.Resyncer,
 ) error {
 	mgrClient := mgr.GetClient()
 	scheme := mgr.GetScheme()
@@ -76,17 +90,23 @@ func RegisterServiceExportController(
 		stackDeployer:    stackDeploy,
 		eventRecorder:    eventRecorder,
 		stackMarshaller:  stackMarshaller,
+		cleanupTracker:   newCleanupTracker(),
 	}
 
 	svcEventHandler := eventhandlers.NewServiceEventHandler(log, r.client)
+	nodeEventHandler := eventhandlers.NewNodeEventHandler(log, r.client)
 
+	tracker := metrics.NewQueueTracker("ServiceExport")
+	gate := eventhandlers.NewPriorityGate(1)
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&anv1alpha1.ServiceExport{}).
-		Watches(&corev1.Service{}, svcEventHandler.MapToServiceExport()).
-		Watches(&discoveryv1.EndpointSlice{}, svcEventHandler.MapToServiceExport())
+		Named("serviceexport").
+		Watches(&anv1alpha1.ServiceExport{}, tracker.EventHandler(gate.EventHandler(&handler.EnqueueRequestForObject{}))).
+		Watches(&corev1.Service{}, tracker.EventHandler(svcEventHandler.MapToServiceExport())).
+		Watches(&discoveryv1.EndpointSlice{}, tracker.EventHandler(svcEventHandler.MapToServiceExport())).
+		Watches(&corev1.Node{}, tracker.EventHandler(nodeEventHandler.MapToServiceExport()))
 
 	if ok, err := k8s.IsGVKSupported(mgr, anv1alpha1.GroupVersion.String(), anv1alpha1.TargetGroupPolicyKind); ok {
-		builder.Watches(&anv1alpha1.TargetGroupPolicy{}, svcEventHandler.MapToServiceExport())
+		builder.Watches(&anv1alpha1.TargetGroupPolicy{}, tracker.EventHandler(svcEventHandler.MapToServiceExport()))
 	} else {
 		if err != nil {
 			return err
@@ -94,7 +114,15 @@ func RegisterServiceExportController(
 		log.Infof(context.TODO(), "TargetGroupPolicy CRD is not installed, skipping watch")
 	}
 
-	return builder.Complete(r)
+	if driftPoller != nil {
+		builder.WatchesRawSource(driftPoller.Source(model.SourceTypeSvcExport), tracker.EventHandler(&handler.EnqueueRequestForObject{}))
+	}
+
+	if resyncer != nil {
+		builder.WatchesRawSource(resyncer.Source(&anv1alpha1.ServiceExportList{}), tracker.EventHandler(gate.Resync(&handler.EnqueueRequestForObject{})))
+	}
+
+	return builder.Complete(tracker.Reconciler(gate.Reconciler(r)))
 }
 
 //+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=serviceexports,verbs=get;list;watch;create;update;patch;delete
@@ -128,7 +156,27 @@ func (r *serviceExportReconciler) reconcile(ctx context.Context, req ctrl.Reques
 
 	if !srvExport.DeletionTimestamp.IsZero() {
 		if err := r.buildAndDeployModel(ctx, srvExport); err != nil {
-			return err
+			if err = r.cleanupTracker.handleCleanupError(srvExport, err); err != nil {
+				return err
+			}
+			r.log.Warnw(ctx, "VPC Lattice is unreachable, removing finalizer without cleanup. Delete leaked resources manually",
+				"name", srvExport.Name,
+				"namespace", srvExport.Namespace,
+				"targetGroupArns", srvExport.Annotations[LatticeTargetGroupArns],
+				"targetGroupTags", map[string]string{
+					model.K8SServiceNameKey:      srvExport.Name,
+					model.K8SServiceNamespaceKey: srvExport.Namespace,
+					model.K8SSourceTypeKey:       string(model.SourceTypeSvcExport),
+				},
+			)
+			leaked := "of the service export"
+			if arns := srvExport.Annotations[LatticeTargetGroupArns]; arns != "" {
+				leaked = arns
+			}
+			r.eventRecorder.Event(srvExport, corev1.EventTypeWarning, k8s.LeakedResourcesEvent,
+				fmt.Sprintf("VPC Lattice is unreachable, target groups %s were not deleted", leaked))
+		} else {
+			r.cleanupTracker.reset(srvExport)
 		}
 		err := r.finalizerManager.RemoveFinalizers(ctx, srvExport, serviceExportFinalizer)
 		if err != nil {
@@ -177,5 +225,41 @@ func (r *serviceExportReconciler) buildAndDeployModel(
 	}
 
 	r.log.Debugf(ctx, "Successfully deployed model for service export %s-%s", srvExport.Name, srvExport.Namespace)
-	return err
+	if !srvExport.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	return r.updateTargetGroupArnsAnnotation(ctx, srvExport, stack)
+}
+
+func (r *serviceExportReconciler) updateTargetGroupArnsAnnotation(
+	ctx context.Context,
+	srvExport *anv1alpha1.ServiceExport,
+	stack core.Stack,
+) error {
+	var targetGroups []*model.TargetGroup
+	if err := stack.ListResources(&targetGroups); err != nil {
+		return err
+	}
+	var arns []string
+	for _, tg := range targetGroups {
+		if !tg.IsDeleted && tg.Status != nil && tg.Status.Arn != "" {
+			arns = append(arns, tg.Status.Arn)
+		}
+	}
+	sort.Strings(arns)
+	value := strings.Join(arns, ",")
+	if srvExport.Annotations[LatticeTargetGroupArns] == value {
+		return nil
+	}
+
+	oldSrvExport := srvExport.DeepCopy()
+	if srvExport.Annotations == nil {
+		srvExport.Annotations = make(map[string]string)
+	}
+	srvExport.Annotations[LatticeTargetGroupArns] = value
+	if err := r.client.Patch(ctx, srvExport, client.MergeFrom(oldSrvExport)); err != nil {
+		return fmt.Errorf("failed to update service export %s-%s annotations due to %w",
+			srvExport.Name, srvExport.Namespace, err)
+	}
+	return nil
 }
@@ -28,8 +28,10 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
 )
 
 type serviceImportReconciler struct {
@@ -61,9 +63,11 @@ func RegisterServiceImportController(
 		eventRecorder:    eventRecorder,
 	}
 
+	tracker := metrics.NewQueueTracker("ServiceImport")
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&anv1alpha1.ServiceImport{}).
-		Complete(r)
+		Named("serviceimport").
+		Watches(&anv1alpha1.ServiceImport{}, tracker.EventHandler(&handler.EnqueueRequestForObject{})).
+		Complete(tracker.Reconciler(r))
 }
 
 //+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=serviceimports,verbs=get;list;watch;create;update;patch;delete
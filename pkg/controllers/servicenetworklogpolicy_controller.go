@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
+	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
+	"github.com/aws/aws-application-networking-k8s/pkg/resync"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type (
+	SNLP = anv1alpha1.ServiceNetworkLogPolicy
+)
+
+const (
+	serviceNetworkLogPolicyFinalizer = "servicenetworklogpolicies.application-networking.k8s.aws/resources"
+)
+
+type serviceNetworkLogPolicyReconciler struct {
+	log              gwlog.Logger
+	client           client.Client
+	finalizerManager k8s.FinalizerManager
+	manager          deploy.ServiceNetworkLogSubscriptionManager
+	ph               *policy.PolicyHandler[*SNLP]
+}
+
+func RegisterServiceNetworkLogPolicyController(log gwlog.Logger, cloud pkg_aws.Cloud, finalizerManager k8s.FinalizerManager, mgr ctrl.Manager, resyncer *resync.Resyncer) error {
+	ph := policy.NewServiceNetworkLogPolicyHandler(log, mgr.GetClient())
+	controller := &serviceNetworkLogPolicyReconciler{
+		log:              log,
+		client:           mgr.GetClient(),
+		finalizerManager: finalizerManager,
+		manager:          deploy.NewServiceNetworkLogSubscriptionManager(log, cloud),
+		ph:               ph,
+	}
+
+	tracker := metrics.NewQueueTracker(anv1alpha1.ServiceNetworkLogPolicyKind)
+	gate := eventhandlers.NewPriorityGate(1)
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("servicenetworklogpolicy").
+		Watches(&anv1alpha1.ServiceNetworkLogPolicy{}, tracker.EventHandler(gate.EventHandler(eventhandlers.Debounce(&handler.EnqueueRequestForObject{}, config.ReconcileDebounce))), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	ph.AddWatchers(b, tracker, &gwv1beta1.Gateway{})
+	if resyncer != nil {
+		b.WatchesRawSource(resyncer.Source(&anv1alpha1.ServiceNetworkLogPolicyList{}), tracker.EventHandler(gate.Resync(&handler.EnqueueRequestForObject{})))
+	}
+	return b.Complete(tracker.Reconciler(gate.Reconciler(controller)))
+}
+
+func (c *serviceNetworkLogPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = gwlog.StartReconcileTrace(ctx, c.log, "servicenetworklogpolicy", req.Name, req.Namespace)
+	defer func() {
+		gwlog.EndReconcileTrace(ctx, c.log)
+	}()
+
+	k8sPolicy := &anv1alpha1.ServiceNetworkLogPolicy{}
+	err := c.client.Get(ctx, req.NamespacedName, k8sPolicy)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	c.log.Infow(ctx, "reconcile", "req", req, "targetRef", k8sPolicy.Spec.TargetRef)
+
+	isDelete := !k8sPolicy.DeletionTimestamp.IsZero()
+
+	var res ctrl.Result
+	if isDelete {
+		err = c.delete(ctx, k8sPolicy)
+	} else {
+		res, err = c.upsert(ctx, k8sPolicy)
+	}
+	if err != nil {
+		c.log.Infof(ctx, "reconcile error, retry in 30 sec: %s", err)
+		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+	}
+
+	c.log.Infow(ctx, "reconciled service network log policy",
+		"req", req,
+		"targetRef", k8sPolicy.Spec.TargetRef,
+		"isDeleted", isDelete,
+	)
+	return res, nil
+}
+
+func (c *serviceNetworkLogPolicyReconciler) upsert(ctx context.Context, k8sPolicy *anv1alpha1.ServiceNetworkLogPolicy) (ctrl.Result, error) {
+	reason, err := c.ph.ValidateAndUpdateCondition(ctx, k8sPolicy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if reason != conditions.ReasonAccepted {
+		return policy.ResultForReason(reason), nil
+	}
+
+	err = c.finalizerManager.AddFinalizers(ctx, k8sPolicy, serviceNetworkLogPolicyFinalizer)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	snName := string(k8sPolicy.Spec.TargetRef.Name)
+	destinationArns := utils.SliceMap(k8sPolicy.Spec.DestinationArns, func(arn anv1alpha1.LogDestinationArn) string {
+		return string(arn)
+	})
+	alsArns, err := c.manager.Upsert(ctx, snName, k8s.NamespacedName(k8sPolicy), destinationArns)
+	if err != nil {
+		return ctrl.Result{}, c.handleUpsertError(ctx, k8sPolicy, err)
+	}
+	err = c.updateLatticeAnnotation(ctx, k8sPolicy, alsArns)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// Conflicting or invalid destinations cannot be fixed by retrying, so they are reported on the
+// policy status instead of being returned.
+func (c *serviceNetworkLogPolicyReconciler) handleUpsertError(ctx context.Context, k8sPolicy *anv1alpha1.ServiceNetworkLogPolicy, err error) error {
+	switch {
+	case services.IsConflictError(err):
+		return c.ph.UpdateAcceptedCondition(ctx, k8sPolicy, conditions.ReasonConflicted,
+			"Service network already has an access log subscription for the same destination type: "+err.Error())
+	case services.IsInvalidError(err):
+		return c.ph.UpdateAcceptedCondition(ctx, k8sPolicy, conditions.ReasonInvalid, err.Error())
+	}
+	return err
+}
+
+func (c *serviceNetworkLogPolicyReconciler) delete(ctx context.Context, k8sPolicy *anv1alpha1.ServiceNetworkLogPolicy) error {
+	snName := string(k8sPolicy.Spec.TargetRef.Name)
+	err := c.manager.Delete(ctx, snName, k8s.NamespacedName(k8sPolicy))
+	if err != nil {
+		return err
+	}
+	err = c.finalizerManager.RemoveFinalizers(ctx, k8sPolicy, serviceNetworkLogPolicyFinalizer)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *serviceNetworkLogPolicyReconciler) updateLatticeAnnotation(ctx context.Context, k8sPolicy *anv1alpha1.ServiceNetworkLogPolicy, alsArns []string) error {
+	if k8sPolicy.Annotations == nil {
+		k8sPolicy.Annotations = make(map[string]string)
+	}
+	k8sPolicy.Annotations[anv1alpha1.ServiceNetworkLogSubscriptionsAnnotationKey] = strings.Join(alsArns, ",")
+	err := c.client.Update(ctx, k8sPolicy)
+	return err
+}
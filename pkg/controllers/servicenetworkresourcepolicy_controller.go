@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
+	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
+	"github.com/aws/aws-application-networking-k8s/pkg/resync"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type (
+	SNRP = anv1alpha1.ServiceNetworkResourcePolicy
+)
+
+const (
+	serviceNetworkResourcePolicyFinalizer = "servicenetworkresourcepolicies.application-networking.k8s.aws/resources"
+)
+
+type serviceNetworkResourcePolicyReconciler struct {
+	log              gwlog.Logger
+	client           client.Client
+	finalizerManager k8s.FinalizerManager
+	manager          *deploy.ServiceNetworkResourcePolicyManager
+	ph               *policy.PolicyHandler[*SNRP]
+}
+
+func RegisterServiceNetworkResourcePolicyController(log gwlog.Logger, cloud pkg_aws.Cloud, finalizerManager k8s.FinalizerManager, mgr ctrl.Manager, resyncer *resync.Resyncer) error {
+	ph := policy.NewServiceNetworkResourcePolicyHandler(log, mgr.GetClient())
+	controller := &serviceNetworkResourcePolicyReconciler{
+		log:              log,
+		client:           mgr.GetClient(),
+		finalizerManager: finalizerManager,
+		manager:          deploy.NewServiceNetworkResourcePolicyManager(log, cloud),
+		ph:               ph,
+	}
+
+	tracker := metrics.NewQueueTracker(anv1alpha1.ServiceNetworkResourcePolicyKind)
+	gate := eventhandlers.NewPriorityGate(1)
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("servicenetworkresourcepolicy").
+		Watches(&anv1alpha1.ServiceNetworkResourcePolicy{}, tracker.EventHandler(gate.EventHandler(eventhandlers.Debounce(&handler.EnqueueRequestForObject{}, config.ReconcileDebounce))), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	ph.AddWatchers(b, tracker, &gwv1beta1.Gateway{})
+	if resyncer != nil {
+		b.WatchesRawSource(resyncer.Source(&anv1alpha1.ServiceNetworkResourcePolicyList{}), tracker.EventHandler(gate.Resync(&handler.EnqueueRequestForObject{})))
+	}
+	return b.Complete(tracker.Reconciler(gate.Reconciler(controller)))
+}
+
+func (c *serviceNetworkResourcePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = gwlog.StartReconcileTrace(ctx, c.log, "servicenetworkresourcepolicy", req.Name, req.Namespace)
+	defer func() {
+		gwlog.EndReconcileTrace(ctx, c.log)
+	}()
+
+	k8sPolicy := &anv1alpha1.ServiceNetworkResourcePolicy{}
+	err := c.client.Get(ctx, req.NamespacedName, k8sPolicy)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	c.log.Infow(ctx, "reconcile", "req", req, "targetRef", k8sPolicy.Spec.TargetRef)
+
+	isDelete := !k8sPolicy.DeletionTimestamp.IsZero()
+
+	var res ctrl.Result
+	if isDelete {
+		err = c.delete(ctx, k8sPolicy)
+	} else {
+		res, err = c.upsert(ctx, k8sPolicy)
+	}
+	if err != nil {
+		c.log.Infof(ctx, "reconcile error, retry in 30 sec: %s", err)
+		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+	}
+
+	c.log.Infow(ctx, "reconciled service network resource policy",
+		"req", req,
+		"targetRef", k8sPolicy.Spec.TargetRef,
+		"isDeleted", isDelete,
+	)
+	return res, nil
+}
+
+func (c *serviceNetworkResourcePolicyReconciler) upsert(ctx context.Context, k8sPolicy *anv1alpha1.ServiceNetworkResourcePolicy) (ctrl.Result, error) {
+	reason, err := c.ph.ValidateAndUpdateCondition(ctx, k8sPolicy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if reason != conditions.ReasonAccepted {
+		return policy.ResultForReason(reason), nil
+	}
+
+	err = c.finalizerManager.AddFinalizers(ctx, k8sPolicy, serviceNetworkResourcePolicyFinalizer)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	snName := string(k8sPolicy.Spec.TargetRef.Name)
+	_, err = c.manager.Put(ctx, snName, k8sPolicy.Spec.Policy)
+	if err != nil {
+		return ctrl.Result{}, c.handleUpsertError(ctx, k8sPolicy, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// Policies rejected by VPC Lattice cannot be fixed by retrying, so they are reported on the
+// policy status instead of being returned.
+func (c *serviceNetworkResourcePolicyReconciler) handleUpsertError(ctx context.Context, k8sPolicy *anv1alpha1.ServiceNetworkResourcePolicy, err error) error {
+	switch {
+	case services.IsInvalidError(err):
+		return c.ph.UpdateAcceptedCondition(ctx, k8sPolicy, conditions.ReasonInvalid, err.Error())
+	case services.IsAccessDeniedError(err):
+		return c.ph.UpdateAcceptedCondition(ctx, k8sPolicy, conditions.ReasonAccessDenied, err.Error())
+	}
+	return err
+}
+
+func (c *serviceNetworkResourcePolicyReconciler) delete(ctx context.Context, k8sPolicy *anv1alpha1.ServiceNetworkResourcePolicy) error {
+	snName := string(k8sPolicy.Spec.TargetRef.Name)
+	err := c.manager.Delete(ctx, snName)
+	if err != nil {
+		return err
+	}
+	err = c.finalizerManager.RemoveFinalizers(ctx, k8sPolicy, serviceNetworkResourcePolicyFinalizer)
+	if err != nil {
+		return err
+	}
+	return nil
+}
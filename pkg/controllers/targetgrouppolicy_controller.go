@@ -7,10 +7,17 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
 	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
+	"github.com/aws/aws-application-networking-k8s/pkg/resync"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
@@ -24,7 +31,7 @@ type TargetGroupPolicyController struct {
 	ph     *policy.PolicyHandler[*TGP]
 }
 
-func RegisterTargetGroupPolicyController(log gwlog.Logger, mgr ctrl.Manager) error {
+func RegisterTargetGroupPolicyController(log gwlog.Logger, mgr ctrl.Manager, resyncer *resync.Resyncer) error {
 	ph := policy.NewTargetGroupPolicyHandler(log, mgr.GetClient())
 	controller := &TargetGroupPolicyController{
 		log:    log,
@@ -32,12 +39,18 @@ func RegisterTargetGroupPolicyController(log gwlog.Logger, mgr ctrl.Manager) err
 		ph:     ph,
 	}
 
+	tracker := metrics.NewQueueTracker(anv1alpha1.TargetGroupPolicyKind)
+	gate := eventhandlers.NewPriorityGate(1)
 	b := ctrl.NewControllerManagedBy(mgr).
-		For(&TGP{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
-	ph.AddWatchers(b, &corev1.Service{})
-	ph.AddWatchers(b, &anv1alpha1.ServiceExport{})
+		Named("targetgrouppolicy").
+		Watches(&TGP{}, tracker.EventHandler(gate.EventHandler(eventhandlers.Debounce(&handler.EnqueueRequestForObject{}, config.ReconcileDebounce))), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	ph.AddWatchers(b, tracker, &corev1.Service{})
+	ph.AddWatchers(b, tracker, &anv1alpha1.ServiceExport{})
+	if resyncer != nil {
+		b.WatchesRawSource(resyncer.Source(&anv1alpha1.TargetGroupPolicyList{}), tracker.EventHandler(gate.Resync(&handler.EnqueueRequestForObject{})))
+	}
 
-	return b.Complete(controller)
+	return b.Complete(tracker.Reconciler(gate.Reconciler(controller)))
 }
 
 func (c *TargetGroupPolicyController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -53,7 +66,16 @@ func (c *TargetGroupPolicyController) Reconcile(ctx context.Context, req ctrl.Re
 	}
 	c.log.Infow(ctx, "reconcile target group policy", "req", req, "targetRef", tgPolicy.Spec.TargetRef)
 
-	_, err = c.ph.ValidateAndUpdateCondition(ctx, tgPolicy)
+	if hc := tgPolicy.Spec.HealthCheck; hc != nil && hc.StatusMatch != nil {
+		if err := gateway.ValidateStatusMatch(*hc.StatusMatch); err != nil {
+			if err := c.ph.UpdateAcceptedCondition(ctx, tgPolicy, conditions.ReasonInvalid, err.Error()); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+	}
+
+	reason, err := c.ph.ValidateAndUpdateCondition(ctx, tgPolicy)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -62,5 +84,5 @@ func (c *TargetGroupPolicyController) Reconcile(ctx context.Context, req ctrl.Re
 		"req", req,
 		"targetRef", tgPolicy.Spec.TargetRef,
 	)
-	return ctrl.Result{}, nil
+	return policy.ResultForReason(reason), nil
 }
@@ -2,20 +2,30 @@ package controllers
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"golang.org/x/exp/maps"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
 	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
 	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
+	"github.com/aws/aws-application-networking-k8s/pkg/resync"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
@@ -26,6 +36,8 @@ type (
 
 const (
 	finalizer = "vpcassociationpolicies.application-networking.k8s.aws/resources"
+	// comma separated keys of the tags the policy applied to the vpc association, tag keys cannot contain commas
+	managedTagKeysAnnotation = "application-networking.k8s.aws/managedTagKeys"
 )
 
 type vpcAssociationPolicyReconciler struct {
@@ -37,7 +49,7 @@ type vpcAssociationPolicyReconciler struct {
 	ph               *policy.PolicyHandler[*VAP]
 }
 
-func RegisterVpcAssociationPolicyController(log gwlog.Logger, cloud pkg_aws.Cloud, finalizerManager k8s.FinalizerManager, mgr ctrl.Manager) error {
+func RegisterVpcAssociationPolicyController(log gwlog.Logger, cloud pkg_aws.Cloud, finalizerManager k8s.FinalizerManager, mgr ctrl.Manager, resyncer *resync.Resyncer) error {
 	ph := policy.NewVpcAssociationPolicyHandler(log, mgr.GetClient())
 	controller := &vpcAssociationPolicyReconciler{
 		log:              log,
@@ -48,10 +60,16 @@ func RegisterVpcAssociationPolicyController(log gwlog.Logger, cloud pkg_aws.Clou
 		ph:               ph,
 	}
 
+	tracker := metrics.NewQueueTracker(anv1alpha1.VpcAssociationPolicyKind)
+	gate := eventhandlers.NewPriorityGate(1)
 	b := ctrl.NewControllerManagedBy(mgr).
-		For(&anv1alpha1.VpcAssociationPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
-	ph.AddWatchers(b, &gwv1beta1.Gateway{})
-	return b.Complete(controller)
+		Named("vpcassociationpolicy").
+		Watches(&anv1alpha1.VpcAssociationPolicy{}, tracker.EventHandler(gate.EventHandler(eventhandlers.Debounce(&handler.EnqueueRequestForObject{}, config.ReconcileDebounce))), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	ph.AddWatchers(b, tracker, &gwv1beta1.Gateway{})
+	if resyncer != nil {
+		b.WatchesRawSource(resyncer.Source(&anv1alpha1.VpcAssociationPolicyList{}), tracker.EventHandler(gate.Resync(&handler.EnqueueRequestForObject{})))
+	}
+	return b.Complete(tracker.Reconciler(gate.Reconciler(controller)))
 }
 
 func (c *vpcAssociationPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -70,10 +88,11 @@ func (c *vpcAssociationPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 	isDelete := !k8sPolicy.DeletionTimestamp.IsZero()
 	isAssociation := k8sPolicy.Spec.AssociateWithVpc == nil || *k8sPolicy.Spec.AssociateWithVpc
 
+	var res ctrl.Result
 	if isDelete || !isAssociation {
 		err = c.delete(ctx, k8sPolicy)
 	} else {
-		err = c.upsert(ctx, k8sPolicy)
+		res, err = c.upsert(ctx, k8sPolicy)
 	}
 	if err != nil {
 		c.log.Infof(ctx, "reconcile error, retry in 30 sec: %s", err)
@@ -85,36 +104,50 @@ func (c *vpcAssociationPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 		"targetRef", k8sPolicy.Spec.TargetRef,
 		"isDeleted", isDelete,
 	)
-	return ctrl.Result{}, nil
+	return res, nil
 }
 
-func (c *vpcAssociationPolicyReconciler) upsert(ctx context.Context, k8sPolicy *anv1alpha1.VpcAssociationPolicy) error {
+func (c *vpcAssociationPolicyReconciler) upsert(ctx context.Context, k8sPolicy *anv1alpha1.VpcAssociationPolicy) (ctrl.Result, error) {
 	reason, err := c.ph.ValidateAndUpdateCondition(ctx, k8sPolicy)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
-	if reason != policy.ReasonAccepted {
-		return nil
+	if reason != conditions.ReasonAccepted {
+		return policy.ResultForReason(reason), nil
 	}
 
 	err = c.finalizerManager.AddFinalizers(ctx, k8sPolicy, finalizer)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 	snName := string(k8sPolicy.Spec.TargetRef.Name)
-	sgIds := utils.SliceMap(k8sPolicy.Spec.SecurityGroupIds, func(sg anv1alpha1.SecurityGroupId) *string {
-		str := string(sg)
-		return &str
-	})
-	snva, err := c.manager.UpsertVpcAssociation(ctx, snName, sgIds)
+	sgIds, tags := vpcAssociationSettings(k8sPolicy)
+	var managedTagKeys []string
+	if value := k8sPolicy.Annotations[managedTagKeysAnnotation]; value != "" {
+		managedTagKeys = strings.Split(value, ",")
+	}
+	snva, err := c.manager.UpsertVpcAssociation(ctx, snName, sgIds, tags, managedTagKeys)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 	err = c.updateLatticeAnnotation(ctx, k8sPolicy, snva)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
-	return nil
+	return ctrl.Result{}, nil
+}
+
+// vpcAssociationSettings returns the security groups and tags the policy sets on the vpc association
+func vpcAssociationSettings(k8sPolicy *anv1alpha1.VpcAssociationPolicy) ([]*string, services.Tags) {
+	sgIds := utils.SliceMap(k8sPolicy.Spec.SecurityGroupIds, func(sg anv1alpha1.SecurityGroupId) *string {
+		str := string(sg)
+		return &str
+	})
+	tags := services.Tags{}
+	for k, v := range k8sPolicy.Spec.Tags {
+		tags[k] = aws.String(v)
+	}
+	return sgIds, tags
 }
 
 func (c *vpcAssociationPolicyReconciler) delete(ctx context.Context, k8sPolicy *anv1alpha1.VpcAssociationPolicy) error {
@@ -143,6 +176,9 @@ func (c *vpcAssociationPolicyReconciler) updateLatticeAnnotation(ctx context.Con
 		k8sPolicy.Annotations = make(map[string]string)
 	}
 	k8sPolicy.Annotations["application-networking.k8s.aws/resourceArn"] = resArn
+	tagKeys := maps.Keys(k8sPolicy.Spec.Tags)
+	sort.Strings(tagKeys)
+	k8sPolicy.Annotations[managedTagKeysAnnotation] = strings.Join(tagKeys, ",")
 	err := c.client.Update(ctx, k8sPolicy)
 	return err
 }
@@ -176,6 +176,12 @@ func (m *defaultAccessLogSubscriptionManager) Delete(
 	ctx context.Context,
 	accessLogSubscriptionArn string,
 ) error {
+	if err := verifyOwnedBeforeDelete(ctx, m.cloud, accessLogSubscriptionArn); err != nil {
+		if services.IsLatticeAPINotFoundErr(err) {
+			return nil
+		}
+		return err
+	}
 	vpcLatticeSess := m.cloud.Lattice()
 	deleteALSInput := &vpclattice.DeleteAccessLogSubscriptionInput{
 		AccessLogSubscriptionIdentifier: aws.String(accessLogSubscriptionArn),
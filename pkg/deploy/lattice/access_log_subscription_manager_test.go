@@ -349,6 +349,7 @@ func TestAccessLogSubscriptionManager(t *testing.T) {
 		mockLattice.EXPECT().UpdateAccessLogSubscriptionWithContext(ctx, updateALSInput).Return(nil, updateALSErr)
 		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
 		mockLattice.EXPECT().CreateAccessLogSubscriptionWithContext(ctx, createALSForSNInput).Return(createALSOutput, nil)
+		expectOwnedBeforeDelete(mockLattice)
 		mockLattice.EXPECT().DeleteAccessLogSubscriptionWithContext(ctx, deleteALSInput).Return(deleteALSOutput, nil)
 
 		mgr := NewAccessLogSubscriptionManager(gwlog.FallbackLogger, cloud)
@@ -385,6 +386,7 @@ func TestAccessLogSubscriptionManager(t *testing.T) {
 		mockLattice.EXPECT().FindServiceNetwork(ctx, newSourceName).Return(serviceNetworkInfo, nil)
 		mockLattice.EXPECT().FindServiceNetwork(ctx, newSourceName).Return(serviceNetworkInfo, nil)
 		mockLattice.EXPECT().CreateAccessLogSubscriptionWithContext(ctx, createALSInput).Return(createALSOutput, nil)
+		expectOwnedBeforeDelete(mockLattice)
 		mockLattice.EXPECT().DeleteAccessLogSubscriptionWithContext(ctx, deleteALSInput).Return(deleteALSOutput, nil)
 
 		mgr := NewAccessLogSubscriptionManager(gwlog.FallbackLogger, cloud)
@@ -508,6 +510,7 @@ func TestAccessLogSubscriptionManager(t *testing.T) {
 	})
 
 	t.Run("Test_Delete_AccessLogSubscriptionExists_ReturnsSuccess", func(t *testing.T) {
+		expectOwnedBeforeDelete(mockLattice)
 		mockLattice.EXPECT().DeleteAccessLogSubscriptionWithContext(ctx, deleteALSInput).Return(deleteALSOutput, nil)
 
 		mgr := NewAccessLogSubscriptionManager(gwlog.FallbackLogger, cloud)
@@ -520,6 +523,7 @@ func TestAccessLogSubscriptionManager(t *testing.T) {
 			ResourceType: aws.String("ACCESS_LOG_SUBSCRIPTION"),
 		}
 
+		expectOwnedBeforeDelete(mockLattice)
 		mockLattice.EXPECT().DeleteAccessLogSubscriptionWithContext(ctx, deleteALSInput).Return(nil, deleteALSErr)
 
 		mgr := NewAccessLogSubscriptionManager(gwlog.FallbackLogger, cloud)
@@ -0,0 +1,62 @@
+package lattice
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// healthGatedTargetGroups leaves the target groups without healthy targets out of weighted target groups when
+// config.BackendHealthGatingInterval is set, so the traffic of their weight goes to the remaining target groups
+// instead of failing. All target groups are kept when none of them has healthy targets.
+func healthGatedTargetGroups(
+	ctx context.Context,
+	log gwlog.Logger,
+	cloud pkg_aws.Cloud,
+	tgs []*vpclattice.WeightedTargetGroup,
+) ([]*vpclattice.WeightedTargetGroup, error) {
+	if config.BackendHealthGatingInterval == 0 || len(tgs) < 2 {
+		return tgs, nil
+	}
+
+	var healthy []*vpclattice.WeightedTargetGroup
+	for _, tg := range tgs {
+		targets, err := cloud.Lattice().ListTargetsAsList(ctx, &vpclattice.ListTargetsInput{
+			TargetGroupIdentifier: tg.TargetGroupIdentifier,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if hasHealthyTarget(targets) {
+			healthy = append(healthy, tg)
+		} else {
+			log.Infof(ctx, "Target group %s has no healthy targets, leaving it out of the weighted target groups",
+				aws.StringValue(tg.TargetGroupIdentifier))
+		}
+	}
+	if len(healthy) == 0 {
+		log.Infof(ctx, "None of the weighted target groups has healthy targets, keeping all of them")
+		return tgs, nil
+	}
+	return healthy, nil
+}
+
+// targets of target groups with disabled health checks are UNAVAILABLE, and still receive traffic. Target groups left
+// out of every rule are not health checked, their targets are UNUSED, and INITIAL once they are used again. Both count
+// as healthy, otherwise a left out target group could never be re-added after it recovers. It is left out again if its
+// health checks fail once it is used.
+func hasHealthyTarget(targets []*vpclattice.TargetSummary) bool {
+	for _, target := range targets {
+		switch aws.StringValue(target.Status) {
+		case vpclattice.TargetStatusHealthy, vpclattice.TargetStatusUnavailable,
+			vpclattice.TargetStatusUnused, vpclattice.TargetStatusInitial:
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,12 @@
 package lattice
 
-import pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+import (
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+)
 
 var TestCloudConfig = pkg_aws.CloudConfig{
 	VpcId:       "vpc-id",
@@ -8,3 +14,11 @@ var TestCloudConfig = pkg_aws.CloudConfig{
 	Region:      "region",
 	ClusterName: "cluster",
 }
+
+// expects the ownership check before deletion of a resource managed by a controller with TestCloudConfig
+func expectOwnedBeforeDelete(mockLattice *services.MockLattice) *gomock.Call {
+	return mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).Return(
+		&vpclattice.ListTagsForResourceOutput{
+			Tags: pkg_aws.NewDefaultCloud(nil, TestCloudConfig).DefaultTags(),
+		}, nil)
+}
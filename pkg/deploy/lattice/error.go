@@ -7,3 +7,6 @@ const (
 )
 
 var RetryErr = errors.New(LATTICE_RETRY)
+
+// Returned when a service network does not exist and the controller is not allowed to create it
+var ErrServiceNetworkNotFound = errors.New("service network not found")
@@ -2,10 +2,12 @@ package lattice
 
 import (
 	"context"
+	"fmt"
 
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 )
 
@@ -34,7 +36,7 @@ func (m *IAMAuthPolicyManager) putSn(ctx context.Context, policy model.IAMAuthPo
 		return model.IAMAuthPolicyStatus{}, err
 	}
 	resourceId := *sn.SvcNetwork.Id
-	err = m.putPolicy(ctx, resourceId, policy.Policy)
+	err = m.putPolicyIfChanged(ctx, resourceId, policy)
 	if err != nil {
 		return model.IAMAuthPolicyStatus{}, err
 	}
@@ -51,7 +53,7 @@ func (m *IAMAuthPolicyManager) putSvc(ctx context.Context, policy model.IAMAuthP
 		return model.IAMAuthPolicyStatus{}, err
 	}
 	resourceId := *svc.Id
-	err = m.putPolicy(ctx, resourceId, policy.Policy)
+	err = m.putPolicyIfChanged(ctx, resourceId, policy)
 	if err != nil {
 		return model.IAMAuthPolicyStatus{}, err
 	}
@@ -62,6 +64,14 @@ func (m *IAMAuthPolicyManager) putSvc(ctx context.Context, policy model.IAMAuthP
 	return model.IAMAuthPolicyStatus{ResourceId: resourceId}, nil
 }
 
+// Policies applied unchanged to the same resource are not put again, see model.IAMAuthPolicy.Applied
+func (m *IAMAuthPolicyManager) putPolicyIfChanged(ctx context.Context, id string, policy model.IAMAuthPolicy) error {
+	if policy.Applied(id) {
+		return nil
+	}
+	return m.putPolicy(ctx, id, policy.Policy)
+}
+
 func (m *IAMAuthPolicyManager) putPolicy(ctx context.Context, id, policy string) error {
 	req := &vpclattice.PutAuthPolicyInput{
 		Policy:             &policy,
@@ -134,12 +144,17 @@ func (m *IAMAuthPolicyManager) disableSnIAMAuth(ctx context.Context, snId string
 	return m.setSnAuthType(ctx, snId, vpclattice.AuthTypeNone)
 }
 
+// Service networks do not report a status, Lattice rejects the auth type change with a conflict
+// while the service network is being updated. The change is retried instead of failing the policy.
 func (m *IAMAuthPolicyManager) setSnAuthType(ctx context.Context, snId, authType string) error {
 	req := &vpclattice.UpdateServiceNetworkInput{
 		AuthType:                 &authType,
 		ServiceNetworkIdentifier: &snId,
 	}
 	_, err := m.cloud.Lattice().UpdateServiceNetworkWithContext(ctx, req)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == vpclattice.ErrCodeConflictException {
+		return fmt.Errorf("%w: service network %s is not ready for auth type %s, %s", RetryErr, snId, authType, aerr.Message())
+	}
 	return err
 }
 
@@ -109,7 +109,7 @@ func (d *defaultListenerManager) create(ctx context.Context, latticeSvcId string
 	resp, err := d.cloud.Lattice().CreateListenerWithContext(ctx, &listenerInput)
 	if err != nil {
 		return model.ListenerStatus{},
-			fmt.Errorf("Failed CreateListener %s due to %s", aws.StringValue(listenerInput.Name), err)
+			fmt.Errorf("Failed CreateListener %s due to %w", aws.StringValue(listenerInput.Name), err)
 	}
 	d.log.Infof(ctx, "Success CreateListener %s, %s", aws.StringValue(resp.Name), aws.StringValue(resp.Id))
 
@@ -130,7 +130,7 @@ func (d *defaultListenerManager) update(ctx context.Context, latticeSvcId string
 		ServiceIdentifier:  aws.String(latticeSvcId),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update lattice listener %s due to %s", aws.StringValue(listener.Id), err)
+		return fmt.Errorf("failed to update lattice listener %s due to %w", aws.StringValue(listener.Id), err)
 	}
 	d.log.Infof(ctx, "Success update listener %s default action", aws.StringValue(listener.Id))
 	return nil
@@ -172,6 +172,10 @@ func (d *defaultListenerManager) getLatticeListenerDefaultAction(ctx context.Con
 		}
 		latticeTGs = append(latticeTGs, &latticeTG)
 	}
+	latticeTGs, err := healthGatedTargetGroups(ctx, d.log, d.cloud, latticeTGs)
+	if err != nil {
+		return nil, err
+	}
 
 	d.log.Debugf(ctx, "DefaultAction Forward target groups: %v", latticeTGs)
 	return &vpclattice.RuleAction{
@@ -269,6 +273,14 @@ func (d *defaultListenerManager) Delete(ctx context.Context, modelListener *mode
 	}
 
 	d.log.Debugf(ctx, "Deleting listener %s in service %s", modelListener.Status.Id, modelListener.Status.ServiceId)
+	if err := verifyOwnedBeforeDelete(ctx, d.cloud, modelListener.Status.ListenerArn); err != nil {
+		if services.IsLatticeAPINotFoundErr(err) {
+			d.log.Debugf(ctx, "Listener already deleted")
+			return nil
+		}
+		return err
+	}
+
 	listenerDeleteInput := vpclattice.DeleteListenerInput{
 		ServiceIdentifier:  aws.String(modelListener.Status.ServiceId),
 		ListenerIdentifier: aws.String(modelListener.Status.Id),
@@ -280,7 +292,7 @@ func (d *defaultListenerManager) Delete(ctx context.Context, modelListener *mode
 			d.log.Debugf(ctx, "Listener already deleted")
 			return nil
 		}
-		return fmt.Errorf("Failed DeleteListener %s, %s due to %s", modelListener.Status.Id, modelListener.Status.ServiceId, err)
+		return fmt.Errorf("Failed DeleteListener %s, %s due to %w", modelListener.Status.Id, modelListener.Status.ServiceId, err)
 	}
 
 	d.log.Infof(ctx, "Success DeleteListener %s, %s", modelListener.Status.Id, modelListener.Status.ServiceId)
@@ -326,7 +326,8 @@ func Test_DeleteListener(t *testing.T) {
 	})
 
 	t.Run("listener only success", func(t *testing.T) {
-		ml := &model.Listener{Status: &model.ListenerStatus{Id: "lid", ServiceId: "sid"}}
+		ml := &model.Listener{Status: &model.ListenerStatus{Id: "lid", ListenerArn: "larn", ServiceId: "sid"}}
+		expectOwnedBeforeDelete(mockLattice)
 		mockLattice.EXPECT().DeleteListenerWithContext(ctx, gomock.Any()).DoAndReturn(
 			func(ctx aws.Context, input *vpclattice.DeleteListenerInput, opts ...request.Option) (*vpclattice.DeleteListenerOutput, error) {
 				assert.Equal(t, "lid", *input.ListenerIdentifier)
@@ -337,6 +338,15 @@ func Test_DeleteListener(t *testing.T) {
 		lm := NewListenerManager(gwlog.FallbackLogger, cloud)
 		assert.NoError(t, lm.Delete(ctx, ml))
 	})
+
+	t.Run("untagged listener is not deleted", func(t *testing.T) {
+		ml := &model.Listener{Status: &model.ListenerStatus{Id: "lid", ListenerArn: "larn", ServiceId: "sid"}}
+		mockLattice.EXPECT().ListTagsForResourceWithContext(ctx, gomock.Any()).Return(
+			&vpclattice.ListTagsForResourceOutput{Tags: mocks.Tags{}}, nil)
+		mockLattice.EXPECT().DeleteListenerWithContext(gomock.Any(), gomock.Any()).Times(0)
+		lm := NewListenerManager(gwlog.FallbackLogger, cloud)
+		assert.ErrorIs(t, lm.Delete(ctx, ml), ErrNotOwned)
+	})
 }
 
 func Test_defaultListenerManager_needToUpdateDefaultAction(t *testing.T) {
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
@@ -52,7 +54,7 @@ func (l *listenerSynthesizer) Synthesize(ctx context.Context) error {
 		if listener.Spec.DefaultAction.Forward != nil {
 			// Fill the listener forward action target group ids
 			if err := l.tgManager.ResolveRuleTgIds(ctx, listener.Spec.DefaultAction.Forward, l.stack); err != nil {
-				return fmt.Errorf("failed to resolve rule tg ids, err = %v", err)
+				return fmt.Errorf("failed to resolve rule tg ids, err = %w", err)
 			}
 		}
 
@@ -80,6 +82,14 @@ func (l *listenerSynthesizer) Synthesize(ctx context.Context) error {
 
 	for _, latticeListenerAsModel := range latticeListenersAsModel {
 		if l.shouldDelete(latticeListenerAsModel, stackListeners) {
+			svc := &model.Service{}
+			if err := l.stack.GetResource(latticeListenerAsModel.Spec.StackServiceId, svc); err == nil &&
+				!utils.InMaintenanceWindow(svc.Spec.MaintenanceWindow, time.Now()) {
+				// the replaced listener keeps serving until the next maintenance window of the route
+				l.log.Infof(ctx, "Deferring deletion of listener %s until the maintenance window %s",
+					latticeListenerAsModel.Status.Id, svc.Spec.MaintenanceWindow)
+				continue
+			}
 			err = l.listenerMgr.Delete(ctx, latticeListenerAsModel)
 			if err != nil {
 				l.log.Infof(ctx, "Failed ListenerManager.Delete %s due to %s", latticeListenerAsModel.Status.Id, err)
@@ -100,6 +100,109 @@ func Test_SynthesizeListener_CreatNewHTTPListener_DeleteStaleHTTPSListener(t *te
 	assert.Nil(t, err)
 }
 
+func Test_SynthesizeListener_CreateNewHTTPListener_StaleListenerKeptOutsideMaintenanceWindow(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockListenerMgr := NewMockListenerManager(c)
+	mockTargetGroupManager := NewMockTargetGroupManager(c)
+	stack := core.NewDefaultStack(core.StackID{Name: "foo", Namespace: "bar"})
+
+	svc := &model.Service{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS:VPCServiceNetwork::Service", "stack-svc-id"),
+		// February 31st, now is always outside of the window
+		Spec:   model.ServiceSpec{MaintenanceWindow: "0 0 31 2 * 1h"},
+		Status: &model.ServiceStatus{Id: "svc-id"},
+	}
+	assert.NoError(t, stack.AddResource(svc))
+
+	l := &model.Listener{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS:VPCServiceNetwork::Listener", "l-id"),
+		Spec: model.ListenerSpec{
+			StackServiceId: "stack-svc-id",
+			Protocol:       vpclattice.ListenerProtocolHttp,
+			Port:           80,
+			DefaultAction: &model.DefaultAction{
+				FixedResponseStatusCode: aws.Int64(404),
+			},
+		},
+	}
+	assert.NoError(t, stack.AddResource(l))
+
+	// the new listener is created right away
+	mockListenerMgr.EXPECT().Upsert(ctx, l, svc).Return(
+		model.ListenerStatus{Id: "new-listener-id"}, nil)
+
+	mockListenerMgr.EXPECT().List(ctx, gomock.Any()).Return([]*vpclattice.ListenerSummary{
+		{
+			Id:       aws.String("stale-id"),
+			Protocol: aws.String(vpclattice.ListenerProtocolHttps),
+			Port:     aws.Int64(443),
+		},
+	}, nil)
+	// the replaced one is only deleted in the maintenance window
+	mockListenerMgr.EXPECT().Delete(ctx, gomock.Any()).Times(0)
+
+	ls := NewListenerSynthesizer(gwlog.FallbackLogger, mockListenerMgr, mockTargetGroupManager, stack)
+	err := ls.Synthesize(ctx)
+	assert.Nil(t, err)
+}
+
+func Test_SynthesizeListener_ListenerRemovedFromGateway_DeleteOnlyRemovedListener(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockListenerMgr := NewMockListenerManager(c)
+	mockTargetGroupManager := NewMockTargetGroupManager(c)
+	stack := core.NewDefaultStack(core.StackID{Name: "foo", Namespace: "bar"})
+
+	svc := &model.Service{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS:VPCServiceNetwork::Service", "stack-svc-id"),
+		Status:       &model.ServiceStatus{Id: "svc-id"},
+	}
+	assert.NoError(t, stack.AddResource(svc))
+
+	// the HTTP listener was removed from the gateway, only the HTTPS listener remains
+	l := &model.Listener{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS:VPCServiceNetwork::Listener", "l-id"),
+		Spec: model.ListenerSpec{
+			StackServiceId: "stack-svc-id",
+			Protocol:       vpclattice.ListenerProtocolHttps,
+			Port:           443,
+			DefaultAction: &model.DefaultAction{
+				FixedResponseStatusCode: aws.Int64(404),
+			},
+		},
+	}
+	assert.NoError(t, stack.AddResource(l))
+
+	mockListenerMgr.EXPECT().Upsert(ctx, l, svc).Return(
+		model.ListenerStatus{Id: "https-listener-id"}, nil)
+
+	mockListenerMgr.EXPECT().List(ctx, gomock.Any()).Return([]*vpclattice.ListenerSummary{
+		{
+			Id:       aws.String("http-listener-id"),
+			Protocol: aws.String(vpclattice.ListenerProtocolHttp),
+			Port:     aws.Int64(80),
+		},
+		{
+			Id:       aws.String("https-listener-id"),
+			Protocol: aws.String(vpclattice.ListenerProtocolHttps),
+			Port:     aws.Int64(443),
+		},
+	}, nil)
+
+	mockListenerMgr.EXPECT().Delete(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, ml *model.Listener) error {
+			assert.Equal(t, "http-listener-id", ml.Status.Id)
+			return nil
+		}).Times(1)
+
+	ls := NewListenerSynthesizer(gwlog.FallbackLogger, mockListenerMgr, mockTargetGroupManager, stack)
+	err := ls.Synthesize(ctx)
+	assert.Nil(t, err)
+}
+
 func Test_SynthesizeListener_CreatNewTLSPassthroughListener_DeleteStaleHTTPSListener(t *testing.T) {
 	c := gomock.NewController(t)
 	defer c.Finish()
@@ -0,0 +1,28 @@
+package lattice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+)
+
+var ErrNotOwned = errors.New("resource is not owned by this controller")
+
+// Verifies the resource has the ManagedBy tag of this controller before it is deleted. Unlike Cloud.TryOwn,
+// resources without the tag are not adopted, so a name collision never deletes a resource created elsewhere.
+func verifyOwnedBeforeDelete(ctx context.Context, cloud pkg_aws.Cloud, arn string) error {
+	owned, err := cloud.IsArnManaged(ctx, arn)
+	if err != nil {
+		return fmt.Errorf("failed to verify ownership of %s: %w", arn, err)
+	}
+	if !owned {
+		managedBy := aws.StringValue(cloud.DefaultTags()[pkg_aws.TagManagedBy])
+		return fmt.Errorf("%w: refusing to delete %s, it is not tagged %s=%s",
+			ErrNotOwned, arn, pkg_aws.TagManagedBy, managedBy)
+	}
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
@@ -82,14 +84,14 @@ func (r *defaultRuleManager) UpdatePriorities(ctx context.Context, svcId string,
 
 	_, err := r.cloud.Lattice().BatchUpdateRuleWithContext(ctx, &batchRuleInput)
 	if err != nil {
-		return fmt.Errorf("failed BatchUpdateRule %s, %s, due to %s", svcId, listenerId, err)
+		return fmt.Errorf("failed BatchUpdateRule %s, %s, due to %w", svcId, listenerId, err)
 	}
 
 	r.log.Infof(ctx, "Success BatchUpdateRule %s, %s", svcId, listenerId)
 	return nil
 }
 
-func (r *defaultRuleManager) buildLatticeRule(modelRule *model.Rule) (*vpclattice.GetRuleOutput, error) {
+func (r *defaultRuleManager) buildLatticeRule(ctx context.Context, modelRule *model.Rule) (*vpclattice.GetRuleOutput, error) {
 	gro := vpclattice.GetRuleOutput{
 		IsDefault: aws.Bool(false),
 		Priority:  aws.Int64(modelRule.Spec.Priority),
@@ -99,16 +101,7 @@ func (r *defaultRuleManager) buildLatticeRule(modelRule *model.Rule) (*vpclattic
 	updateMatchFromRule(&httpMatch, modelRule)
 	gro.Match = &vpclattice.RuleMatch{HttpMatch: &httpMatch}
 
-	// check if we have at least one valid target group
-	var hasValidTargetGroup bool
-	for _, tg := range modelRule.Spec.Action.TargetGroups {
-		if tg.LatticeTgId != model.InvalidBackendRefTgId {
-			hasValidTargetGroup = true
-			break
-		}
-	}
-
-	if hasValidTargetGroup {
+	if hasValidTargetGroup(modelRule) {
 		var latticeTGs []*vpclattice.WeightedTargetGroup
 		for _, ruleTg := range modelRule.Spec.Action.TargetGroups {
 			// skip any invalid TGs - eventually VPC Lattice may support weighted fixed response
@@ -124,6 +117,10 @@ func (r *defaultRuleManager) buildLatticeRule(modelRule *model.Rule) (*vpclattic
 
 			latticeTGs = append(latticeTGs, &latticeTG)
 		}
+		latticeTGs, err := healthGatedTargetGroups(ctx, r.log, r.cloud, latticeTGs)
+		if err != nil {
+			return nil, err
+		}
 
 		gro.Action = &vpclattice.RuleAction{
 			Forward: &vpclattice.ForwardAction{
@@ -131,7 +128,7 @@ func (r *defaultRuleManager) buildLatticeRule(modelRule *model.Rule) (*vpclattic
 			},
 		}
 	} else {
-		r.log.Debugf(context.TODO(), "There are no valid target groups, defaulting to 404 Fixed response")
+		r.log.Debugf(ctx, "There are no valid target groups, defaulting to 404 Fixed response")
 		gro.Action = &vpclattice.RuleAction{
 			FixedResponse: &vpclattice.FixedResponseAction{
 				StatusCode: aws.Int64(model.DefaultActionFixedResponseStatusCode),
@@ -164,7 +161,7 @@ func (r *defaultRuleManager) Upsert(
 	latticeListenerId := modelListener.Status.Id
 
 	// this allows us to make apples to apples comparisons with what's in Lattice already
-	latticeRuleFromModel, err := r.buildLatticeRule(modelRule)
+	latticeRuleFromModel, err := r.buildLatticeRule(ctx, modelRule)
 	if err != nil {
 		return model.RuleStatus{}, err
 	}
@@ -193,9 +190,24 @@ func (r *defaultRuleManager) Upsert(
 
 	if matchingRule == nil {
 		return r.create(ctx, currentLatticeRules, latticeRuleFromModel, latticeServiceId, latticeListenerId)
-	} else {
-		return r.updateIfNeeded(ctx, latticeRuleFromModel, matchingRule, latticeServiceId, latticeListenerId)
 	}
+	if !hasValidTargetGroup(modelRule) && config.NoValidBackends == config.NoValidBackendsPolicyKeepLastKnownGood &&
+		matchingRule.Action != nil && matchingRule.Action.Forward != nil {
+		r.log.Infof(ctx, "Rule %s has no valid backendRef, keeping its last known good target groups",
+			aws.StringValue(matchingRule.Id))
+		latticeRuleFromModel.Action = matchingRule.Action
+	}
+	return r.updateIfNeeded(ctx, latticeRuleFromModel, matchingRule, latticeServiceId, latticeListenerId)
+}
+
+// returns true when at least one backendRef of the rule resolved to a target group
+func hasValidTargetGroup(modelRule *model.Rule) bool {
+	for _, tg := range modelRule.Spec.Action.TargetGroups {
+		if tg.LatticeTgId != model.InvalidBackendRefTgId {
+			return true
+		}
+	}
+	return false
 }
 
 func (r *defaultRuleManager) updateIfNeeded(
@@ -236,7 +248,7 @@ func (r *defaultRuleManager) updateIfNeeded(
 
 	_, err := r.cloud.Lattice().UpdateRuleWithContext(ctx, &uri)
 	if err != nil {
-		return model.RuleStatus{}, fmt.Errorf("failed UpdateRule %d for %s, %s due to %s",
+		return model.RuleStatus{}, fmt.Errorf("failed UpdateRule %d for %s, %s due to %w",
 			ruleToUpdate.Priority, latticeListenerId, latticeSvcId, err)
 	}
 
@@ -257,7 +269,7 @@ func (r *defaultRuleManager) create(
 	// approach is not fully compliant with the gw spec
 	priority, err := r.nextAvailablePriority(currentLatticeRules)
 	if err != nil {
-		return model.RuleStatus{}, err
+		return model.RuleStatus{}, &model.RuleLimitExceededError{Limit: model.MaxRulePriority}
 	}
 	ruleToCreate.Priority = aws.Int64(priority)
 
@@ -273,7 +285,10 @@ func (r *defaultRuleManager) create(
 
 	res, err := r.cloud.Lattice().CreateRuleWithContext(ctx, &cri)
 	if err != nil {
-		return model.RuleStatus{}, fmt.Errorf("failed CreateRule %s, %s due to %s", latticeListenerId, latticeSvcId, err)
+		if services.IsServiceQuotaExceededError(err) {
+			return model.RuleStatus{}, &model.RuleLimitExceededError{Limit: config.ListenerRuleLimit}
+		}
+		return model.RuleStatus{}, fmt.Errorf("failed CreateRule %s, %s due to %w", latticeListenerId, latticeSvcId, err)
 	}
 
 	r.log.Infof(ctx, "Success CreateRule %s, %s", aws.StringValue(res.Name), aws.StringValue(res.Id))
@@ -366,15 +381,31 @@ func (r *defaultRuleManager) nextAvailablePriority(latticeRules []*vpclattice.Ge
 func (r *defaultRuleManager) Delete(ctx context.Context, ruleId string, serviceId string, listenerId string) error {
 	r.log.Debugf(ctx, "Deleting rule %s for listener %s and service %s", ruleId, listenerId, serviceId)
 
+	getResp, err := r.cloud.Lattice().GetRuleWithContext(ctx, &vpclattice.GetRuleInput{
+		ServiceIdentifier:  aws.String(serviceId),
+		ListenerIdentifier: aws.String(listenerId),
+		RuleIdentifier:     aws.String(ruleId),
+	})
+	if err != nil {
+		if services.IsLatticeAPINotFoundErr(err) {
+			r.log.Debugf(ctx, "Rule %s/%s/%s already deleted", serviceId, listenerId, ruleId)
+			return nil
+		}
+		return fmt.Errorf("failed GetRule %s/%s/%s due to %w", serviceId, listenerId, ruleId, err)
+	}
+	if err := verifyOwnedBeforeDelete(ctx, r.cloud, aws.StringValue(getResp.Arn)); err != nil {
+		return err
+	}
+
 	deleteInput := vpclattice.DeleteRuleInput{
 		ServiceIdentifier:  aws.String(serviceId),
 		ListenerIdentifier: aws.String(listenerId),
 		RuleIdentifier:     aws.String(ruleId),
 	}
 
-	_, err := r.cloud.Lattice().DeleteRuleWithContext(ctx, &deleteInput)
+	_, err = r.cloud.Lattice().DeleteRuleWithContext(ctx, &deleteInput)
 	if err != nil {
-		return fmt.Errorf("failed DeleteRule %s/%s/%s due to %s", serviceId, listenerId, ruleId, err)
+		return fmt.Errorf("failed DeleteRule %s/%s/%s due to %w", serviceId, listenerId, ruleId, err)
 	}
 
 	r.log.Infof(ctx, "Success DeleteRule %s/%s/%s", serviceId, listenerId, ruleId)
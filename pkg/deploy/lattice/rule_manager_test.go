@@ -2,15 +2,19 @@ package lattice
 
 import (
 	"context"
+	"fmt"
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func Test_Create(t *testing.T) {
@@ -295,6 +299,212 @@ func Test_Create(t *testing.T) {
 	})
 }
 
+func Test_UpsertNoValidBackendRefs(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	defer func() { config.NoValidBackends = config.NoValidBackendsPolicyTearDown }()
+
+	svc := &model.Service{
+		Status: &model.ServiceStatus{Id: "svc-id"},
+	}
+	l := &model.Listener{
+		Spec: model.ListenerSpec{
+			Port:     80,
+			Protocol: "HTTP",
+		},
+		Status: &model.ListenerStatus{Id: "listener-id"},
+	}
+	// all the Services of the rule backendRefs were deleted
+	r := &model.Rule{
+		Spec: model.RuleSpec{
+			Priority: 1,
+			Method:   "POST",
+			Action: model.RuleAction{
+				TargetGroups: []*model.RuleTargetGroup{
+					{LatticeTgId: model.InvalidBackendRefTgId, Weight: 1},
+					{LatticeTgId: model.InvalidBackendRefTgId, Weight: 1},
+				},
+			},
+		},
+	}
+	lastKnownGood := &vpclattice.RuleAction{
+		Forward: &vpclattice.ForwardAction{
+			TargetGroups: []*vpclattice.WeightedTargetGroup{
+				{TargetGroupIdentifier: aws.String("tg-1"), Weight: aws.Int64(1)},
+				{TargetGroupIdentifier: aws.String("tg-2"), Weight: aws.Int64(1)},
+			},
+		},
+	}
+	existingRules := func() []*vpclattice.GetRuleOutput {
+		return []*vpclattice.GetRuleOutput{
+			{
+				Id:  aws.String("existing-id"),
+				Arn: aws.String("existing-arn"),
+				Match: &vpclattice.RuleMatch{
+					HttpMatch: &vpclattice.HttpMatch{
+						Method: aws.String("POST"),
+					},
+				},
+				Action:   lastKnownGood,
+				Name:     aws.String("existing-name"),
+				Priority: aws.Int64(1),
+			},
+		}
+	}
+
+	t.Run("teardown responds with 404", func(t *testing.T) {
+		config.NoValidBackends = config.NoValidBackendsPolicyTearDown
+		mockLattice.EXPECT().GetRulesAsList(ctx, gomock.Any()).Return(existingRules(), nil)
+		mockLattice.EXPECT().UpdateRuleWithContext(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, input *vpclattice.UpdateRuleInput, i ...interface{}) (*vpclattice.UpdateRuleOutput, error) {
+				assert.Nil(t, input.Action.Forward)
+				assert.Equal(t, int64(404), aws.Int64Value(input.Action.FixedResponse.StatusCode))
+				return &vpclattice.UpdateRuleOutput{Id: aws.String("existing-id")}, nil
+			})
+
+		rm := NewRuleManager(gwlog.FallbackLogger, cloud)
+		ruleStatus, err := rm.Upsert(ctx, r, l, svc)
+		assert.Nil(t, err)
+		assert.Equal(t, "existing-arn", ruleStatus.Arn)
+	})
+
+	t.Run("keep last known good keeps forwarding", func(t *testing.T) {
+		config.NoValidBackends = config.NoValidBackendsPolicyKeepLastKnownGood
+		mockLattice.EXPECT().GetRulesAsList(ctx, gomock.Any()).Return(existingRules(), nil)
+		mockLattice.EXPECT().UpdateRuleWithContext(ctx, gomock.Any()).Times(0)
+
+		rm := NewRuleManager(gwlog.FallbackLogger, cloud)
+		ruleStatus, err := rm.Upsert(ctx, r, l, svc)
+		assert.Nil(t, err)
+		assert.Equal(t, "existing-arn", ruleStatus.Arn)
+	})
+
+	t.Run("keep last known good creates new rules with 404", func(t *testing.T) {
+		config.NoValidBackends = config.NoValidBackendsPolicyKeepLastKnownGood
+		mockLattice.EXPECT().GetRulesAsList(ctx, gomock.Any()).Return([]*vpclattice.GetRuleOutput{}, nil)
+		mockLattice.EXPECT().CreateRuleWithContext(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, input *vpclattice.CreateRuleInput, i ...interface{}) (*vpclattice.CreateRuleOutput, error) {
+				assert.Equal(t, int64(404), aws.Int64Value(input.Action.FixedResponse.StatusCode))
+				return &vpclattice.CreateRuleOutput{Arn: aws.String("arn"), Id: aws.String("id")}, nil
+			})
+
+		rm := NewRuleManager(gwlog.FallbackLogger, cloud)
+		ruleStatus, err := rm.Upsert(ctx, r, l, svc)
+		assert.Nil(t, err)
+		assert.Equal(t, "arn", ruleStatus.Arn)
+	})
+}
+
+func Test_UpsertHealthGatedWeights(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	defer func() { config.BackendHealthGatingInterval = 0 }()
+
+	svc := &model.Service{
+		Status: &model.ServiceStatus{Id: "svc-id"},
+	}
+	l := &model.Listener{
+		Spec: model.ListenerSpec{
+			Port:     80,
+			Protocol: "HTTP",
+		},
+		Status: &model.ListenerStatus{Id: "listener-id"},
+	}
+	r := &model.Rule{
+		Spec: model.RuleSpec{
+			Priority: 1,
+			Method:   "POST",
+			Action: model.RuleAction{
+				TargetGroups: []*model.RuleTargetGroup{
+					{LatticeTgId: "tg-healthy", Weight: 20},
+					{LatticeTgId: "tg-unhealthy", Weight: 80},
+					{LatticeTgId: "tg-no-health-checks", Weight: 10},
+				},
+			},
+		},
+	}
+	targets := func(statuses ...string) []*vpclattice.TargetSummary {
+		var summaries []*vpclattice.TargetSummary
+		for _, status := range statuses {
+			summaries = append(summaries, &vpclattice.TargetSummary{Id: aws.String("10.0.0.1"), Status: aws.String(status)})
+		}
+		return summaries
+	}
+	expectTargets := func(tgId string, statuses ...string) {
+		mockLattice.EXPECT().ListTargetsAsList(ctx, &vpclattice.ListTargetsInput{
+			TargetGroupIdentifier: aws.String(tgId),
+		}).Return(targets(statuses...), nil)
+	}
+	createdTargetGroups := func() []*vpclattice.WeightedTargetGroup {
+		var tgs []*vpclattice.WeightedTargetGroup
+		mockLattice.EXPECT().GetRulesAsList(ctx, gomock.Any()).Return([]*vpclattice.GetRuleOutput{}, nil)
+		mockLattice.EXPECT().CreateRuleWithContext(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, input *vpclattice.CreateRuleInput, i ...interface{}) (*vpclattice.CreateRuleOutput, error) {
+				tgs = input.Action.Forward.TargetGroups
+				return &vpclattice.CreateRuleOutput{Arn: aws.String("arn"), Id: aws.String("id")}, nil
+			})
+
+		rm := NewRuleManager(gwlog.FallbackLogger, cloud)
+		_, err := rm.Upsert(ctx, r, l, svc)
+		assert.Nil(t, err)
+		return tgs
+	}
+
+	t.Run("disabled keeps backends without healthy targets", func(t *testing.T) {
+		config.BackendHealthGatingInterval = 0
+		mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Times(0)
+
+		assert.Len(t, createdTargetGroups(), 3)
+	})
+
+	t.Run("backend without healthy targets is left out", func(t *testing.T) {
+		config.BackendHealthGatingInterval = time.Minute
+		expectTargets("tg-healthy", vpclattice.TargetStatusHealthy, vpclattice.TargetStatusUnhealthy)
+		expectTargets("tg-unhealthy", vpclattice.TargetStatusUnhealthy, vpclattice.TargetStatusDraining)
+		expectTargets("tg-no-health-checks", vpclattice.TargetStatusUnavailable)
+
+		assert.Equal(t, []*vpclattice.WeightedTargetGroup{
+			{TargetGroupIdentifier: aws.String("tg-healthy"), Weight: aws.Int64(20)},
+			{TargetGroupIdentifier: aws.String("tg-no-health-checks"), Weight: aws.Int64(10)},
+		}, createdTargetGroups())
+	})
+
+	t.Run("all backends are kept when none has healthy targets", func(t *testing.T) {
+		config.BackendHealthGatingInterval = time.Minute
+		expectTargets("tg-healthy")
+		expectTargets("tg-unhealthy", vpclattice.TargetStatusUnhealthy)
+		expectTargets("tg-no-health-checks", vpclattice.TargetStatusDraining)
+
+		assert.Len(t, createdTargetGroups(), 3)
+	})
+
+	t.Run("recovered backend is re-added", func(t *testing.T) {
+		config.BackendHealthGatingInterval = time.Minute
+		expectTargets("tg-healthy", vpclattice.TargetStatusHealthy)
+		expectTargets("tg-unhealthy", vpclattice.TargetStatusUnhealthy)
+		expectTargets("tg-no-health-checks", vpclattice.TargetStatusUnavailable)
+		assert.Len(t, createdTargetGroups(), 2)
+
+		// the left out target group is not in any rule, it is not health checked anymore
+		expectTargets("tg-healthy", vpclattice.TargetStatusHealthy)
+		expectTargets("tg-unhealthy", vpclattice.TargetStatusUnused, vpclattice.TargetStatusUnused)
+		expectTargets("tg-no-health-checks", vpclattice.TargetStatusUnavailable)
+		assert.Len(t, createdTargetGroups(), 3)
+
+		// health checks resume once it is used again
+		expectTargets("tg-healthy", vpclattice.TargetStatusHealthy)
+		expectTargets("tg-unhealthy", vpclattice.TargetStatusInitial)
+		expectTargets("tg-no-health-checks", vpclattice.TargetStatusUnavailable)
+		assert.Len(t, createdTargetGroups(), 3)
+	})
+}
+
 func Test_CreateWithTempPriority(t *testing.T) {
 	c := gomock.NewController(t)
 	defer c.Finish()
@@ -358,6 +568,69 @@ func Test_CreateWithTempPriority(t *testing.T) {
 	assert.Equal(t, expectedPriority, ruleStatus.Priority)
 }
 
+func Test_CreateRuleLimitExceeded(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+	svc := &model.Service{
+		Status: &model.ServiceStatus{Id: "svc-id"},
+	}
+
+	l := &model.Listener{
+		Spec: model.ListenerSpec{
+			Port:     80,
+			Protocol: "HTTP",
+		},
+		Status: &model.ListenerStatus{Id: "listener-id"},
+	}
+
+	r := &model.Rule{
+		Spec: model.RuleSpec{
+			Priority: 1,
+			Method:   "POST",
+		},
+	}
+
+	t.Run("lattice quota exceeded", func(t *testing.T) {
+		mockLattice.EXPECT().GetRulesAsList(ctx, gomock.Any()).Return([]*vpclattice.GetRuleOutput{}, nil)
+		mockLattice.EXPECT().CreateRuleWithContext(ctx, gomock.Any()).Return(nil,
+			awserr.New(vpclattice.ErrCodeServiceQuotaExceededException, "rules per listener quota exceeded", nil))
+
+		rm := NewRuleManager(gwlog.FallbackLogger, cloud)
+		_, err := rm.Upsert(ctx, r, l, svc)
+
+		rle := &model.RuleLimitExceededError{}
+		assert.ErrorAs(t, err, &rle)
+		assert.Equal(t, config.ListenerRuleLimit, rle.Limit)
+	})
+
+	t.Run("no available priority", func(t *testing.T) {
+		var existingRules []*vpclattice.GetRuleOutput
+		for i := 1; i <= model.MaxRulePriority; i++ {
+			existingRules = append(existingRules, &vpclattice.GetRuleOutput{
+				Id: aws.String(fmt.Sprintf("existing-id-%d", i)),
+				Match: &vpclattice.RuleMatch{
+					HttpMatch: &vpclattice.HttpMatch{
+						Method: aws.String("GET"),
+					},
+				},
+				Priority: aws.Int64(int64(i)),
+			})
+		}
+		mockLattice.EXPECT().GetRulesAsList(ctx, gomock.Any()).Return(existingRules, nil)
+
+		rm := NewRuleManager(gwlog.FallbackLogger, cloud)
+		_, err := rm.Upsert(ctx, r, l, svc)
+
+		rle := &model.RuleLimitExceededError{}
+		assert.ErrorAs(t, err, &rle)
+		assert.Equal(t, model.MaxRulePriority, rle.Limit)
+	})
+}
+
 func Test_UpdatePriorities(t *testing.T) {
 	c := gomock.NewController(t)
 	defer c.Finish()
@@ -399,3 +672,39 @@ func Test_UpdatePriorities(t *testing.T) {
 	err := rm.UpdatePriorities(ctx, "svc-id", "l-id", rules)
 	assert.Nil(t, err)
 }
+
+func Test_DeleteRule(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	rm := NewRuleManager(gwlog.FallbackLogger, cloud)
+
+	getRuleInput := &vpclattice.GetRuleInput{
+		ServiceIdentifier:  aws.String("svc-id"),
+		ListenerIdentifier: aws.String("listener-id"),
+		RuleIdentifier:     aws.String("rule-id"),
+	}
+	getRuleOutput := &vpclattice.GetRuleOutput{Arn: aws.String("rule-arn"), Id: aws.String("rule-id")}
+
+	t.Run("owned rule is deleted", func(t *testing.T) {
+		mockLattice.EXPECT().GetRuleWithContext(ctx, getRuleInput).Return(getRuleOutput, nil)
+		expectOwnedBeforeDelete(mockLattice)
+		mockLattice.EXPECT().DeleteRuleWithContext(ctx, gomock.Any()).Return(&vpclattice.DeleteRuleOutput{}, nil)
+		assert.Nil(t, rm.Delete(ctx, "rule-id", "svc-id", "listener-id"))
+	})
+
+	t.Run("untagged rule is not deleted", func(t *testing.T) {
+		mockLattice.EXPECT().GetRuleWithContext(ctx, getRuleInput).Return(getRuleOutput, nil)
+		mockLattice.EXPECT().ListTagsForResourceWithContext(ctx, gomock.Any()).Return(
+			&vpclattice.ListTagsForResourceOutput{Tags: mocks.Tags{}}, nil)
+		mockLattice.EXPECT().DeleteRuleWithContext(gomock.Any(), gomock.Any()).Times(0)
+		assert.ErrorIs(t, rm.Delete(ctx, "rule-id", "svc-id", "listener-id"), ErrNotOwned)
+	})
+
+	t.Run("already deleted rule", func(t *testing.T) {
+		mockLattice.EXPECT().GetRuleWithContext(ctx, getRuleInput).Return(nil, &vpclattice.ResourceNotFoundException{})
+		assert.Nil(t, rm.Delete(ctx, "rule-id", "svc-id", "listener-id"))
+	})
+}
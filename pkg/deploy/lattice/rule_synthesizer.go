@@ -87,7 +87,7 @@ func (r *ruleSynthesizer) createOrUpdateRules(ctx context.Context, rule *model.R
 	}
 	status, err := r.ruleManager.Upsert(ctx, rule, stackListener, stackSvc)
 	if err != nil {
-		return fmt.Errorf("Failed RuleManager.Upsert due to %s", err)
+		return fmt.Errorf("Failed RuleManager.Upsert due to %w", err)
 	}
 	rule.Status = &status
 
@@ -113,7 +113,7 @@ func (r *ruleSynthesizer) deleteStaleLatticeRules(ctx context.Context, snlRules
 	for snl := range snlRules {
 		allLatticeRules, err := r.ruleManager.List(ctx, snl.SvcId, snl.ListenerId)
 		if err != nil {
-			return fmt.Errorf("failed RuleManager.List %s/%s, due to %s", snl.SvcId, snl.ListenerId, err)
+			return fmt.Errorf("failed RuleManager.List %s/%s, due to %w", snl.SvcId, snl.ListenerId, err)
 		}
 
 		activeRules := snlRules[snl]
@@ -129,7 +129,7 @@ func (r *ruleSynthesizer) deleteStaleLatticeRules(ctx context.Context, snlRules
 				err := r.ruleManager.Delete(ctx, ruleId, snl.SvcId, snl.ListenerId)
 				if err != nil {
 					delErr = errors.Join(delErr,
-						fmt.Errorf("failed RuleManager.Delete %s/%s/%s, due to %s", snl.SvcId, snl.ListenerId, ruleId, err))
+						fmt.Errorf("failed RuleManager.Delete %s/%s/%s, due to %w", snl.SvcId, snl.ListenerId, ruleId, err))
 				}
 			}
 		}
@@ -154,7 +154,7 @@ func (r *ruleSynthesizer) adjustPriorities(ctx context.Context, snlStackRules ma
 				err := r.ruleManager.UpdatePriorities(ctx, snl.SvcId, snl.ListenerId, rulesToUpdate)
 				if err != nil {
 					updateErr = errors.Join(updateErr,
-						fmt.Errorf("failed RuleManager.UpdatePriorities for rules %+v due to %s", resRule, err))
+						fmt.Errorf("failed RuleManager.UpdatePriorities for rules %+v due to %w", resRule, err))
 				}
 				break
 			}
@@ -2,6 +2,7 @@ package lattice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"golang.org/x/exp/maps"
 
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
@@ -52,7 +54,7 @@ func (m *defaultServiceManager) createServiceAndAssociate(ctx context.Context, s
 	createSvcReq := m.newCreateSvcReq(svc)
 	createSvcResp, err := m.cloud.Lattice().CreateServiceWithContext(ctx, createSvcReq)
 	if err != nil {
-		return ServiceInfo{}, fmt.Errorf("failed CreateService %s due to %s", aws.StringValue(createSvcReq.Name), err)
+		return ServiceInfo{}, fmt.Errorf("failed CreateService %s due to %w", aws.StringValue(createSvcReq.Name), err)
 	}
 
 	m.log.Infof(ctx, "Success CreateService %s %s",
@@ -81,7 +83,7 @@ func (m *defaultServiceManager) createAssociation(ctx context.Context, svcId *st
 	}
 	assocResp, err := m.cloud.Lattice().CreateServiceNetworkServiceAssociationWithContext(ctx, assocReq)
 	if err != nil {
-		return fmt.Errorf("failed CreateServiceNetworkServiceAssociation %s %s due to %s",
+		return fmt.Errorf("failed CreateServiceNetworkServiceAssociation %s %s due to %w",
 			aws.StringValue(assocReq.ServiceNetworkIdentifier), aws.StringValue(assocReq.ServiceIdentifier), err)
 	}
 	m.log.Infof(ctx, "Success CreateServiceNetworkServiceAssociation %s %s",
@@ -100,6 +102,7 @@ func (m *defaultServiceManager) newCreateSvcReq(svc *Service) *CreateSvcReq {
 		Name: &svcName,
 		Tags: m.cloud.DefaultTagsMergedWith(svc.Spec.ToTags()),
 	}
+	maps.Copy(req.Tags, svc.Spec.InfrastructureTags)
 
 	if svc.Spec.CustomerDomainName != "" {
 		req.CustomDomainName = &svc.Spec.CustomerDomainName
@@ -158,7 +161,10 @@ func (m *defaultServiceManager) checkAndUpdateTags(ctx context.Context, svc *Ser
 		return services.NewConflictError("service", svc.Spec.RouteName+"/"+svc.Spec.RouteNamespace,
 			fmt.Sprintf("Found existing resource with conflicting service name: %s", *svcSum.Arn))
 	}
-	return nil
+	if svc.IsDeleted {
+		return nil
+	}
+	return upsertTags(ctx, m.cloud, *svcSum.Arn, tagsResp.Tags, svc.Spec.InfrastructureTags)
 }
 
 func (m *defaultServiceManager) updateServiceAndAssociations(ctx context.Context, svc *Service, svcSum *SvcSummary) (ServiceInfo, error) {
@@ -333,7 +339,7 @@ func (m *defaultServiceManager) deleteAssociation(ctx context.Context, assocArn
 	delReq := &DelSnSvcAssocReq{ServiceNetworkServiceAssociationIdentifier: assocArn}
 	_, err := m.cloud.Lattice().DeleteServiceNetworkServiceAssociationWithContext(ctx, delReq)
 	if err != nil {
-		return fmt.Errorf("failed DeleteServiceNetworkServiceAssociation %s due to %s",
+		return fmt.Errorf("failed DeleteServiceNetworkServiceAssociation %s due to %w",
 			aws.StringValue(assocArn), err)
 	}
 
@@ -347,16 +353,80 @@ func (m *defaultServiceManager) deleteService(ctx context.Context, svc *SvcSumma
 	}
 	_, err := m.cloud.Lattice().DeleteServiceWithContext(ctx, &delInput)
 	if err != nil {
-		return fmt.Errorf("failed DeleteService %s due to %s", aws.StringValue(svc.Id), err)
+		return fmt.Errorf("failed DeleteService %s due to %w", aws.StringValue(svc.Id), err)
 	}
 
 	m.log.Infof(ctx, "Success DeleteService %s", *svc.Id)
 	return nil
 }
 
+// returns svc with the service networks selected by tags replaced by the name of the selected service network
+func (m *defaultServiceManager) resolveServiceNetworkSelectors(ctx context.Context, svc *Service) (*Service, error) {
+	if len(svc.Spec.ServiceNetworkSelectors) == 0 {
+		return svc, nil
+	}
+	resolved := *svc
+	resolved.Spec.ServiceNetworkNames = make([]string, len(svc.Spec.ServiceNetworkNames))
+	for i, snName := range svc.Spec.ServiceNetworkNames {
+		selector, ok := svc.Spec.ServiceNetworkSelectors[snName]
+		if !ok {
+			resolved.Spec.ServiceNetworkNames[i] = snName
+			continue
+		}
+		snInfo, err := m.cloud.Lattice().FindServiceNetworkByTags(ctx, selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find service network of gateway %s: %w", snName, err)
+		}
+		resolved.Spec.ServiceNetworkNames[i] = aws.StringValue(snInfo.SvcNetwork.Name)
+	}
+	return &resolved, nil
+}
+
+// findService returns the service of the model, services shared by other accounts can have the same name. Of the
+// duplicates, the one tagged as managed by this controller is selected, otherwise the first one of
+// services.FindServices. The duplicates are logged.
+func (m *defaultServiceManager) findService(ctx context.Context, svc *Service) (*SvcSummary, error) {
+	svcMatches, err := m.cloud.Lattice().FindServices(ctx, svc.LatticeServiceName())
+	if err != nil {
+		return nil, err
+	}
+	if len(svcMatches) == 1 {
+		return svcMatches[0], nil
+	}
+
+	selected := svcMatches[0]
+	arns := make([]string, len(svcMatches))
+	for i, svcMatch := range svcMatches {
+		arns[i] = aws.StringValue(svcMatch.Arn)
+	}
+	for _, svcMatch := range svcMatches {
+		// tags of services shared by other accounts may not be readable, these are not selected
+		managed, err := m.cloud.IsArnManaged(ctx, aws.StringValue(svcMatch.Arn))
+		if err != nil {
+			m.log.Debugf(ctx, "Failed to read tags of service %s due to %s", aws.StringValue(svcMatch.Arn), err)
+			continue
+		}
+		if managed {
+			selected = svcMatch
+			break
+		}
+	}
+	m.log.Warnw(ctx, "Found several VPC Lattice services with the same name",
+		"name", svc.LatticeServiceName(),
+		"arns", arns,
+		"selected", aws.StringValue(selected.Arn),
+	)
+	return selected, nil
+}
+
 // Create or update Service and ServiceNetwork-Service associations
 func (m *defaultServiceManager) Upsert(ctx context.Context, svc *Service) (ServiceInfo, error) {
-	svcSum, err := m.cloud.Lattice().FindService(ctx, svc.LatticeServiceName())
+	svc, err := m.resolveServiceNetworkSelectors(ctx, svc)
+	if err != nil {
+		return ServiceInfo{}, err
+	}
+
+	svcSum, err := m.findService(ctx, svc)
 	if err != nil && !services.IsNotFoundError(err) {
 		return ServiceInfo{}, err
 	}
@@ -378,7 +448,7 @@ func (m *defaultServiceManager) Upsert(ctx context.Context, svc *Service) (Servi
 }
 
 func (m *defaultServiceManager) Delete(ctx context.Context, svc *Service) error {
-	svcSum, err := m.cloud.Lattice().FindService(ctx, svc.LatticeServiceName())
+	svcSum, err := m.findService(ctx, svc)
 	if err != nil {
 		if services.IsNotFoundError(err) {
 			return nil // already deleted
@@ -387,6 +457,15 @@ func (m *defaultServiceManager) Delete(ctx context.Context, svc *Service) error
 		}
 	}
 
+	err = verifyOwnedBeforeDelete(ctx, m.cloud, *svcSum.Arn)
+	if errors.Is(err, ErrNotOwned) {
+		m.log.Infof(ctx, "Skipping VPC Lattice resource deletion of service %s: %s", svc.LatticeServiceName(), err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
 	err = m.checkAndUpdateTags(ctx, svc, svcSum)
 	if err != nil {
 		m.log.Infof(ctx, "Service %s is either invalid or not owned. Skipping VPC Lattice resource deletion.", svc.LatticeServiceName())
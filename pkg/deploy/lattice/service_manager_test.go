@@ -45,7 +45,7 @@ func TestServiceManagerInteg(t *testing.T) {
 
 		// service does not exist in lattice
 		mockLattice.EXPECT().
-			FindService(gomock.Any(), gomock.Any()).
+			FindServices(gomock.Any(), gomock.Any()).
 			Return(nil, mocks.NewNotFoundError("", "")).
 			Times(1)
 
@@ -97,6 +97,68 @@ func TestServiceManagerInteg(t *testing.T) {
 		assert.Equal(t, "arn", status.Arn)
 	})
 
+	// The gateway selects its service network by tags, the service is associated to the selected one
+	t.Run("create new service and association by service network selector", func(t *testing.T) {
+		svc := &Service{
+			Spec: model.ServiceSpec{
+				ServiceTagFields: model.ServiceTagFields{
+					RouteName:      "svc",
+					RouteNamespace: "ns",
+					RouteType:      core.HttpRouteType,
+				},
+				ServiceNetworkNames:     []string{"prod"},
+				ServiceNetworkSelectors: map[string]map[string]string{"prod": {"environment": "prod"}},
+			},
+		}
+
+		mockLattice.EXPECT().
+			FindServices(gomock.Any(), gomock.Any()).
+			Return(nil, mocks.NewNotFoundError("", "")).
+			Times(1)
+		mockLattice.EXPECT().
+			CreateServiceWithContext(gomock.Any(), gomock.Any()).
+			Return(&CreateSvcResp{
+				Arn:      aws.String("arn"),
+				DnsEntry: &vpclattice.DnsEntry{DomainName: aws.String("dns")},
+				Id:       aws.String("svc-id"),
+			}, nil).
+			Times(1)
+		mockLattice.EXPECT().
+			FindServiceNetworkByTags(gomock.Any(), map[string]string{"environment": "prod"}).
+			Return(&mocks.ServiceNetworkInfo{
+				SvcNetwork: vpclattice.ServiceNetworkSummary{
+					Arn:  aws.String("prod-a1b2-arn"),
+					Id:   aws.String("prod-a1b2-id"),
+					Name: aws.String("prod-a1b2"),
+				},
+			}, nil).
+			Times(1)
+		mockLattice.EXPECT().
+			FindServiceNetwork(gomock.Any(), "prod-a1b2").
+			Return(&mocks.ServiceNetworkInfo{
+				SvcNetwork: vpclattice.ServiceNetworkSummary{
+					Arn:  aws.String("prod-a1b2-arn"),
+					Id:   aws.String("prod-a1b2-id"),
+					Name: aws.String("prod-a1b2"),
+				},
+			}, nil).
+			Times(1)
+		mockLattice.EXPECT().
+			CreateServiceNetworkServiceAssociationWithContext(gomock.Any(), gomock.Any()).
+			DoAndReturn(
+				func(_ context.Context, req *CreateSnSvcAssocReq, _ ...interface{}) (*CreateSnSvcAssocResp, error) {
+					assert.Equal(t, "prod-a1b2-id", *req.ServiceNetworkIdentifier)
+					return &CreateSnSvcAssocResp{
+						Status: aws.String(vpclattice.ServiceNetworkServiceAssociationStatusActive),
+					}, nil
+				}).
+			Times(1)
+
+		_, err := m.Upsert(ctx, svc)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"prod"}, svc.Spec.ServiceNetworkNames)
+	})
+
 	// Update is more complex than create, we need to apply diff for Sn-Svc associations
 	// This test covers creation/deletion for multiple SN's
 	// sn-keep - no changes
@@ -124,12 +186,12 @@ func TestServiceManagerInteg(t *testing.T) {
 
 		// service exists in lattice
 		mockLattice.EXPECT().
-			FindService(gomock.Any(), gomock.Any()).
-			Return(&vpclattice.ServiceSummary{
+			FindServices(gomock.Any(), gomock.Any()).
+			Return([]*vpclattice.ServiceSummary{{
 				Arn:  aws.String("svc-arn"),
 				Id:   aws.String("svc-id"),
 				Name: aws.String(svc.LatticeServiceName()),
-			}, nil).
+			}}, nil).
 			Times(1)
 
 		mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
@@ -227,12 +289,12 @@ func TestServiceManagerInteg(t *testing.T) {
 
 		// service exists in lattice
 		mockLattice.EXPECT().
-			FindService(gomock.Any(), gomock.Any()).
-			Return(&vpclattice.ServiceSummary{
+			FindServices(gomock.Any(), gomock.Any()).
+			Return([]*vpclattice.ServiceSummary{{
 				Arn:  aws.String("svc-arn"),
 				Id:   aws.String("svc-id"),
 				Name: aws.String(svc.LatticeServiceName()),
-			}, nil).
+			}}, nil).
 			Times(1)
 
 		mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
@@ -267,6 +329,55 @@ func TestServiceManagerInteg(t *testing.T) {
 		assert.Equal(t, "svc-arn", status.Arn)
 	})
 
+	t.Run("updating infrastructure tags", func(t *testing.T) {
+		svc := &Service{
+			Spec: model.ServiceSpec{
+				ServiceTagFields: model.ServiceTagFields{
+					RouteName:      "svc",
+					RouteNamespace: "ns",
+					RouteType:      core.HttpRouteType,
+				},
+				InfrastructureTags: mocks.Tags{
+					"team":  aws.String("payments"),
+					"owner": aws.String("alice"),
+					"env":   aws.String("prod"),
+				},
+			},
+		}
+
+		mockLattice.EXPECT().
+			FindServices(gomock.Any(), gomock.Any()).
+			Return([]*vpclattice.ServiceSummary{{
+				Arn:  aws.String("svc-arn"),
+				Id:   aws.String("svc-id"),
+				Name: aws.String(svc.LatticeServiceName()),
+			}}, nil).
+			Times(1)
+
+		existingTags := cl.DefaultTagsMergedWith(svc.Spec.ToTags())
+		existingTags["team"] = aws.String("checkout")
+		existingTags["env"] = aws.String("prod")
+		existingTags["added-out-of-band"] = aws.String("value")
+		mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
+			Return(&vpclattice.ListTagsForResourceOutput{Tags: existingTags}, nil).
+			Times(1)
+
+		// only changed and missing tags are updated, other tags are kept
+		mockLattice.EXPECT().TagResourceWithContext(gomock.Any(), gomock.Eq(&vpclattice.TagResourceInput{
+			ResourceArn: aws.String("svc-arn"),
+			Tags: mocks.Tags{
+				"team":  aws.String("payments"),
+				"owner": aws.String("alice"),
+			},
+		})).Times(1)
+
+		mockLattice.EXPECT().ListServiceNetworkServiceAssociationsAsList(gomock.Any(), gomock.Any()).Times(1)
+
+		status, err := m.Upsert(ctx, svc)
+		assert.Nil(t, err)
+		assert.Equal(t, "svc-arn", status.Arn)
+	})
+
 	t.Run("delete service and association", func(t *testing.T) {
 		svc := &Service{
 			Spec: model.ServiceSpec{
@@ -280,12 +391,12 @@ func TestServiceManagerInteg(t *testing.T) {
 
 		// service exists
 		mockLattice.EXPECT().
-			FindService(gomock.Any(), gomock.Any()).
-			Return(&vpclattice.ServiceSummary{
+			FindServices(gomock.Any(), gomock.Any()).
+			Return([]*vpclattice.ServiceSummary{{
 				Arn:  aws.String("svc-arn"),
 				Id:   aws.String("svc-id"),
 				Name: aws.String(svc.LatticeServiceName()),
-			}, nil)
+			}}, nil)
 
 		mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
 			DoAndReturn(func(_ context.Context, req *vpclattice.ListTagsForResourceInput, _ ...interface{}) (*vpclattice.ListTagsForResourceOutput, error) {
@@ -293,7 +404,7 @@ func TestServiceManagerInteg(t *testing.T) {
 					Tags: cl.DefaultTagsMergedWith(svc.Spec.ToTags()),
 				}, nil
 			}).
-			Times(1) // for service only
+			Times(2) // ownership check and tag check of the service
 
 		mockLattice.EXPECT().
 			ListServiceNetworkServiceAssociationsAsList(gomock.Any(), gomock.Any()).
@@ -337,6 +448,156 @@ func TestServiceManagerInteg(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("untagged service is not deleted", func(t *testing.T) {
+		svc := &Service{
+			Spec: model.ServiceSpec{
+				ServiceTagFields: model.ServiceTagFields{
+					RouteName:      "svc",
+					RouteNamespace: "ns",
+				},
+			},
+		}
+
+		mockLattice.EXPECT().
+			FindServices(gomock.Any(), gomock.Any()).
+			Return([]*vpclattice.ServiceSummary{{
+				Arn:  aws.String("svc-arn"),
+				Id:   aws.String("svc-id"),
+				Name: aws.String(svc.LatticeServiceName()),
+			}}, nil)
+		mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
+			Return(&vpclattice.ListTagsForResourceOutput{Tags: svc.Spec.ToTags()}, nil)
+
+		// the service is neither adopted nor deleted
+		mockLattice.EXPECT().TagResourceWithContext(gomock.Any(), gomock.Any()).Times(0)
+		mockLattice.EXPECT().DeleteServiceWithContext(gomock.Any(), gomock.Any()).Times(0)
+
+		err := m.Delete(ctx, svc)
+		assert.Nil(t, err)
+	})
+
+	t.Run("managed one of duplicate services is used", func(t *testing.T) {
+		svc := &Service{
+			Spec: model.ServiceSpec{
+				ServiceTagFields: model.ServiceTagFields{
+					RouteName:      "svc",
+					RouteNamespace: "ns",
+				},
+			},
+		}
+
+		mockLattice.EXPECT().
+			FindServices(gomock.Any(), gomock.Any()).
+			Return([]*vpclattice.ServiceSummary{{
+				Arn:  aws.String("shared-svc-arn"),
+				Id:   aws.String("shared-svc-id"),
+				Name: aws.String(svc.LatticeServiceName()),
+			}, {
+				Arn:  aws.String("svc-arn"),
+				Id:   aws.String("svc-id"),
+				Name: aws.String(svc.LatticeServiceName()),
+			}}, nil)
+		var taggedArns []string
+		mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, input *vpclattice.ListTagsForResourceInput, opts ...interface{}) (*vpclattice.ListTagsForResourceOutput, error) {
+				taggedArns = append(taggedArns, aws.StringValue(input.ResourceArn))
+				return &vpclattice.ListTagsForResourceOutput{Tags: svc.Spec.ToTags()}, nil
+			}).
+			AnyTimes()
+		mockLattice.EXPECT().DeleteServiceWithContext(gomock.Any(), gomock.Any()).Times(0)
+
+		err := m.Delete(ctx, svc)
+		assert.Nil(t, err)
+		// none of the duplicates is managed, the first one is used and not deleted
+		assert.Equal(t, []string{"shared-svc-arn", "svc-arn", "shared-svc-arn"}, taggedArns)
+	})
+
+}
+
+func TestFindService(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.Background()
+
+	mockLattice := mocks.NewMockLattice(c)
+	cfg := pkg_aws.CloudConfig{VpcId: "vpc-id", AccountId: "account-id", ClusterName: "cluster"}
+	cl := pkg_aws.NewDefaultCloud(mockLattice, cfg)
+	m := NewServiceManager(gwlog.FallbackLogger, cl)
+
+	svc := &Service{
+		Spec: model.ServiceSpec{
+			ServiceTagFields: model.ServiceTagFields{
+				RouteName:      "svc",
+				RouteNamespace: "ns",
+			},
+		},
+	}
+	summary := func(arn string) *SvcSummary {
+		return &SvcSummary{Arn: aws.String(arn), Name: aws.String(svc.LatticeServiceName())}
+	}
+	otherManagedTags := mocks.Tags{pkg_aws.TagManagedBy: aws.String("other-account/other-cluster/vpc")}
+
+	tests := []struct {
+		name        string
+		matches     []*SvcSummary
+		tags        map[string]mocks.Tags
+		tagsErr     map[string]error
+		expectedArn string
+	}{
+		{
+			name:        "single service, tags are not read",
+			matches:     []*SvcSummary{summary("svc-arn")},
+			expectedArn: "svc-arn",
+		},
+		{
+			name:    "service tagged by the controller is selected",
+			matches: []*SvcSummary{summary("local-svc-arn"), summary("shared-svc-arn")},
+			tags: map[string]mocks.Tags{
+				"local-svc-arn":  otherManagedTags,
+				"shared-svc-arn": cl.DefaultTags(),
+			},
+			expectedArn: "shared-svc-arn",
+		},
+		{
+			name:    "services with unreadable tags are skipped",
+			matches: []*SvcSummary{summary("shared-svc-arn"), summary("local-svc-arn")},
+			tags: map[string]mocks.Tags{
+				"local-svc-arn": cl.DefaultTags(),
+			},
+			tagsErr: map[string]error{
+				"shared-svc-arn": errors.New("access denied"),
+			},
+			expectedArn: "local-svc-arn",
+		},
+		{
+			name:    "first service is selected when none is tagged by the controller",
+			matches: []*SvcSummary{summary("local-svc-arn"), summary("shared-svc-arn")},
+			tags: map[string]mocks.Tags{
+				"local-svc-arn":  otherManagedTags,
+				"shared-svc-arn": {},
+			},
+			expectedArn: "local-svc-arn",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLattice.EXPECT().FindServices(gomock.Any(), svc.LatticeServiceName()).Return(tt.matches, nil)
+			mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, input *vpclattice.ListTagsForResourceInput, opts ...interface{}) (*vpclattice.ListTagsForResourceOutput, error) {
+					arn := aws.StringValue(input.ResourceArn)
+					if err, ok := tt.tagsErr[arn]; ok {
+						return nil, err
+					}
+					return &vpclattice.ListTagsForResourceOutput{Tags: tt.tags[arn]}, nil
+				}).
+				MaxTimes(len(tt.tags) + len(tt.tagsErr))
+
+			svcSum, err := m.findService(ctx, svc)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedArn, aws.StringValue(svcSum.Arn))
+		})
+	}
 }
 
 func TestCreateSvcReq(t *testing.T) {
@@ -352,6 +613,7 @@ func TestCreateSvcReq(t *testing.T) {
 		},
 		CustomerDomainName: "dns",
 		CustomerCertARN:    "cert-arn",
+		InfrastructureTags: mocks.Tags{"team": aws.String("payments")},
 	}
 
 	svcModel := &model.Service{
@@ -360,7 +622,9 @@ func TestCreateSvcReq(t *testing.T) {
 
 	req := m.newCreateSvcReq(svcModel)
 
-	assert.Equal(t, req.Tags, cl.DefaultTagsMergedWith(spec.ToTags()))
+	expectedTags := cl.DefaultTagsMergedWith(spec.ToTags())
+	expectedTags["team"] = aws.String("payments")
+	assert.Equal(t, req.Tags, expectedTags)
 	assert.Equal(t, *req.Name, svcModel.LatticeServiceName())
 	assert.Equal(t, *req.CustomDomainName, spec.CustomerDomainName)
 	assert.Equal(t, *req.CertificateArn, spec.CustomerCertARN)
@@ -0,0 +1,194 @@
+package lattice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"k8s.io/apimachinery/pkg/types"
+
+	an_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+//go:generate mockgen -destination service_network_log_subscription_manager_mock.go -package lattice github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice ServiceNetworkLogSubscriptionManager
+
+// ServiceNetworkLogSubscriptionManager manages the set of access log subscriptions of a service network
+// that belong to a single ServiceNetworkLogPolicy. Subscriptions are identified by the policy tag.
+type ServiceNetworkLogSubscriptionManager interface {
+	Upsert(ctx context.Context, snName string, policyName types.NamespacedName, destinationArns []string) ([]string, error)
+	Delete(ctx context.Context, snName string, policyName types.NamespacedName) error
+}
+
+type defaultServiceNetworkLogSubscriptionManager struct {
+	log   gwlog.Logger
+	cloud an_aws.Cloud
+}
+
+func NewServiceNetworkLogSubscriptionManager(
+	log gwlog.Logger,
+	cloud an_aws.Cloud,
+) *defaultServiceNetworkLogSubscriptionManager {
+	return &defaultServiceNetworkLogSubscriptionManager{
+		log:   log,
+		cloud: cloud,
+	}
+}
+
+// Upsert makes the policy's subscriptions match the given destinations and returns their ARNs.
+// Subscriptions of removed destinations are deleted first, as the service network only allows one
+// subscription per destination type and a replacement destination would otherwise conflict.
+func (m *defaultServiceNetworkLogSubscriptionManager) Upsert(
+	ctx context.Context,
+	snName string,
+	policyName types.NamespacedName,
+	destinationArns []string,
+) ([]string, error) {
+	sn, err := m.cloud.Lattice().FindServiceNetwork(ctx, snName)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := m.listPolicySubscriptions(ctx, sn.SvcNetwork.Arn, policyName)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := make(map[string]bool, len(destinationArns))
+	for _, destinationArn := range destinationArns {
+		desired[destinationArn] = true
+	}
+	for destinationArn, alsArn := range existing {
+		if desired[destinationArn] {
+			continue
+		}
+		m.log.Debugf(ctx, "Deleting access log subscription %s of service network %s for removed destination %s",
+			alsArn, snName, destinationArn)
+		if err := m.deleteSubscription(ctx, alsArn); err != nil {
+			return nil, err
+		}
+	}
+
+	alsArns := make([]string, 0, len(destinationArns))
+	for _, destinationArn := range destinationArns {
+		if alsArn, ok := existing[destinationArn]; ok {
+			alsArns = append(alsArns, alsArn)
+			continue
+		}
+		alsArn, err := m.createSubscription(ctx, sn.SvcNetwork.Arn, snName, policyName, destinationArn)
+		if err != nil {
+			return nil, err
+		}
+		alsArns = append(alsArns, alsArn)
+	}
+	return alsArns, nil
+}
+
+// Delete removes all subscriptions of the policy. A missing service network is not an error.
+func (m *defaultServiceNetworkLogSubscriptionManager) Delete(
+	ctx context.Context,
+	snName string,
+	policyName types.NamespacedName,
+) error {
+	sn, err := m.cloud.Lattice().FindServiceNetwork(ctx, snName)
+	if err != nil {
+		if services.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	existing, err := m.listPolicySubscriptions(ctx, sn.SvcNetwork.Arn, policyName)
+	if err != nil {
+		return err
+	}
+	for _, alsArn := range existing {
+		if err := m.deleteSubscription(ctx, alsArn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *defaultServiceNetworkLogSubscriptionManager) createSubscription(
+	ctx context.Context,
+	snArn *string,
+	snName string,
+	policyName types.NamespacedName,
+	destinationArn string,
+) (string, error) {
+	tags := m.cloud.DefaultTagsMergedWith(services.Tags{
+		lattice.ServiceNetworkLogPolicyTagKey: aws.String(policyName.String()),
+	})
+	createALSInput := &vpclattice.CreateAccessLogSubscriptionInput{
+		ResourceIdentifier: snArn,
+		DestinationArn:     aws.String(destinationArn),
+		Tags:               tags,
+	}
+	createALSOutput, err := m.cloud.Lattice().CreateAccessLogSubscriptionWithContext(ctx, createALSInput)
+	if err == nil {
+		return aws.StringValue(createALSOutput.Arn), nil
+	}
+
+	switch e := err.(type) {
+	case *vpclattice.AccessDeniedException:
+		return "", services.NewInvalidError(e.Message())
+	case *vpclattice.ResourceNotFoundException:
+		if aws.StringValue(e.ResourceType) == "SERVICE_NETWORK" {
+			return "", services.NewNotFoundError(string(lattice.ServiceNetworkSourceType), snName)
+		}
+		return "", services.NewInvalidError(e.Message())
+	case *vpclattice.ConflictException:
+		return "", services.NewConflictError(string(lattice.ServiceNetworkSourceType), snName, e.Message())
+	default:
+		return "", err
+	}
+}
+
+func (m *defaultServiceNetworkLogSubscriptionManager) deleteSubscription(ctx context.Context, alsArn string) error {
+	deleteALSInput := &vpclattice.DeleteAccessLogSubscriptionInput{
+		AccessLogSubscriptionIdentifier: aws.String(alsArn),
+	}
+	_, err := m.cloud.Lattice().DeleteAccessLogSubscriptionWithContext(ctx, deleteALSInput)
+	if err != nil && !services.IsLatticeAPINotFoundErr(err) {
+		return err
+	}
+	return nil
+}
+
+// Returns the ARNs of the subscriptions created by this controller for the policy, keyed by destination ARN.
+func (m *defaultServiceNetworkLogSubscriptionManager) listPolicySubscriptions(
+	ctx context.Context,
+	snArn *string,
+	policyName types.NamespacedName,
+) (map[string]string, error) {
+	vpcLatticeSess := m.cloud.Lattice()
+	listALSInput := &vpclattice.ListAccessLogSubscriptionsInput{
+		ResourceIdentifier: snArn,
+	}
+	listALSOutput, err := vpcLatticeSess.ListAccessLogSubscriptionsWithContext(ctx, listALSInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access log subscriptions of %s: %w", aws.StringValue(snArn), err)
+	}
+
+	managedBy := aws.StringValue(m.cloud.DefaultTags()[an_aws.TagManagedBy])
+	subscriptions := make(map[string]string)
+	for _, als := range listALSOutput.Items {
+		listTagsInput := &vpclattice.ListTagsForResourceInput{
+			ResourceArn: als.Arn,
+		}
+		listTagsOutput, err := vpcLatticeSess.ListTagsForResourceWithContext(ctx, listTagsInput)
+		if err != nil {
+			return nil, err
+		}
+		if aws.StringValue(listTagsOutput.Tags[an_aws.TagManagedBy]) != managedBy {
+			continue
+		}
+		if aws.StringValue(listTagsOutput.Tags[lattice.ServiceNetworkLogPolicyTagKey]) != policyName.String() {
+			continue
+		}
+		subscriptions[aws.StringValue(als.DestinationArn)] = aws.StringValue(als.Arn)
+	}
+	return subscriptions, nil
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice (interfaces: ServiceNetworkLogSubscriptionManager)
+
+// Package lattice is a generated GoMock package.
+package lattice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// MockServiceNetworkLogSubscriptionManager is a mock of ServiceNetworkLogSubscriptionManager interface.
+type MockServiceNetworkLogSubscriptionManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceNetworkLogSubscriptionManagerMockRecorder
+}
+
+// MockServiceNetworkLogSubscriptionManagerMockRecorder is the mock recorder for MockServiceNetworkLogSubscriptionManager.
+type MockServiceNetworkLogSubscriptionManagerMockRecorder struct {
+	mock *MockServiceNetworkLogSubscriptionManager
+}
+
+// NewMockServiceNetworkLogSubscriptionManager creates a new mock instance.
+func NewMockServiceNetworkLogSubscriptionManager(ctrl *gomock.Controller) *MockServiceNetworkLogSubscriptionManager {
+	mock := &MockServiceNetworkLogSubscriptionManager{ctrl: ctrl}
+	mock.recorder = &MockServiceNetworkLogSubscriptionManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceNetworkLogSubscriptionManager) EXPECT() *MockServiceNetworkLogSubscriptionManagerMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockServiceNetworkLogSubscriptionManager) Delete(arg0 context.Context, arg1 string, arg2 types.NamespacedName) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceNetworkLogSubscriptionManagerMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceNetworkLogSubscriptionManager)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Upsert mocks base method.
+func (m *MockServiceNetworkLogSubscriptionManager) Upsert(arg0 context.Context, arg1 string, arg2 types.NamespacedName, arg3 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockServiceNetworkLogSubscriptionManagerMockRecorder) Upsert(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockServiceNetworkLogSubscriptionManager)(nil).Upsert), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,177 @@
+package lattice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+
+	an_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	s3SubscriptionArn         = "arn:aws:vpc-lattice:us-west-2:123456789012:accesslogsubscription/als-s3000000000000000"
+	cloudWatchSubscriptionArn = "arn:aws:vpc-lattice:us-west-2:123456789012:accesslogsubscription/als-cw000000000000000"
+	firehoseSubscriptionArn   = "arn:aws:vpc-lattice:us-west-2:123456789012:accesslogsubscription/als-fh000000000000000"
+	foreignSubscriptionArn    = "arn:aws:vpc-lattice:us-west-2:123456789012:accesslogsubscription/als-ot000000000000000"
+)
+
+var serviceNetworkLogPolicyNamespacedName = types.NamespacedName{
+	Namespace: "test-namespace",
+	Name:      "sn-log-policy",
+}
+
+func TestServiceNetworkLogSubscriptionManager(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := services.NewMockLattice(c)
+	cloud := an_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	policyTags := cloud.DefaultTagsMergedWith(services.Tags{
+		lattice.ServiceNetworkLogPolicyTagKey: aws.String(serviceNetworkLogPolicyNamespacedName.String()),
+	})
+	otherPolicyTags := cloud.DefaultTagsMergedWith(services.Tags{
+		lattice.ServiceNetworkLogPolicyTagKey: aws.String("test-namespace/other"),
+	})
+	serviceNetworkInfo := &services.ServiceNetworkInfo{
+		SvcNetwork: vpclattice.ServiceNetworkSummary{
+			Arn:  aws.String(serviceNetworkArn),
+			Name: aws.String(sourceName),
+		},
+	}
+	listALSInput := &vpclattice.ListAccessLogSubscriptionsInput{
+		ResourceIdentifier: aws.String(serviceNetworkArn),
+	}
+	createInput := func(destinationArn string) *vpclattice.CreateAccessLogSubscriptionInput {
+		return &vpclattice.CreateAccessLogSubscriptionInput{
+			ResourceIdentifier: aws.String(serviceNetworkArn),
+			DestinationArn:     aws.String(destinationArn),
+			Tags:               policyTags,
+		}
+	}
+	deleteInput := func(alsArn string) *vpclattice.DeleteAccessLogSubscriptionInput {
+		return &vpclattice.DeleteAccessLogSubscriptionInput{
+			AccessLogSubscriptionIdentifier: aws.String(alsArn),
+		}
+	}
+	summary := func(alsArn, destinationArn string) *vpclattice.AccessLogSubscriptionSummary {
+		return &vpclattice.AccessLogSubscriptionSummary{
+			Arn:            aws.String(alsArn),
+			DestinationArn: aws.String(destinationArn),
+			ResourceArn:    aws.String(serviceNetworkArn),
+		}
+	}
+	expectTags := func(alsArn string, tags services.Tags) {
+		mockLattice.EXPECT().ListTagsForResourceWithContext(ctx, &vpclattice.ListTagsForResourceInput{
+			ResourceArn: aws.String(alsArn),
+		}).Return(&vpclattice.ListTagsForResourceOutput{Tags: tags}, nil)
+	}
+
+	t.Run("Upsert_NoExistingSubscriptions_CreatesOnePerDestination", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().ListAccessLogSubscriptionsWithContext(ctx, listALSInput).
+			Return(&vpclattice.ListAccessLogSubscriptionsOutput{}, nil)
+		mockLattice.EXPECT().CreateAccessLogSubscriptionWithContext(ctx, createInput(s3DestinationArn)).
+			Return(&vpclattice.CreateAccessLogSubscriptionOutput{Arn: aws.String(s3SubscriptionArn)}, nil)
+		mockLattice.EXPECT().CreateAccessLogSubscriptionWithContext(ctx, createInput(cloudWatchDestinationArn)).
+			Return(&vpclattice.CreateAccessLogSubscriptionOutput{Arn: aws.String(cloudWatchSubscriptionArn)}, nil)
+
+		mgr := NewServiceNetworkLogSubscriptionManager(gwlog.FallbackLogger, cloud)
+		arns, err := mgr.Upsert(ctx, sourceName, serviceNetworkLogPolicyNamespacedName,
+			[]string{s3DestinationArn, cloudWatchDestinationArn})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{s3SubscriptionArn, cloudWatchSubscriptionArn}, arns)
+	})
+
+	t.Run("Upsert_ChangedDestinations_KeepsUnchangedAndReplacesRemoved", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().ListAccessLogSubscriptionsWithContext(ctx, listALSInput).
+			Return(&vpclattice.ListAccessLogSubscriptionsOutput{
+				Items: []*vpclattice.AccessLogSubscriptionSummary{
+					summary(s3SubscriptionArn, s3DestinationArn),
+					summary(cloudWatchSubscriptionArn, cloudWatchDestinationArn),
+					summary(foreignSubscriptionArn, firehoseDestinationArn),
+				},
+			}, nil)
+		expectTags(s3SubscriptionArn, policyTags)
+		expectTags(cloudWatchSubscriptionArn, policyTags)
+		expectTags(foreignSubscriptionArn, otherPolicyTags)
+		gomock.InOrder(
+			mockLattice.EXPECT().DeleteAccessLogSubscriptionWithContext(ctx, deleteInput(cloudWatchSubscriptionArn)).
+				Return(&vpclattice.DeleteAccessLogSubscriptionOutput{}, nil),
+			mockLattice.EXPECT().CreateAccessLogSubscriptionWithContext(ctx, createInput(firehoseDestinationArn)).
+				Return(&vpclattice.CreateAccessLogSubscriptionOutput{Arn: aws.String(firehoseSubscriptionArn)}, nil),
+		)
+
+		mgr := NewServiceNetworkLogSubscriptionManager(gwlog.FallbackLogger, cloud)
+		arns, err := mgr.Upsert(ctx, sourceName, serviceNetworkLogPolicyNamespacedName,
+			[]string{s3DestinationArn, firehoseDestinationArn})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{s3SubscriptionArn, firehoseSubscriptionArn}, arns)
+	})
+
+	t.Run("Upsert_DestinationTypeTakenByOtherSubscription_ReturnsConflictError", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().ListAccessLogSubscriptionsWithContext(ctx, listALSInput).
+			Return(&vpclattice.ListAccessLogSubscriptionsOutput{
+				Items: []*vpclattice.AccessLogSubscriptionSummary{
+					summary(foreignSubscriptionArn, "arn:aws:s3:::other"),
+				},
+			}, nil)
+		expectTags(foreignSubscriptionArn, services.Tags{})
+		mockLattice.EXPECT().CreateAccessLogSubscriptionWithContext(ctx, createInput(s3DestinationArn)).
+			Return(nil, &vpclattice.ConflictException{})
+
+		mgr := NewServiceNetworkLogSubscriptionManager(gwlog.FallbackLogger, cloud)
+		arns, err := mgr.Upsert(ctx, sourceName, serviceNetworkLogPolicyNamespacedName, []string{s3DestinationArn})
+		assert.Nil(t, arns)
+		assert.True(t, services.IsConflictError(err))
+	})
+
+	t.Run("Upsert_AccessDenied_ReturnsInvalidError", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().ListAccessLogSubscriptionsWithContext(ctx, listALSInput).
+			Return(&vpclattice.ListAccessLogSubscriptionsOutput{}, nil)
+		mockLattice.EXPECT().CreateAccessLogSubscriptionWithContext(ctx, createInput(s3DestinationArn)).
+			Return(nil, &vpclattice.AccessDeniedException{})
+
+		mgr := NewServiceNetworkLogSubscriptionManager(gwlog.FallbackLogger, cloud)
+		_, err := mgr.Upsert(ctx, sourceName, serviceNetworkLogPolicyNamespacedName, []string{s3DestinationArn})
+		assert.True(t, services.IsInvalidError(err))
+	})
+
+	t.Run("Delete_DeletesOnlySubscriptionsOfPolicy", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().ListAccessLogSubscriptionsWithContext(ctx, listALSInput).
+			Return(&vpclattice.ListAccessLogSubscriptionsOutput{
+				Items: []*vpclattice.AccessLogSubscriptionSummary{
+					summary(s3SubscriptionArn, s3DestinationArn),
+					summary(foreignSubscriptionArn, cloudWatchDestinationArn),
+				},
+			}, nil)
+		expectTags(s3SubscriptionArn, policyTags)
+		expectTags(foreignSubscriptionArn, otherPolicyTags)
+		mockLattice.EXPECT().DeleteAccessLogSubscriptionWithContext(ctx, deleteInput(s3SubscriptionArn)).
+			Return(nil, &vpclattice.ResourceNotFoundException{})
+
+		mgr := NewServiceNetworkLogSubscriptionManager(gwlog.FallbackLogger, cloud)
+		err := mgr.Delete(ctx, sourceName, serviceNetworkLogPolicyNamespacedName)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Delete_ServiceNetworkNotFound_ReturnsNil", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).
+			Return(nil, services.NewNotFoundError("Service network", sourceName))
+
+		mgr := NewServiceNetworkLogSubscriptionManager(gwlog.FallbackLogger, cloud)
+		err := mgr.Delete(ctx, sourceName, serviceNetworkLogPolicyNamespacedName)
+		assert.Nil(t, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"golang.org/x/exp/slices"
 
@@ -22,10 +23,12 @@ import (
 //go:generate mockgen -destination service_network_manager_mock.go -package lattice github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice ServiceNetworkManager
 
 type ServiceNetworkManager interface {
-	UpsertVpcAssociation(ctx context.Context, snName string, sgIds []*string) (string, error)
+	UpsertVpcAssociation(ctx context.Context, snName string, sgIds []*string, tags services.Tags, managedTagKeys []string) (string, error)
 	DeleteVpcAssociation(ctx context.Context, snName string) error
+	SwitchVpcAssociation(ctx context.Context, fromSnName string, toSnName string, sgIds []*string, tags services.Tags) (model.VpcAssociationSwitchoverPhase, error)
 
 	CreateOrUpdate(ctx context.Context, serviceNetwork *model.ServiceNetwork) (model.ServiceNetworkStatus, error)
+	UpsertInfrastructureTags(ctx context.Context, snInfo *services.ServiceNetworkInfo, tags services.Tags) error
 }
 
 func NewDefaultServiceNetworkManager(log gwlog.Logger, cloud pkg_aws.Cloud) *defaultServiceNetworkManager {
@@ -40,7 +43,10 @@ type defaultServiceNetworkManager struct {
 	cloud pkg_aws.Cloud
 }
 
-func (m *defaultServiceNetworkManager) UpsertVpcAssociation(ctx context.Context, snName string, sgIds []*string) (string, error) {
+// UpsertVpcAssociation associates the cluster VPC with the service network, or updates the security groups and tags
+// of the existing association. managedTagKeys are the keys of the tags applied by a previous upsert, only these are
+// removed from the association when they are no longer in tags, the other tags were added outside of the controller.
+func (m *defaultServiceNetworkManager) UpsertVpcAssociation(ctx context.Context, snName string, sgIds []*string, tags services.Tags, managedTagKeys []string) (string, error) {
 	sn, err := m.cloud.Lattice().FindServiceNetwork(ctx, snName)
 	if err != nil {
 		return "", err
@@ -52,7 +58,13 @@ func (m *defaultServiceNetworkManager) UpsertVpcAssociation(ctx context.Context,
 	}
 	if snva != nil {
 		// association is active
-		owned, err := m.cloud.TryOwn(ctx, *snva.Arn)
+		tagsResp, err := m.cloud.Lattice().ListTagsForResourceWithContext(ctx, &vpclattice.ListTagsForResourceInput{
+			ResourceArn: snva.Arn,
+		})
+		if err != nil {
+			return "", err
+		}
+		owned, err := m.cloud.TryOwnFromTags(ctx, *snva.Arn, tagsResp.Tags)
 		if err != nil {
 			return "", err
 		}
@@ -64,15 +76,14 @@ func (m *defaultServiceNetworkManager) UpsertVpcAssociation(ctx context.Context,
 		if err != nil {
 			return "", err
 		}
+		err = m.updateServiceNetworkVpcAssociationTags(ctx, *snva.Arn, tagsResp.Tags, tags, managedTagKeys)
+		if err != nil {
+			return "", err
+		}
 		return *snva.Arn, nil
 	} else {
-		req := vpclattice.CreateServiceNetworkVpcAssociationInput{
-			ServiceNetworkIdentifier: sn.SvcNetwork.Id,
-			VpcIdentifier:            &config.VpcID,
-			SecurityGroupIds:         sgIds,
-			Tags:                     m.cloud.DefaultTags(),
-		}
-		resp, err := m.cloud.Lattice().CreateServiceNetworkVpcAssociationWithContext(ctx, &req)
+		req := m.newVpcAssociationInput(sn.SvcNetwork.Id, sgIds, tags)
+		resp, err := m.cloud.Lattice().CreateServiceNetworkVpcAssociationWithContext(ctx, req)
 		if err != nil {
 			return "", err
 		}
@@ -130,6 +141,100 @@ func (m *defaultServiceNetworkManager) DeleteVpcAssociation(ctx context.Context,
 	return nil
 }
 
+// SwitchVpcAssociation moves the cluster VPC association from one service network to another without a gap.
+// The VPC is associated with the new service network first, and the old association is removed only after
+// the new one becomes active. Each call advances the switchover by at most one step and reports the current phase,
+// the caller is expected to call it again until the phase is completed. The new association is created with the
+// security groups and tags of UpsertVpcAssociation, so it does not lose the settings of the VpcAssociationPolicy.
+func (m *defaultServiceNetworkManager) SwitchVpcAssociation(ctx context.Context, fromSnName string, toSnName string, sgIds []*string, tags services.Tags) (model.VpcAssociationSwitchoverPhase, error) {
+	toSn, err := m.cloud.Lattice().FindServiceNetwork(ctx, toSnName)
+	if err != nil {
+		return "", err
+	}
+	toSnva, err := m.getVpcAssociation(ctx, *toSn.SvcNetwork.Id)
+	if err != nil {
+		return "", err
+	}
+	if toSnva == nil || aws.StringValue(toSnva.Status) == vpclattice.ServiceNetworkVpcAssociationStatusCreateFailed {
+		m.log.Infof(ctx, "Associating ServiceNetwork %s with VPC %s for switchover from %s", toSnName, config.VpcID, fromSnName)
+		_, err = m.cloud.Lattice().CreateServiceNetworkVpcAssociationWithContext(ctx, m.newVpcAssociationInput(toSn.SvcNetwork.Id, sgIds, tags))
+		if err != nil {
+			return "", err
+		}
+		return model.VpcAssociationSwitchoverAssociating, nil
+	}
+	if aws.StringValue(toSnva.Status) != vpclattice.ServiceNetworkVpcAssociationStatusActive {
+		m.log.Debugf(ctx, "Waiting for vpc association %s to become active, status: %s",
+			aws.StringValue(toSnva.Arn), aws.StringValue(toSnva.Status))
+		return model.VpcAssociationSwitchoverAssociating, nil
+	}
+
+	fromSn, err := m.cloud.Lattice().FindServiceNetwork(ctx, fromSnName)
+	if err != nil {
+		if services.IsNotFoundError(err) {
+			return model.VpcAssociationSwitchoverCompleted, nil
+		}
+		return "", err
+	}
+	fromSnva, err := m.getVpcAssociation(ctx, *fromSn.SvcNetwork.Id)
+	if err != nil {
+		return "", err
+	}
+	if fromSnva == nil {
+		return model.VpcAssociationSwitchoverCompleted, nil
+	}
+	switch aws.StringValue(fromSnva.Status) {
+	case vpclattice.ServiceNetworkVpcAssociationStatusCreateInProgress,
+		vpclattice.ServiceNetworkVpcAssociationStatusUpdateInProgress,
+		vpclattice.ServiceNetworkVpcAssociationStatusDeleteInProgress:
+		// let the in-flight mutation finish before deleting
+		return model.VpcAssociationSwitchoverDisassociating, nil
+	}
+
+	owned, err := m.cloud.IsArnManaged(ctx, *fromSnva.Arn)
+	if err != nil {
+		return "", err
+	}
+	if !owned {
+		m.log.Infof(ctx, "Association %s for %s not owned by controller, skipping disassociation", *fromSnva.Arn, fromSnName)
+		return model.VpcAssociationSwitchoverCompleted, nil
+	}
+	m.log.Infof(ctx, "Disassociating ServiceNetwork %s from VPC %s after switchover to %s", fromSnName, config.VpcID, toSnName)
+	_, err = m.cloud.Lattice().DeleteServiceNetworkVpcAssociationWithContext(ctx, &vpclattice.DeleteServiceNetworkVpcAssociationInput{
+		ServiceNetworkVpcAssociationIdentifier: fromSnva.Id,
+	})
+	if err != nil {
+		return "", err
+	}
+	return model.VpcAssociationSwitchoverDisassociating, nil
+}
+
+// newVpcAssociationInput returns the request associating the cluster VPC with the service network. The user tags
+// are merged with the controller tags, which cannot be overridden.
+func (m *defaultServiceNetworkManager) newVpcAssociationInput(snId *string, sgIds []*string, tags services.Tags) *vpclattice.CreateServiceNetworkVpcAssociationInput {
+	return &vpclattice.CreateServiceNetworkVpcAssociationInput{
+		ServiceNetworkIdentifier: snId,
+		VpcIdentifier:            &config.VpcID,
+		SecurityGroupIds:         sgIds,
+		Tags:                     m.cloud.DefaultTagsMergedWith(userTags(tags)),
+	}
+}
+
+// getVpcAssociation returns the association between the service network and the cluster VPC regardless of its status.
+func (m *defaultServiceNetworkManager) getVpcAssociation(ctx context.Context, serviceNetworkId string) (*vpclattice.ServiceNetworkVpcAssociationSummary, error) {
+	resp, err := m.cloud.Lattice().ListServiceNetworkVpcAssociationsAsList(ctx, &vpclattice.ListServiceNetworkVpcAssociationsInput{
+		ServiceNetworkIdentifier: &serviceNetworkId,
+		VpcIdentifier:            &config.VpcID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) == 0 {
+		return nil, nil
+	}
+	return resp[0], nil
+}
+
 func (m *defaultServiceNetworkManager) getActiveVpcAssociation(ctx context.Context, serviceNetworkId string) (*vpclattice.ServiceNetworkVpcAssociationSummary, error) {
 	vpcLatticeSess := m.cloud.Lattice()
 	associationStatusInput := vpclattice.ListServiceNetworkVpcAssociationsInput{
@@ -180,6 +285,10 @@ func (m *defaultServiceNetworkManager) CreateOrUpdate(ctx context.Context, servi
 	var serviceNetworkArn string
 	vpcLatticeSess := m.cloud.Lattice()
 	if foundSnSummary == nil {
+		if config.ServiceNetworkCreationDisabled {
+			return model.ServiceNetworkStatus{}, fmt.Errorf("%w: service network %s, creation is disabled by --disable-service-network-creation",
+				ErrServiceNetworkNotFound, serviceNetwork.Spec.Name)
+		}
 		m.log.Debugf(ctx, "Creating ServiceNetwork %s and tagging it with vpcId %s",
 			serviceNetwork.Spec.Name, config.VpcID)
 
@@ -257,6 +366,99 @@ func (m *defaultServiceNetworkManager) updateServiceNetworkVpcAssociation(ctx co
 	}
 }
 
+// updateServiceNetworkVpcAssociationTags reconciles user provided tags on the association.
+// Tags under the controller prefix, including ManagedBy, are never added or removed here. Only the managedTagKeys
+// are removed, so tags added to the association outside of the controller are kept.
+func (m *defaultServiceNetworkManager) updateServiceNetworkVpcAssociationTags(ctx context.Context, snvaArn string, existingTags services.Tags, desiredTags services.Tags, managedTagKeys []string) error {
+	desired := userTags(desiredTags)
+	existing := userTags(existingTags)
+
+	tagsToAdd := services.Tags{}
+	for k, v := range desired {
+		current, ok := existing[k]
+		if !ok || aws.StringValue(current) != aws.StringValue(v) {
+			tagsToAdd[k] = v
+		}
+	}
+	var tagKeysToRemove []*string
+	for _, k := range managedTagKeys {
+		_, isExisting := existing[k]
+		_, isDesired := desired[k]
+		if isExisting && !isDesired {
+			tagKeysToRemove = append(tagKeysToRemove, aws.String(k))
+		}
+	}
+
+	if len(tagsToAdd) > 0 {
+		m.log.Debugf(ctx, "Adding %d tags to vpc association %s", len(tagsToAdd), snvaArn)
+		_, err := m.cloud.Lattice().TagResourceWithContext(ctx, &vpclattice.TagResourceInput{
+			ResourceArn: &snvaArn,
+			Tags:        tagsToAdd,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(tagKeysToRemove) > 0 {
+		m.log.Debugf(ctx, "Removing tags %v from vpc association %s", aws.StringValueSlice(tagKeysToRemove), snvaArn)
+		_, err := m.cloud.Lattice().UntagResourceWithContext(ctx, &vpclattice.UntagResourceInput{
+			ResourceArn: &snvaArn,
+			TagKeys:     tagKeysToRemove,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertInfrastructureTags tags the service network with the infrastructure tags of its Gateway.
+// Service networks not managed by this controller are left untouched, they are managed outside the cluster.
+func (m *defaultServiceNetworkManager) UpsertInfrastructureTags(ctx context.Context, snInfo *services.ServiceNetworkInfo, tags services.Tags) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	snArn := aws.StringValue(snInfo.SvcNetwork.Arn)
+	managedBy := m.cloud.DefaultTags()[pkg_aws.TagManagedBy]
+	if aws.StringValue(snInfo.Tags[pkg_aws.TagManagedBy]) != aws.StringValue(managedBy) {
+		m.log.Debugf(ctx, "Skipping infrastructure tags of service network %s, not managed by controller", snArn)
+		return nil
+	}
+	return upsertTags(ctx, m.cloud, snArn, snInfo.Tags, tags)
+}
+
+// upsertTags adds the desired tags missing from the resource and updates the ones with a different value.
+// Other tags of the resource are kept, so tags added outside of the controller are not removed.
+func upsertTags(ctx context.Context, cloud pkg_aws.Cloud, arn string, existingTags services.Tags, desiredTags services.Tags) error {
+	tagsToAdd := services.Tags{}
+	for k, v := range desiredTags {
+		current, ok := existingTags[k]
+		if !ok || aws.StringValue(current) != aws.StringValue(v) {
+			tagsToAdd[k] = v
+		}
+	}
+	if len(tagsToAdd) == 0 {
+		return nil
+	}
+	_, err := cloud.Lattice().TagResourceWithContext(ctx, &vpclattice.TagResourceInput{
+		ResourceArn: &arn,
+		Tags:        tagsToAdd,
+	})
+	return err
+}
+
+// userTags returns the given tags without the ones reserved for the controller.
+func userTags(tags services.Tags) services.Tags {
+	result := services.Tags{}
+	for k, v := range tags {
+		if strings.HasPrefix(k, pkg_aws.TagBase) {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
 func securityGroupIdsEqual(arr1, arr2 []*string) bool {
 	ids1 := utils.SliceMap(arr1, aws.StringValue)
 	slices.Sort(ids1)
@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	services "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	lattice "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -64,17 +65,46 @@ func (mr *MockServiceNetworkManagerMockRecorder) DeleteVpcAssociation(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpcAssociation", reflect.TypeOf((*MockServiceNetworkManager)(nil).DeleteVpcAssociation), arg0, arg1)
 }
 
+// SwitchVpcAssociation mocks base method.
+func (m *MockServiceNetworkManager) SwitchVpcAssociation(arg0 context.Context, arg1, arg2 string, arg3 []*string, arg4 map[string]*string) (lattice.VpcAssociationSwitchoverPhase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwitchVpcAssociation", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(lattice.VpcAssociationSwitchoverPhase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwitchVpcAssociation indicates an expected call of SwitchVpcAssociation.
+func (mr *MockServiceNetworkManagerMockRecorder) SwitchVpcAssociation(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwitchVpcAssociation", reflect.TypeOf((*MockServiceNetworkManager)(nil).SwitchVpcAssociation), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpsertInfrastructureTags mocks base method.
+func (m *MockServiceNetworkManager) UpsertInfrastructureTags(arg0 context.Context, arg1 *services.ServiceNetworkInfo, arg2 map[string]*string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertInfrastructureTags", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertInfrastructureTags indicates an expected call of UpsertInfrastructureTags.
+func (mr *MockServiceNetworkManagerMockRecorder) UpsertInfrastructureTags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertInfrastructureTags", reflect.TypeOf((*MockServiceNetworkManager)(nil).UpsertInfrastructureTags), arg0, arg1, arg2)
+}
+
 // UpsertVpcAssociation mocks base method.
-func (m *MockServiceNetworkManager) UpsertVpcAssociation(arg0 context.Context, arg1 string, arg2 []*string) (string, error) {
+func (m *MockServiceNetworkManager) UpsertVpcAssociation(arg0 context.Context, arg1 string, arg2 []*string, arg3 map[string]*string, arg4 []string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertVpcAssociation", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpsertVpcAssociation", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpsertVpcAssociation indicates an expected call of UpsertVpcAssociation.
-func (mr *MockServiceNetworkManagerMockRecorder) UpsertVpcAssociation(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockServiceNetworkManagerMockRecorder) UpsertVpcAssociation(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVpcAssociation", reflect.TypeOf((*MockServiceNetworkManager)(nil).UpsertVpcAssociation), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVpcAssociation", reflect.TypeOf((*MockServiceNetworkManager)(nil).UpsertVpcAssociation), arg0, arg1, arg2, arg3, arg4)
 }
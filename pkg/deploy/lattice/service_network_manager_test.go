@@ -527,7 +527,7 @@ func Test_defaultServiceNetworkManager_CreateOrUpdate_SnExists_SnvaExists_Update
 	}, nil)
 
 	snMgr := NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud)
-	resp, err := snMgr.UpsertVpcAssociation(ctx, name, securityGroupIds)
+	resp, err := snMgr.UpsertVpcAssociation(ctx, name, securityGroupIds, nil, nil)
 
 	assert.Equal(t, err, nil)
 	assert.Equal(t, resp, snArn)
@@ -583,7 +583,7 @@ func Test_defaultServiceNetworkManager_CreateOrUpdate_SnExists_SnvaExists_Securi
 	mockLattice.EXPECT().UpdateServiceNetworkVpcAssociationWithContext(ctx, gomock.Any()).Times(0)
 
 	snMgr := NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud)
-	resp, err := snMgr.UpsertVpcAssociation(ctx, name, securityGroupIds)
+	resp, err := snMgr.UpsertVpcAssociation(ctx, name, securityGroupIds, nil, nil)
 
 	assert.Equal(t, err, nil)
 	assert.Equal(t, resp, snArn)
@@ -627,7 +627,7 @@ func Test_defaultServiceNetworkManager_CreateOrUpdate_SnExists_SnvaCreateInProgr
 	mockLattice.EXPECT().UpdateServiceNetworkVpcAssociationWithContext(ctx, gomock.Any()).Times(0)
 
 	snMgr := NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud)
-	_, err := snMgr.UpsertVpcAssociation(ctx, name, securityGroupIds)
+	_, err := snMgr.UpsertVpcAssociation(ctx, name, securityGroupIds, nil, nil)
 
 	assert.Equal(t, err, errors.New(LATTICE_RETRY))
 }
@@ -682,7 +682,238 @@ func Test_defaultServiceNetworkManager_CreateOrUpdate_SnExists_SnvaExists_Cannot
 	mockLattice.EXPECT().UpdateServiceNetworkVpcAssociationWithContext(ctx, gomock.Any()).Return(&vpclattice.UpdateServiceNetworkVpcAssociationOutput{}, updateSNVAError)
 
 	snMgr := NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud)
-	_, err := snMgr.UpsertVpcAssociation(ctx, name, []*string{})
+	_, err := snMgr.UpsertVpcAssociation(ctx, name, []*string{}, nil, nil)
 
 	assert.Equal(t, err, updateSNVAError)
 }
+
+func Test_defaultServiceNetworkManager_UpsertVpcAssociation_SnvaNotExist_CreateWithTags(t *testing.T) {
+	snId := "sn-id"
+	snArn := "sn-arn"
+	snvaArn := "snva-arn"
+	name := "test"
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	mockLattice.EXPECT().FindServiceNetwork(ctx, gomock.Any()).Return(
+		&mocks.ServiceNetworkInfo{
+			SvcNetwork: vpclattice.ServiceNetworkSummary{Arn: &snArn, Id: &snId, Name: &name},
+		}, nil)
+	mockLattice.EXPECT().ListServiceNetworkVpcAssociationsAsList(ctx, gomock.Any()).Return(nil, nil)
+	mockLattice.EXPECT().CreateServiceNetworkVpcAssociationWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.CreateServiceNetworkVpcAssociationInput, opts ...interface{}) (*vpclattice.CreateServiceNetworkVpcAssociationOutput, error) {
+			assert.Equal(t, "finance", aws.StringValue(input.Tags["team"]))
+			// controller owned tags cannot be overridden by the user
+			assert.Equal(t, cloud.DefaultTags()[pkg_aws.TagManagedBy], input.Tags[pkg_aws.TagManagedBy])
+			return &vpclattice.CreateServiceNetworkVpcAssociationOutput{
+				Arn:    &snvaArn,
+				Status: aws.String(vpclattice.ServiceNetworkVpcAssociationStatusActive),
+			}, nil
+		})
+
+	snMgr := NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud)
+	resp, err := snMgr.UpsertVpcAssociation(ctx, name, nil, mocks.Tags{
+		"team":               aws.String("finance"),
+		pkg_aws.TagManagedBy: aws.String("someone-else"),
+	}, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, snvaArn, resp)
+}
+
+func Test_defaultServiceNetworkManager_UpsertVpcAssociation_SnvaExists_ReconcileTags(t *testing.T) {
+	tests := []struct {
+		name             string
+		existingTags     mocks.Tags
+		desiredTags      mocks.Tags
+		managedTagKeys   []string
+		expectedTagged   mocks.Tags
+		expectedUntagged []string
+	}{
+		{
+			name:           "add tags",
+			existingTags:   mocks.Tags{},
+			desiredTags:    mocks.Tags{"team": aws.String("finance")},
+			expectedTagged: mocks.Tags{"team": aws.String("finance")},
+		},
+		{
+			name:           "change tags",
+			existingTags:   mocks.Tags{"team": aws.String("finance"), "env": aws.String("prod")},
+			desiredTags:    mocks.Tags{"team": aws.String("payments"), "env": aws.String("prod")},
+			expectedTagged: mocks.Tags{"team": aws.String("payments")},
+		},
+		{
+			name:             "remove tags",
+			existingTags:     mocks.Tags{"team": aws.String("finance"), "env": aws.String("prod")},
+			desiredTags:      mocks.Tags{"env": aws.String("prod")},
+			managedTagKeys:   []string{"env", "team"},
+			expectedUntagged: []string{"team"},
+		},
+		{
+			name:             "tags added outside of the controller are preserved",
+			existingTags:     mocks.Tags{"team": aws.String("finance"), "owner": aws.String("platform")},
+			desiredTags:      nil,
+			managedTagKeys:   []string{"team"},
+			expectedUntagged: []string{"team"},
+		},
+		{
+			name:           "tags are not removed without managed tag keys",
+			existingTags:   mocks.Tags{"team": aws.String("finance")},
+			desiredTags:    nil,
+			managedTagKeys: nil,
+		},
+		{
+			name:           "removed managed tags are skipped",
+			existingTags:   mocks.Tags{"env": aws.String("prod")},
+			desiredTags:    mocks.Tags{"env": aws.String("prod")},
+			managedTagKeys: []string{"env", "team"},
+		},
+		{
+			name:           "controller tags are preserved",
+			existingTags:   mocks.Tags{pkg_aws.TagBase + "Other": aws.String("value")},
+			desiredTags:    nil,
+			managedTagKeys: []string{pkg_aws.TagBase + "Other"},
+		},
+		{
+			name:         "no changes",
+			existingTags: mocks.Tags{"team": aws.String("finance")},
+			desiredTags:  mocks.Tags{"team": aws.String("finance")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snId := "sn-id"
+			snArn := "sn-arn"
+			snvaId := "snva-id"
+			snvaArn := "snva-arn"
+			name := "test"
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+			mockLattice := mocks.NewMockLattice(c)
+			cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+			existingTags := cloud.DefaultTagsMergedWith(tt.existingTags)
+			mockLattice.EXPECT().FindServiceNetwork(ctx, gomock.Any()).Return(
+				&mocks.ServiceNetworkInfo{
+					SvcNetwork: vpclattice.ServiceNetworkSummary{Arn: &snArn, Id: &snId, Name: &name},
+				}, nil)
+			mockLattice.EXPECT().ListServiceNetworkVpcAssociationsAsList(ctx, gomock.Any()).Return(
+				[]*vpclattice.ServiceNetworkVpcAssociationSummary{
+					{
+						Arn:    &snvaArn,
+						Id:     &snvaId,
+						Status: aws.String(vpclattice.ServiceNetworkVpcAssociationStatusActive),
+					},
+				}, nil)
+			mockLattice.EXPECT().ListTagsForResourceWithContext(ctx, gomock.Any()).Return(
+				&vpclattice.ListTagsForResourceOutput{Tags: existingTags}, nil)
+			mockLattice.EXPECT().GetServiceNetworkVpcAssociationWithContext(ctx, gomock.Any()).Return(
+				&vpclattice.GetServiceNetworkVpcAssociationOutput{}, nil)
+
+			if tt.expectedTagged != nil {
+				mockLattice.EXPECT().TagResourceWithContext(ctx, &vpclattice.TagResourceInput{
+					ResourceArn: &snvaArn,
+					Tags:        tt.expectedTagged,
+				}).Return(&vpclattice.TagResourceOutput{}, nil)
+			} else {
+				mockLattice.EXPECT().TagResourceWithContext(ctx, gomock.Any()).Times(0)
+			}
+			if tt.expectedUntagged != nil {
+				mockLattice.EXPECT().UntagResourceWithContext(ctx, &vpclattice.UntagResourceInput{
+					ResourceArn: &snvaArn,
+					TagKeys:     aws.StringSlice(tt.expectedUntagged),
+				}).Return(&vpclattice.UntagResourceOutput{}, nil)
+			} else {
+				mockLattice.EXPECT().UntagResourceWithContext(ctx, gomock.Any()).Times(0)
+			}
+
+			snMgr := NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud)
+			resp, err := snMgr.UpsertVpcAssociation(ctx, name, nil, tt.desiredTags, tt.managedTagKeys)
+
+			assert.Nil(t, err)
+			assert.Equal(t, snvaArn, resp)
+		})
+	}
+}
+
+func Test_defaultServiceNetworkManager_UpsertInfrastructureTags(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	snMgr := NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud)
+
+	infraTags := mocks.Tags{
+		"team":  aws.String("payments"),
+		"owner": aws.String("alice"),
+	}
+	snInfo := func(tags mocks.Tags) *mocks.ServiceNetworkInfo {
+		return &mocks.ServiceNetworkInfo{
+			SvcNetwork: vpclattice.ServiceNetworkSummary{
+				Arn:  aws.String("sn-arn"),
+				Name: aws.String("sn"),
+			},
+			Tags: tags,
+		}
+	}
+
+	t.Run("managed service network is tagged", func(t *testing.T) {
+		existingTags := cloud.DefaultTagsMergedWith(mocks.Tags{
+			"team":  aws.String("checkout"),
+			"other": aws.String("value"),
+		})
+		mockLattice.EXPECT().TagResourceWithContext(ctx, &vpclattice.TagResourceInput{
+			ResourceArn: aws.String("sn-arn"),
+			Tags:        infraTags,
+		}).Return(&vpclattice.TagResourceOutput{}, nil)
+
+		err := snMgr.UpsertInfrastructureTags(ctx, snInfo(existingTags), infraTags)
+		assert.Nil(t, err)
+	})
+
+	t.Run("up to date tags are not updated", func(t *testing.T) {
+		err := snMgr.UpsertInfrastructureTags(ctx, snInfo(cloud.DefaultTagsMergedWith(infraTags)), infraTags)
+		assert.Nil(t, err)
+	})
+
+	t.Run("service network managed outside the cluster is not tagged", func(t *testing.T) {
+		err := snMgr.UpsertInfrastructureTags(ctx, snInfo(mocks.Tags{"team": aws.String("checkout")}), infraTags)
+		assert.Nil(t, err)
+	})
+}
+
+func Test_CreateOrUpdateServiceNetwork_CreationDisabled(t *testing.T) {
+	config.ServiceNetworkCreationDisabled = true
+	defer func() { config.ServiceNetworkCreationDisabled = false }()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	snMgr := NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud)
+	sn := &model.ServiceNetwork{Spec: model.ServiceNetworkSpec{Name: "test"}}
+
+	// missing service network is not created, no CreateServiceNetwork call is expected
+	mockLattice.EXPECT().FindServiceNetwork(ctx, "test").Return(nil, mocks.NewNotFoundError("Service network", "test"))
+	_, err := snMgr.CreateOrUpdate(ctx, sn)
+	assert.ErrorIs(t, err, ErrServiceNetworkNotFound)
+
+	// existing service network is still associated with the VPC
+	mockLattice.EXPECT().FindServiceNetwork(ctx, "test").Return(&mocks.ServiceNetworkInfo{
+		SvcNetwork: vpclattice.ServiceNetworkSummary{Arn: aws.String("sn-arn"), Id: aws.String("sn-id"), Name: aws.String("test")},
+	}, nil)
+	mockLattice.EXPECT().ListServiceNetworkVpcAssociationsAsList(ctx, gomock.Any()).Return(nil, nil)
+	mockLattice.EXPECT().CreateServiceNetworkVpcAssociationWithContext(ctx, gomock.Any()).
+		Return(&vpclattice.CreateServiceNetworkVpcAssociationOutput{}, nil)
+	status, err := snMgr.CreateOrUpdate(ctx, sn)
+	assert.Nil(t, err)
+	assert.Equal(t, "sn-id", status.ServiceNetworkID)
+}
@@ -0,0 +1,66 @@
+package lattice
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// ServiceNetworkResourcePolicyManager sets the resource-based policy of service networks, which controls
+// access to the service network resource itself, e.g. for AWS RAM sharing. Auth policies authorizing
+// requests to the services of the service network are managed by IAMAuthPolicyManager.
+type ServiceNetworkResourcePolicyManager struct {
+	log   gwlog.Logger
+	cloud pkg_aws.Cloud
+}
+
+func NewServiceNetworkResourcePolicyManager(log gwlog.Logger, cloud pkg_aws.Cloud) *ServiceNetworkResourcePolicyManager {
+	return &ServiceNetworkResourcePolicyManager{log: log, cloud: cloud}
+}
+
+// Put sets the resource policy of the service network, replacing any existing one.
+// Returns the ARN of the service network.
+func (m *ServiceNetworkResourcePolicyManager) Put(ctx context.Context, snName, policy string) (string, error) {
+	sn, err := m.cloud.Lattice().FindServiceNetwork(ctx, snName)
+	if err != nil {
+		return "", err
+	}
+	req := &vpclattice.PutResourcePolicyInput{
+		ResourceArn: sn.SvcNetwork.Arn,
+		Policy:      aws.String(policy),
+	}
+	_, err = m.cloud.Lattice().PutResourcePolicyWithContext(ctx, req)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == vpclattice.ErrCodeValidationException {
+		return "", services.NewInvalidError(aerr.Message())
+	}
+	if err != nil {
+		return "", err
+	}
+	m.log.Debugf(ctx, "Put resource policy of service network %s", aws.StringValue(sn.SvcNetwork.Arn))
+	return aws.StringValue(sn.SvcNetwork.Arn), nil
+}
+
+// Delete clears the resource policy of the service network. A missing service network is not an error.
+func (m *ServiceNetworkResourcePolicyManager) Delete(ctx context.Context, snName string) error {
+	sn, err := m.cloud.Lattice().FindServiceNetwork(ctx, snName)
+	if err != nil {
+		if services.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	req := &vpclattice.DeleteResourcePolicyInput{
+		ResourceArn: sn.SvcNetwork.Arn,
+	}
+	_, err = m.cloud.Lattice().DeleteResourcePolicyWithContext(ctx, req)
+	if err != nil && !services.IsLatticeAPINotFoundErr(err) {
+		return err
+	}
+	return nil
+}
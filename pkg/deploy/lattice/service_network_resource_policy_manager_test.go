@@ -0,0 +1,104 @@
+package lattice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	an_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestServiceNetworkResourcePolicyManager(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := services.NewMockLattice(c)
+	cloud := an_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	m := NewServiceNetworkResourcePolicyManager(gwlog.FallbackLogger, cloud)
+
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"111122223333"},"Action":"vpc-lattice:CreateServiceNetworkVpcAssociation","Resource":"*"}]}`
+	serviceNetworkInfo := &services.ServiceNetworkInfo{
+		SvcNetwork: vpclattice.ServiceNetworkSummary{
+			Arn:  aws.String(serviceNetworkArn),
+			Name: aws.String(sourceName),
+		},
+	}
+
+	t.Run("set resource policy", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().PutResourcePolicyWithContext(ctx, &vpclattice.PutResourcePolicyInput{
+			ResourceArn: aws.String(serviceNetworkArn),
+			Policy:      aws.String(policy),
+		}).Return(&vpclattice.PutResourcePolicyOutput{}, nil)
+
+		snArn, err := m.Put(ctx, sourceName, policy)
+		assert.Nil(t, err)
+		assert.Equal(t, serviceNetworkArn, snArn)
+	})
+
+	t.Run("policy rejected by Lattice is invalid", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().PutResourcePolicyWithContext(ctx, gomock.Any()).
+			Return(nil, awserr.New(vpclattice.ErrCodeValidationException, "invalid policy document", nil))
+
+		_, err := m.Put(ctx, sourceName, "{}")
+		assert.True(t, services.IsInvalidError(err))
+	})
+
+	t.Run("policy denied by Lattice is not invalid", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().PutResourcePolicyWithContext(ctx, gomock.Any()).
+			Return(nil, awserr.New(vpclattice.ErrCodeAccessDeniedException, "not authorized", nil))
+
+		_, err := m.Put(ctx, sourceName, policy)
+		assert.False(t, services.IsInvalidError(err))
+		assert.True(t, services.IsAccessDeniedError(err))
+	})
+
+	t.Run("missing service network is retried", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).
+			Return(nil, services.NewNotFoundError("Service network", sourceName))
+
+		_, err := m.Put(ctx, sourceName, policy)
+		assert.True(t, services.IsNotFoundError(err))
+	})
+
+	t.Run("clear resource policy", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().DeleteResourcePolicyWithContext(ctx, &vpclattice.DeleteResourcePolicyInput{
+			ResourceArn: aws.String(serviceNetworkArn),
+		}).Return(&vpclattice.DeleteResourcePolicyOutput{}, nil)
+
+		assert.Nil(t, m.Delete(ctx, sourceName))
+	})
+
+	t.Run("clear resource policy already cleared", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().DeleteResourcePolicyWithContext(ctx, gomock.Any()).
+			Return(nil, awserr.New(vpclattice.ErrCodeResourceNotFoundException, "no policy", nil))
+
+		assert.Nil(t, m.Delete(ctx, sourceName))
+	})
+
+	t.Run("clear resource policy of deleted service network", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).
+			Return(nil, services.NewNotFoundError("Service network", sourceName))
+
+		assert.Nil(t, m.Delete(ctx, sourceName))
+	})
+
+	t.Run("clear resource policy fails", func(t *testing.T) {
+		mockLattice.EXPECT().FindServiceNetwork(ctx, sourceName).Return(serviceNetworkInfo, nil)
+		mockLattice.EXPECT().DeleteResourcePolicyWithContext(ctx, gomock.Any()).Return(nil, errors.New("throttled"))
+
+		assert.NotNil(t, m.Delete(ctx, sourceName))
+	})
+}
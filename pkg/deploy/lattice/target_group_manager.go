@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 
@@ -47,11 +50,29 @@ func (s *defaultTargetGroupManager) Upsert(
 	modelTg *model.TargetGroup,
 ) (model.TargetGroupStatus, error) {
 	// check if exists
-	latticeTgSummary, err := s.findTargetGroup(ctx, modelTg)
+	latticeTgSummary, outdatedTg, err := s.findTargetGroups(ctx, modelTg)
 	if err != nil {
 		return model.TargetGroupStatus{}, err
 	}
 
+	if latticeTgSummary == nil && modelTg.Spec.IsSourceTypeRoute() {
+		latticeTgSummary, err = s.adoptPrewarmedTargetGroup(ctx, modelTg)
+		if err != nil {
+			return model.TargetGroupStatus{}, err
+		}
+	}
+
+	if latticeTgSummary == nil && outdatedTg != nil && !utils.InMaintenanceWindow(modelTg.Spec.MaintenanceWindow, time.Now()) {
+		// keep using the target group as it is, the route is reconciled again in its next maintenance window
+		s.log.Infof(ctx, "Deferring recreation of target group %s until the maintenance window %s",
+			aws.StringValue(outdatedTg.Arn), modelTg.Spec.MaintenanceWindow)
+		return model.TargetGroupStatus{
+			Name: aws.StringValue(outdatedTg.Name),
+			Arn:  aws.StringValue(outdatedTg.Arn),
+			Id:   aws.StringValue(outdatedTg.Id),
+		}, nil
+	}
+
 	if latticeTgSummary == nil {
 		return s.create(ctx, modelTg)
 	} else {
@@ -70,13 +91,20 @@ func (s *defaultTargetGroupManager) create(ctx context.Context, modelTg *model.T
 		protocolVersion = &modelTg.Spec.ProtocolVersion
 	}
 
+	// apply the controller defaults on creation too, instead of the Lattice ones
+	healthCheckConfig := &vpclattice.HealthCheckConfig{}
+	if modelTg.Spec.HealthCheckConfig != nil {
+		*healthCheckConfig = *modelTg.Spec.HealthCheckConfig
+	}
+	s.fillDefaultHealthCheckConfig(healthCheckConfig, modelTg.Spec.Protocol, modelTg.Spec.ProtocolVersion)
+
 	latticeTgCfg := &vpclattice.TargetGroupConfig{
 		Port:            aws.Int64(int64(modelTg.Spec.Port)),
 		Protocol:        &modelTg.Spec.Protocol,
 		ProtocolVersion: protocolVersion,
 		VpcIdentifier:   &modelTg.Spec.VpcId,
 		IpAddressType:   ipAddressType,
-		HealthCheck:     modelTg.Spec.HealthCheckConfig,
+		HealthCheck:     healthCheckConfig,
 	}
 
 	latticeTgType := string(modelTg.Spec.Type)
@@ -103,7 +131,7 @@ func (s *defaultTargetGroupManager) create(ctx context.Context, modelTg *model.T
 	resp, err := lattice.CreateTargetGroupWithContext(ctx, &createInput)
 	if err != nil {
 		return model.TargetGroupStatus{},
-			fmt.Errorf("Failed CreateTargetGroup %s due to %s", latticeTgName, err)
+			fmt.Errorf("Failed CreateTargetGroup %s due to %w", latticeTgName, err)
 	}
 	s.log.Infof(ctx, "Success CreateTargetGroup %s", latticeTgName)
 
@@ -130,6 +158,10 @@ func (s *defaultTargetGroupManager) update(ctx context.Context, targetGroup *mod
 		s.log.Debugf(ctx, "HealthCheck is empty. Resetting to default settings")
 		healthCheckConfig = &vpclattice.HealthCheckConfig{}
 	}
+	if targetGroup.Spec.ReconcileMode == model.ReconcileModeAdditive && latticeTg.Config.HealthCheck != nil {
+		// keep the settings not set by the model, e.g. edited outside of the controller, instead of the defaults
+		mergeHealthCheckConfig(healthCheckConfig, latticeTg.Config.HealthCheck)
+	}
 	s.fillDefaultHealthCheckConfig(healthCheckConfig, targetGroup.Spec.Protocol, targetGroup.Spec.ProtocolVersion)
 
 	if !reflect.DeepEqual(healthCheckConfig, latticeTg.Config.HealthCheck) {
@@ -175,6 +207,27 @@ func (s *defaultTargetGroupManager) Delete(ctx context.Context, modelTg *model.T
 
 	lattice := s.cloud.Lattice()
 
+	if modelTg.Status.Arn == "" {
+		getResp, err := lattice.GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
+			TargetGroupIdentifier: &modelTg.Status.Id,
+		})
+		if err != nil {
+			if services.IsLatticeAPINotFoundErr(err) {
+				s.log.Debugf(ctx, "Target group %s was already deleted", modelTg.Status.Id)
+				return nil
+			}
+			return fmt.Errorf("failed GetTargetGroup %s due to %w", modelTg.Status.Id, err)
+		}
+		modelTg.Status.Arn = aws.StringValue(getResp.Arn)
+	}
+	if err := verifyOwnedBeforeDelete(ctx, s.cloud, modelTg.Status.Arn); err != nil {
+		if services.IsLatticeAPINotFoundErr(err) {
+			s.log.Debugf(ctx, "Target group %s was already deleted", modelTg.Status.Id)
+			return nil
+		}
+		return err
+	}
+
 	// de-register all targets first
 	listTargetsInput := vpclattice.ListTargetsInput{
 		TargetGroupIdentifier: &modelTg.Status.Id,
@@ -186,7 +239,7 @@ func (s *defaultTargetGroupManager) Delete(ctx context.Context, modelTg *model.T
 			s.log.Debugf(ctx, "Target group %s was already deleted", modelTg.Status.Id)
 			return nil
 		}
-		return fmt.Errorf("failed ListTargets %s due to %s", modelTg.Status.Id, err)
+		return fmt.Errorf("failed ListTargets %s due to %w", modelTg.Status.Id, err)
 	}
 
 	var targetsToDeregister []*vpclattice.Target
@@ -217,7 +270,7 @@ func (s *defaultTargetGroupManager) Delete(ctx context.Context, modelTg *model.T
 			}
 			deregisterResponse, err := lattice.DeregisterTargetsWithContext(ctx, &deregisterInput)
 			if err != nil {
-				deregisterTargetsError = errors.Join(deregisterTargetsError, fmt.Errorf("failed to deregister targets from VPC Lattice Target Group %s due to %s", modelTg.Status.Id, err))
+				deregisterTargetsError = errors.Join(deregisterTargetsError, fmt.Errorf("failed to deregister targets from VPC Lattice Target Group %s due to %w", modelTg.Status.Id, err))
 			}
 			if len(deregisterResponse.Unsuccessful) > 0 {
 				deregisterTargetsError = errors.Join(deregisterTargetsError, fmt.Errorf("failed to deregister targets from VPC Lattice Target Group %s for chunk %d/%d, unsuccessful targets %v",
@@ -239,7 +292,7 @@ func (s *defaultTargetGroupManager) Delete(ctx context.Context, modelTg *model.T
 			s.log.Infof(ctx, "Target group %s was already deleted", modelTg.Status.Id)
 			return nil
 		} else {
-			return fmt.Errorf("failed DeleteTargetGroup %s due to %s", modelTg.Status.Id, err)
+			return fmt.Errorf("failed DeleteTargetGroup %s due to %w", modelTg.Status.Id, err)
 		}
 	}
 
@@ -285,15 +338,27 @@ func (s *defaultTargetGroupManager) findTargetGroup(
 	ctx context.Context,
 	modelTargetGroup *model.TargetGroup,
 ) (*vpclattice.GetTargetGroupOutput, error) {
+	latticeTg, _, err := s.findTargetGroups(ctx, modelTargetGroup)
+	return latticeTg, err
+}
+
+// findTargetGroups returns the target group matching the model, and when there is none, an active one of the
+// same tags which has to be recreated since its immutable fields changed
+func (s *defaultTargetGroupManager) findTargetGroups(
+	ctx context.Context,
+	modelTargetGroup *model.TargetGroup,
+) (*vpclattice.GetTargetGroupOutput, *vpclattice.GetTargetGroupOutput, error) {
 	arns, err := s.cloud.Tagging().FindResourcesByTags(ctx, services.ResourceTypeTargetGroup,
 		model.TagsFromTGTagFields(modelTargetGroup.Spec.TargetGroupTagFields))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if len(arns) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
+	var outdatedTg *vpclattice.GetTargetGroupOutput
+
 	for _, arn := range arns {
 		latticeTg, err := s.cloud.Lattice().GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
 			TargetGroupIdentifier: &arn,
@@ -302,7 +367,7 @@ func (s *defaultTargetGroupManager) findTargetGroup(
 			if services.IsNotFoundError(err) {
 				continue
 			}
-			return nil, err
+			return nil, nil, err
 		}
 
 		// we ignore create failed status, so may as well check for it first
@@ -311,29 +376,33 @@ func (s *defaultTargetGroupManager) findTargetGroup(
 			continue
 		}
 
-		// Check the immutable fields to ensure TG is valid
-		match, err := s.IsTargetGroupMatch(ctx, modelTargetGroup, &vpclattice.TargetGroupSummary{
+		// Check the immutable fields to ensure TG is valid, we already know that tags match.
+		// Mutable fields like the health check are updated in place instead.
+		changes := immutableFieldChanges(modelTargetGroup, &vpclattice.TargetGroupSummary{
 			Arn:           latticeTg.Arn,
 			Port:          latticeTg.Config.Port,
 			Protocol:      latticeTg.Config.Protocol,
 			IpAddressType: latticeTg.Config.IpAddressType,
 			Type:          latticeTg.Type,
 			VpcIdentifier: latticeTg.Config.VpcIdentifier,
-		}, nil) // we already know that tags match
-		if err != nil {
-			return nil, err
-		}
-		if match {
-			switch status {
-			case vpclattice.TargetGroupStatusCreateInProgress, vpclattice.TargetGroupStatusDeleteInProgress:
-				return nil, errors.New(LATTICE_RETRY)
-			case vpclattice.TargetGroupStatusDeleteFailed, vpclattice.TargetGroupStatusActive:
-				return latticeTg, nil
+		})
+		if len(changes) > 0 {
+			s.log.Infof(ctx, "Target group %s requires recreation, immutable fields changed: %s",
+				aws.StringValue(latticeTg.Arn), strings.Join(changes, ", "))
+			if outdatedTg == nil && status == vpclattice.TargetGroupStatusActive {
+				outdatedTg = latticeTg
 			}
+			continue
+		}
+		switch status {
+		case vpclattice.TargetGroupStatusCreateInProgress, vpclattice.TargetGroupStatusDeleteInProgress:
+			return nil, nil, errors.New(LATTICE_RETRY)
+		case vpclattice.TargetGroupStatusDeleteFailed, vpclattice.TargetGroupStatusActive:
+			return latticeTg, nil, nil
 		}
 	}
 
-	return nil, nil
+	return nil, outdatedTg, nil
 }
 
 // Skips tag verification if not provided
@@ -341,12 +410,7 @@ func (s *defaultTargetGroupManager) IsTargetGroupMatch(ctx context.Context,
 	modelTg *model.TargetGroup, latticeTg *vpclattice.TargetGroupSummary,
 	latticeTagsAsModelTags *model.TargetGroupTagFields) (bool, error) {
 
-	if aws.Int64Value(latticeTg.Port) != int64(modelTg.Spec.Port) ||
-		aws.StringValue(latticeTg.Protocol) != modelTg.Spec.Protocol ||
-		aws.StringValue(latticeTg.IpAddressType) != modelTg.Spec.IpAddressType ||
-		aws.StringValue(latticeTg.Type) != string(modelTg.Spec.Type) ||
-		aws.StringValue(latticeTg.VpcIdentifier) != modelTg.Spec.VpcId {
-
+	if len(immutableFieldChanges(modelTg, latticeTg)) > 0 {
 		return false, nil
 	}
 
@@ -360,6 +424,29 @@ func (s *defaultTargetGroupManager) IsTargetGroupMatch(ctx context.Context,
 	return true, nil
 }
 
+// immutableFieldChanges lists the fields of the lattice target group which differ from the model and cannot be
+// changed with UpdateTargetGroup. A target group with any of them changed has to be recreated, which
+// re-registers all of its targets.
+func immutableFieldChanges(modelTg *model.TargetGroup, latticeTg *vpclattice.TargetGroupSummary) []string {
+	var changes []string
+	if aws.Int64Value(latticeTg.Port) != int64(modelTg.Spec.Port) {
+		changes = append(changes, "port")
+	}
+	if aws.StringValue(latticeTg.Protocol) != modelTg.Spec.Protocol {
+		changes = append(changes, "protocol")
+	}
+	if aws.StringValue(latticeTg.IpAddressType) != modelTg.Spec.IpAddressType {
+		changes = append(changes, "ipAddressType")
+	}
+	if aws.StringValue(latticeTg.Type) != string(modelTg.Spec.Type) {
+		changes = append(changes, "type")
+	}
+	if aws.StringValue(latticeTg.VpcIdentifier) != modelTg.Spec.VpcId {
+		changes = append(changes, "vpcIdentifier")
+	}
+	return changes
+}
+
 // Get default health check configuration according to
 // https://docs.aws.amazon.com/vpc-lattice/latest/ug/target-group-health-checks.html#health-check-settings
 func (s *defaultTargetGroupManager) getDefaultHealthCheckConfig(targetGroupProtocol string, targetGroupProtocolVersion string) *vpclattice.HealthCheckConfig {
@@ -377,7 +464,7 @@ func (s *defaultTargetGroupManager) getDefaultHealthCheckConfig(targetGroupProto
 		defaultMatcher                          = vpclattice.Matcher{
 			HttpCode: aws.String("200"),
 		}
-		defaultPath     = "/"
+		defaultPath     = config.HTTPHealthCheckPath
 		defaultProtocol = vpclattice.TargetGroupProtocolHttp
 	)
 
@@ -389,7 +476,14 @@ func (s *defaultTargetGroupManager) getDefaultHealthCheckConfig(targetGroupProto
 	healthCheckProtocolVersion := targetGroupProtocolVersion
 
 	if targetGroupProtocolVersion == vpclattice.TargetGroupProtocolVersionGrpc {
+		// Lattice health checks are plain HTTP requests, so these are only enabled on request, for gRPC servers
+		// answering them on the health service path. gRPC servers only accept HTTP2.
+		defaultPath = config.GRPCHealthCheckPath
+		enabled = config.GRPCHealthCheckEnabled
 		healthCheckProtocolVersion = vpclattice.HealthCheckProtocolVersionHttp1
+		if enabled {
+			healthCheckProtocolVersion = vpclattice.HealthCheckProtocolVersionHttp2
+		}
 	}
 
 	return &vpclattice.HealthCheckConfig{
@@ -407,36 +501,55 @@ func (s *defaultTargetGroupManager) getDefaultHealthCheckConfig(targetGroupProto
 }
 
 func (s *defaultTargetGroupManager) fillDefaultHealthCheckConfig(hc *vpclattice.HealthCheckConfig, targetGroupProtocol string, targetGroupProtocolVersion string) {
-	defaultCfg := s.getDefaultHealthCheckConfig(targetGroupProtocol, targetGroupProtocolVersion)
+	mergeHealthCheckConfig(hc, s.getDefaultHealthCheckConfig(targetGroupProtocol, targetGroupProtocolVersion))
+}
+
+// mergeHealthCheckConfig sets the settings of hc which are not set to the ones of from
+func mergeHealthCheckConfig(hc *vpclattice.HealthCheckConfig, from *vpclattice.HealthCheckConfig) {
 	if hc.Enabled == nil {
-		hc.Enabled = defaultCfg.Enabled
+		hc.Enabled = from.Enabled
 	}
 	if hc.Protocol == nil {
-		hc.Protocol = defaultCfg.Protocol
+		hc.Protocol = from.Protocol
 	}
 	if hc.ProtocolVersion == nil {
-		hc.ProtocolVersion = defaultCfg.ProtocolVersion
+		hc.ProtocolVersion = from.ProtocolVersion
 	}
 	if hc.Path == nil {
-		hc.Path = defaultCfg.Path
+		hc.Path = from.Path
+	}
+	if hc.Port == nil {
+		hc.Port = from.Port
 	}
 	if hc.Matcher == nil {
-		hc.Matcher = defaultCfg.Matcher
+		hc.Matcher = from.Matcher
 	}
 	if hc.HealthCheckTimeoutSeconds == nil {
-		hc.HealthCheckTimeoutSeconds = defaultCfg.HealthCheckTimeoutSeconds
+		hc.HealthCheckTimeoutSeconds = from.HealthCheckTimeoutSeconds
 	}
 	if hc.HealthCheckIntervalSeconds == nil {
-		hc.HealthCheckIntervalSeconds = defaultCfg.HealthCheckIntervalSeconds
+		hc.HealthCheckIntervalSeconds = from.HealthCheckIntervalSeconds
 	}
 	if hc.HealthyThresholdCount == nil {
-		hc.HealthyThresholdCount = defaultCfg.HealthyThresholdCount
+		hc.HealthyThresholdCount = from.HealthyThresholdCount
 	}
 	if hc.UnhealthyThresholdCount == nil {
-		hc.UnhealthyThresholdCount = defaultCfg.UnhealthyThresholdCount
+		hc.UnhealthyThresholdCount = from.UnhealthyThresholdCount
 	}
 }
 
+// ServiceImportTargetGroupNotFoundError is returned when no target group is exported for a ServiceImport
+// backendRef, as its service is not exported by any cluster yet.
+type ServiceImportTargetGroupNotFoundError struct {
+	K8SServiceName      string
+	K8SServiceNamespace string
+}
+
+func (e *ServiceImportTargetGroupNotFoundError) Error() string {
+	return fmt.Sprintf("no exported target group found for ServiceImport %s/%s, the service must be exported with a ServiceExport",
+		e.K8SServiceNamespace, e.K8SServiceName)
+}
+
 func (s *defaultTargetGroupManager) findSvcExportTG(ctx context.Context, svcImportTg model.SvcImportTargetGroup) (string, error) {
 	tgs, err := s.List(ctx)
 	if err != nil {
@@ -452,7 +565,10 @@ func (s *defaultTargetGroupManager) findSvcExportTG(ctx context.Context, svcImpo
 			return *tg.tgSummary.Id, nil
 		}
 	}
-	return "", errors.New("target group for service import could not be found")
+	return "", &ServiceImportTargetGroupNotFoundError{
+		K8SServiceName:      svcImportTg.K8SServiceName,
+		K8SServiceNamespace: svcImportTg.K8SServiceNamespace,
+	}
 }
 
 // ResolveRuleTgIds populates all target group ids in the rule's actions
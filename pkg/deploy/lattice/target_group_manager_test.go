@@ -88,6 +88,10 @@ func Test_CreateTargetGroup_TGNotExist_Active(t *testing.T) {
 		}
 
 		mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
+		if tgType == "by-backendref" {
+			// no prewarmed target group to adopt
+			mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
+		}
 		mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).DoAndReturn(
 			func(ctx context.Context, input *vpclattice.CreateTargetGroupInput, arg3 ...interface{}) (*vpclattice.CreateTargetGroupOutput, error) {
 				assert.Equal(t, aws.Int64(int64(tgSpec.Port)), input.Config.Port)
@@ -309,6 +313,262 @@ func Test_CreateTargetGroup_TGActive_HealthCheckSame(t *testing.T) {
 	assert.Equal(t, "id", resp.Id)
 }
 
+func Test_UpsertTargetGroup_HealthCheckChanged_UpdateInPlace(t *testing.T) {
+	ctx := context.TODO()
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	healthCheck := func(intervalSeconds int64) *vpclattice.HealthCheckConfig {
+		return &vpclattice.HealthCheckConfig{
+			Enabled:                    aws.Bool(true),
+			HealthCheckIntervalSeconds: aws.Int64(intervalSeconds),
+			HealthCheckTimeoutSeconds:  aws.Int64(3),
+			HealthyThresholdCount:      aws.Int64(3),
+			Matcher:                    &vpclattice.Matcher{HttpCode: aws.String("200")},
+			Path:                       aws.String("/"),
+			Protocol:                   aws.String(vpclattice.TargetGroupProtocolHttp),
+			ProtocolVersion:            aws.String(vpclattice.TargetGroupProtocolVersionHttp1),
+			UnhealthyThresholdCount:    aws.Int64(3),
+		}
+	}
+	tgCreateInput := model.TargetGroup{
+		Spec: model.TargetGroupSpec{
+			Type:              model.TargetGroupTypeIP,
+			Port:              80,
+			Protocol:          vpclattice.TargetGroupProtocolHttp,
+			ProtocolVersion:   vpclattice.TargetGroupProtocolVersionHttp1,
+			IpAddressType:     vpclattice.IpAddressTypeIpv4,
+			VpcId:             "vpc-id",
+			HealthCheckConfig: healthCheck(10),
+		},
+	}
+
+	tgOutput := vpclattice.GetTargetGroupOutput{
+		Arn:    aws.String("arn"),
+		Id:     aws.String("id"),
+		Name:   aws.String("test-http-http1"),
+		Type:   aws.String(vpclattice.TargetGroupTypeIp),
+		Status: aws.String(vpclattice.TargetGroupStatusActive),
+		Config: &vpclattice.TargetGroupConfig{
+			Port:            aws.Int64(80),
+			Protocol:        aws.String(vpclattice.TargetGroupProtocolHttp),
+			ProtocolVersion: aws.String(vpclattice.TargetGroupProtocolVersionHttp1),
+			IpAddressType:   aws.String(vpclattice.IpAddressTypeIpv4),
+			VpcIdentifier:   aws.String("vpc-id"),
+			HealthCheck:     healthCheck(30),
+		},
+	}
+
+	mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{"arn"}, nil)
+	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&tgOutput, nil)
+	mockLattice.EXPECT().UpdateTargetGroupWithContext(ctx, &vpclattice.UpdateTargetGroupInput{
+		HealthCheck:           healthCheck(10),
+		TargetGroupIdentifier: aws.String("id"),
+	}).Return(&vpclattice.UpdateTargetGroupOutput{}, nil)
+	mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).Times(0)
+
+	tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+	resp, err := tgManager.Upsert(ctx, &tgCreateInput)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "arn", resp.Arn)
+	assert.Equal(t, "id", resp.Id)
+}
+
+func Test_UpsertTargetGroup_PortChanged_Recreate(t *testing.T) {
+	ctx := context.TODO()
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	tgCreateInput := model.TargetGroup{
+		Spec: model.TargetGroupSpec{
+			Port:            8080,
+			Protocol:        vpclattice.TargetGroupProtocolHttp,
+			ProtocolVersion: vpclattice.TargetGroupProtocolVersionHttp1,
+		},
+	}
+
+	tgOutput := vpclattice.GetTargetGroupOutput{
+		Arn:    aws.String("old-arn"),
+		Id:     aws.String("old-id"),
+		Status: aws.String(vpclattice.TargetGroupStatusActive),
+		Config: &vpclattice.TargetGroupConfig{
+			Port:            aws.Int64(80),
+			Protocol:        aws.String(vpclattice.TargetGroupProtocolHttp),
+			ProtocolVersion: aws.String(vpclattice.TargetGroupProtocolVersionHttp1),
+		},
+	}
+
+	mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{"old-arn"}, nil)
+	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&tgOutput, nil)
+	mockLattice.EXPECT().UpdateTargetGroupWithContext(ctx, gomock.Any()).Times(0)
+	mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).Return(&vpclattice.CreateTargetGroupOutput{
+		Arn:    aws.String("new-arn"),
+		Id:     aws.String("new-id"),
+		Status: aws.String(vpclattice.TargetGroupStatusActive),
+	}, nil)
+
+	tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+	resp, err := tgManager.Upsert(ctx, &tgCreateInput)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "new-arn", resp.Arn)
+	assert.Equal(t, "new-id", resp.Id)
+}
+
+func Test_UpsertTargetGroup_PortChanged_DeferredOutsideMaintenanceWindow(t *testing.T) {
+	ctx := context.TODO()
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	tgCreateInput := model.TargetGroup{
+		Spec: model.TargetGroupSpec{
+			Port:            8080,
+			Protocol:        vpclattice.TargetGroupProtocolHttp,
+			ProtocolVersion: vpclattice.TargetGroupProtocolVersionHttp1,
+			// February 31st, now is always outside of the window
+			MaintenanceWindow: "0 0 31 2 * 1h",
+		},
+	}
+
+	tgOutput := vpclattice.GetTargetGroupOutput{
+		Arn:    aws.String("old-arn"),
+		Id:     aws.String("old-id"),
+		Name:   aws.String("old-name"),
+		Status: aws.String(vpclattice.TargetGroupStatusActive),
+		Config: &vpclattice.TargetGroupConfig{
+			Port:            aws.Int64(80),
+			Protocol:        aws.String(vpclattice.TargetGroupProtocolHttp),
+			ProtocolVersion: aws.String(vpclattice.TargetGroupProtocolVersionHttp1),
+		},
+	}
+
+	mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{"old-arn"}, nil)
+	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&tgOutput, nil)
+	mockLattice.EXPECT().UpdateTargetGroupWithContext(ctx, gomock.Any()).Times(0)
+	mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).Times(0)
+
+	tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+	resp, err := tgManager.Upsert(ctx, &tgCreateInput)
+
+	// the outdated target group is kept until the window
+	assert.Nil(t, err)
+	assert.Equal(t, "old-arn", resp.Arn)
+	assert.Equal(t, "old-id", resp.Id)
+	assert.Equal(t, "old-name", resp.Name)
+}
+
+func Test_UpsertTargetGroup_ReconcileMode_ExternallyModifiedHealthCheck(t *testing.T) {
+	defaultHealthCheck := func() *vpclattice.HealthCheckConfig {
+		return &vpclattice.HealthCheckConfig{
+			Enabled:                    aws.Bool(true),
+			HealthCheckIntervalSeconds: aws.Int64(30),
+			HealthCheckTimeoutSeconds:  aws.Int64(5),
+			HealthyThresholdCount:      aws.Int64(5),
+			Matcher:                    &vpclattice.Matcher{HttpCode: aws.String("200")},
+			Path:                       aws.String("/healthz"),
+			Protocol:                   aws.String(vpclattice.TargetGroupProtocolHttp),
+			ProtocolVersion:            aws.String(vpclattice.TargetGroupProtocolVersionHttp1),
+			UnhealthyThresholdCount:    aws.Int64(2),
+		}
+	}
+	// the interval is not set by the policy, and was edited outside of the controller
+	externallyModified := defaultHealthCheck()
+	externallyModified.HealthCheckIntervalSeconds = aws.Int64(60)
+
+	tests := []struct {
+		name          string
+		reconcileMode model.ReconcileMode
+		policyPath    string
+		wantUpdate    *vpclattice.HealthCheckConfig
+	}{
+		{
+			name:          "strict resets the field to the default",
+			reconcileMode: model.ReconcileModeStrict,
+			policyPath:    "/healthz",
+			wantUpdate:    defaultHealthCheck(),
+		},
+		{
+			name:          "additive keeps the field",
+			reconcileMode: model.ReconcileModeAdditive,
+			policyPath:    "/healthz",
+		},
+		{
+			name:          "additive keeps the field when updating fields set by the policy",
+			reconcileMode: model.ReconcileModeAdditive,
+			policyPath:    "/ready",
+			wantUpdate: func() *vpclattice.HealthCheckConfig {
+				hc := defaultHealthCheck()
+				hc.HealthCheckIntervalSeconds = aws.Int64(60)
+				hc.Path = aws.String("/ready")
+				return hc
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			mockLattice := mocks.NewMockLattice(c)
+			mockTagging := mocks.NewMockTagging(c)
+			cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+			tgCreateInput := model.TargetGroup{
+				Spec: model.TargetGroupSpec{
+					Port:              80,
+					Protocol:          vpclattice.TargetGroupProtocolHttp,
+					ProtocolVersion:   vpclattice.TargetGroupProtocolVersionHttp1,
+					HealthCheckConfig: &vpclattice.HealthCheckConfig{Path: aws.String(tt.policyPath)},
+					ReconcileMode:     tt.reconcileMode,
+				},
+			}
+			tgOutput := vpclattice.GetTargetGroupOutput{
+				Arn:    aws.String("arn"),
+				Id:     aws.String("id"),
+				Status: aws.String(vpclattice.TargetGroupStatusActive),
+				Config: &vpclattice.TargetGroupConfig{
+					Port:            aws.Int64(80),
+					Protocol:        aws.String(vpclattice.TargetGroupProtocolHttp),
+					ProtocolVersion: aws.String(vpclattice.TargetGroupProtocolVersionHttp1),
+					HealthCheck:     externallyModified,
+				},
+			}
+
+			mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{"arn"}, nil)
+			mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&tgOutput, nil)
+			if tt.wantUpdate != nil {
+				mockLattice.EXPECT().UpdateTargetGroupWithContext(ctx, &vpclattice.UpdateTargetGroupInput{
+					HealthCheck:           tt.wantUpdate,
+					TargetGroupIdentifier: aws.String("id"),
+				}).Return(&vpclattice.UpdateTargetGroupOutput{}, nil)
+			} else {
+				mockLattice.EXPECT().UpdateTargetGroupWithContext(ctx, gomock.Any()).Times(0)
+			}
+
+			tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+			resp, err := tgManager.Upsert(ctx, &tgCreateInput)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "id", resp.Id)
+		})
+	}
+}
+
 // target group status is create-in-progress before creation, return Retry
 func Test_CreateTargetGroup_ExistingTG_Status_Retry(t *testing.T) {
 	c := gomock.NewController(t)
@@ -481,6 +741,7 @@ func Test_DeleteTG_DeRegisterTargets_DeleteTargetGroup(t *testing.T) {
 	defer c.Finish()
 	ctx := context.TODO()
 	mockLattice := mocks.NewMockLattice(c)
+	expectOwnedBeforeDelete(mockLattice)
 	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetsOutput, nil)
 	mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, gomock.Any()).Return(deRegisterTargetsOutput, nil)
 	mockLattice.EXPECT().DeleteTargetGroupWithContext(ctx, gomock.Any()).Return(deleteTargetGroupOutput, nil)
@@ -521,6 +782,7 @@ func Test_DeleteTG_NoRegisteredTargets_DeleteTargetGroup(t *testing.T) {
 	defer c.Finish()
 	ctx := context.TODO()
 	mockLattice := mocks.NewMockLattice(c)
+	expectOwnedBeforeDelete(mockLattice)
 	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetsOutput, nil)
 	mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, gomock.Any()).Return(deRegisterTargetsOutput, nil)
 	mockLattice.EXPECT().DeleteTargetGroupWithContext(ctx, gomock.Any()).Return(deleteTargetGroupOutput, nil)
@@ -539,6 +801,7 @@ func Test_DeleteTG_WithExistingTG(t *testing.T) {
 	defer c.Finish()
 	ctx := context.TODO()
 	mockLattice := mocks.NewMockLattice(c)
+	expectOwnedBeforeDelete(mockLattice)
 	mockTagging := mocks.NewMockTagging(c)
 	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
 
@@ -580,6 +843,58 @@ func Test_DeleteTG_WithExistingTG(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func Test_DeleteTG_NotOwned(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	tgDeleteInput := model.TargetGroup{
+		Status: &model.TargetGroupStatus{
+			Name: "name",
+			Arn:  "arn",
+			Id:   "id",
+		},
+	}
+	otherOwner := "other-account/other-cluster/other-vpc"
+	for _, tags := range []mocks.Tags{{}, {pkg_aws.TagManagedBy: &otherOwner}} {
+		mockLattice.EXPECT().ListTagsForResourceWithContext(ctx, &vpclattice.ListTagsForResourceInput{
+			ResourceArn: aws.String("arn"),
+		}).Return(&vpclattice.ListTagsForResourceOutput{Tags: tags}, nil)
+		mockLattice.EXPECT().ListTargetsAsList(gomock.Any(), gomock.Any()).Times(0)
+		mockLattice.EXPECT().DeleteTargetGroupWithContext(gomock.Any(), gomock.Any()).Times(0)
+
+		tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+		err := tgManager.Delete(ctx, &tgDeleteInput)
+		assert.ErrorIs(t, err, ErrNotOwned)
+	}
+}
+
+func Test_DeleteTG_ResolvesArnForOwnershipCheck(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	tgDeleteInput := model.TargetGroup{
+		Status: &model.TargetGroupStatus{Id: "id"},
+	}
+	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
+		TargetGroupIdentifier: aws.String("id"),
+	}).Return(&vpclattice.GetTargetGroupOutput{Arn: aws.String("arn"), Id: aws.String("id")}, nil)
+	expectOwnedBeforeDelete(mockLattice)
+	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(nil, nil)
+	mockLattice.EXPECT().DeleteTargetGroupWithContext(ctx, gomock.Any()).Return(&vpclattice.DeleteTargetGroupOutput{}, nil)
+
+	tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+	assert.Nil(t, tgManager.Delete(ctx, &tgDeleteInput))
+	assert.Equal(t, "arn", tgDeleteInput.Status.Arn)
+}
+
 func Test_DeleteTG_NothingToDelete(t *testing.T) {
 	c := gomock.NewController(t)
 	defer c.Finish()
@@ -632,6 +947,7 @@ func Test_DeleteTG_DeRegisteredTargetsFailed(t *testing.T) {
 	defer c.Finish()
 	ctx := context.TODO()
 	mockLattice := mocks.NewMockLattice(c)
+	expectOwnedBeforeDelete(mockLattice)
 	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetsOutput, nil)
 	mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, gomock.Any()).Return(deRegisterTargetsOutput, errors.New("Deregister_failed"))
 	mockTagging := mocks.NewMockTagging(c)
@@ -668,6 +984,7 @@ func Test_DeleteTG_ListTargetsFailed(t *testing.T) {
 	defer c.Finish()
 	ctx := context.TODO()
 	mockLattice := mocks.NewMockLattice(c)
+	expectOwnedBeforeDelete(mockLattice)
 	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetsOutput, errors.New("Listregister_failed"))
 	mockTagging := mocks.NewMockTagging(c)
 	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
@@ -713,6 +1030,7 @@ func Test_DeleteTG_DeRegisterTargetsUnsuccessfully(t *testing.T) {
 	defer c.Finish()
 	ctx := context.TODO()
 	mockLattice := mocks.NewMockLattice(c)
+	expectOwnedBeforeDelete(mockLattice)
 	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetsOutput, nil)
 	mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, gomock.Any()).Return(deRegisterTargetsOutput, nil)
 	mockTagging := mocks.NewMockTagging(c)
@@ -760,6 +1078,7 @@ func Test_DeleteTG_DeRegisterTargets_DeleteTargetGroupFailed(t *testing.T) {
 	defer c.Finish()
 	ctx := context.TODO()
 	mockLattice := mocks.NewMockLattice(c)
+	expectOwnedBeforeDelete(mockLattice)
 	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetsOutput, nil)
 	mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, gomock.Any()).Return(deRegisterTargetsOutput, nil)
 	mockLattice.EXPECT().DeleteTargetGroupWithContext(ctx, gomock.Any()).Return(deleteTargetGroupOutput, errors.New("DeleteTG_failed"))
@@ -959,7 +1278,7 @@ func Test_defaultTargetGroupManager_getDefaultHealthCheckConfig(t *testing.T) {
 				HealthyThresholdCount:      defaultHealthyThresholdCount,
 				UnhealthyThresholdCount:    defaultUnhealthyThresholdCount,
 				Matcher:                    defaultMatcher,
-				Path:                       defaultPath,
+				Path:                       aws.String("/grpc.health.v1.Health/Check"),
 				Port:                       nil,
 				Protocol:                   defaultProtocol,
 				ProtocolVersion:            aws.String(vpclattice.HealthCheckProtocolVersionHttp1),
@@ -993,6 +1312,129 @@ func Test_defaultTargetGroupManager_getDefaultHealthCheckConfig(t *testing.T) {
 	}
 }
 
+func Test_CreateTargetGroup_DefaultHealthCheck(t *testing.T) {
+	defaultHTTPPath, defaultGRPCPath, defaultGRPCEnabled := config.HTTPHealthCheckPath, config.GRPCHealthCheckPath, config.GRPCHealthCheckEnabled
+	defer func() {
+		config.HTTPHealthCheckPath, config.GRPCHealthCheckPath, config.GRPCHealthCheckEnabled = defaultHTTPPath, defaultGRPCPath, defaultGRPCEnabled
+	}()
+
+	tests := []struct {
+		name                string
+		protocolVersion     string
+		healthCheck         *vpclattice.HealthCheckConfig
+		httpPath            string
+		grpcPath            string
+		grpcEnabled         bool
+		wantEnabled         bool
+		wantPath            string
+		wantProtocolVersion string
+	}{
+		{
+			name:                "HTTP target group",
+			protocolVersion:     vpclattice.TargetGroupProtocolVersionHttp1,
+			wantEnabled:         true,
+			wantPath:            "/",
+			wantProtocolVersion: vpclattice.HealthCheckProtocolVersionHttp1,
+		},
+		{
+			name:                "GRPC target group",
+			protocolVersion:     vpclattice.TargetGroupProtocolVersionGrpc,
+			wantEnabled:         false,
+			wantPath:            "/grpc.health.v1.Health/Check",
+			wantProtocolVersion: vpclattice.HealthCheckProtocolVersionHttp1,
+		},
+		{
+			name:                "HTTP target group with overridden path",
+			protocolVersion:     vpclattice.TargetGroupProtocolVersionHttp1,
+			httpPath:            "/healthz",
+			wantEnabled:         true,
+			wantPath:            "/healthz",
+			wantProtocolVersion: vpclattice.HealthCheckProtocolVersionHttp1,
+		},
+		{
+			name:                "GRPC target group with health checks enabled",
+			protocolVersion:     vpclattice.TargetGroupProtocolVersionGrpc,
+			grpcPath:            "/health",
+			grpcEnabled:         true,
+			wantEnabled:         true,
+			wantPath:            "/health",
+			wantProtocolVersion: vpclattice.HealthCheckProtocolVersionHttp2,
+		},
+		{
+			name:            "TargetGroupPolicy settings are kept",
+			protocolVersion: vpclattice.TargetGroupProtocolVersionGrpc,
+			healthCheck: &vpclattice.HealthCheckConfig{
+				Enabled: aws.Bool(true),
+				Path:    aws.String("/custom"),
+			},
+			wantEnabled:         true,
+			wantPath:            "/custom",
+			wantProtocolVersion: vpclattice.HealthCheckProtocolVersionHttp1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+
+			config.HTTPHealthCheckPath, config.GRPCHealthCheckPath, config.GRPCHealthCheckEnabled = defaultHTTPPath, defaultGRPCPath, tt.grpcEnabled
+			if tt.httpPath != "" {
+				config.HTTPHealthCheckPath = tt.httpPath
+			}
+			if tt.grpcPath != "" {
+				config.GRPCHealthCheckPath = tt.grpcPath
+			}
+
+			mockLattice := mocks.NewMockLattice(c)
+			mockTagging := mocks.NewMockTagging(c)
+			cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+			tg := &model.TargetGroup{
+				Spec: model.TargetGroupSpec{
+					Port:              int32(8080),
+					Protocol:          vpclattice.TargetGroupProtocolHttp,
+					ProtocolVersion:   tt.protocolVersion,
+					HealthCheckConfig: tt.healthCheck,
+					TargetGroupTagFields: model.TargetGroupTagFields{
+						K8SClusterName:      "cluster-name",
+						K8SSourceType:       model.SourceTypeSvcExport,
+						K8SServiceName:      "svc",
+						K8SServiceNamespace: "default",
+					},
+					VpcId: "vpc-id",
+					Type:  model.TargetGroupTypeIP,
+				},
+			}
+
+			mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
+			mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, input *vpclattice.CreateTargetGroupInput, arg3 ...interface{}) (*vpclattice.CreateTargetGroupOutput, error) {
+					hc := input.Config.HealthCheck
+					assert.Equal(t, tt.wantEnabled, aws.BoolValue(hc.Enabled))
+					assert.Equal(t, tt.wantPath, aws.StringValue(hc.Path))
+					assert.Equal(t, tt.wantProtocolVersion, aws.StringValue(hc.ProtocolVersion))
+					assert.Equal(t, int64(30), aws.Int64Value(hc.HealthCheckIntervalSeconds))
+					return &vpclattice.CreateTargetGroupOutput{
+						Arn:    aws.String("tg-arn"),
+						Id:     aws.String("tg-id"),
+						Name:   aws.String("tg-name"),
+						Status: aws.String(vpclattice.TargetGroupStatusActive),
+					}, nil
+				},
+			)
+
+			tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+			_, err := tgManager.Upsert(ctx, tg)
+			assert.Nil(t, err)
+			if tt.healthCheck != nil {
+				assert.Nil(t, tt.healthCheck.HealthCheckIntervalSeconds, "model health check must not be modified")
+			}
+		})
+	}
+}
+
 func Test_IsTargetGroupMatch(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1083,6 +1525,39 @@ func Test_IsTargetGroupMatch(t *testing.T) {
 	}
 }
 
+func Test_immutableFieldChanges(t *testing.T) {
+	modelTg := &model.TargetGroup{
+		Spec: model.TargetGroupSpec{
+			Type:          model.TargetGroupTypeIP,
+			Port:          80,
+			Protocol:      vpclattice.TargetGroupProtocolHttp,
+			IpAddressType: vpclattice.IpAddressTypeIpv4,
+			VpcId:         "vpc-id",
+			HealthCheckConfig: &vpclattice.HealthCheckConfig{
+				HealthCheckIntervalSeconds: aws.Int64(10),
+			},
+		},
+	}
+
+	unchanged := &vpclattice.TargetGroupSummary{
+		Type:          aws.String(vpclattice.TargetGroupTypeIp),
+		Port:          aws.Int64(80),
+		Protocol:      aws.String(vpclattice.TargetGroupProtocolHttp),
+		IpAddressType: aws.String(vpclattice.IpAddressTypeIpv4),
+		VpcIdentifier: aws.String("vpc-id"),
+	}
+	assert.Empty(t, immutableFieldChanges(modelTg, unchanged))
+
+	changed := &vpclattice.TargetGroupSummary{
+		Type:          aws.String(vpclattice.TargetGroupTypeIp),
+		Port:          aws.Int64(8080),
+		Protocol:      aws.String(vpclattice.TargetGroupProtocolHttps),
+		IpAddressType: aws.String(vpclattice.IpAddressTypeIpv4),
+		VpcIdentifier: aws.String("other-vpc-id"),
+	}
+	assert.Equal(t, []string{"port", "protocol", "vpcIdentifier"}, immutableFieldChanges(modelTg, changed))
+}
+
 func Test_ResolveRuleTgIds(t *testing.T) {
 	config.VpcID = "vpc-id"
 	config.ClusterName = "cluster-name"
@@ -1153,3 +1628,49 @@ func Test_ResolveRuleTgIds(t *testing.T) {
 	assert.Equal(t, "tg-id", stackRule.Spec.Action.TargetGroups[1].LatticeTgId)
 	assert.Equal(t, model.InvalidBackendRefTgId, stackRule.Spec.Action.TargetGroups[2].LatticeTgId)
 }
+
+func Test_ResolveRuleTgIds_ServiceImportNotExported(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	mockCloud := pkg_aws.NewMockCloud(c)
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+	mockCloud.EXPECT().Tagging().Return(mockTagging).AnyTimes()
+	// only the target group of another exported service exists
+	mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(
+		map[string]map[string]*string{
+			"svc-export-tg-arn": {
+				model.K8SServiceNameKey:      aws.String("other-svc"),
+				model.K8SServiceNamespaceKey: aws.String("ns"),
+				model.K8SSourceTypeKey:       aws.String(string(model.SourceTypeSvcExport)),
+			},
+		}, nil)
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return(
+		[]*vpclattice.TargetGroupSummary{
+			{
+				Arn: aws.String("svc-export-tg-arn"),
+				Id:  aws.String("svc-export-tg-id"),
+			},
+		}, nil)
+
+	ruleAction := &model.RuleAction{
+		TargetGroups: []*model.RuleTargetGroup{
+			{
+				SvcImportTG: &model.SvcImportTargetGroup{
+					K8SServiceName:      "svc-name",
+					K8SServiceNamespace: "ns",
+				},
+			},
+		},
+	}
+	s := NewTargetGroupManager(gwlog.FallbackLogger, mockCloud)
+	err := s.ResolveRuleTgIds(ctx, ruleAction, core.NewDefaultStack(core.StackID{Name: "foo", Namespace: "bar"}))
+
+	notFoundErr := &ServiceImportTargetGroupNotFoundError{}
+	assert.ErrorAs(t, err, &notFoundErr)
+	assert.Equal(t, "svc-name", notFoundErr.K8SServiceName)
+	assert.Equal(t, "ns", notFoundErr.K8SServiceNamespace)
+	assert.Empty(t, ruleAction.TargetGroups[0].LatticeTgId)
+}
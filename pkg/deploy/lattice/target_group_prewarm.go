@@ -0,0 +1,181 @@
+package lattice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+)
+
+//go:generate mockgen -destination target_group_prewarm_mock.go -package lattice github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice TargetGroupPrewarmManager
+
+// TargetGroupPrewarmManager manages empty target groups created for a service before any route refers to it.
+// A prewarmed target group is adopted by the first route whose target group for the service matches it.
+type TargetGroupPrewarmManager interface {
+	Prewarm(ctx context.Context, modelTg *model.TargetGroup) (model.TargetGroupStatus, error)
+	DeletePrewarmed(ctx context.Context, svcName types.NamespacedName) error
+}
+
+func NewTargetGroupPrewarmManager(log gwlog.Logger, cloud pkg_aws.Cloud) TargetGroupPrewarmManager {
+	return NewTargetGroupManager(log, cloud)
+}
+
+// Prewarm creates the target group unless one already exists for the service, either prewarmed or owned by a route.
+// An empty status is returned when a route target group already exists for the service.
+func (s *defaultTargetGroupManager) Prewarm(ctx context.Context, modelTg *model.TargetGroup) (model.TargetGroupStatus, error) {
+	if !modelTg.Spec.IsSourceTypePrewarm() {
+		return model.TargetGroupStatus{}, fmt.Errorf("target group for %s/%s is not a prewarm target group",
+			modelTg.Spec.K8SServiceNamespace, modelTg.Spec.K8SServiceName)
+	}
+
+	latticeTg, err := s.findTargetGroup(ctx, modelTg)
+	if err != nil {
+		return model.TargetGroupStatus{}, err
+	}
+	if latticeTg != nil {
+		return s.update(ctx, modelTg, latticeTg)
+	}
+
+	// once adopted, the prewarmed target group is tagged with the route and must not be created again
+	arns, err := s.cloud.Tagging().FindResourcesByTags(ctx, services.ResourceTypeTargetGroup, services.Tags{
+		model.K8SClusterNameKey:      aws.String(modelTg.Spec.K8SClusterName),
+		model.K8SServiceNameKey:      aws.String(modelTg.Spec.K8SServiceName),
+		model.K8SServiceNamespaceKey: aws.String(modelTg.Spec.K8SServiceNamespace),
+		model.K8SProtocolVersionKey:  aws.String(modelTg.Spec.K8SProtocolVersion),
+	})
+	if err != nil {
+		return model.TargetGroupStatus{}, err
+	}
+	if len(arns) > 0 {
+		tgArnToTags, err := s.cloud.Tagging().GetTagsForArns(ctx, arns)
+		if err != nil {
+			return model.TargetGroupStatus{}, err
+		}
+		for arn, tags := range tgArnToTags {
+			tagFields := model.TGTagFieldsFromTags(tags)
+			if tagFields.IsSourceTypeRoute() {
+				s.log.Debugf(ctx, "Service %s/%s already has route target group %s, skipping prewarm",
+					modelTg.Spec.K8SServiceNamespace, modelTg.Spec.K8SServiceName, arn)
+				return model.TargetGroupStatus{}, nil
+			}
+		}
+	}
+
+	return s.create(ctx, modelTg)
+}
+
+// DeletePrewarmed deletes the target groups prewarmed for the service which have not been adopted by a route.
+func (s *defaultTargetGroupManager) DeletePrewarmed(ctx context.Context, svcName types.NamespacedName) error {
+	arns, err := s.cloud.Tagging().FindResourcesByTags(ctx, services.ResourceTypeTargetGroup, services.Tags{
+		model.K8SClusterNameKey:      aws.String(config.ClusterName),
+		model.K8SServiceNameKey:      aws.String(svcName.Name),
+		model.K8SServiceNamespaceKey: aws.String(svcName.Namespace),
+		model.K8SSourceTypeKey:       aws.String(string(model.SourceTypePrewarm)),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, arn := range arns {
+		latticeTg, err := s.cloud.Lattice().GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
+			TargetGroupIdentifier: aws.String(arn),
+		})
+		if err != nil {
+			if services.IsNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+		if len(latticeTg.ServiceArns) > 0 {
+			s.log.Infof(ctx, "Prewarmed target group %s is in use, skipping deletion", arn)
+			continue
+		}
+
+		s.log.Infof(ctx, "Deleting prewarmed target group %s for service %s", arn, svcName)
+		err = s.Delete(ctx, &model.TargetGroup{
+			Status: &model.TargetGroupStatus{
+				Name: aws.StringValue(latticeTg.Name),
+				Arn:  aws.StringValue(latticeTg.Arn),
+				Id:   aws.StringValue(latticeTg.Id),
+			},
+			IsDeleted: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// adoptPrewarmedTargetGroup looks for a target group prewarmed for the service of the given route target group,
+// and hands it over to the route by tagging it with the route information.
+func (s *defaultTargetGroupManager) adoptPrewarmedTargetGroup(
+	ctx context.Context,
+	modelTg *model.TargetGroup,
+) (*vpclattice.GetTargetGroupOutput, error) {
+	prewarmTagFields := modelTg.Spec.TargetGroupTagFields
+	prewarmTagFields.K8SSourceType = model.SourceTypePrewarm
+	prewarmTagFields.K8SRouteName = ""
+	prewarmTagFields.K8SRouteNamespace = ""
+
+	arns, err := s.cloud.Tagging().FindResourcesByTags(ctx, services.ResourceTypeTargetGroup,
+		model.TagsFromTGTagFields(prewarmTagFields))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arn := range arns {
+		latticeTg, err := s.cloud.Lattice().GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
+			TargetGroupIdentifier: aws.String(arn),
+		})
+		if err != nil {
+			if services.IsNotFoundError(err) {
+				continue
+			}
+			return nil, err
+		}
+		if aws.StringValue(latticeTg.Status) != vpclattice.TargetGroupStatusActive {
+			continue
+		}
+
+		match, err := s.IsTargetGroupMatch(ctx, modelTg, &vpclattice.TargetGroupSummary{
+			Arn:           latticeTg.Arn,
+			Port:          latticeTg.Config.Port,
+			Protocol:      latticeTg.Config.Protocol,
+			IpAddressType: latticeTg.Config.IpAddressType,
+			Type:          latticeTg.Type,
+			VpcIdentifier: latticeTg.Config.VpcIdentifier,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+
+		_, err = s.cloud.Lattice().TagResourceWithContext(ctx, &vpclattice.TagResourceInput{
+			ResourceArn: latticeTg.Arn,
+			Tags: services.Tags{
+				model.K8SSourceTypeKey:     aws.String(string(modelTg.Spec.K8SSourceType)),
+				model.K8SRouteNameKey:      aws.String(modelTg.Spec.K8SRouteName),
+				model.K8SRouteNamespaceKey: aws.String(modelTg.Spec.K8SRouteNamespace),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to adopt prewarmed target group %s due to %w", arn, err)
+		}
+		s.log.Infof(ctx, "Route %s/%s adopted prewarmed target group %s",
+			modelTg.Spec.K8SRouteNamespace, modelTg.Spec.K8SRouteName, arn)
+		return latticeTg, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice (interfaces: TargetGroupPrewarmManager)
+
+// Package lattice is a generated GoMock package.
+package lattice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	lattice0 "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	gomock "github.com/golang/mock/gomock"
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// MockTargetGroupPrewarmManager is a mock of TargetGroupPrewarmManager interface.
+type MockTargetGroupPrewarmManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTargetGroupPrewarmManagerMockRecorder
+}
+
+// MockTargetGroupPrewarmManagerMockRecorder is the mock recorder for MockTargetGroupPrewarmManager.
+type MockTargetGroupPrewarmManagerMockRecorder struct {
+	mock *MockTargetGroupPrewarmManager
+}
+
+// NewMockTargetGroupPrewarmManager creates a new mock instance.
+func NewMockTargetGroupPrewarmManager(ctrl *gomock.Controller) *MockTargetGroupPrewarmManager {
+	mock := &MockTargetGroupPrewarmManager{ctrl: ctrl}
+	mock.recorder = &MockTargetGroupPrewarmManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTargetGroupPrewarmManager) EXPECT() *MockTargetGroupPrewarmManagerMockRecorder {
+	return m.recorder
+}
+
+// DeletePrewarmed mocks base method.
+func (m *MockTargetGroupPrewarmManager) DeletePrewarmed(arg0 context.Context, arg1 types.NamespacedName) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrewarmed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrewarmed indicates an expected call of DeletePrewarmed.
+func (mr *MockTargetGroupPrewarmManagerMockRecorder) DeletePrewarmed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrewarmed", reflect.TypeOf((*MockTargetGroupPrewarmManager)(nil).DeletePrewarmed), arg0, arg1)
+}
+
+// Prewarm mocks base method.
+func (m *MockTargetGroupPrewarmManager) Prewarm(arg0 context.Context, arg1 *lattice0.TargetGroup) (lattice0.TargetGroupStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prewarm", arg0, arg1)
+	ret0, _ := ret[0].(lattice0.TargetGroupStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prewarm indicates an expected call of Prewarm.
+func (mr *MockTargetGroupPrewarmManagerMockRecorder) Prewarm(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prewarm", reflect.TypeOf((*MockTargetGroupPrewarmManager)(nil).Prewarm), arg0, arg1)
+}
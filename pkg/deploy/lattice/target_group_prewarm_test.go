@@ -0,0 +1,191 @@
+package lattice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func prewarmTgSpec(sourceType model.K8SSourceType) model.TargetGroupSpec {
+	spec := model.TargetGroupSpec{
+		Type:            model.TargetGroupTypeIP,
+		Port:            80,
+		Protocol:        vpclattice.TargetGroupProtocolHttp,
+		ProtocolVersion: vpclattice.TargetGroupProtocolVersionHttp1,
+		IpAddressType:   vpclattice.IpAddressTypeIpv4,
+	}
+	spec.VpcId = config.VpcID
+	spec.K8SClusterName = config.ClusterName
+	spec.K8SSourceType = sourceType
+	spec.K8SServiceName = "svc"
+	spec.K8SServiceNamespace = "ns"
+	spec.K8SProtocolVersion = vpclattice.TargetGroupProtocolVersionHttp1
+	if sourceType != model.SourceTypePrewarm {
+		spec.K8SRouteName = "route"
+		spec.K8SRouteNamespace = "ns"
+	}
+	return spec
+}
+
+func Test_Prewarm_CreatesTargetGroup(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	config.VpcID = "vpc-id"
+	config.ClusterName = "cluster-name"
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	// no prewarmed target group, and no target group for the service
+	mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.CreateTargetGroupInput, arg3 ...interface{}) (*vpclattice.CreateTargetGroupOutput, error) {
+			assert.Equal(t, string(model.SourceTypePrewarm), aws.StringValue(input.Tags[model.K8SSourceTypeKey]))
+			assert.Equal(t, "svc", aws.StringValue(input.Tags[model.K8SServiceNameKey]))
+			assert.NotContains(t, input.Tags, model.K8SRouteNameKey)
+			assert.NotContains(t, input.Tags, model.K8SRouteNamespaceKey)
+			return &vpclattice.CreateTargetGroupOutput{
+				Arn:    aws.String("tg-arn"),
+				Id:     aws.String("tg-id"),
+				Name:   aws.String("tg-name"),
+				Status: aws.String(vpclattice.TargetGroupStatusActive),
+			}, nil
+		})
+
+	tgManager := NewTargetGroupPrewarmManager(gwlog.FallbackLogger, cloud)
+	status, err := tgManager.Prewarm(ctx, &model.TargetGroup{Spec: prewarmTgSpec(model.SourceTypePrewarm)})
+	assert.Nil(t, err)
+	assert.Equal(t, "tg-arn", status.Arn)
+}
+
+func Test_Prewarm_SkipsWhenRouteTargetGroupExists(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	config.VpcID = "vpc-id"
+	config.ClusterName = "cluster-name"
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	gomock.InOrder(
+		mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return(nil, nil),
+		mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{"route-tg-arn"}, nil),
+	)
+	mockTagging.EXPECT().GetTagsForArns(ctx, []string{"route-tg-arn"}).Return(map[string]mocks.Tags{
+		"route-tg-arn": model.TagsFromTGTagFields(prewarmTgSpec(model.SourceTypeHTTPRoute).TargetGroupTagFields),
+	}, nil)
+	mockLattice.EXPECT().CreateTargetGroupWithContext(gomock.Any(), gomock.Any()).Times(0)
+
+	tgManager := NewTargetGroupPrewarmManager(gwlog.FallbackLogger, cloud)
+	status, err := tgManager.Prewarm(ctx, &model.TargetGroup{Spec: prewarmTgSpec(model.SourceTypePrewarm)})
+	assert.Nil(t, err)
+	assert.Equal(t, "", status.Arn)
+}
+
+func Test_Upsert_RouteAdoptsPrewarmedTargetGroup(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	config.VpcID = "vpc-id"
+	config.ClusterName = "cluster-name"
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	routeSpec := prewarmTgSpec(model.SourceTypeHTTPRoute)
+	prewarmTags := model.TagsFromTGTagFields(prewarmTgSpec(model.SourceTypePrewarm).TargetGroupTagFields)
+
+	gomock.InOrder(
+		// no target group for the route yet
+		mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return(nil, nil),
+		mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), prewarmTags).Return([]string{"prewarm-tg-arn"}, nil),
+	)
+	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&vpclattice.GetTargetGroupOutput{
+		Arn:    aws.String("prewarm-tg-arn"),
+		Id:     aws.String("prewarm-tg-id"),
+		Name:   aws.String("prewarm-tg-name"),
+		Status: aws.String(vpclattice.TargetGroupStatusActive),
+		Type:   aws.String(string(model.TargetGroupTypeIP)),
+		Config: &vpclattice.TargetGroupConfig{
+			Port:          aws.Int64(80),
+			Protocol:      aws.String(vpclattice.TargetGroupProtocolHttp),
+			IpAddressType: aws.String(vpclattice.IpAddressTypeIpv4),
+			VpcIdentifier: aws.String(config.VpcID),
+			HealthCheck:   NewTargetGroupManager(gwlog.FallbackLogger, cloud).getDefaultHealthCheckConfig(vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+		},
+	}, nil)
+	mockLattice.EXPECT().TagResourceWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.TagResourceInput, arg3 ...interface{}) (*vpclattice.TagResourceOutput, error) {
+			assert.Equal(t, "prewarm-tg-arn", aws.StringValue(input.ResourceArn))
+			assert.Equal(t, string(model.SourceTypeHTTPRoute), aws.StringValue(input.Tags[model.K8SSourceTypeKey]))
+			assert.Equal(t, "route", aws.StringValue(input.Tags[model.K8SRouteNameKey]))
+			assert.Equal(t, "ns", aws.StringValue(input.Tags[model.K8SRouteNamespaceKey]))
+			return &vpclattice.TagResourceOutput{}, nil
+		})
+	mockLattice.EXPECT().CreateTargetGroupWithContext(gomock.Any(), gomock.Any()).Times(0)
+
+	tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+	status, err := tgManager.Upsert(ctx, &model.TargetGroup{Spec: routeSpec})
+	assert.Nil(t, err)
+	assert.Equal(t, "prewarm-tg-arn", status.Arn)
+	assert.Equal(t, "prewarm-tg-id", status.Id)
+}
+
+func Test_DeletePrewarmed(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	config.VpcID = "vpc-id"
+	config.ClusterName = "cluster-name"
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), mocks.Tags{
+		model.K8SClusterNameKey:      aws.String("cluster-name"),
+		model.K8SServiceNameKey:      aws.String("svc"),
+		model.K8SServiceNamespaceKey: aws.String("ns"),
+		model.K8SSourceTypeKey:       aws.String(string(model.SourceTypePrewarm)),
+	}).Return([]string{"unused-arn", "in-use-arn"}, nil)
+	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
+		TargetGroupIdentifier: aws.String("unused-arn"),
+	}).Return(&vpclattice.GetTargetGroupOutput{
+		Arn: aws.String("unused-arn"),
+		Id:  aws.String("unused-id"),
+	}, nil)
+	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
+		TargetGroupIdentifier: aws.String("in-use-arn"),
+	}).Return(&vpclattice.GetTargetGroupOutput{
+		Arn:         aws.String("in-use-arn"),
+		Id:          aws.String("in-use-id"),
+		ServiceArns: []*string{aws.String("svc-arn")},
+	}, nil)
+
+	// only the target group not associated to any service is deleted
+	expectOwnedBeforeDelete(mockLattice)
+	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(nil, nil)
+	mockLattice.EXPECT().DeleteTargetGroupWithContext(ctx, &vpclattice.DeleteTargetGroupInput{
+		TargetGroupIdentifier: aws.String("unused-id"),
+	}).Return(&vpclattice.DeleteTargetGroupOutput{}, nil)
+
+	tgManager := NewTargetGroupPrewarmManager(gwlog.FallbackLogger, cloud)
+	err := tgManager.DeletePrewarmed(ctx, types.NamespacedName{Namespace: "ns", Name: "svc"})
+	assert.Nil(t, err)
+}
@@ -106,7 +106,7 @@ func (t *TargetGroupSynthesizer) SynthesizeDelete(ctx context.Context) error {
 		err := t.targetGroupManager.Delete(ctx, resTargetGroup)
 		if err != nil {
 			prefix := model.TgNamePrefix(resTargetGroup.Spec)
-			retErr = errors.Join(retErr, fmt.Errorf("failed TargetGroupManager.Delete %s due to %s", prefix, err))
+			retErr = errors.Join(retErr, fmt.Errorf("failed TargetGroupManager.Delete %s due to %w", prefix, err))
 		}
 	}
 
@@ -158,7 +158,7 @@ func (t *TargetGroupSynthesizer) SynthesizeUnusedDelete(ctx context.Context) ([]
 func (t *TargetGroupSynthesizer) calculateTargetGroupsToDelete(ctx context.Context) ([]tgListOutput, error) {
 	latticeTgs, err := t.targetGroupManager.List(ctx)
 	if err != nil {
-		return latticeTgs, fmt.Errorf("failed TargetGroupManager.List due to %s", err)
+		return latticeTgs, fmt.Errorf("failed TargetGroupManager.List due to %w", err)
 	}
 
 	var tgsToDelete []tgListOutput
@@ -179,6 +179,11 @@ func (t *TargetGroupSynthesizer) calculateTargetGroupsToDelete(ctx context.Conte
 			continue
 		}
 
+		// prewarmed target groups are cleaned up by the service controller once their TTL expires
+		if tagFields.IsSourceTypePrewarm() {
+			continue
+		}
+
 		// most importantly, is the tg in use?
 		if len(latticeTg.tgSummary.ServiceArns) > 0 {
 			t.log.Debugf(ctx, "TargetGroup %s (%s) is referenced by lattice service",
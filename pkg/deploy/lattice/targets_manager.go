@@ -4,6 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 
@@ -18,6 +23,10 @@ const (
 	// Maximum allowed number of targets per each VPC Lattice RegisterTargets/DeregisterTargets API call
 	// https://docs.aws.amazon.com/vpc-lattice/latest/APIReference/API_RegisterTargets.html
 	maxTargetsPerLatticeTargetsApiCall = 100
+
+	// EndpointSlices can be empty for a moment during rolling updates. Serving targets of a target group
+	// are only all deregistered once it has no targets for at least this long
+	emptyTargetsConfirmDelay = 10 * time.Second
 )
 
 //go:generate mockgen -destination targets_manager_mock.go -package lattice github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice TargetsManager
@@ -30,6 +39,11 @@ type TargetsManager interface {
 type defaultTargetsManager struct {
 	log   gwlog.Logger
 	cloud pkg_aws.Cloud
+
+	lock sync.Mutex
+	// when target groups were first seen without targets while having serving targets, by target group id
+	emptySince map[string]time.Time
+	now        func() time.Time
 }
 
 func NewTargetsManager(
@@ -37,8 +51,10 @@ func NewTargetsManager(
 	cloud pkg_aws.Cloud,
 ) *defaultTargetsManager {
 	return &defaultTargetsManager{
-		log:   log,
-		cloud: cloud,
+		log:        log,
+		cloud:      cloud,
+		emptySince: make(map[string]time.Time),
+		now:        time.Now,
 	}
 }
 
@@ -65,13 +81,52 @@ func (s *defaultTargetsManager) Update(ctx context.Context, modelTargets *model.
 	if err != nil {
 		return err
 	}
+	desiredTargets := sortTargets(modelTargets.Spec.TargetList)
 	staleTargets := s.findStaleTargets(modelTargets, latticeTargets)
+	if !s.confirmEmptyTargets(modelTg, modelTargets, latticeTargets) {
+		return fmt.Errorf("%w: target group %s has no targets, confirming before deregistering its serving targets",
+			RetryErr, modelTg.Status.Id)
+	}
 
 	err1 := s.deregisterTargets(ctx, modelTg, staleTargets)
-	err2 := s.registerTargets(ctx, modelTg, modelTargets.Spec.TargetList)
+	err2 := s.registerTargets(ctx, modelTg, desiredTargets)
 	return errors.Join(err1, err2)
 }
 
+// confirmEmptyTargets returns false when the target group has no targets but still has serving ones in
+// Lattice, until it stayed without targets for emptyTargetsConfirmDelay. Deregistering all of them on a
+// transient empty EndpointSlice would cause an outage until the new targets are registered and healthy.
+func (s *defaultTargetsManager) confirmEmptyTargets(modelTg *model.TargetGroup, modelTargets *model.Targets, latticeTargets []*vpclattice.TargetSummary) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	tgId := modelTg.Status.Id
+	if len(modelTargets.Spec.TargetList) > 0 || !hasServingTarget(latticeTargets) {
+		delete(s.emptySince, tgId)
+		return true
+	}
+	since, ok := s.emptySince[tgId]
+	if !ok {
+		s.emptySince[tgId] = s.now()
+		return false
+	}
+	if s.now().Sub(since) < emptyTargetsConfirmDelay {
+		return false
+	}
+	delete(s.emptySince, tgId)
+	return true
+}
+
+// Targets are serving when healthy, or when health checks are disabled
+func hasServingTarget(latticeTargets []*vpclattice.TargetSummary) bool {
+	for _, target := range latticeTargets {
+		switch aws.StringValue(target.Status) {
+		case vpclattice.TargetStatusHealthy, vpclattice.TargetStatusUnavailable:
+			return true
+		}
+	}
+	return false
+}
+
 func (s *defaultTargetsManager) findStaleTargets(
 	modelTargets *model.Targets,
 	listTargetsOutput []*vpclattice.TargetSummary) []model.Target {
@@ -96,7 +151,38 @@ func (s *defaultTargetsManager) findStaleTargets(
 			staleTargets = append(staleTargets, ipPort)
 		}
 	}
-	return staleTargets
+	return sortTargets(staleTargets)
+}
+
+// sortTargets returns a copy of targets ordered by IP, then port, so register and deregister calls do not
+// depend on the order of EndpointSlices or of ListTargets results.
+func sortTargets(targets []model.Target) []model.Target {
+	sorted := append([]model.Target(nil), targets...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.TargetIP != b.TargetIP {
+			return compareTargetIP(a.TargetIP, b.TargetIP) < 0
+		}
+		return a.Port < b.Port
+	})
+	return sorted
+}
+
+// IPs are compared numerically, anything else, e.g. instance ids, as strings after all IPs
+func compareTargetIP(a, b string) int {
+	aIP, aErr := netip.ParseAddr(a)
+	bIP, bErr := netip.ParseAddr(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		if c := aIP.Compare(bIP); c != 0 {
+			return c
+		}
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
 }
 
 func (s *defaultTargetsManager) registerTargets(
@@ -119,7 +205,7 @@ func (s *defaultTargetsManager) registerTargets(
 		}
 		resp, err := s.cloud.Lattice().RegisterTargetsWithContext(ctx, &registerTargetsInput)
 		if err != nil {
-			registerTargetsError = errors.Join(registerTargetsError, fmt.Errorf("Failed to register targets from VPC Lattice Target Group %s due to %s", modelTg.Status.Id, err))
+			registerTargetsError = errors.Join(registerTargetsError, fmt.Errorf("Failed to register targets from VPC Lattice Target Group %s due to %w", modelTg.Status.Id, err))
 		}
 		if len(resp.Unsuccessful) > 0 {
 			registerTargetsError = errors.Join(registerTargetsError, fmt.Errorf("Failed to register targets from VPC Lattice Target Group %s for chunk %d/%d, unsuccessful targets %v",
@@ -152,7 +238,7 @@ func (s *defaultTargetsManager) deregisterTargets(
 		}
 		resp, err := s.cloud.Lattice().DeregisterTargetsWithContext(ctx, &deregisterTargetsInput)
 		if err != nil {
-			deregisterTargetsError = errors.Join(deregisterTargetsError, fmt.Errorf("Failed to deregister targets from VPC Lattice Target Group %s due to %s", modelTg.Status.Id, err))
+			deregisterTargetsError = errors.Join(deregisterTargetsError, fmt.Errorf("Failed to deregister targets from VPC Lattice Target Group %s due to %w", modelTg.Status.Id, err))
 		}
 		if len(resp.Unsuccessful) > 0 {
 			deregisterTargetsError = errors.Join(deregisterTargetsError, fmt.Errorf("Failed to deregister targets from VPC Lattice Target Group %s for chunk %d/%d, unsuccessful targets %v",
@@ -3,8 +3,10 @@ package lattice
 import (
 	"context"
 	"errors"
+	"math/rand"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 
@@ -314,6 +316,67 @@ func TestTargetsManager(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("transient empty targets do not deregister serving targets", func(t *testing.T) {
+		emptyModelTargets := model.Targets{
+			Spec: model.TargetsSpec{
+				StackTargetGroupId: "tg-stack-id",
+				TargetList:         []model.Target{},
+			},
+		}
+		servingTargets := []*vpclattice.TargetSummary{
+			{
+				Id:     aws.String("192.0.2.250"),
+				Port:   aws.Int64(8080),
+				Status: aws.String(vpclattice.TargetStatusHealthy),
+			},
+			{
+				Id:     aws.String("192.0.2.251"),
+				Port:   aws.Int64(8080),
+				Status: aws.String(vpclattice.TargetStatusUnhealthy),
+			},
+		}
+		now := time.Now()
+		targetsManager := NewTargetsManager(gwlog.FallbackLogger, mockCloud)
+		targetsManager.now = func() time.Time { return now }
+
+		// slice is empty for a moment, targets are kept and the update is retried
+		mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(servingTargets, nil).Times(2)
+		err := targetsManager.Update(ctx, &emptyModelTargets, &modelTg)
+		assert.ErrorIs(t, err, RetryErr)
+		now = now.Add(5 * time.Second)
+		err = targetsManager.Update(ctx, &emptyModelTargets, &modelTg)
+		assert.ErrorIs(t, err, RetryErr)
+
+		// slice has endpoints again, only the stale targets are deregistered
+		mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(servingTargets, nil)
+		mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, gomock.Any()).Return(&vpclattice.DeregisterTargetsOutput{}, nil)
+		mockLattice.EXPECT().RegisterTargetsWithContext(ctx, gomock.Any()).Return(registerTargetsOutput, nil)
+		err = targetsManager.Update(ctx, &model.Targets{
+			Spec: model.TargetsSpec{
+				StackTargetGroupId: "tg-stack-id",
+				TargetList:         []model.Target{{TargetIP: "192.0.2.10", Port: 8080, Ready: true}},
+			},
+		}, &modelTg)
+		assert.Nil(t, err)
+		assert.Empty(t, targetsManager.emptySince)
+
+		// slice stays empty, targets are deregistered once confirmed
+		mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(servingTargets, nil).Times(2)
+		err = targetsManager.Update(ctx, &emptyModelTargets, &modelTg)
+		assert.ErrorIs(t, err, RetryErr)
+		now = now.Add(emptyTargetsConfirmDelay)
+		mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, &vpclattice.DeregisterTargetsInput{
+			TargetGroupIdentifier: aws.String("tg-id"),
+			Targets: []*vpclattice.Target{
+				{Id: aws.String("192.0.2.250"), Port: aws.Int64(8080)},
+				{Id: aws.String("192.0.2.251"), Port: aws.Int64(8080)},
+			},
+		}).Return(&vpclattice.DeregisterTargetsOutput{}, nil)
+		err = targetsManager.Update(ctx, &emptyModelTargets, &modelTg)
+		assert.Nil(t, err)
+		assert.Empty(t, targetsManager.emptySince)
+	})
+
 	t.Run("deregister more than 100 targets at once, handled correctly", func(t *testing.T) {
 
 		modelTargets.Spec.TargetList = []model.Target{}
@@ -334,4 +397,56 @@ func TestTargetsManager(t *testing.T) {
 
 		assert.Nil(t, err)
 	})
+
+	t.Run("register and deregister order is stable across shuffled inputs", func(t *testing.T) {
+		desired := []model.Target{
+			{TargetIP: "192.0.2.10", Port: 8080, Ready: true},
+			{TargetIP: "192.0.2.9", Port: 8081, Ready: true},
+			{TargetIP: "192.0.2.9", Port: 8080, Ready: true},
+			{TargetIP: "2001:db8::1", Port: 8080, Ready: true},
+		}
+		existing := []*vpclattice.TargetSummary{
+			{Id: aws.String("192.0.2.9"), Port: aws.Int64(8080)},
+			{Id: aws.String("192.0.2.100"), Port: aws.Int64(8080)},
+			{Id: aws.String("192.0.2.11"), Port: aws.Int64(8080)},
+			{Id: aws.String("192.0.2.11"), Port: aws.Int64(80)},
+		}
+		wantRegister := []*vpclattice.Target{
+			{Id: aws.String("192.0.2.9"), Port: aws.Int64(8080)},
+			{Id: aws.String("192.0.2.9"), Port: aws.Int64(8081)},
+			{Id: aws.String("192.0.2.10"), Port: aws.Int64(8080)},
+			{Id: aws.String("2001:db8::1"), Port: aws.Int64(8080)},
+		}
+		wantDeregister := []*vpclattice.Target{
+			{Id: aws.String("192.0.2.11"), Port: aws.Int64(80)},
+			{Id: aws.String("192.0.2.11"), Port: aws.Int64(8080)},
+			{Id: aws.String("192.0.2.100"), Port: aws.Int64(8080)},
+		}
+
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 10; i++ {
+			r.Shuffle(len(desired), func(i, j int) { desired[i], desired[j] = desired[j], desired[i] })
+			r.Shuffle(len(existing), func(i, j int) { existing[i], existing[j] = existing[j], existing[i] })
+			shuffledTargets := model.Targets{
+				Spec: model.TargetsSpec{
+					StackTargetGroupId: "tg-stack-id",
+					TargetList:         desired,
+				},
+			}
+
+			mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(existing, nil)
+			mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, &vpclattice.DeregisterTargetsInput{
+				TargetGroupIdentifier: aws.String(modelTg.Status.Id),
+				Targets:               wantDeregister,
+			}).Return(&vpclattice.DeregisterTargetsOutput{}, nil)
+			mockLattice.EXPECT().RegisterTargetsWithContext(ctx, &vpclattice.RegisterTargetsInput{
+				TargetGroupIdentifier: aws.String(modelTg.Status.Id),
+				Targets:               wantRegister,
+			}).Return(registerTargetsOutput, nil)
+
+			targetsManager := NewTargetsManager(gwlog.FallbackLogger, mockCloud)
+			err := targetsManager.Update(ctx, &shuffledTargets, &modelTg)
+			assert.Nil(t, err)
+		}
+	})
 }
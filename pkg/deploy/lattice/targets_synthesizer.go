@@ -9,6 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
@@ -18,13 +19,6 @@ import (
 
 const (
 	LatticeReadinessGateConditionType = webhook.PodReadinessGateConditionType
-
-	ReadinessReasonHealthy                = "Healthy"
-	ReadinessReasonUnhealthy              = "Unhealthy"
-	ReadinessReasonUnused                 = "Unused"
-	ReadinessReasonInitial                = "Initial"
-	ReadinessReasonHealthCheckUnavailable = "HealthCheckUnavailable"
-	ReadinessReasonTargetNotFound         = "TargetNotFound"
 )
 
 func NewTargetsSynthesizer(
@@ -68,7 +62,7 @@ func (t *targetsSynthesizer) Synthesize(ctx context.Context) error {
 			if tg.Status != nil && tg.Status.Id != "" {
 				identifier = tg.Status.Id
 			}
-			return fmt.Errorf("failed to synthesize targets %s due to %s", identifier, err)
+			return fmt.Errorf("failed to synthesize targets %s due to %w", identifier, err)
 		}
 	}
 	return nil
@@ -161,26 +155,26 @@ func (t *targetsSynthesizer) syncStatus(ctx context.Context, modelTargets []mode
 			switch status := aws.StringValue(latticeTarget.Status); status {
 			case vpclattice.TargetStatusHealthy:
 				newCond.Status = corev1.ConditionTrue
-				newCond.Reason = ReadinessReasonHealthy
+				newCond.Reason = string(conditions.ReasonHealthy)
 			case vpclattice.TargetStatusUnavailable:
 				// Lattice HC not turned on. Readiness is designed to work only with HC but do not block deployment on this case.
 				newCond.Status = corev1.ConditionTrue
-				newCond.Reason = ReadinessReasonHealthCheckUnavailable
+				newCond.Reason = string(conditions.ReasonHealthCheckUnavailable)
 			case vpclattice.TargetStatusUnused:
 				// Since this logic is called after HTTPRoute is wired, this only happens for ServiceExport TGs.
 				// In this case we do not have to evaluate them as Healthy, but we also do not have to requeue.
-				newCond.Reason = ReadinessReasonUnused
+				newCond.Reason = string(conditions.ReasonUnused)
 			case vpclattice.TargetStatusInitial:
 				requeue = true
-				newCond.Reason = ReadinessReasonInitial
+				newCond.Reason = string(conditions.ReasonInitial)
 			default:
 				requeue = true
-				newCond.Reason = ReadinessReasonUnhealthy
+				newCond.Reason = string(conditions.ReasonUnhealthy)
 				newCond.Message = fmt.Sprintf("Target health check status: %s", status)
 			}
 		} else {
 			requeue = true
-			newCond.Reason = ReadinessReasonTargetNotFound
+			newCond.Reason = string(conditions.ReasonTargetNotFound)
 		}
 
 		// Step 4: Update status.
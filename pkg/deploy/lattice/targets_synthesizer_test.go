@@ -2,6 +2,7 @@ package lattice
 
 import (
 	"context"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
@@ -81,13 +82,13 @@ func Test_PostSynthesize_Conditions(t *testing.T) {
 		condition := corev1.PodCondition{
 			Type:   LatticeReadinessGateConditionType,
 			Status: corev1.ConditionFalse,
-			Reason: ReadinessReasonUnhealthy,
+			Reason: string(conditions.ReasonUnhealthy),
 		}
 		if ready {
 			condition = corev1.PodCondition{
 				Type:   LatticeReadinessGateConditionType,
 				Status: corev1.ConditionTrue,
-				Reason: ReadinessReasonHealthy,
+				Reason: string(conditions.ReasonHealthy),
 			}
 		}
 
@@ -137,7 +138,7 @@ func Test_PostSynthesize_Conditions(t *testing.T) {
 			lattice:        newLatticeTarget("10.10.1.1", 8675, vpclattice.TargetStatusHealthy),
 			pod:            newPod("ns", "pod1", true, false),
 			expectedStatus: corev1.ConditionTrue,
-			expectedReason: ReadinessReasonHealthy,
+			expectedReason: string(conditions.ReasonHealthy),
 			requeue:        false,
 		},
 		{
@@ -146,7 +147,7 @@ func Test_PostSynthesize_Conditions(t *testing.T) {
 			lattice:        newLatticeTarget("10.10.1.1", 8675, vpclattice.TargetStatusUnavailable),
 			pod:            newPod("ns", "pod1", true, false),
 			expectedStatus: corev1.ConditionTrue,
-			expectedReason: ReadinessReasonHealthCheckUnavailable,
+			expectedReason: string(conditions.ReasonHealthCheckUnavailable),
 			requeue:        false,
 		},
 		{
@@ -155,7 +156,7 @@ func Test_PostSynthesize_Conditions(t *testing.T) {
 			lattice:        newLatticeTarget("10.10.1.1", 8675, vpclattice.TargetStatusInitial),
 			pod:            newPod("ns", "pod1", true, false),
 			expectedStatus: corev1.ConditionFalse,
-			expectedReason: ReadinessReasonInitial,
+			expectedReason: string(conditions.ReasonInitial),
 			requeue:        true,
 		},
 		{
@@ -164,7 +165,7 @@ func Test_PostSynthesize_Conditions(t *testing.T) {
 			lattice:        newLatticeTarget("10.10.1.1", 8675, vpclattice.TargetStatusUnhealthy),
 			pod:            newPod("ns", "pod1", true, false),
 			expectedStatus: corev1.ConditionFalse,
-			expectedReason: ReadinessReasonUnhealthy,
+			expectedReason: string(conditions.ReasonUnhealthy),
 			requeue:        true,
 		},
 		{
@@ -173,7 +174,7 @@ func Test_PostSynthesize_Conditions(t *testing.T) {
 			lattice:        newLatticeTarget("10.10.1.1", 8675, vpclattice.TargetStatusDraining),
 			pod:            newPod("ns", "pod1", true, false),
 			expectedStatus: corev1.ConditionFalse,
-			expectedReason: ReadinessReasonUnhealthy,
+			expectedReason: string(conditions.ReasonUnhealthy),
 			requeue:        true,
 		},
 		{
@@ -182,7 +183,7 @@ func Test_PostSynthesize_Conditions(t *testing.T) {
 			lattice:        newLatticeTarget("dummy", 8675, vpclattice.TargetStatusHealthy),
 			pod:            newPod("ns", "pod1", true, false),
 			expectedStatus: corev1.ConditionFalse,
-			expectedReason: ReadinessReasonTargetNotFound,
+			expectedReason: string(conditions.ReasonTargetNotFound),
 			requeue:        true,
 		},
 		{
@@ -191,7 +192,7 @@ func Test_PostSynthesize_Conditions(t *testing.T) {
 			lattice:        newLatticeTarget("10.10.1.1", 8675, vpclattice.TargetStatusHealthy),
 			pod:            newPod("ns", "pod1", false, false),
 			expectedStatus: corev1.ConditionFalse,
-			expectedReason: ReadinessReasonUnhealthy,
+			expectedReason: string(conditions.ReasonUnhealthy),
 			requeue:        false,
 		},
 		{
@@ -200,7 +201,7 @@ func Test_PostSynthesize_Conditions(t *testing.T) {
 			lattice:        newLatticeTarget("10.10.1.1", 8675, vpclattice.TargetStatusUnhealthy),
 			pod:            newPod("ns", "pod1", true, true),
 			expectedStatus: corev1.ConditionTrue,
-			expectedReason: ReadinessReasonHealthy,
+			expectedReason: string(conditions.ReasonHealthy),
 			requeue:        false,
 		},
 		{
@@ -209,7 +210,7 @@ func Test_PostSynthesize_Conditions(t *testing.T) {
 			lattice:        newLatticeTarget("10.10.1.1", 8675, vpclattice.TargetStatusUnused),
 			pod:            newPod("ns", "pod1", true, false),
 			expectedStatus: corev1.ConditionFalse,
-			expectedReason: ReadinessReasonUnused,
+			expectedReason: string(conditions.ReasonUnused),
 			requeue:        false,
 		},
 	}
@@ -0,0 +1,127 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const snapshotFileSuffix = ".json"
+
+// snapshotFile is the file of a stack in a snapshot directory, namespaces cannot contain underscores
+func snapshotFile(dir string, stackID core.StackID) string {
+	return filepath.Join(dir, stackID.Namespace+"_"+stackID.Name+snapshotFileSuffix)
+}
+
+// WriteSnapshot saves the marshalled stack into the snapshot directory, replacing its previous snapshot.
+// The file is renamed into place so that readers never see a partial stack.
+func WriteSnapshot(dir string, stackID core.StackID, stackJSON string) error {
+	file := snapshotFile(dir, stackID)
+	tmp, err := os.CreateTemp(dir, ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(stackJSON); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// RemoveSnapshot removes the stack from the snapshot directory, if it is there
+func RemoveSnapshot(dir string, stackID core.StackID) error {
+	err := os.Remove(snapshotFile(dir, stackID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// ReadSnapshot loads the stacks of a snapshot directory written by WriteSnapshot, sorted by file name
+func ReadSnapshot(dir string) ([]core.Stack, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+snapshotFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	unmarshaller := NewDefaultStackUnmarshaller()
+	var stacks []core.Stack
+	for _, file := range files {
+		payload, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		stack, err := unmarshaller.Unmarshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot %s: %w", file, err)
+		}
+		stacks = append(stacks, stack)
+	}
+	return stacks, nil
+}
+
+// noopDnsEndpointManager skips DNSEndpoints, which are Kubernetes objects recreated by the route controller
+type noopDnsEndpointManager struct{}
+
+func (m *noopDnsEndpointManager) Create(ctx context.Context, service *model.Service) error {
+	return nil
+}
+
+type latticeSnapshotStackDeployer struct {
+	log                   gwlog.Logger
+	cloud                 pkg_aws.Cloud
+	latticeServiceManager lattice.ServiceManager
+	targetGroupManager    lattice.TargetGroupManager
+	listenerManager       lattice.ListenerManager
+	ruleManager           lattice.RuleManager
+}
+
+// NewSnapshotStackDeploy deploys stacks read from a snapshot, without a Kubernetes cluster. Target groups,
+// services, listeners and rules are created or updated like the route controller does, found by name and tags,
+// so deploying the same snapshot again makes no changes. Targets are not registered, the endpoints of a snapshot
+// are likely stale, they are registered by the controller once it reconciles the routes again.
+func NewSnapshotStackDeploy(log gwlog.Logger, cloud pkg_aws.Cloud) *latticeSnapshotStackDeployer {
+	return &latticeSnapshotStackDeployer{
+		log:                   log,
+		cloud:                 cloud,
+		latticeServiceManager: lattice.NewServiceManager(log, cloud),
+		targetGroupManager:    lattice.NewTargetGroupManager(log, cloud),
+		listenerManager:       lattice.NewListenerManager(log, cloud),
+		ruleManager:           lattice.NewRuleManager(log, cloud),
+	}
+}
+
+func (d *latticeSnapshotStackDeployer) Deploy(ctx context.Context, stack core.Stack) error {
+	targetGroupSynthesizer := lattice.NewTargetGroupSynthesizer(d.log, d.cloud, nil, d.targetGroupManager, nil, nil, stack)
+	serviceSynthesizer := lattice.NewServiceSynthesizer(d.log, d.latticeServiceManager, &noopDnsEndpointManager{}, stack)
+	listenerSynthesizer := lattice.NewListenerSynthesizer(d.log, d.listenerManager, d.targetGroupManager, stack)
+	ruleSynthesizer := lattice.NewRuleSynthesizer(d.log, d.ruleManager, d.targetGroupManager, stack)
+
+	if err := targetGroupSynthesizer.SynthesizeCreate(ctx); err != nil {
+		return fmt.Errorf("error during tg synthesis %w", err)
+	}
+	if err := serviceSynthesizer.Synthesize(ctx); err != nil {
+		return fmt.Errorf("error during service synthesis %w", err)
+	}
+	if err := listenerSynthesizer.Synthesize(ctx); err != nil {
+		return fmt.Errorf("error during listener synthesis %w", err)
+	}
+	if err := ruleSynthesizer.Synthesize(ctx); err != nil {
+		return fmt.Errorf("error during rule synthesis %w", err)
+	}
+	return nil
+}
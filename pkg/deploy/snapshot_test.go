@@ -0,0 +1,195 @@
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/stretchr/testify/assert"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services/latticefake"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// fakeTagging finds the target groups of the fake by their tags
+type fakeTagging struct {
+	fake *latticefake.Lattice
+}
+
+func (t *fakeTagging) GetTagsForArns(ctx context.Context, arns []string) (map[string]services.Tags, error) {
+	result := make(map[string]services.Tags)
+	for _, arn := range arns {
+		result[arn] = t.fake.Tags(arn)
+	}
+	return result, nil
+}
+
+func (t *fakeTagging) FindResourcesByTags(ctx context.Context, resourceType services.ResourceType, tags services.Tags) ([]string, error) {
+	var arns []string
+	for _, tg := range t.fake.TargetGroups() {
+		tgTags := t.fake.Tags(aws.StringValue(tg.Arn))
+		match := true
+		for k, v := range tags {
+			if aws.StringValue(tgTags[k]) != aws.StringValue(v) {
+				match = false
+			}
+		}
+		if match {
+			arns = append(arns, aws.StringValue(tg.Arn))
+		}
+	}
+	return arns, nil
+}
+
+func snapshotTestStack(t *testing.T) core.Stack {
+	stack := core.NewDefaultStack(core.StackID{Namespace: "ns1", Name: "inventory"})
+	tg, err := model.NewTargetGroup(stack, model.TargetGroupSpec{
+		VpcId:           "vpc-id",
+		Type:            model.TargetGroupTypeIP,
+		Port:            8080,
+		Protocol:        vpclattice.TargetGroupProtocolHttp,
+		ProtocolVersion: vpclattice.TargetGroupProtocolVersionHttp1,
+		IpAddressType:   vpclattice.IpAddressTypeIpv4,
+		TargetGroupTagFields: model.TargetGroupTagFields{
+			K8SClusterName:      "cluster",
+			K8SSourceType:       model.SourceTypeHTTPRoute,
+			K8SServiceName:      "inventory-ver1",
+			K8SServiceNamespace: "ns1",
+			K8SRouteName:        "inventory",
+			K8SRouteNamespace:   "ns1",
+			K8SProtocolVersion:  vpclattice.TargetGroupProtocolVersionHttp1,
+		},
+	})
+	assert.NoError(t, err)
+	_, err = model.NewTargets(stack, model.TargetsSpec{
+		StackTargetGroupId: tg.ID(),
+		TargetList:         []model.Target{{TargetIP: "10.0.0.1", Port: 8080, Ready: true}},
+	})
+	assert.NoError(t, err)
+	svc, err := model.NewLatticeService(stack, model.ServiceSpec{
+		ServiceTagFields: model.ServiceTagFields{
+			RouteName:      "inventory",
+			RouteNamespace: "ns1",
+			RouteType:      core.HttpRouteType,
+		},
+		ServiceNetworkNames: []string{"sn"},
+	})
+	assert.NoError(t, err)
+	listener, err := model.NewListener(stack, model.ListenerSpec{
+		StackServiceId:    svc.ID(),
+		K8SRouteName:      "inventory",
+		K8SRouteNamespace: "ns1",
+		Port:              80,
+		Protocol:          vpclattice.ListenerProtocolHttp,
+		DefaultAction:     &model.DefaultAction{FixedResponseStatusCode: aws.Int64(404)},
+	})
+	assert.NoError(t, err)
+	_, err = model.NewRule(stack, model.RuleSpec{
+		StackListenerId: listener.ID(),
+		PathMatchValue:  "/",
+		PathMatchPrefix: true,
+		Priority:        1,
+		Action: model.RuleAction{
+			TargetGroups: []*model.RuleTargetGroup{{StackTargetGroupId: tg.ID(), Weight: 1}},
+		},
+	})
+	assert.NoError(t, err)
+	return stack
+}
+
+func Test_Snapshot_WriteAndRead(t *testing.T) {
+	dir := t.TempDir()
+	stack := snapshotTestStack(t)
+	stackJSON, err := NewDefaultStackMarshaller().Marshal(stack)
+	assert.NoError(t, err)
+	assert.NoError(t, WriteSnapshot(dir, stack.StackID(), stackJSON))
+	// rewriting replaces the previous snapshot
+	assert.NoError(t, WriteSnapshot(dir, stack.StackID(), stackJSON))
+
+	stacks, err := ReadSnapshot(dir)
+	assert.NoError(t, err)
+	assert.Len(t, stacks, 1)
+	assert.Equal(t, stack.StackID(), stacks[0].StackID())
+	readJSON, err := NewDefaultStackMarshaller().Marshal(stacks[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, stackJSON, readJSON)
+
+	assert.NoError(t, RemoveSnapshot(dir, stack.StackID()))
+	assert.NoError(t, RemoveSnapshot(dir, stack.StackID()))
+	stacks, err = ReadSnapshot(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, stacks)
+}
+
+func Test_Snapshot_UnsupportedResource(t *testing.T) {
+	_, err := NewDefaultStackUnmarshaller().Unmarshal([]byte(
+		`{"id": "ns1/gw", "resources": {"AWS::VPCServiceNetwork::ServiceNetwork": {"sn": {}}}}`))
+	assert.ErrorContains(t, err, "unsupported resource type")
+}
+
+func Test_SnapshotStackDeploy(t *testing.T) {
+	ctx := context.TODO()
+	dir := t.TempDir()
+	stack := snapshotTestStack(t)
+	stackJSON, err := NewDefaultStackMarshaller().Marshal(stack)
+	assert.NoError(t, err)
+	assert.NoError(t, WriteSnapshot(dir, stack.StackID(), stackJSON))
+
+	fake := latticefake.New("account-id", "us-west-2")
+	fake.AddServiceNetwork("sn", nil)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(fake, &fakeTagging{fake: fake}, pkg_aws.CloudConfig{
+		VpcId:       "vpc-id",
+		AccountId:   "account-id",
+		Region:      "us-west-2",
+		ClusterName: "cluster",
+	})
+	deployer := NewSnapshotStackDeploy(gwlog.FallbackLogger, cloud)
+
+	apply := func() {
+		stacks, err := ReadSnapshot(dir)
+		assert.NoError(t, err)
+		for _, s := range stacks {
+			assert.NoError(t, deployer.Deploy(ctx, s))
+		}
+	}
+	apply()
+
+	svcs := fake.Services()
+	assert.Len(t, svcs, 1)
+	assert.Equal(t, "inventory-ns1", aws.StringValue(svcs[0].Name))
+	tgs := fake.TargetGroups()
+	assert.Len(t, tgs, 1)
+	assert.Empty(t, fake.Targets(aws.StringValue(tgs[0].Id)), "targets are left to the controller")
+	listeners, err := fake.ListListenersAsList(ctx, &vpclattice.ListListenersInput{ServiceIdentifier: svcs[0].Id})
+	assert.NoError(t, err)
+	assert.Len(t, listeners, 1)
+	rules, err := fake.GetRulesAsList(ctx, &vpclattice.ListRulesInput{
+		ServiceIdentifier:  svcs[0].Id,
+		ListenerIdentifier: listeners[0].Id,
+	})
+	assert.NoError(t, err)
+	var forwardedTgs []string
+	for _, rule := range rules {
+		if rule.Action.Forward != nil {
+			for _, tg := range rule.Action.Forward.TargetGroups {
+				forwardedTgs = append(forwardedTgs, aws.StringValue(tg.TargetGroupIdentifier))
+			}
+		}
+	}
+	assert.Equal(t, []string{aws.StringValue(tgs[0].Id)}, forwardedTgs)
+
+	// applying the snapshot again creates nothing
+	fake.ResetCalls()
+	apply()
+	for _, method := range []string{"CreateServiceWithContext", "CreateTargetGroupWithContext",
+		"CreateListenerWithContext", "CreateRuleWithContext", "CreateServiceNetworkServiceAssociationWithContext"} {
+		assert.Equal(t, 0, fake.CallCount(method), method)
+	}
+	assert.Len(t, fake.Services(), 1)
+	assert.Len(t, fake.TargetGroups(), 1)
+}
@@ -237,6 +237,7 @@ type latticeTargetGroupStackDeployer struct {
 	cloud              pkg_aws.Cloud
 	k8sclient          client.Client
 	targetGroupManager lattice.TargetGroupManager
+	targetsManager     lattice.TargetsManager
 	svcExportTgBuilder gateway.SvcExportTargetGroupModelBuilder
 	svcBuilder         gateway.LatticeServiceBuilder
 }
@@ -254,6 +255,7 @@ func NewTargetGroupStackDeploy(
 		cloud:              cloud,
 		k8sclient:          k8sClient,
 		targetGroupManager: lattice.NewTargetGroupManager(log, cloud),
+		targetsManager:     lattice.NewTargetsManager(log, cloud),
 		svcExportTgBuilder: gateway.NewSvcExportTargetGroupBuilder(log, k8sClient),
 		svcBuilder:         gateway.NewLatticeServiceBuilder(log, k8sClient, brTgBuilder),
 	}
@@ -267,7 +269,7 @@ func (d *latticeTargetGroupStackDeployer) Deploy(ctx context.Context, stack core
 
 	synthesizers := []ResourceSynthesizer{
 		lattice.NewTargetGroupSynthesizer(d.log, d.cloud, d.k8sclient, d.targetGroupManager, d.svcExportTgBuilder, d.svcBuilder, stack),
-		lattice.NewTargetsSynthesizer(d.log, d.k8sclient, lattice.NewTargetsManager(d.log, d.cloud), stack),
+		lattice.NewTargetsSynthesizer(d.log, d.k8sclient, d.targetsManager, stack),
 	}
 	return deploy(ctx, stack, synthesizers)
 }
@@ -0,0 +1,95 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+)
+
+// StackUnmarshaller rebuilds a resource stack from the JSON of StackMarshaller.
+type StackUnmarshaller interface {
+	Unmarshal(payload []byte) (core.Stack, error)
+}
+
+func NewDefaultStackUnmarshaller() *defaultStackUnmarshaller {
+	return &defaultStackUnmarshaller{}
+}
+
+var _ StackUnmarshaller = &defaultStackUnmarshaller{}
+
+type defaultStackUnmarshaller struct{}
+
+// Unmarshal supports the resources of route and service export stacks. Statuses are dropped, they refer to
+// VPC Lattice resources which may no longer exist and are populated again when the stack is deployed.
+func (u *defaultStackUnmarshaller) Unmarshal(payload []byte) (core.Stack, error) {
+	var stackSchema struct {
+		ID        string                                `json:"id"`
+		Resources map[string]map[string]json.RawMessage `json:"resources"`
+	}
+	if err := json.Unmarshal(payload, &stackSchema); err != nil {
+		return nil, err
+	}
+	if stackSchema.ID == "" {
+		return nil, fmt.Errorf("stack has no id")
+	}
+
+	stackID := core.StackID{Name: stackSchema.ID}
+	if namespace, name, ok := strings.Cut(stackSchema.ID, "/"); ok {
+		stackID = core.StackID{Namespace: namespace, Name: name}
+	}
+	stack := core.NewDefaultStack(stackID)
+
+	for resType, resources := range stackSchema.Resources {
+		for id, payload := range resources {
+			res, err := newResource(stack, resType, id, payload)
+			if err != nil {
+				return nil, fmt.Errorf("invalid resource %s %s of stack %s: %w", resType, id, stackSchema.ID, err)
+			}
+			if err := stack.AddResource(res); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stack, nil
+}
+
+func newResource(stack core.Stack, resType string, id string, payload json.RawMessage) (core.Resource, error) {
+	meta := core.NewResourceMeta(stack, resType, id)
+	var res core.Resource
+	var err error
+	switch resType {
+	case "AWS::VPCServiceNetwork::Service":
+		svc := &model.Service{ResourceMeta: meta}
+		err = json.Unmarshal(payload, svc)
+		svc.Status = nil
+		res = svc
+	case "AWS::VPCServiceNetwork::Listener":
+		listener := &model.Listener{ResourceMeta: meta}
+		err = json.Unmarshal(payload, listener)
+		listener.Status = nil
+		res = listener
+	case "AWS::VPCServiceNetwork::Rule":
+		rule := &model.Rule{ResourceMeta: meta}
+		err = json.Unmarshal(payload, rule)
+		rule.Status = nil
+		res = rule
+	case "AWS:VPCServiceNetwork::TargetGroup":
+		tg := &model.TargetGroup{ResourceMeta: meta}
+		err = json.Unmarshal(payload, tg)
+		tg.Status = nil
+		res = tg
+	case "AWS:VPCServiceNetwork::Targets":
+		targets := &model.Targets{ResourceMeta: meta}
+		err = json.Unmarshal(payload, targets)
+		res = targets
+	default:
+		return nil, fmt.Errorf("unsupported resource type")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,166 @@
+package drift
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	OwnershipKindServiceNetwork = "ServiceNetwork"
+	OwnershipKindService        = "Service"
+	OwnershipKindTargetGroup    = "TargetGroup"
+)
+
+// OwnershipWatcher periodically reads the ownership tag of the VPC Lattice service networks, services and target
+// groups, and caches it. A resource once tagged as managed by this controller whose tag is later removed, or set to
+// another owner, is reported as a conflict, as it usually means another controller or a user took it over. Conflicts
+// are logged and counted in the ownership_conflicts metric, until the resource is tagged back or deleted.
+type OwnershipWatcher struct {
+	log      gwlog.Logger
+	cloud    pkg_aws.Cloud
+	interval time.Duration
+
+	lock sync.Mutex
+	// ARNs of the resources seen managed by this controller, with their kind
+	owned map[string]string
+	// ARNs of the owned resources with a conflicting ownership tag, with the owner found in the tag
+	conflicts map[string]string
+}
+
+func NewOwnershipWatcher(log gwlog.Logger, cloud pkg_aws.Cloud, interval time.Duration) *OwnershipWatcher {
+	return &OwnershipWatcher{
+		log:       log,
+		cloud:     cloud,
+		interval:  interval,
+		owned:     make(map[string]string),
+		conflicts: make(map[string]string),
+	}
+}
+
+// Start checks the ownership tags every interval until the context is cancelled, implements manager.Runnable.
+func (w *OwnershipWatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Check(ctx); err != nil {
+			w.log.Warnf(ctx, "Failed to check ownership tags of VPC Lattice resources: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check reads the ownership tags of all resources, updates the cache and reports the conflicts.
+func (w *OwnershipWatcher) Check(ctx context.Context) error {
+	arns, err := w.listArns(ctx)
+	if err != nil {
+		return err
+	}
+	arnList := make([]string, 0, len(arns))
+	for arn := range arns {
+		arnList = append(arnList, arn)
+	}
+	tags, err := w.cloud.Tagging().GetTagsForArns(ctx, arnList)
+	if err != nil {
+		return err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	managedBy := aws.StringValue(w.cloud.DefaultTags()[pkg_aws.TagManagedBy])
+	for arn, kind := range arns {
+		// resources without any tag may be missing from the result
+		owner := aws.StringValue(tags[arn][pkg_aws.TagManagedBy])
+		if owner == managedBy {
+			if _, ok := w.conflicts[arn]; ok {
+				w.log.Infof(ctx, "Ownership tag of %s %s is restored", kind, arn)
+				delete(w.conflicts, arn)
+			}
+			w.owned[arn] = kind
+			continue
+		}
+		if _, ok := w.owned[arn]; !ok {
+			continue
+		}
+		if prev, ok := w.conflicts[arn]; ok && prev == owner {
+			continue
+		}
+		w.conflicts[arn] = owner
+		if owner == "" {
+			w.log.Warnf(ctx, "Ownership tag %s of %s %s was removed out of band", pkg_aws.TagManagedBy, kind, arn)
+		} else {
+			w.log.Warnf(ctx, "Ownership tag %s of %s %s was changed out of band to %s, another controller may manage it",
+				pkg_aws.TagManagedBy, kind, arn, owner)
+		}
+	}
+	// forget deleted resources
+	for arn := range w.owned {
+		if _, ok := arns[arn]; !ok {
+			delete(w.owned, arn)
+			delete(w.conflicts, arn)
+		}
+	}
+
+	counts := map[string]int{
+		OwnershipKindServiceNetwork: 0,
+		OwnershipKindService:        0,
+		OwnershipKindTargetGroup:    0,
+	}
+	for arn := range w.conflicts {
+		counts[w.owned[arn]]++
+	}
+	for kind, count := range counts {
+		metrics.SetOwnershipConflicts(kind, count)
+	}
+	return nil
+}
+
+// Conflicts returns the ARNs of the resources with a conflicting ownership tag, with the owner found in the tag,
+// empty when the tag was removed.
+func (w *OwnershipWatcher) Conflicts() map[string]string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	conflicts := make(map[string]string, len(w.conflicts))
+	for arn, owner := range w.conflicts {
+		conflicts[arn] = owner
+	}
+	return conflicts
+}
+
+func (w *OwnershipWatcher) listArns(ctx context.Context) (map[string]string, error) {
+	arns := make(map[string]string)
+	sns, err := w.cloud.Lattice().ListServiceNetworksAsList(ctx, &vpclattice.ListServiceNetworksInput{})
+	if err != nil {
+		return nil, err
+	}
+	for _, sn := range sns {
+		arns[aws.StringValue(sn.Arn)] = OwnershipKindServiceNetwork
+	}
+	svcs, err := w.cloud.Lattice().ListServicesAsList(ctx, &vpclattice.ListServicesInput{})
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range svcs {
+		arns[aws.StringValue(svc.Arn)] = OwnershipKindService
+	}
+	tgs, err := w.cloud.Lattice().ListTargetGroupsAsList(ctx, &vpclattice.ListTargetGroupsInput{})
+	if err != nil {
+		return nil, err
+	}
+	for _, tg := range tgs {
+		arns[aws.StringValue(tg.Arn)] = OwnershipKindTargetGroup
+	}
+	return arns, nil
+}
@@ -0,0 +1,80 @@
+package drift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func Test_OwnershipWatcher_DetectsOwnershipTagRemoval(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, pkg_aws.CloudConfig{
+		VpcId:       "vpc-id",
+		AccountId:   "account-id",
+		Region:      "us-west-2",
+		ClusterName: "cluster",
+	})
+
+	mockLattice.EXPECT().ListServiceNetworksAsList(ctx, gomock.Any()).Return([]*vpclattice.ServiceNetworkSummary{
+		{Arn: aws.String("sn-arn")},
+	}, nil).Times(3)
+	mockLattice.EXPECT().ListServicesAsList(ctx, gomock.Any()).Return([]*vpclattice.ServiceSummary{
+		{Arn: aws.String("svc-arn")},
+		{Arn: aws.String("other-svc-arn")},
+	}, nil).Times(3)
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return([]*vpclattice.TargetGroupSummary{
+		{Arn: aws.String("tg-arn")},
+	}, nil).Times(3)
+
+	otherTags := map[string]*string{pkg_aws.TagManagedBy: aws.String("account-id/other-cluster/vpc-id")}
+	gomock.InOrder(
+		mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(map[string]mocks.Tags{
+			"sn-arn":        cloud.DefaultTags(),
+			"svc-arn":       cloud.DefaultTags(),
+			"other-svc-arn": otherTags,
+			"tg-arn":        cloud.DefaultTags(),
+		}, nil),
+		// svc-arn lost its tags, tg-arn was taken over by another controller
+		mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(map[string]mocks.Tags{
+			"sn-arn":        cloud.DefaultTags(),
+			"other-svc-arn": otherTags,
+			"tg-arn":        otherTags,
+		}, nil),
+		mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(map[string]mocks.Tags{
+			"sn-arn":        cloud.DefaultTags(),
+			"svc-arn":       cloud.DefaultTags(),
+			"other-svc-arn": otherTags,
+			"tg-arn":        otherTags,
+		}, nil),
+	)
+
+	watcher := NewOwnershipWatcher(gwlog.FallbackLogger, cloud, 0)
+
+	assert.NoError(t, watcher.Check(ctx))
+	assert.Empty(t, watcher.Conflicts())
+
+	assert.NoError(t, watcher.Check(ctx))
+	assert.Equal(t, map[string]string{
+		"svc-arn": "",
+		"tg-arn":  "account-id/other-cluster/vpc-id",
+	}, watcher.Conflicts())
+
+	// restoring the tag resolves the conflict
+	assert.NoError(t, watcher.Check(ctx))
+	assert.Equal(t, map[string]string{
+		"tg-arn": "account-id/other-cluster/vpc-id",
+	}, watcher.Conflicts())
+}
@@ -0,0 +1,314 @@
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	latticeEventSource = "aws.vpc-lattice"
+
+	latticeResourceService     = "service"
+	latticeResourceTargetGroup = "targetgroup"
+
+	receiveWaitTimeSeconds   = 20
+	receiveMaxMessages       = 10
+	receiveErrorBackoff      = 10 * time.Second
+	eventChannelBufferLength = 100
+)
+
+var routeTypeToSourceType = map[core.RouteType]model.K8SSourceType{
+	core.HttpRouteType: model.SourceTypeHTTPRoute,
+	core.GrpcRouteType: model.SourceTypeGRPCRoute,
+	core.TlsRouteType:  model.SourceTypeTLSRoute,
+}
+
+// eventBridgeEvent is the subset of an EventBridge event delivered to SQS needed to find the changed resources.
+// VPC Lattice API calls are delivered as "AWS API Call via CloudTrail" events, in which case the affected
+// resources are only found in the request parameters and response elements.
+type eventBridgeEvent struct {
+	Source    string   `json:"source"`
+	Resources []string `json:"resources"`
+	Detail    struct {
+		UserIdentity struct {
+			Arn string `json:"arn"`
+		} `json:"userIdentity"`
+		RequestParameters map[string]interface{} `json:"requestParameters"`
+		ResponseElements  map[string]interface{} `json:"responseElements"`
+	} `json:"detail"`
+}
+
+type latticeResource struct {
+	resourceType string
+	identifier   string
+}
+
+// Poller consumes VPC Lattice change notifications from an SQS queue fed by EventBridge,
+// and enqueues the Kubernetes resources owning the changed Lattice resources for reconcile.
+// Only resources managed by this controller are considered, based on their tags. Changes made by the controller
+// itself, e.g. the target registrations of every reconcile, are not drift and are ignored.
+type Poller struct {
+	log      gwlog.Logger
+	cloud    pkg_aws.Cloud
+	sqs      sqsiface.SQSAPI
+	queueUrl string
+	// principal of the controller calling VPC Lattice, see principalOf
+	principal string
+	events    map[model.K8SSourceType]chan event.GenericEvent
+}
+
+// NewPoller returns a poller ignoring the changes made by the given caller ARN, the one of the VPC Lattice credentials
+// of the controller as returned by sts:GetCallerIdentity.
+func NewPoller(log gwlog.Logger, cloud pkg_aws.Cloud, sqsClient sqsiface.SQSAPI, queueUrl string, callerArn string) *Poller {
+	events := make(map[model.K8SSourceType]chan event.GenericEvent)
+	for _, sourceType := range []model.K8SSourceType{
+		model.SourceTypeHTTPRoute,
+		model.SourceTypeGRPCRoute,
+		model.SourceTypeTLSRoute,
+		model.SourceTypeSvcExport,
+	} {
+		events[sourceType] = make(chan event.GenericEvent, eventChannelBufferLength)
+	}
+	return &Poller{
+		log:       log,
+		cloud:     cloud,
+		sqs:       sqsClient,
+		queueUrl:  queueUrl,
+		principal: principalOf(callerArn),
+		events:    events,
+	}
+}
+
+// Source returns the source of reconcile events for the given kind of Kubernetes resource.
+func (p *Poller) Source(sourceType model.K8SSourceType) source.Source {
+	return &source.Channel{Source: p.events[sourceType]}
+}
+
+// Start polls the queue until the context is cancelled, implements manager.Runnable.
+func (p *Poller) Start(ctx context.Context) error {
+	p.log.Infof(ctx, "Polling %s for VPC Lattice change notifications", p.queueUrl)
+	for ctx.Err() == nil {
+		out, err := p.sqs.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            &p.queueUrl,
+			MaxNumberOfMessages: aws.Int64(receiveMaxMessages),
+			WaitTimeSeconds:     aws.Int64(receiveWaitTimeSeconds),
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			p.log.Warnf(ctx, "Failed to receive VPC Lattice change notifications: %s", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(receiveErrorBackoff):
+			}
+			continue
+		}
+
+		for _, msg := range out.Messages {
+			if err := p.handleMessage(ctx, aws.StringValue(msg.Body)); err != nil {
+				// keep the message, it becomes visible again after the visibility timeout
+				p.log.Warnf(ctx, "Failed to process VPC Lattice change notification %s: %s",
+					aws.StringValue(msg.MessageId), err)
+				continue
+			}
+			_, err := p.sqs.DeleteMessageWithContext(ctx, &sqs.DeleteMessageInput{
+				QueueUrl:      &p.queueUrl,
+				ReceiptHandle: msg.ReceiptHandle,
+			})
+			if err != nil {
+				p.log.Warnf(ctx, "Failed to delete VPC Lattice change notification %s: %s",
+					aws.StringValue(msg.MessageId), err)
+			}
+		}
+	}
+	p.log.Infof(ctx, "Stopped polling %s", p.queueUrl)
+	return nil
+}
+
+func (p *Poller) handleMessage(ctx context.Context, body string) error {
+	var e eventBridgeEvent
+	if err := json.Unmarshal([]byte(body), &e); err != nil {
+		// malformed messages will never succeed, drop them
+		p.log.Debugf(ctx, "Ignoring malformed notification: %s", err)
+		return nil
+	}
+	if e.Source != latticeEventSource {
+		p.log.Debugf(ctx, "Ignoring notification from %s", e.Source)
+		return nil
+	}
+	// reconciling the owner of a change made by the controller would make the same change again, endlessly
+	if p.principal != "" && principalOf(e.Detail.UserIdentity.Arn) == p.principal {
+		p.log.Debugf(ctx, "Ignoring notification of a change made by the controller")
+		return nil
+	}
+
+	for _, r := range changedResources(&e) {
+		sourceType, name, err := p.resolveOwner(ctx, r)
+		if err != nil {
+			if services.IsNotFoundError(err) {
+				p.log.Debugf(ctx, "Lattice %s %s no longer exists, skipping", r.resourceType, r.identifier)
+				continue
+			}
+			return err
+		}
+		if name.Name == "" {
+			continue
+		}
+		p.log.Infof(ctx, "Lattice %s %s changed, reconciling %s %s", r.resourceType, r.identifier, sourceType, name)
+		ch, ok := p.events[sourceType]
+		if !ok {
+			continue
+		}
+		obj := &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name.Name,
+				Namespace: name.Namespace,
+			},
+		}
+		select {
+		case ch <- event.GenericEvent{Object: obj}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// resolveOwner finds the Kubernetes resource owning the given Lattice resource from its tags.
+// Returns an empty name when the Lattice resource is not managed by this controller.
+func (p *Poller) resolveOwner(ctx context.Context, r latticeResource) (model.K8SSourceType, types.NamespacedName, error) {
+	var resourceArn string
+	switch r.resourceType {
+	case latticeResourceService:
+		svc, err := p.cloud.Lattice().GetServiceWithContext(ctx, &vpclattice.GetServiceInput{
+			ServiceIdentifier: aws.String(r.identifier),
+		})
+		if err != nil {
+			return "", types.NamespacedName{}, err
+		}
+		resourceArn = aws.StringValue(svc.Arn)
+	case latticeResourceTargetGroup:
+		tg, err := p.cloud.Lattice().GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
+			TargetGroupIdentifier: aws.String(r.identifier),
+		})
+		if err != nil {
+			return "", types.NamespacedName{}, err
+		}
+		resourceArn = aws.StringValue(tg.Arn)
+	}
+
+	managed, err := p.cloud.IsArnManaged(ctx, resourceArn)
+	if err != nil || !managed {
+		return "", types.NamespacedName{}, err
+	}
+	tagsOut, err := p.cloud.Lattice().ListTagsForResourceWithContext(ctx, &vpclattice.ListTagsForResourceInput{
+		ResourceArn: aws.String(resourceArn),
+	})
+	if err != nil {
+		return "", types.NamespacedName{}, err
+	}
+
+	if r.resourceType == latticeResourceService {
+		tags := model.ServiceTagFieldsFromTags(tagsOut.Tags)
+		return routeTypeToSourceType[tags.RouteType], types.NamespacedName{
+			Namespace: tags.RouteNamespace,
+			Name:      tags.RouteName,
+		}, nil
+	}
+
+	tags := model.TGTagFieldsFromTags(tagsOut.Tags)
+	if tags.K8SSourceType == model.SourceTypeSvcExport {
+		return tags.K8SSourceType, types.NamespacedName{
+			Namespace: tags.K8SServiceNamespace,
+			Name:      tags.K8SServiceName,
+		}, nil
+	}
+	return tags.K8SSourceType, types.NamespacedName{
+		Namespace: tags.K8SRouteNamespace,
+		Name:      tags.K8SRouteName,
+	}, nil
+}
+
+// changedResources collects the services and target groups referred by the event.
+// Changes to sub-resources, like listeners, rules or targets, are attributed to their parent resource.
+func changedResources(e *eventBridgeEvent) []latticeResource {
+	var identifiers []string
+	identifiers = append(identifiers, e.Resources...)
+	for _, key := range []string{"serviceIdentifier", "targetGroupIdentifier", "resourceArn"} {
+		if v, ok := e.Detail.RequestParameters[key].(string); ok {
+			identifiers = append(identifiers, v)
+		}
+	}
+	if v, ok := e.Detail.ResponseElements["arn"].(string); ok {
+		identifiers = append(identifiers, v)
+	}
+
+	seen := make(map[latticeResource]bool)
+	var resources []latticeResource
+	for _, identifier := range identifiers {
+		r, ok := parseIdentifier(identifier)
+		if !ok || seen[r] {
+			continue
+		}
+		seen[r] = true
+		resources = append(resources, r)
+	}
+	return resources
+}
+
+// parseIdentifier accepts either an ARN or an ID of a service, a target group, or one of their sub-resources.
+func parseIdentifier(identifier string) (latticeResource, bool) {
+	id := identifier
+	if arn.IsARN(identifier) {
+		a, err := arn.Parse(identifier)
+		if err != nil {
+			return latticeResource{}, false
+		}
+		// e.g. service/svc-0123/listener/listener-0123
+		parts := strings.Split(a.Resource, "/")
+		if len(parts) < 2 {
+			return latticeResource{}, false
+		}
+		id = parts[1]
+	}
+	switch {
+	case strings.HasPrefix(id, "svc-"):
+		return latticeResource{resourceType: latticeResourceService, identifier: id}, true
+	case strings.HasPrefix(id, "tg-"):
+		return latticeResource{resourceType: latticeResourceTargetGroup, identifier: id}, true
+	}
+	return latticeResource{}, false
+}
+
+// principalOf returns the principal of a caller ARN, the role of an assumed role session, e.g.
+// arn:aws:sts::123456789012:assumed-role/my-role for arn:aws:sts::123456789012:assumed-role/my-role/my-session.
+// Web identity sessions are named after the time their credentials are refreshed, so sessions are matched by role.
+func principalOf(callerArn string) string {
+	a, err := arn.Parse(callerArn)
+	if err != nil {
+		return callerArn
+	}
+	if parts := strings.SplitN(a.Resource, "/", 3); a.Service == "sts" && parts[0] == "assumed-role" && len(parts) == 3 {
+		a.Resource = parts[0] + "/" + parts[1]
+	}
+	return a.String()
+}
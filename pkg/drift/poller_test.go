@@ -0,0 +1,280 @@
+package drift
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type fakeSqs struct {
+	sqsiface.SQSAPI
+	messages chan *sqs.Message
+	deleted  chan string
+}
+
+func (f *fakeSqs) ReceiveMessageWithContext(ctx context.Context, input *sqs.ReceiveMessageInput, opts ...request.Option) (*sqs.ReceiveMessageOutput, error) {
+	select {
+	case msg := <-f.messages:
+		return &sqs.ReceiveMessageOutput{Messages: []*sqs.Message{msg}}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (f *fakeSqs) DeleteMessageWithContext(ctx context.Context, input *sqs.DeleteMessageInput, opts ...request.Option) (*sqs.DeleteMessageOutput, error) {
+	f.deleted <- aws.StringValue(input.ReceiptHandle)
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+// the caller identity of the controller, refreshed web identity credentials have another session name
+const controllerArn = "arn:aws:sts::account-id:assumed-role/lattice-controller/1700000000000000000"
+
+func newTestCloud(mockLattice *mocks.MockLattice) pkg_aws.Cloud {
+	return pkg_aws.NewDefaultCloud(mockLattice, pkg_aws.CloudConfig{
+		VpcId:       "vpc-id",
+		AccountId:   "account-id",
+		Region:      "us-west-2",
+		ClusterName: "cluster",
+	})
+}
+
+func receiveEvent(t *testing.T, ch chan event.GenericEvent) (event.GenericEvent, bool) {
+	select {
+	case e := <-ch:
+		return e, true
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reconcile event")
+	}
+	return event.GenericEvent{}, false
+}
+
+func Test_Poller_EnqueuesRouteOnListenerChange(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := newTestCloud(mockLattice)
+
+	mockLattice.EXPECT().GetServiceWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.GetServiceInput, opts ...request.Option) (*vpclattice.GetServiceOutput, error) {
+			assert.Equal(t, "svc-123", aws.StringValue(input.ServiceIdentifier))
+			return &vpclattice.GetServiceOutput{Arn: aws.String("svc-arn")}, nil
+		})
+	mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).Return(
+		&vpclattice.ListTagsForResourceOutput{
+			Tags: cloud.DefaultTagsMergedWith(map[string]*string{
+				model.K8SRouteNameKey:      aws.String("my-route"),
+				model.K8SRouteNamespaceKey: aws.String("my-namespace"),
+				model.K8SRouteTypeKey:      aws.String("http"),
+			}),
+		}, nil).AnyTimes()
+
+	fake := &fakeSqs{
+		messages: make(chan *sqs.Message, 1),
+		deleted:  make(chan string, 1),
+	}
+	poller := NewPoller(gwlog.FallbackLogger, cloud, fake, "queue-url", controllerArn)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	done := make(chan error)
+	go func() {
+		done <- poller.Start(ctx)
+	}()
+
+	fake.messages <- &sqs.Message{
+		MessageId:     aws.String("msg-id"),
+		ReceiptHandle: aws.String("receipt-handle"),
+		Body: aws.String(`{
+			"source": "aws.vpc-lattice",
+			"detail-type": "AWS API Call via CloudTrail",
+			"resources": [],
+			"detail": {
+				"eventSource": "vpc-lattice.amazonaws.com",
+				"eventName": "UpdateListener",
+				"requestParameters": {
+					"serviceIdentifier": "svc-123",
+					"listenerIdentifier": "listener-123"
+				}
+			}
+		}`),
+	}
+
+	e, ok := receiveEvent(t, poller.events[model.SourceTypeHTTPRoute])
+	if ok {
+		assert.Equal(t, "my-route", e.Object.GetName())
+		assert.Equal(t, "my-namespace", e.Object.GetNamespace())
+	}
+	assert.Equal(t, "receipt-handle", <-fake.deleted)
+
+	cancel()
+	assert.Nil(t, <-done)
+}
+
+func Test_Poller_handleMessage(t *testing.T) {
+	svcExportTgTags := map[string]*string{
+		model.K8SServiceNameKey:      aws.String("my-service"),
+		model.K8SServiceNamespaceKey: aws.String("my-namespace"),
+		model.K8SSourceTypeKey:       aws.String(string(model.SourceTypeSvcExport)),
+	}
+	routeTgTags := map[string]*string{
+		model.K8SServiceNameKey:      aws.String("my-service"),
+		model.K8SServiceNamespaceKey: aws.String("my-namespace"),
+		model.K8SRouteNameKey:        aws.String("my-route"),
+		model.K8SRouteNamespaceKey:   aws.String("my-route-namespace"),
+		model.K8SSourceTypeKey:       aws.String(string(model.SourceTypeGRPCRoute)),
+	}
+
+	tests := []struct {
+		name          string
+		body          string
+		tgNotFound    bool
+		managed       bool
+		tags          map[string]*string
+		expectedType  model.K8SSourceType
+		expectedName  string
+		expectedNs    string
+		expectEnqueue bool
+	}{
+		{
+			name:          "target registration enqueues service export",
+			body:          `{"source":"aws.vpc-lattice","detail":{"eventName":"DeregisterTargets","requestParameters":{"targetGroupIdentifier":"tg-123"}}}`,
+			managed:       true,
+			tags:          svcExportTgTags,
+			expectedType:  model.SourceTypeSvcExport,
+			expectedName:  "my-service",
+			expectedNs:    "my-namespace",
+			expectEnqueue: true,
+		},
+		{
+			name:          "target group arn in resources enqueues route",
+			body:          `{"source":"aws.vpc-lattice","resources":["arn:aws:vpc-lattice:us-west-2:account-id:targetgroup/tg-123"]}`,
+			managed:       true,
+			tags:          routeTgTags,
+			expectedType:  model.SourceTypeGRPCRoute,
+			expectedName:  "my-route",
+			expectedNs:    "my-route-namespace",
+			expectEnqueue: true,
+		},
+		{
+			name:          "target registration by another principal enqueues service export",
+			body:          `{"source":"aws.vpc-lattice","detail":{"eventName":"RegisterTargets","userIdentity":{"arn":"arn:aws:sts::account-id:assumed-role/admin/alice"},"requestParameters":{"targetGroupIdentifier":"tg-123"}}}`,
+			managed:       true,
+			tags:          svcExportTgTags,
+			expectedType:  model.SourceTypeSvcExport,
+			expectedName:  "my-service",
+			expectedNs:    "my-namespace",
+			expectEnqueue: true,
+		},
+		{
+			name:    "target registration by the controller is ignored",
+			body:    `{"source":"aws.vpc-lattice","detail":{"eventName":"RegisterTargets","userIdentity":{"arn":"arn:aws:sts::account-id:assumed-role/lattice-controller/1700000999000000000"},"requestParameters":{"targetGroupIdentifier":"tg-123"}}}`,
+			managed: true,
+			tags:    svcExportTgTags,
+		},
+		{
+			name:    "resources not managed by the controller are ignored",
+			body:    `{"source":"aws.vpc-lattice","detail":{"requestParameters":{"targetGroupIdentifier":"tg-123"}}}`,
+			managed: false,
+			tags:    svcExportTgTags,
+		},
+		{
+			name:       "deleted resources are ignored",
+			body:       `{"source":"aws.vpc-lattice","detail":{"requestParameters":{"targetGroupIdentifier":"tg-123"}}}`,
+			tgNotFound: true,
+		},
+		{
+			name: "events from other sources are ignored",
+			body: `{"source":"aws.ec2","detail":{"requestParameters":{"targetGroupIdentifier":"tg-123"}}}`,
+		},
+		{
+			name: "malformed messages are ignored",
+			body: `not json`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+
+			mockLattice := mocks.NewMockLattice(c)
+			cloud := newTestCloud(mockLattice)
+
+			if tt.tgNotFound {
+				mockLattice.EXPECT().GetTargetGroupWithContext(gomock.Any(), gomock.Any()).Return(nil,
+					awserr.New(vpclattice.ErrCodeResourceNotFoundException, "not found", nil))
+			} else {
+				mockLattice.EXPECT().GetTargetGroupWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, input *vpclattice.GetTargetGroupInput, opts ...request.Option) (*vpclattice.GetTargetGroupOutput, error) {
+						assert.Equal(t, "tg-123", aws.StringValue(input.TargetGroupIdentifier))
+						return &vpclattice.GetTargetGroupOutput{Arn: aws.String("tg-arn")}, nil
+					}).AnyTimes()
+			}
+			tags := tt.tags
+			if tt.managed {
+				tags = cloud.DefaultTagsMergedWith(tags)
+			}
+			mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).Return(
+				&vpclattice.ListTagsForResourceOutput{Tags: tags}, nil).AnyTimes()
+
+			poller := NewPoller(gwlog.FallbackLogger, cloud, &fakeSqs{}, "queue-url", controllerArn)
+			assert.Nil(t, poller.handleMessage(ctx, tt.body))
+
+			for sourceType, ch := range poller.events {
+				if tt.expectEnqueue && sourceType == tt.expectedType {
+					if assert.Len(t, ch, 1) {
+						e := <-ch
+						assert.Equal(t, tt.expectedName, e.Object.GetName())
+						assert.Equal(t, tt.expectedNs, e.Object.GetNamespace())
+					}
+				} else {
+					assert.Len(t, ch, 0, "unexpected event for %s", sourceType)
+				}
+			}
+		})
+	}
+}
+
+func Test_principalOf(t *testing.T) {
+	assert.Equal(t, "arn:aws:sts::123456789012:assumed-role/my-role",
+		principalOf("arn:aws:sts::123456789012:assumed-role/my-role/my-session"))
+	assert.Equal(t, "arn:aws:iam::123456789012:user/my-user", principalOf("arn:aws:iam::123456789012:user/my-user"))
+	assert.Equal(t, "", principalOf(""))
+}
+
+func Test_parseIdentifier(t *testing.T) {
+	tests := []struct {
+		identifier string
+		expected   latticeResource
+		ok         bool
+	}{
+		{"svc-123", latticeResource{latticeResourceService, "svc-123"}, true},
+		{"tg-123", latticeResource{latticeResourceTargetGroup, "tg-123"}, true},
+		{"arn:aws:vpc-lattice:us-west-2:123456789012:service/svc-123", latticeResource{latticeResourceService, "svc-123"}, true},
+		{"arn:aws:vpc-lattice:us-west-2:123456789012:service/svc-123/listener/listener-123/rule/rule-123", latticeResource{latticeResourceService, "svc-123"}, true},
+		{"arn:aws:vpc-lattice:us-west-2:123456789012:targetgroup/tg-123", latticeResource{latticeResourceTargetGroup, "tg-123"}, true},
+		{"arn:aws:vpc-lattice:us-west-2:123456789012:servicenetwork/sn-123", latticeResource{}, false},
+		{"sn-123", latticeResource{}, false},
+	}
+	for _, tt := range tests {
+		r, ok := parseIdentifier(tt.identifier)
+		assert.Equal(t, tt.ok, ok, tt.identifier)
+		assert.Equal(t, tt.expected, r, tt.identifier)
+	}
+}
@@ -6,12 +6,15 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
@@ -99,6 +102,12 @@ func (t *latticeServiceModelBuildTask) buildModel(ctx context.Context) error {
 		}
 	}
 
+	for _, modelListener := range modelListeners {
+		if err := t.validateListenerProtocol(modelListener); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -121,6 +130,7 @@ func (t *latticeServiceModelBuildTask) buildLatticeService(ctx context.Context)
 			RouteNamespace: t.route.Namespace(),
 			RouteType:      routeType,
 		},
+		MaintenanceWindow: t.route.K8sObject().GetAnnotations()[k8s.MaintenanceWindowAnnotation],
 	}
 
 	for _, parentRef := range t.route.Spec().ParentRefs() {
@@ -128,6 +138,12 @@ func (t *latticeServiceModelBuildTask) buildLatticeService(ctx context.Context)
 	}
 	if config.ServiceNetworkOverrideMode {
 		spec.ServiceNetworkNames = []string{config.DefaultServiceNetwork}
+	} else {
+		selectors, err := t.getServiceNetworkSelectors(ctx)
+		if err != nil {
+			return nil, err
+		}
+		spec.ServiceNetworkSelectors = selectors
 	}
 
 	if len(t.route.Spec().Hostnames()) > 0 {
@@ -148,6 +164,12 @@ func (t *latticeServiceModelBuildTask) buildLatticeService(ctx context.Context)
 	}
 	spec.CustomerCertARN = certArn
 
+	infraTags, err := t.getInfrastructureTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	spec.InfrastructureTags = infraTags
+
 	svc, err := model.NewLatticeService(t.stack, spec)
 	if err != nil {
 		return nil, err
@@ -158,6 +180,50 @@ func (t *latticeServiceModelBuildTask) buildLatticeService(ctx context.Context)
 	return svc, nil
 }
 
+// returns the service network tag selectors of the parent gateways which have one, by gateway name
+func (t *latticeServiceModelBuildTask) getServiceNetworkSelectors(ctx context.Context) (map[string]map[string]string, error) {
+	var selectors map[string]map[string]string
+	for _, parentRef := range t.route.Spec().ParentRefs() {
+		gwName := types.NamespacedName{
+			Namespace: t.route.Namespace(),
+			Name:      string(parentRef.Name),
+		}
+		if parentRef.Namespace != nil {
+			gwName.Namespace = string(*parentRef.Namespace)
+		}
+		gw := &gwv1beta1.Gateway{}
+		if err := t.client.Get(ctx, gwName, gw); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get gateway, name %s, err %w", gwName, err)
+		}
+		selector, err := k8s.ServiceNetworkSelector(gw)
+		if err != nil {
+			return nil, err
+		}
+		if selector != nil {
+			if selectors == nil {
+				selectors = make(map[string]map[string]string)
+			}
+			selectors[gwName.Name] = selector
+		}
+	}
+	return selectors, nil
+}
+
+// like listeners, services take the infrastructure of the 1st gateway
+func (t *latticeServiceModelBuildTask) getInfrastructureTags(ctx context.Context) (services.Tags, error) {
+	gw, err := t.getGateway(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) && !t.route.DeletionTimestamp().IsZero() {
+			return nil, nil // ok if we're deleting the route
+		}
+		return nil, err
+	}
+	return model.GatewayInfrastructureTags(gw.Spec.Infrastructure), nil
+}
+
 // returns empty string if not found
 func (t *latticeServiceModelBuildTask) getACMCertArn(ctx context.Context) (string, error) {
 	gw, err := t.getGateway(ctx)
@@ -176,20 +242,20 @@ func (t *latticeServiceModelBuildTask) getACMCertArn(ctx context.Context) (strin
 			continue
 		}
 
-		if parentRef.SectionName == nil {
-			continue
-		}
-
 		for _, section := range gw.Spec.Listeners {
-			if section.Name == *parentRef.SectionName && section.TLS != nil {
-				if section.TLS.Mode != nil && *section.TLS.Mode == gwv1.TLSModeTerminate {
-					curCertARN, ok := section.TLS.Options[awsCustomCertARN]
-					if ok {
-						t.log.Debugf(ctx, "Found certification %s under section %s", curCertARN, section.Name)
-						return string(curCertARN), nil
-					}
+			if parentRef.SectionName != nil && section.Name != *parentRef.SectionName {
+				continue
+			}
+			// without sectionName, the route attaches to all listeners of the gateway
+			if parentRef.SectionName == nil && parentRef.Port != nil && section.Port != *parentRef.Port {
+				continue
+			}
+			if section.TLS != nil && section.TLS.Mode != nil && *section.TLS.Mode == gwv1.TLSModeTerminate {
+				curCertARN, ok := section.TLS.Options[awsCustomCertARN]
+				if ok {
+					t.log.Debugf(ctx, "Found certification %s under section %s", curCertARN, section.Name)
+					return string(curCertARN), nil
 				}
-				break
 			}
 		}
 	}
@@ -203,4 +269,13 @@ type latticeServiceModelBuildTask struct {
 	client      client.Client
 	stack       core.Stack
 	brTgBuilder BackendRefTargetGroupModelBuilder
+
+	// stack target group ids of the Service backendRefs built so far. Listeners on several ports, and rules
+	// forwarding to the same Service, share its target group instead of building it again.
+	backendRefTgIds map[backendRefTgKey]string
+}
+
+type backendRefTgKey struct {
+	name types.NamespacedName
+	port int32
 }
@@ -2,10 +2,12 @@ package gateway
 
 import (
 	"context"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -394,6 +396,60 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 				ServiceNetworkNames: []string{"gateway1", "gateway2"},
 			},
 		},
+		{
+			name:          "Gateway infrastructure labels and annotations become tags",
+			wantIsDeleted: false,
+			wantErrIsNil:  true,
+			gw: gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.GatewaySpec{
+					Infrastructure: &gwv1.GatewayInfrastructure{
+						Labels: map[gwv1.AnnotationKey]gwv1.AnnotationValue{
+							"team":                               "payments",
+							"cost-center":                        "1234",
+							"application-networking.k8s.aws/Foo": "bar",
+						},
+						Annotations: map[gwv1.AnnotationKey]gwv1.AnnotationValue{
+							"example.com/owner": "alice",
+							"cost-center":       "5678",
+							"aws:reserved":      "value",
+						},
+					},
+				},
+			},
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:      "gateway1",
+								Namespace: namespacePtr("default"),
+							},
+						},
+					},
+				},
+			}),
+			expected: model.ServiceSpec{
+				ServiceTagFields: model.ServiceTagFields{
+					RouteName:      "service1",
+					RouteNamespace: "default",
+					RouteType:      core.HttpRouteType,
+				},
+				ServiceNetworkNames: []string{"gateway1"},
+				InfrastructureTags: services.Tags{
+					"team":              aws.String("payments"),
+					"cost-center":       aws.String("5678"),
+					"example.com/owner": aws.String("alice"),
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -432,6 +488,115 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 			assert.Equal(t, tt.expected.CustomerDomainName, svc.Spec.CustomerDomainName)
 			assert.Equal(t, tt.expected.RouteType, svc.Spec.RouteType)
 			assert.Equal(t, tt.expected.ServiceNetworkNames, svc.Spec.ServiceNetworkNames)
+			assert.Equal(t, tt.expected.InfrastructureTags, svc.Spec.InfrastructureTags)
 		})
 	}
 }
+
+func Test_LatticeServiceModelBuild_ListenersOnSeveralPortsShareTargetGroups(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	serviceKind := gwv1beta1.Kind("Service")
+	pathPrefix := gwv1.PathMatchPathPrefix
+	backendRef := func(name string) gwv1beta1.HTTPBackendRef {
+		return gwv1beta1.HTTPBackendRef{BackendRef: gwv1beta1.BackendRef{
+			BackendObjectReference: gwv1beta1.BackendObjectReference{Name: gwv1beta1.ObjectName(name), Kind: &serviceKind},
+		}}
+	}
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw1", Namespace: "default"},
+		Spec: gwv1beta1.GatewaySpec{
+			Listeners: []gwv1beta1.Listener{
+				{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType},
+				{Name: "http-alt", Port: 8080, Protocol: gwv1.HTTPProtocolType},
+			},
+		},
+	}
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "service1", Namespace: "default"},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "gw1"}},
+			},
+			Rules: []gwv1beta1.HTTPRouteRule{
+				{
+					Matches:     []gwv1beta1.HTTPRouteMatch{{Path: &gwv1beta1.HTTPPathMatch{Type: &pathPrefix, Value: aws.String("/v1")}}},
+					BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef("svc-v1")},
+				},
+				{
+					Matches:     []gwv1beta1.HTTPRouteMatch{{Path: &gwv1beta1.HTTPPathMatch{Type: &pathPrefix, Value: aws.String("/v2")}}},
+					BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef("svc-v2"), backendRef("svc-v1")},
+				},
+			},
+		},
+	})
+
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+	assert.NoError(t, k8sClient.Create(ctx, gw))
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+
+	// every Service gets a single target group, whatever the number of listeners and rules forwarding to it
+	brTgBuilder := NewMockBackendRefTargetGroupModelBuilder(c)
+	brTgBuilder.EXPECT().Build(ctx, route, gomock.Any(), stack).DoAndReturn(
+		func(ctx context.Context, route core.Route, backendRef core.BackendRef, stack core.Stack) (core.Stack, *model.TargetGroup, error) {
+			tg, err := model.NewTargetGroup(stack, model.TargetGroupSpec{
+				VpcId:           "vpc-id",
+				Port:            80,
+				Protocol:        "HTTP",
+				ProtocolVersion: "HTTP1",
+				IpAddressType:   "IPV4",
+				TargetGroupTagFields: model.TargetGroupTagFields{
+					K8SClusterName:      "cluster",
+					K8SSourceType:       model.SourceTypeHTTPRoute,
+					K8SServiceName:      string(backendRef.Name()),
+					K8SServiceNamespace: "default",
+					K8SRouteName:        "service1",
+					K8SRouteNamespace:   "default",
+				},
+			})
+			return stack, tg, err
+		}).Times(2)
+
+	task := &latticeServiceModelBuildTask{
+		log:         gwlog.FallbackLogger,
+		route:       route,
+		stack:       stack,
+		client:      k8sClient,
+		brTgBuilder: brTgBuilder,
+	}
+	assert.NoError(t, task.buildModel(ctx))
+
+	var listeners []*model.Listener
+	assert.NoError(t, stack.ListResources(&listeners))
+	assert.Len(t, listeners, 2)
+	var tgs []*model.TargetGroup
+	assert.NoError(t, stack.ListResources(&tgs))
+	assert.Len(t, tgs, 2)
+	var rules []*model.Rule
+	assert.NoError(t, stack.ListResources(&rules))
+	assert.Len(t, rules, 4)
+
+	// both listeners get the same rule set, forwarding to the same target groups
+	ruleTgs := make(map[int64][]map[string][]string)
+	for _, l := range listeners {
+		var listenerRules []map[string][]string
+		for _, r := range rules {
+			if r.Spec.StackListenerId != l.ID() {
+				continue
+			}
+			var tgIds []string
+			for _, tg := range r.Spec.Action.TargetGroups {
+				tgIds = append(tgIds, tg.StackTargetGroupId)
+			}
+			listenerRules = append(listenerRules, map[string][]string{r.Spec.PathMatchValue: tgIds})
+		}
+		ruleTgs[l.Spec.Port] = listenerRules
+	}
+	assert.Len(t, ruleTgs[80], 2)
+	assert.ElementsMatch(t, ruleTgs[80], ruleTgs[8080])
+}
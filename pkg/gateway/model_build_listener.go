@@ -11,6 +11,7 @@ import (
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 )
 
@@ -18,10 +19,94 @@ const (
 	awsCustomCertARN = "application-networking.k8s.aws/certificate-arn"
 )
 
+// ProtocolMismatchError is returned when a backend target group cannot serve the traffic of its listener.
+// Lattice accepts such a configuration, but requests to the backend fail.
+type ProtocolMismatchError struct {
+	BackendRef                 string
+	ListenerProtocol           string
+	TargetGroupProtocol        string
+	TargetGroupProtocolVersion string
+}
+
+func (e *ProtocolMismatchError) Error() string {
+	tgProtocol := e.TargetGroupProtocol
+	if e.TargetGroupProtocolVersion != "" {
+		tgProtocol += "/" + e.TargetGroupProtocolVersion
+	}
+	return fmt.Sprintf("backendRef %s target group protocol %s is not compatible with %s listener",
+		e.BackendRef, tgProtocol, e.ListenerProtocol)
+}
+
+// checks the target group can serve requests received by a listener of the given protocol:
+// - TLS_PASSTHROUGH listeners forward TCP streams, and require TCP target groups
+// - HTTP and HTTPS listeners require HTTP or HTTPS target groups
+// - HTTP2 and GRPC target groups require HTTPS listeners, as plain HTTP listeners only speak HTTP/1.1
+func validateListenerTargetGroupProtocol(listenerProtocol string, tg *model.TargetGroupSpec) error {
+	compatible := true
+	switch listenerProtocol {
+	case vpclattice.ListenerProtocolTlsPassthrough:
+		compatible = tg.Protocol == vpclattice.TargetGroupProtocolTcp
+	case vpclattice.ListenerProtocolHttp:
+		compatible = tg.Protocol != vpclattice.TargetGroupProtocolTcp &&
+			tg.ProtocolVersion != vpclattice.TargetGroupProtocolVersionHttp2 &&
+			tg.ProtocolVersion != vpclattice.TargetGroupProtocolVersionGrpc
+	case vpclattice.ListenerProtocolHttps:
+		compatible = tg.Protocol != vpclattice.TargetGroupProtocolTcp
+	}
+	if compatible {
+		return nil
+	}
+	return &ProtocolMismatchError{
+		BackendRef:                 types.NamespacedName{Namespace: tg.K8SServiceNamespace, Name: tg.K8SServiceName}.String(),
+		ListenerProtocol:           listenerProtocol,
+		TargetGroupProtocol:        tg.Protocol,
+		TargetGroupProtocolVersion: tg.ProtocolVersion,
+	}
+}
+
+// validates the protocol of every target group the listener forwards to, including through rules
+func (t *latticeServiceModelBuildTask) validateListenerProtocol(modelListener *model.Listener) error {
+	var ruleTgs []*model.RuleTargetGroup
+	if modelListener.Spec.DefaultAction != nil && modelListener.Spec.DefaultAction.Forward != nil {
+		ruleTgs = append(ruleTgs, modelListener.Spec.DefaultAction.Forward.TargetGroups...)
+	}
+
+	var modelRules []*model.Rule
+	if err := t.stack.ListResources(&modelRules); err != nil {
+		return err
+	}
+	for _, modelRule := range modelRules {
+		if modelRule.Spec.StackListenerId == modelListener.ID() {
+			ruleTgs = append(ruleTgs, modelRule.Spec.Action.TargetGroups...)
+		}
+	}
+
+	for _, ruleTg := range ruleTgs {
+		if ruleTg.StackTargetGroupId == "" || ruleTg.StackTargetGroupId == model.InvalidBackendRefTgId {
+			continue // service imports and invalid backendRefs have no target group spec to check
+		}
+		tg := &model.TargetGroup{}
+		if err := t.stack.GetResource(ruleTg.StackTargetGroupId, tg); err != nil {
+			return err
+		}
+		if err := validateListenerTargetGroupProtocol(modelListener.Spec.Protocol, &tg.Spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type listenerInfo struct {
+	port     int64
+	protocol string
+}
+
+// returns the port and protocol of the Gateway listeners the parentRef attaches the route to. Without
+// a sectionName, the route attaches to every listener which serves its kind, filtered by the parentRef port.
 func (t *latticeServiceModelBuildTask) extractListenerInfo(
 	ctx context.Context,
 	parentRef gwv1beta1.ParentReference,
-) (int64, string, error) {
+) ([]listenerInfo, error) {
 	if parentRef.SectionName != nil {
 		t.log.Debugf(ctx, "Listener parentRef SectionName is %s", *parentRef.SectionName)
 	}
@@ -29,30 +114,42 @@ func (t *latticeServiceModelBuildTask) extractListenerInfo(
 	t.log.Debugf(ctx, "Building Listener for Route %s-%s", t.route.Name(), t.route.Namespace())
 	gw, err := t.getGateway(ctx)
 	if err != nil {
-		return 0, "", err
+		return nil, err
 	}
-	// If no SectionName is specified, use the first listener port
-	if parentRef.SectionName == nil {
-		if len(gw.Spec.Listeners) == 0 {
-			return 0, "", errors.New("error building listener, there is NO listeners on GW")
-		}
-		listenerPort := int(gw.Spec.Listeners[0].Port)
-		protocol := gw.Spec.Listeners[0].Protocol
-		return int64(listenerPort), string(protocol), nil
+	if len(gw.Spec.Listeners) == 0 {
+		return nil, errors.New("error building listener, there is NO listeners on GW")
 	}
-	// Find the matching section name
+
+	var infos []listenerInfo
 	for _, section := range gw.Spec.Listeners {
-		if section.Name == *parentRef.SectionName {
-			listenerPort := int(section.Port)
-			protocol := section.Protocol
-			if isTLSPassthroughGatewayListener(&section) {
-				t.log.Debugf(ctx, "Found TLS passthrough section %v", section.TLS)
-				protocol = vpclattice.ListenerProtocolTlsPassthrough
+		if parentRef.SectionName != nil {
+			if section.Name != *parentRef.SectionName {
+				continue
+			}
+		} else {
+			if parentRef.Port != nil && *parentRef.Port != section.Port {
+				continue
 			}
-			return int64(listenerPort), string(protocol), nil
+			if !k8s.IsRouteKindServedByListener(section, t.route.GroupKind().Kind) {
+				continue
+			}
+		}
+		protocol := string(section.Protocol)
+		if isTLSPassthroughGatewayListener(&section) {
+			t.log.Debugf(ctx, "Found TLS passthrough section %v", section.TLS)
+			protocol = vpclattice.ListenerProtocolTlsPassthrough
+		}
+		infos = append(infos, listenerInfo{port: int64(section.Port), protocol: protocol})
+	}
+
+	if len(infos) == 0 {
+		if parentRef.SectionName != nil {
+			return nil, fmt.Errorf("error building listener, no matching sectionName in parentRef for Name %s, Section %s", parentRef.Name, *parentRef.SectionName)
 		}
+		return nil, fmt.Errorf("error building listener, no listener of gateway %s serves %s %s-%s",
+			parentRef.Name, t.route.GroupKind().Kind, t.route.Name(), t.route.Namespace())
 	}
-	return 0, "", fmt.Errorf("error building listener, no matching sectionName in parentRef for Name %s, Section %s", parentRef.Name, *parentRef.SectionName)
+	return infos, nil
 }
 
 func isTLSPassthroughGatewayListener(listener *gwv1.Listener) bool {
@@ -86,6 +183,7 @@ func (t *latticeServiceModelBuildTask) buildListeners(ctx context.Context, stack
 		return nil
 	}
 
+	builtPorts := make(map[int64]bool)
 	for _, parentRef := range t.route.Spec().ParentRefs() {
 		if parentRef.Name != t.route.Spec().ParentRefs()[0].Name {
 			// when a service is associate to multiple service network(s), all listener config MUST be same
@@ -94,31 +192,39 @@ func (t *latticeServiceModelBuildTask) buildListeners(ctx context.Context, stack
 			continue
 		}
 
-		port, protocol, err := t.extractListenerInfo(ctx, parentRef)
+		infos, err := t.extractListenerInfo(ctx, parentRef)
 		if err != nil {
 			return err
 		}
 
-		defaultAction, err := t.getListenerDefaultAction(ctx, protocol)
-		if err != nil {
-			return err
-		}
-		spec := model.ListenerSpec{
-			StackServiceId:    stackSvcId,
-			K8SRouteName:      t.route.Name(),
-			K8SRouteNamespace: t.route.Namespace(),
-			Port:              port,
-			Protocol:          protocol,
-			DefaultAction:     defaultAction,
-		}
+		for _, info := range infos {
+			if builtPorts[info.port] {
+				// parentRefs with and without sectionName can select the same gateway listener
+				continue
+			}
+			builtPorts[info.port] = true
 
-		modelListener, err := model.NewListener(t.stack, spec)
-		if err != nil {
-			return err
-		}
+			defaultAction, err := t.getListenerDefaultAction(ctx, info.protocol)
+			if err != nil {
+				return err
+			}
+			spec := model.ListenerSpec{
+				StackServiceId:    stackSvcId,
+				K8SRouteName:      t.route.Name(),
+				K8SRouteNamespace: t.route.Namespace(),
+				Port:              info.port,
+				Protocol:          info.protocol,
+				DefaultAction:     defaultAction,
+			}
 
-		t.log.Debugf(ctx, "Added listener %s-%s to the stack (ID %s)",
-			modelListener.Spec.K8SRouteName, modelListener.Spec.K8SRouteNamespace, modelListener.ID())
+			modelListener, err := model.NewListener(t.stack, spec)
+			if err != nil {
+				return err
+			}
+
+			t.log.Debugf(ctx, "Added listener %s-%s to the stack (ID %s)",
+				modelListener.Spec.K8SRouteName, modelListener.Spec.K8SRouteNamespace, modelListener.ID())
+		}
 	}
 
 	return nil
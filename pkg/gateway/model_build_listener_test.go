@@ -151,6 +151,84 @@ func Test_ListenerModelBuild(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:                   "Build HTTPS listener on non-default port",
+			gwListenerPort:         *PortNumberPtr(8443),
+			wantErrIsNil:           true,
+			k8sGetGatewayCall:      true,
+			k8sGatewayReturnOK:     true,
+			k8sGatewayListenerType: HTTPS,
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:        "gw1",
+								SectionName: &sectionName,
+							},
+						},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: backendRef,
+								},
+							},
+						},
+					},
+				},
+			}),
+			expectedSpec: []model.ListenerSpec{
+				{
+					StackServiceId:    "svc-id",
+					K8SRouteName:      "service1",
+					K8SRouteNamespace: "default",
+					Port:              8443,
+					Protocol:          "HTTPS",
+					DefaultAction: &model.DefaultAction{
+						FixedResponseStatusCode: aws.Int64(404),
+					},
+				},
+			},
+		},
+		{
+			name:                   "Listener port out of range",
+			gwListenerPort:         *PortNumberPtr(0),
+			wantErrIsNil:           false,
+			k8sGetGatewayCall:      true,
+			k8sGatewayReturnOK:     true,
+			k8sGatewayListenerType: HTTP,
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:        "gw1",
+								SectionName: &sectionName,
+							},
+						},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: backendRef,
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
 		{
 			name:                    "Build TLS_PASSTHROUGH listener",
 			gwListenerPort:          *PortNumberPtr(443),
@@ -511,3 +589,228 @@ func Test_ListenerModelBuild(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListenerModelBuild_MultipleGatewayListeners(t *testing.T) {
+	serviceKind := gwv1beta1.Kind("Service")
+	passthrough := gwv1.TLSModePassthrough
+	terminate := gwv1.TLSModeTerminate
+	var httpsSection gwv1beta1.SectionName = "https"
+	gwListeners := []gwv1beta1.Listener{
+		{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType},
+		{Name: "https", Port: 443, Protocol: gwv1.HTTPSProtocolType, TLS: &gwv1beta1.GatewayTLSConfig{Mode: &terminate}},
+		{Name: "tls", Port: 8443, Protocol: gwv1.TLSProtocolType, TLS: &gwv1beta1.GatewayTLSConfig{Mode: &passthrough}},
+	}
+	httpRoute := func(parentRefs ...gwv1beta1.ParentReference) core.Route {
+		return core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "service1", Namespace: "default"},
+			Spec: gwv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gwv1beta1.CommonRouteSpec{ParentRefs: parentRefs},
+				Rules: []gwv1beta1.HTTPRouteRule{{
+					BackendRefs: []gwv1beta1.HTTPBackendRef{{BackendRef: gwv1beta1.BackendRef{
+						BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "targetgroup1", Kind: &serviceKind},
+					}}},
+				}},
+			},
+		})
+	}
+
+	tests := []struct {
+		name          string
+		route         core.Route
+		wantListeners []listenerInfo
+		wantErr       bool
+	}{
+		{
+			name:  "HTTPRoute without sectionName attaches to HTTP and HTTPS listeners",
+			route: httpRoute(gwv1beta1.ParentReference{Name: "gw1"}),
+			wantListeners: []listenerInfo{
+				{port: 80, protocol: vpclattice.ListenerProtocolHttp},
+				{port: 443, protocol: vpclattice.ListenerProtocolHttps},
+			},
+		},
+		{
+			name:  "parentRef port selects a single listener",
+			route: httpRoute(gwv1beta1.ParentReference{Name: "gw1", Port: PortNumberPtr(443)}),
+			wantListeners: []listenerInfo{
+				{port: 443, protocol: vpclattice.ListenerProtocolHttps},
+			},
+		},
+		{
+			name: "listener selected by multiple parentRefs is built once",
+			route: httpRoute(
+				gwv1beta1.ParentReference{Name: "gw1", SectionName: &httpsSection},
+				gwv1beta1.ParentReference{Name: "gw1"},
+			),
+			wantListeners: []listenerInfo{
+				{port: 443, protocol: vpclattice.ListenerProtocolHttps},
+				{port: 80, protocol: vpclattice.ListenerProtocolHttp},
+			},
+		},
+		{
+			name: "GRPCRoute without sectionName attaches to HTTPS listener only",
+			route: core.NewGRPCRoute(gwv1alpha2.GRPCRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "service1", Namespace: "default"},
+				Spec: gwv1alpha2.GRPCRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{ParentRefs: []gwv1beta1.ParentReference{{Name: "gw1"}}},
+				},
+			}),
+			wantListeners: []listenerInfo{
+				{port: 443, protocol: vpclattice.ListenerProtocolHttps},
+			},
+		},
+		{
+			name:    "no listener on parentRef port",
+			route:   httpRoute(gwv1beta1.ParentReference{Name: "gw1", Port: PortNumberPtr(8443)}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+
+			mockK8sClient := mock_client.NewMockClient(c)
+			mockK8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.AssignableToTypeOf(&gwv1beta1.Gateway{})).DoAndReturn(
+				func(ctx context.Context, gwName types.NamespacedName, gw *gwv1beta1.Gateway, arg3 ...interface{}) error {
+					gw.Spec.Listeners = gwListeners
+					return nil
+				},
+			).AnyTimes()
+			stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(tt.route.K8sObject())))
+
+			task := &latticeServiceModelBuildTask{
+				log:         gwlog.FallbackLogger,
+				route:       tt.route,
+				client:      mockK8sClient,
+				stack:       stack,
+				brTgBuilder: NewMockBackendRefTargetGroupModelBuilder(c),
+			}
+
+			err := task.buildListeners(ctx, "svc-id")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var resListener []*model.Listener
+			stack.ListResources(&resListener)
+			var got []listenerInfo
+			for _, l := range resListener {
+				assert.Equal(t, "svc-id", l.Spec.StackServiceId)
+				assert.Equal(t, aws.Int64(404), l.Spec.DefaultAction.FixedResponseStatusCode)
+				got = append(got, listenerInfo{port: l.Spec.Port, protocol: l.Spec.Protocol})
+			}
+			assert.Equal(t, tt.wantListeners, got)
+		})
+	}
+}
+
+func Test_validateListenerTargetGroupProtocol(t *testing.T) {
+	tests := []struct {
+		name             string
+		listenerProtocol string
+		tgProtocol       string
+		tgVersion        string
+		wantErr          bool
+	}{
+		{"HTTP listener, HTTP1 target group", vpclattice.ListenerProtocolHttp, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1, false},
+		{"HTTP listener, HTTPS target group", vpclattice.ListenerProtocolHttp, vpclattice.TargetGroupProtocolHttps, vpclattice.TargetGroupProtocolVersionHttp1, false},
+		{"HTTP listener, HTTP2 target group", vpclattice.ListenerProtocolHttp, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp2, true},
+		{"HTTP listener, GRPC target group", vpclattice.ListenerProtocolHttp, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionGrpc, true},
+		{"HTTP listener, TCP target group", vpclattice.ListenerProtocolHttp, vpclattice.TargetGroupProtocolTcp, "", true},
+		{"HTTPS listener, HTTP2 target group", vpclattice.ListenerProtocolHttps, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp2, false},
+		{"HTTPS listener, GRPC target group", vpclattice.ListenerProtocolHttps, vpclattice.TargetGroupProtocolHttps, vpclattice.TargetGroupProtocolVersionGrpc, false},
+		{"HTTPS listener, TCP target group", vpclattice.ListenerProtocolHttps, vpclattice.TargetGroupProtocolTcp, "", true},
+		{"TLS_PASSTHROUGH listener, TCP target group", vpclattice.ListenerProtocolTlsPassthrough, vpclattice.TargetGroupProtocolTcp, "", false},
+		{"TLS_PASSTHROUGH listener, HTTP target group", vpclattice.ListenerProtocolTlsPassthrough, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tg := &model.TargetGroupSpec{
+				Protocol:        tt.tgProtocol,
+				ProtocolVersion: tt.tgVersion,
+			}
+			tg.K8SServiceName = "svc"
+			tg.K8SServiceNamespace = "ns"
+
+			err := validateListenerTargetGroupProtocol(tt.listenerProtocol, tg)
+			if !tt.wantErr {
+				assert.Nil(t, err)
+				return
+			}
+			pme := &ProtocolMismatchError{}
+			assert.True(t, errors.As(err, &pme))
+			assert.Equal(t, "ns/svc", pme.BackendRef)
+			assert.Equal(t, tt.listenerProtocol, pme.ListenerProtocol)
+		})
+	}
+}
+
+func Test_validateListenerProtocol(t *testing.T) {
+	stack := core.NewDefaultStack(core.StackID{Name: "foo", Namespace: "default"})
+	task := &latticeServiceModelBuildTask{
+		log:   gwlog.FallbackLogger,
+		stack: stack,
+	}
+
+	newTg := func(version string) *model.TargetGroup {
+		spec := model.TargetGroupSpec{
+			Type:            model.TargetGroupTypeIP,
+			Port:            80,
+			Protocol:        vpclattice.TargetGroupProtocolHttp,
+			ProtocolVersion: version,
+			IpAddressType:   vpclattice.IpAddressTypeIpv4,
+		}
+		spec.VpcId = "vpc-id"
+		spec.K8SClusterName = "cluster"
+		spec.K8SSourceType = model.SourceTypeHTTPRoute
+		spec.K8SServiceName = "svc-" + version
+		spec.K8SServiceNamespace = "ns"
+		spec.K8SRouteName = "route"
+		spec.K8SRouteNamespace = "ns"
+		tg, err := model.NewTargetGroup(stack, spec)
+		assert.Nil(t, err)
+		return tg
+	}
+	http1Tg := newTg(vpclattice.TargetGroupProtocolVersionHttp1)
+	grpcTg := newTg(vpclattice.TargetGroupProtocolVersionGrpc)
+
+	listener, err := model.NewListener(stack, model.ListenerSpec{
+		StackServiceId: "svc-id",
+		Port:           80,
+		Protocol:       vpclattice.ListenerProtocolHttp,
+		DefaultAction: &model.DefaultAction{
+			FixedResponseStatusCode: aws.Int64(model.DefaultActionFixedResponseStatusCode),
+		},
+	})
+	assert.Nil(t, err)
+
+	_, err = model.NewRule(stack, model.RuleSpec{
+		StackListenerId: listener.ID(),
+		Priority:        1,
+		Action: model.RuleAction{TargetGroups: []*model.RuleTargetGroup{
+			{StackTargetGroupId: http1Tg.ID()},
+			{StackTargetGroupId: model.InvalidBackendRefTgId},
+		}},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, task.validateListenerProtocol(listener))
+
+	_, err = model.NewRule(stack, model.RuleSpec{
+		StackListenerId: listener.ID(),
+		Priority:        2,
+		Action: model.RuleAction{TargetGroups: []*model.RuleTargetGroup{
+			{StackTargetGroupId: grpcTg.ID()},
+		}},
+	})
+	assert.Nil(t, err)
+	err = task.validateListenerProtocol(listener)
+	pme := &ProtocolMismatchError{}
+	assert.True(t, errors.As(err, &pme))
+	assert.Equal(t, "ns/svc-GRPC", pme.BackendRef)
+	assert.Equal(t, vpclattice.TargetGroupProtocolVersionGrpc, pme.TargetGroupProtocolVersion)
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -34,10 +36,10 @@ func (t *latticeServiceModelBuildTask) buildRules(ctx context.Context, stackList
 	// note we only build rules for non-deleted routes
 	t.log.Debugf(ctx, "Processing %d rules", len(t.route.Spec().Rules()))
 
-	for i, rule := range t.route.Spec().Rules() {
+	var ruleSpecs []model.RuleSpec
+	for _, rule := range t.route.Spec().Rules() {
 		ruleSpec := model.RuleSpec{
 			StackListenerId: stackListenerId,
-			Priority:        int64(i + 1),
 		}
 
 		if len(rule.Matches()) > 1 {
@@ -82,6 +84,18 @@ func (t *latticeServiceModelBuildTask) buildRules(ctx context.Context, stackList
 		ruleSpec.Action = model.RuleAction{
 			TargetGroups: ruleTgList,
 		}
+		ruleSpecs = append(ruleSpecs, ruleSpec)
+	}
+
+	// fail before deploying anything, instead of creating rules until Lattice rejects the one over its quota
+	if t.route.DeletionTimestamp().IsZero() && len(ruleSpecs) > config.ListenerRuleLimit {
+		return &model.RuleLimitExceededError{Rules: len(ruleSpecs), Limit: config.ListenerRuleLimit}
+	}
+
+	sortRuleSpecsByPrecedence(ruleSpecs)
+
+	for i, ruleSpec := range ruleSpecs {
+		ruleSpec.Priority = int64(i + 1)
 
 		// don't bother adding rules on delete, these will be removed automatically with the owning route/lattice service
 		// target groups will still be present and removed as needed
@@ -99,6 +113,30 @@ func (t *latticeServiceModelBuildTask) buildRules(ctx context.Context, stackList
 	return nil
 }
 
+// sortRuleSpecsByPrecedence orders rules following the Gateway API match precedence, so that the most
+// specific match gets the lowest Lattice rule priority:
+//  1. exact path match
+//  2. prefix path match with the largest number of characters
+//  3. method match
+//  4. largest number of header matches
+//
+// Rules with equal precedence keep the order they are declared in the route.
+func sortRuleSpecsByPrecedence(ruleSpecs []model.RuleSpec) {
+	sort.SliceStable(ruleSpecs, func(i, j int) bool {
+		a, b := ruleSpecs[i], ruleSpecs[j]
+		if a.PathMatchExact != b.PathMatchExact {
+			return a.PathMatchExact
+		}
+		if len(a.PathMatchValue) != len(b.PathMatchValue) {
+			return len(a.PathMatchValue) > len(b.PathMatchValue)
+		}
+		if (a.Method != "") != (b.Method != "") {
+			return a.Method != ""
+		}
+		return len(a.MatchedHeaders) > len(b.MatchedHeaders)
+	})
+}
+
 func (t *latticeServiceModelBuildTask) updateRuleSpecForHttpRoute(m *core.HTTPRouteMatch, ruleSpec *model.RuleSpec) error {
 	hasPath := m.Path() != nil
 	hasType := hasPath && m.Path().Type != nil
@@ -259,19 +297,11 @@ func (t *latticeServiceModelBuildTask) getTargetGroupsForRuleAction(ctx context.
 		}
 
 		if string(*backendRef.Kind()) == "Service" {
-			// generate the actual target group model for the backendRef
-			_, tg, err := t.brTgBuilder.Build(ctx, t.route, backendRef, t.stack)
+			tgId, err := t.getBackendRefTargetGroupId(ctx, backendRef)
 			if err != nil {
-				ibre := &InvalidBackendRefError{}
-				if !errors.As(err, &ibre) {
-					return nil, err
-				}
-
-				t.log.Infof(ctx, "Invalid backendRef found on route %s", t.route.Name())
-				ruleTG.StackTargetGroupId = model.InvalidBackendRefTgId
-			} else {
-				ruleTG.StackTargetGroupId = tg.ID()
+				return nil, err
 			}
+			ruleTG.StackTargetGroupId = tgId
 		}
 
 		tgList = append(tgList, &ruleTG)
@@ -279,3 +309,34 @@ func (t *latticeServiceModelBuildTask) getTargetGroupsForRuleAction(ctx context.
 
 	return tgList, nil
 }
+
+// getBackendRefTargetGroupId returns the stack id of the target group of a Service backendRef, building it
+// with its targets the first time the backendRef is seen. Invalid backendRefs get model.InvalidBackendRefTgId.
+func (t *latticeServiceModelBuildTask) getBackendRefTargetGroupId(ctx context.Context, backendRef core.BackendRef) (string, error) {
+	key := backendRefTgKey{name: getBackendRefNsName(t.route, backendRef)}
+	if backendRef.Port() != nil {
+		key.port = int32(*backendRef.Port())
+	}
+	if tgId, ok := t.backendRefTgIds[key]; ok {
+		return tgId, nil
+	}
+
+	// generate the actual target group model for the backendRef
+	tgId := model.InvalidBackendRefTgId
+	_, tg, err := t.brTgBuilder.Build(ctx, t.route, backendRef, t.stack)
+	if err != nil {
+		ibre := &InvalidBackendRefError{}
+		if !errors.As(err, &ibre) {
+			return "", err
+		}
+		t.log.Infof(ctx, "Invalid backendRef found on route %s", t.route.Name())
+	} else {
+		tgId = tg.ID()
+	}
+
+	if t.backendRefTgIds == nil {
+		t.backendRefTgIds = make(map[backendRefTgKey]string)
+	}
+	t.backendRefTgIds[key] = tgId
+	return tgId, nil
+}
@@ -22,6 +22,7 @@ import (
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
@@ -426,6 +427,91 @@ func Test_RuleModelBuild(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:         "rule, method and path based",
+			wantErrIsNil: true,
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: apimachineryv1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:        "gw1",
+								SectionName: &httpSectionName,
+							},
+						},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							Matches: []gwv1beta1.HTTPRouteMatch{
+								{
+									Method: &httpGet,
+									Path: &gwv1beta1.HTTPPathMatch{
+										Type:  &k8sPathMatchPrefix,
+										Value: &path1,
+									},
+								},
+							},
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: backendRef1,
+								},
+							},
+						},
+						{
+							Matches: []gwv1beta1.HTTPRouteMatch{
+								{
+									Method: &httpPost,
+									Path: &gwv1beta1.HTTPPathMatch{
+										Type:  &k8sPathMatchExactType,
+										Value: &path2,
+									},
+								},
+							},
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: backendRef1,
+								},
+							},
+						},
+					},
+				},
+			}),
+			expectedSpec: []model.RuleSpec{
+				{
+					StackListenerId: "listener-id",
+					Method:          string(httpPost),
+					PathMatchExact:  true,
+					PathMatchValue:  path2,
+					Action: model.RuleAction{
+						TargetGroups: []*model.RuleTargetGroup{
+							{
+								// both rules forward to backendRef1, and share its target group
+								StackTargetGroupId: "tg-0",
+								Weight:             int64(weight1),
+							},
+						},
+					},
+				},
+				{
+					StackListenerId: "listener-id",
+					Method:          string(httpGet),
+					PathMatchPrefix: true,
+					PathMatchValue:  path1,
+					Action: model.RuleAction{
+						TargetGroups: []*model.RuleTargetGroup{
+							{
+								StackTargetGroupId: "tg-0",
+								Weight:             int64(weight1),
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:         "rule, different namespace combination",
 			wantErrIsNil: true,
@@ -1556,3 +1642,207 @@ func validateEqual(t *testing.T, expectedRules []model.RuleSpec, actualRules []*
 		}
 	}
 }
+
+func Test_RuleModelBuild_PriorityFollowsMatchPrecedence(t *testing.T) {
+	var serviceKind gwv1beta1.Kind = "Service"
+	var httpGet = gwv1.HTTPMethodGet
+	var k8sHeaderExactType = gwv1.HeaderMatchExact
+
+	backendRefs := []gwv1beta1.HTTPBackendRef{
+		{
+			BackendRef: gwv1beta1.BackendRef{
+				BackendObjectReference: gwv1beta1.BackendObjectReference{
+					Name: "targetgroup1",
+					Kind: &serviceKind,
+				},
+			},
+		},
+	}
+	pathRule := func(pathType gwv1.PathMatchType, path string) gwv1beta1.HTTPRouteRule {
+		return gwv1beta1.HTTPRouteRule{
+			Matches: []gwv1beta1.HTTPRouteMatch{
+				{
+					Path: &gwv1beta1.HTTPPathMatch{
+						Type:  &pathType,
+						Value: aws.String(path),
+					},
+				},
+			},
+			BackendRefs: backendRefs,
+		}
+	}
+	methodRule := pathRule(gwv1.PathMatchPathPrefix, "/ver1")
+	methodRule.Matches[0].Method = &httpGet
+	oneHeaderRule := pathRule(gwv1.PathMatchPathPrefix, "/ver1")
+	oneHeaderRule.Matches[0].Headers = []gwv1beta1.HTTPHeaderMatch{
+		{Type: &k8sHeaderExactType, Name: "env1", Value: "test1"},
+	}
+	twoHeadersRule := pathRule(gwv1.PathMatchPathPrefix, "/ver1")
+	twoHeadersRule.Matches[0].Headers = []gwv1beta1.HTTPHeaderMatch{
+		{Type: &k8sHeaderExactType, Name: "env1", Value: "test1"},
+		{Type: &k8sHeaderExactType, Name: "env2", Value: "test2"},
+	}
+	defaultRule := gwv1beta1.HTTPRouteRule{BackendRefs: backendRefs}
+
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: apimachineryv1.ObjectMeta{
+			Name:      "service1",
+			Namespace: "default",
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			Rules: []gwv1beta1.HTTPRouteRule{
+				defaultRule, // 0
+				pathRule(gwv1.PathMatchPathPrefix, "/ver1"), // 1
+				oneHeaderRule,                          // 2
+				pathRule(gwv1.PathMatchExact, "/ver1"), // 3
+				methodRule,                             // 4
+				pathRule(gwv1.PathMatchPathPrefix, "/ver1/a"), // 5
+				twoHeadersRule,                              // 6
+				pathRule(gwv1.PathMatchExact, "/ver1/a"),    // 7
+				pathRule(gwv1.PathMatchPathPrefix, "/ver2"), // 8, ties with 1
+			},
+		},
+	})
+
+	// expected rule (by index in the route) for each priority, starting from 1
+	expectedOrder := []int{7, 3, 5, 4, 6, 2, 1, 8, 0}
+
+	ctx := context.TODO()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+
+	// build the same route twice to make sure the priorities are stable
+	for n := 0; n < 2; n++ {
+		stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+		task := &latticeServiceModelBuildTask{
+			log:         gwlog.FallbackLogger,
+			route:       route,
+			stack:       stack,
+			client:      k8sClient,
+			brTgBuilder: &dummyTgBuilder{},
+		}
+		assert.NoError(t, task.buildRules(ctx, "listener-id"))
+
+		var resRules []*model.Rule
+		stack.ListResources(&resRules)
+		assert.Equal(t, len(expectedOrder), len(resRules))
+
+		priorities := map[int64]*model.Rule{}
+		for _, rule := range resRules {
+			priorities[rule.Spec.Priority] = rule
+		}
+		assert.Equal(t, len(expectedOrder), len(priorities), "priorities must be unique")
+
+		for i, ruleIndex := range expectedOrder {
+			rule, ok := priorities[int64(i+1)]
+			if !assert.True(t, ok, "missing rule with priority %d", i+1) {
+				continue
+			}
+			match := route.Spec().Rules()[ruleIndex].Matches()
+			if len(match) == 0 {
+				assert.Equal(t, "/", rule.Spec.PathMatchValue)
+				assert.Empty(t, rule.Spec.MatchedHeaders)
+				continue
+			}
+			m := match[0].(*core.HTTPRouteMatch)
+			assert.Equal(t, *m.Path().Value, rule.Spec.PathMatchValue, "priority %d", i+1)
+			assert.Equal(t, *m.Path().Type == gwv1.PathMatchExact, rule.Spec.PathMatchExact, "priority %d", i+1)
+			assert.Equal(t, len(m.Headers()), len(rule.Spec.MatchedHeaders), "priority %d", i+1)
+			assert.Equal(t, m.Method() != nil, rule.Spec.Method != "", "priority %d", i+1)
+		}
+	}
+}
+
+func Test_RuleModelBuild_RuleLimitExceeded(t *testing.T) {
+	var serviceKind gwv1beta1.Kind = "Service"
+	pathType := gwv1.PathMatchPathPrefix
+
+	newRoute := func(rules int, deleted bool) core.Route {
+		var routeRules []gwv1beta1.HTTPRouteRule
+		for i := 0; i < rules; i++ {
+			routeRules = append(routeRules, gwv1beta1.HTTPRouteRule{
+				Matches: []gwv1beta1.HTTPRouteMatch{
+					{
+						Path: &gwv1beta1.HTTPPathMatch{
+							Type:  &pathType,
+							Value: aws.String(fmt.Sprintf("/ver%d", i)),
+						},
+					},
+				},
+				BackendRefs: []gwv1beta1.HTTPBackendRef{
+					{
+						BackendRef: gwv1beta1.BackendRef{
+							BackendObjectReference: gwv1beta1.BackendObjectReference{
+								Name: "targetgroup1",
+								Kind: &serviceKind,
+							},
+						},
+					},
+				},
+			})
+		}
+		route := gwv1beta1.HTTPRoute{
+			ObjectMeta: apimachineryv1.ObjectMeta{
+				Name:      "service1",
+				Namespace: "default",
+			},
+			Spec: gwv1beta1.HTTPRouteSpec{
+				Rules: routeRules,
+			},
+		}
+		if deleted {
+			now := apimachineryv1.Now()
+			route.DeletionTimestamp = &now
+		}
+		return core.NewHTTPRoute(route)
+	}
+
+	tests := []struct {
+		name      string
+		rules     int
+		deleted   bool
+		wantError bool
+	}{
+		{name: "rules within the limit", rules: 3},
+		{name: "rules over the limit", rules: 4, wantError: true},
+		{name: "deleted route over the limit", rules: 4, deleted: true},
+	}
+
+	defaultLimit := config.ListenerRuleLimit
+	config.ListenerRuleLimit = 3
+	defer func() { config.ListenerRuleLimit = defaultLimit }()
+
+	ctx := context.TODO()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := newRoute(tt.rules, tt.deleted)
+			stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+			task := &latticeServiceModelBuildTask{
+				log:         gwlog.FallbackLogger,
+				route:       route,
+				stack:       stack,
+				client:      k8sClient,
+				brTgBuilder: &dummyTgBuilder{},
+			}
+
+			err := task.buildRules(ctx, "listener-id")
+			var resRules []*model.Rule
+			stack.ListResources(&resRules)
+			if !tt.wantError {
+				assert.NoError(t, err)
+				return
+			}
+
+			rle := &model.RuleLimitExceededError{}
+			assert.ErrorAs(t, err, &rle)
+			assert.Equal(t, tt.rules, rle.Rules)
+			assert.Equal(t, 3, rle.Limit)
+			assert.Empty(t, resRules, "no rule should be built over the limit")
+		})
+	}
+}
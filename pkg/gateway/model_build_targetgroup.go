@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	corev1 "k8s.io/api/core/v1"
@@ -24,6 +26,42 @@ type (
 	TGP = anv1alpha1.TargetGroupPolicy
 )
 
+// BackendProtocolsAnnotation overrides the target group protocol of the Service backendRefs of an HTTPRoute or
+// GRPCRoute, e.g. "secure-svc=HTTPS,legacy/plain-svc=HTTP", so one route can front both HTTP and HTTPS backends.
+// Services are named as in their backendRefs, with an optional namespace. It takes precedence over the protocol of
+// their TargetGroupPolicy for this route only.
+const BackendProtocolsAnnotation = k8s.AnnotationPrefix + "backend-protocols"
+
+// ParseBackendProtocols returns the target group protocol overrides of the route by backendRef Service
+func ParseBackendProtocols(route core.Route) (map[types.NamespacedName]string, error) {
+	value, ok := route.K8sObject().GetAnnotations()[BackendProtocolsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	protocols := make(map[types.NamespacedName]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, protocol, ok := strings.Cut(entry, "=")
+		name, protocol = strings.TrimSpace(name), strings.TrimSpace(protocol)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid %s entry %s, must be <service>=<protocol>", BackendProtocolsAnnotation, entry)
+		}
+		if protocol != vpclattice.TargetGroupProtocolHttp && protocol != vpclattice.TargetGroupProtocolHttps {
+			return nil, fmt.Errorf("invalid %s protocol %s of %s, must be %s or %s", BackendProtocolsAnnotation,
+				protocol, name, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolHttps)
+		}
+		nsName := types.NamespacedName{Namespace: route.Namespace(), Name: name}
+		if namespace, svcName, ok := strings.Cut(name, "/"); ok {
+			nsName = types.NamespacedName{Namespace: namespace, Name: svcName}
+		}
+		protocols[nsName] = protocol
+	}
+	return protocols, nil
+}
+
 type InvalidBackendRefError struct {
 	BackendRef core.BackendRef
 	Reason     string
@@ -142,23 +180,26 @@ func (t *svcExportTargetGroupModelBuildTask) buildTargetGroup(ctx context.Contex
 		}
 	}
 
+	tgp, err := t.tgp.ObjResolvedPolicy(ctx, t.serviceExport)
+	if err != nil {
+		return nil, err
+	}
+
 	var ipAddressType string
-	var err error
 	if noSvcFoundAndDeleting {
 		ipAddressType = "IPV4" // just pick a default
 	} else {
-		ipAddressType, err = buildTargetGroupIpAddressType(svc)
+		ipAddressType, err = buildTargetGroupIpAddressType(svc, tgp)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	tgp, err := t.tgp.ObjResolvedPolicy(ctx, t.serviceExport)
+	protocol, protocolVersion, healthCheckConfig, err := parseTargetGroupConfig(tgp)
 	if err != nil {
 		return nil, err
 	}
-
-	protocol, protocolVersion, healthCheckConfig, err := parseTargetGroupConfig(tgp)
+	reconcileMode, err := parseReconcileMode(tgp)
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +211,7 @@ func (t *svcExportTargetGroupModelBuildTask) buildTargetGroup(ctx context.Contex
 		ProtocolVersion:   protocolVersion,
 		IpAddressType:     ipAddressType,
 		HealthCheckConfig: healthCheckConfig,
+		ReconcileMode:     reconcileMode,
 	}
 	spec.VpcId = config.VpcID
 	spec.K8SSourceType = model.SourceTypeSvcExport
@@ -187,6 +229,60 @@ func (t *svcExportTargetGroupModelBuildTask) buildTargetGroup(ctx context.Contex
 	return stackTG, nil
 }
 
+// PrewarmTargetGroupBuilder builds the target group created for a service ahead of any route referring to it.
+// The spec matches the one built for an HTTPRoute backendRef to the service, so the target group can be adopted.
+type PrewarmTargetGroupBuilder struct {
+	log    gwlog.Logger
+	client client.Client
+}
+
+func NewPrewarmTargetGroupBuilder(log gwlog.Logger, client client.Client) *PrewarmTargetGroupBuilder {
+	return &PrewarmTargetGroupBuilder{
+		log:    log,
+		client: client,
+	}
+}
+
+func (b *PrewarmTargetGroupBuilder) BuildTargetGroup(ctx context.Context, svc *corev1.Service) (*model.TargetGroup, error) {
+	tgp, err := policy.NewTargetGroupPolicyHandler(b.log, b.client).ObjResolvedPolicy(ctx, svc)
+	if err != nil {
+		return nil, err
+	}
+
+	ipAddressType, err := buildTargetGroupIpAddressType(svc, tgp)
+	if err != nil {
+		return nil, err
+	}
+
+	protocol, protocolVersion, healthCheckConfig, err := parseTargetGroupConfig(tgp)
+	if err != nil {
+		return nil, err
+	}
+	reconcileMode, err := parseReconcileMode(tgp)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := model.TargetGroupSpec{
+		Type:              model.TargetGroupTypeIP,
+		Port:              80,
+		Protocol:          protocol,
+		ProtocolVersion:   protocolVersion,
+		IpAddressType:     ipAddressType,
+		HealthCheckConfig: healthCheckConfig,
+		ReconcileMode:     reconcileMode,
+	}
+	spec.VpcId = config.VpcID
+	spec.K8SSourceType = model.SourceTypePrewarm
+	spec.K8SClusterName = config.ClusterName
+	spec.K8SServiceName = svc.Name
+	spec.K8SServiceNamespace = svc.Namespace
+	spec.K8SProtocolVersion = protocolVersion
+
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(svc)))
+	return model.NewTargetGroup(stack, spec)
+}
+
 type BackendRefTargetGroupModelBuilder interface {
 	Build(ctx context.Context, route core.Route, backendRef core.BackendRef, stack core.Stack) (core.Stack, *model.TargetGroup, error)
 }
@@ -257,7 +353,10 @@ func (t *backendRefTargetGroupModelBuildTask) buildTargetGroup(ctx context.Conte
 
 	stackTG.IsDeleted = !t.route.DeletionTimestamp().IsZero() // should always be false
 	if !stackTG.IsDeleted {
-		t.buildTargets(ctx, stackTG.ID())
+		// other target build failures leave the targets of the target group unchanged
+		if err := t.buildTargets(ctx, stackTG.ID()); errors.Is(err, ErrNoEndpoints) {
+			return nil, err
+		}
 	}
 
 	return stackTG, nil
@@ -304,14 +403,19 @@ func (t *backendRefTargetGroupModelBuildTask) buildTargetGroupSpec(ctx context.C
 				fmt.Errorf("error finding backend service %s due to %s", backendRefNsName, err)
 		}
 	}
+	if t.backendRef.Port() != nil && !k8s.HasServicePort(svc, int32(*t.backendRef.Port())) {
+		return model.TargetGroupSpec{}, &InvalidBackendRefError{
+			BackendRef: t.backendRef,
+			Reason:     fmt.Sprintf("service %s on route %s has no port %d, backendRef invalid", backendRefNsName.Name, t.route.Name(), *t.backendRef.Port()),
+		}
+	}
 
-	var err error
-	ipAddressType, err := buildTargetGroupIpAddressType(svc)
+	tgp, err := t.tgp.ObjResolvedPolicy(ctx, svc)
 	if err != nil {
 		return model.TargetGroupSpec{}, err
 	}
 
-	tgp, err := t.tgp.ObjResolvedPolicy(ctx, svc)
+	ipAddressType, err := buildTargetGroupIpAddressType(svc, tgp)
 	if err != nil {
 		return model.TargetGroupSpec{}, err
 	}
@@ -320,6 +424,10 @@ func (t *backendRefTargetGroupModelBuildTask) buildTargetGroupSpec(ctx context.C
 	if err != nil {
 		return model.TargetGroupSpec{}, err
 	}
+	reconcileMode, err := parseReconcileMode(tgp)
+	if err != nil {
+		return model.TargetGroupSpec{}, err
+	}
 
 	var parentRefType model.K8SSourceType
 	switch t.route.(type) {
@@ -338,6 +446,20 @@ func (t *backendRefTargetGroupModelBuildTask) buildTargetGroupSpec(ctx context.C
 		return model.TargetGroupSpec{}, fmt.Errorf("unsupported route type %T", t.route)
 	}
 
+	if parentRefType != model.SourceTypeTLSRoute {
+		protocols, err := ParseBackendProtocols(t.route)
+		if err != nil {
+			return model.TargetGroupSpec{}, err
+		}
+		if override, ok := protocols[backendRefNsName]; ok {
+			protocol = override
+			if protocolVersion == "" {
+				// the TargetGroupPolicy of the service is TCP
+				protocolVersion = vpclattice.TargetGroupProtocolVersionHttp1
+			}
+		}
+	}
+
 	spec := model.TargetGroupSpec{
 		Type:              model.TargetGroupTypeIP,
 		Port:              80,
@@ -345,6 +467,7 @@ func (t *backendRefTargetGroupModelBuildTask) buildTargetGroupSpec(ctx context.C
 		ProtocolVersion:   protocolVersion,
 		IpAddressType:     ipAddressType,
 		HealthCheckConfig: healthCheckConfig,
+		ReconcileMode:     reconcileMode,
 	}
 	spec.VpcId = vpc
 	spec.K8SSourceType = parentRefType
@@ -354,6 +477,7 @@ func (t *backendRefTargetGroupModelBuildTask) buildTargetGroupSpec(ctx context.C
 	spec.K8SRouteName = t.route.Name()
 	spec.K8SRouteNamespace = t.route.Namespace()
 	spec.K8SProtocolVersion = protocolVersion
+	spec.MaintenanceWindow = t.route.K8sObject().GetAnnotations()[k8s.MaintenanceWindowAnnotation]
 
 	return spec, nil
 }
@@ -392,17 +516,23 @@ func parseTargetGroupConfig(tgp *anv1alpha1.TargetGroupPolicy) (
 	if tgp.Spec.ProtocolVersion != nil && protocol != vpclattice.TargetGroupProtocolTcp {
 		protocolVersion = *tgp.Spec.ProtocolVersion
 	}
-	healthCheckConfig = parseHealthCheckConfig(tgp)
+	healthCheckConfig, err = parseHealthCheckConfig(tgp)
+	if err != nil {
+		return "", "", nil, err
+	}
 	return protocol, protocolVersion, healthCheckConfig, nil
 }
 
-func parseHealthCheckConfig(tgp *anv1alpha1.TargetGroupPolicy) *vpclattice.HealthCheckConfig {
+func parseHealthCheckConfig(tgp *anv1alpha1.TargetGroupPolicy) (*vpclattice.HealthCheckConfig, error) {
 	hc := tgp.Spec.HealthCheck
 	if hc == nil {
-		return nil
+		return nil, nil
 	}
 	var matcher *vpclattice.Matcher
 	if hc.StatusMatch != nil {
+		if err := ValidateStatusMatch(*hc.StatusMatch); err != nil {
+			return nil, err
+		}
 		matcher = &vpclattice.Matcher{HttpCode: hc.StatusMatch}
 	}
 	return &vpclattice.HealthCheckConfig{
@@ -416,27 +546,90 @@ func parseHealthCheckConfig(tgp *anv1alpha1.TargetGroupPolicy) *vpclattice.Healt
 		Port:                       hc.Port,
 		Protocol:                   (*string)(hc.Protocol),
 		ProtocolVersion:            (*string)(hc.ProtocolVersion),
+	}, nil
+}
+
+func parseReconcileMode(tgp *anv1alpha1.TargetGroupPolicy) (model.ReconcileMode, error) {
+	if tgp == nil {
+		return model.ReconcileModeStrict, nil
+	}
+	switch mode := model.ReconcileMode(tgp.Annotations[anv1alpha1.ReconcileModeAnnotationKey]); mode {
+	case "", model.ReconcileModeStrict:
+		return model.ReconcileModeStrict, nil
+	case model.ReconcileModeAdditive:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid %s annotation %q, must be one of %q, %q", anv1alpha1.ReconcileModeAnnotationKey,
+			mode, model.ReconcileModeStrict, model.ReconcileModeAdditive)
+	}
+}
+
+// Lattice health checks accept HTTP status codes between 200 and 599 as successful responses
+const (
+	minStatusMatchCode = 200
+	maxStatusMatchCode = 599
+)
+
+// ValidateStatusMatch validates the health check statusMatch of a TargetGroupPolicy, a comma-separated
+// list of HTTP status codes and ranges of codes, e.g. "200", "200,202" or "200-399".
+func ValidateStatusMatch(statusMatch string) error {
+	for _, entry := range strings.Split(statusMatch, ",") {
+		low, high, isRange := strings.Cut(entry, "-")
+		if !isRange {
+			high = low
+		}
+		lowCode, lowErr := parseStatusMatchCode(low)
+		highCode, highErr := parseStatusMatchCode(high)
+		if lowErr != nil || highErr != nil || lowCode > highCode {
+			return fmt.Errorf("invalid healthCheck.statusMatch %q, must be comma-separated HTTP status codes or ranges of codes "+
+				"between %d and %d, e.g. 200,202 or 200-399", statusMatch, minStatusMatchCode, maxStatusMatchCode)
+		}
+	}
+	return nil
+}
+
+func parseStatusMatchCode(s string) (int, error) {
+	if len(s) != 3 {
+		return 0, fmt.Errorf("invalid status code %s", s)
 	}
+	code, err := strconv.Atoi(s)
+	if err != nil || code < minStatusMatchCode || code > maxStatusMatchCode {
+		return 0, fmt.Errorf("invalid status code %s", s)
+	}
+	return code, nil
 }
 
-func buildTargetGroupIpAddressType(svc *corev1.Service) (string, error) {
+// Lattice target groups have a single IP address type, the one set by the TargetGroupPolicy or the one of the
+// primary IP family of the Service. Only the endpoints of that family are registered for dual-stack Services.
+func buildTargetGroupIpAddressType(svc *corev1.Service, tgp *anv1alpha1.TargetGroupPolicy) (string, error) {
 	ipFamilies := svc.Spec.IPFamilies
 
-	if len(ipFamilies) != 1 {
-		return "", errors.New("Lattice Target Group only supports single stack IP addresses")
+	if len(ipFamilies) == 0 {
+		return "", errors.New("service has no IP families")
 	}
 
-	// IpFamilies will always have at least 1 element
-	ipFamily := ipFamilies[0]
+	var ipAddressTypes []string
+	for _, ipFamily := range ipFamilies {
+		switch ipFamily {
+		case corev1.IPv4Protocol:
+			ipAddressTypes = append(ipAddressTypes, vpclattice.IpAddressTypeIpv4)
+		case corev1.IPv6Protocol:
+			ipAddressTypes = append(ipAddressTypes, vpclattice.IpAddressTypeIpv6)
+		default:
+			return "", fmt.Errorf("unknown ipFamily: %s", ipFamily)
+		}
+	}
 
-	switch ipFamily {
-	case corev1.IPv4Protocol:
-		return vpclattice.IpAddressTypeIpv4, nil
-	case corev1.IPv6Protocol:
-		return vpclattice.IpAddressTypeIpv6, nil
-	default:
-		return "", fmt.Errorf("unknown ipFamily: %s", ipFamily)
+	if tgp == nil || tgp.Spec.IpAddressType == nil {
+		return ipAddressTypes[0], nil
+	}
+	for _, ipAddressType := range ipAddressTypes {
+		if ipAddressType == *tgp.Spec.IpAddressType {
+			return ipAddressType, nil
+		}
 	}
+	return "", fmt.Errorf("ipAddressType %s of TargetGroupPolicy %s is not an IP family of service %s",
+		*tgp.Spec.IpAddressType, tgp.Name, k8s.NamespacedName(svc))
 }
 
 func GetServiceForBackendRef(ctx context.Context, client client.Client, route core.Route, backendRef core.BackendRef) (*corev1.Service, error) {
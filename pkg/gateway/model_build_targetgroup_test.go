@@ -15,12 +15,14 @@ import (
 
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -172,7 +174,7 @@ func Test_TGModelByServiceExportBuild(t *testing.T) {
 			wantIPv6TargetGroup: true,
 		},
 		{
-			name: "Failed to create IPv6 ServiceExport where service object with dual stack IpFamilies exists",
+			name: "Adding ServiceExport where service object with dual stack IpFamilies exists, primary family IPv6",
 			svcExport: &anv1alpha1.ServiceExport{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:       "export6",
@@ -186,7 +188,7 @@ func Test_TGModelByServiceExportBuild(t *testing.T) {
 					Namespace: "ns1",
 				},
 				Spec: corev1.ServiceSpec{
-					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+					IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
 					Ports: []corev1.ServicePort{
 						{},
 					},
@@ -200,8 +202,9 @@ func Test_TGModelByServiceExportBuild(t *testing.T) {
 					},
 				},
 			},
-			wantErrIsNil:  false,
-			wantIsDeleted: false,
+			wantErrIsNil:        true,
+			wantIsDeleted:       false,
+			wantIPv6TargetGroup: true,
 		},
 	}
 
@@ -628,9 +631,159 @@ func Test_ServiceImportToTGBuildReturnsError(t *testing.T) {
 	}
 }
 
+func Test_TGModelByMixedProtocolHTTPRouteBuild(t *testing.T) {
+	config.VpcID = "vpc-id"
+	config.ClusterName = "cluster-name"
+	ctx := context.Background()
+
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+
+	for _, name := range []string{"secure-svc", "plain-svc"} {
+		assert.NoError(t, k8sClient.Create(ctx, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"},
+			Spec:       corev1.ServiceSpec{IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol}},
+		}))
+	}
+
+	kind := gwv1beta1.Kind("Service")
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mixed",
+			Namespace: "ns1",
+			Annotations: map[string]string{
+				BackendProtocolsAnnotation: "secure-svc=HTTPS",
+			},
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			Rules: []gwv1beta1.HTTPRouteRule{
+				{
+					BackendRefs: []gwv1beta1.HTTPBackendRef{
+						{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "secure-svc", Kind: &kind}}},
+						{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "plain-svc", Kind: &kind}}},
+					},
+				},
+			},
+		},
+	})
+
+	builder := NewBackendRefTargetGroupBuilder(gwlog.FallbackLogger, k8sClient)
+	wantProtocols := []string{vpclattice.TargetGroupProtocolHttps, vpclattice.TargetGroupProtocolHttp}
+	for i, backendRef := range route.Spec().Rules()[0].BackendRefs() {
+		_, stackTg, err := builder.Build(ctx, route, backendRef, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, wantProtocols[i], stackTg.Spec.Protocol)
+		assert.Equal(t, vpclattice.TargetGroupProtocolVersionHttp1, stackTg.Spec.ProtocolVersion)
+		assert.Equal(t, string(backendRef.Name()), stackTg.Spec.K8SServiceName)
+	}
+}
+
+func Test_TGModelByHTTPRouteBuild_BackendRefPort(t *testing.T) {
+	config.VpcID = "vpc-id"
+	config.ClusterName = "cluster-name"
+	ctx := context.Background()
+
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+	assert.NoError(t, k8sClient.Create(ctx, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "ns1"},
+		Spec: corev1.ServiceSpec{
+			IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+			Ports:      []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}))
+
+	kind := gwv1beta1.Kind("Service")
+	validPort := gwv1beta1.PortNumber(8080)
+	missingPort := gwv1beta1.PortNumber(9090)
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns1"},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			Rules: []gwv1beta1.HTTPRouteRule{
+				{
+					BackendRefs: []gwv1beta1.HTTPBackendRef{
+						{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "svc", Kind: &kind, Port: &validPort}}},
+						{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "svc", Kind: &kind, Port: &missingPort}}},
+					},
+				},
+			},
+		},
+	})
+	backendRefs := route.Spec().Rules()[0].BackendRefs()
+
+	builder := NewBackendRefTargetGroupBuilder(gwlog.FallbackLogger, k8sClient)
+	_, stackTg, err := builder.Build(ctx, route, backendRefs[0], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "svc", stackTg.Spec.K8SServiceName)
+
+	_, _, err = builder.Build(ctx, route, backendRefs[1], nil)
+	ibre := &InvalidBackendRefError{}
+	assert.ErrorAs(t, err, &ibre)
+	assert.Equal(t, "service svc on route route has no port 9090, backendRef invalid", ibre.Reason)
+}
+
+func Test_ParseBackendProtocols(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation *string
+		want       map[types.NamespacedName]string
+		wantErr    string
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:       "services with and without namespace",
+			annotation: aws.String("secure-svc=HTTPS, other-ns/plain-svc = HTTP,"),
+			want: map[types.NamespacedName]string{
+				{Namespace: "ns1", Name: "secure-svc"}:     vpclattice.TargetGroupProtocolHttps,
+				{Namespace: "other-ns", Name: "plain-svc"}: vpclattice.TargetGroupProtocolHttp,
+			},
+		},
+		{
+			name:       "missing protocol",
+			annotation: aws.String("secure-svc"),
+			wantErr:    "invalid application-networking.k8s.aws/backend-protocols entry secure-svc, must be <service>=<protocol>",
+		},
+		{
+			name:       "unsupported protocol",
+			annotation: aws.String("secure-svc=TCP"),
+			wantErr:    "invalid application-networking.k8s.aws/backend-protocols protocol TCP of secure-svc, must be HTTP or HTTPS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpRoute := gwv1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns1"}}
+			if tt.annotation != nil {
+				httpRoute.Annotations = map[string]string{BackendProtocolsAnnotation: *tt.annotation}
+			}
+			protocols, err := ParseBackendProtocols(core.NewHTTPRoute(httpRoute))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, protocols)
+		})
+	}
+}
+
 func Test_buildTargetGroupIpAddressType(t *testing.T) {
 	type args struct {
 		svc *corev1.Service
+		tgp *anv1alpha1.TargetGroupPolicy
+	}
+	tgpWithIpAddressType := func(ipAddressType string) *anv1alpha1.TargetGroupPolicy {
+		return &anv1alpha1.TargetGroupPolicy{
+			Spec: anv1alpha1.TargetGroupPolicySpec{IpAddressType: &ipAddressType},
+		}
 	}
 
 	tests := []struct {
@@ -663,6 +816,43 @@ func Test_buildTargetGroupIpAddressType(t *testing.T) {
 			want:    vpclattice.IpAddressTypeIpv6,
 			wantErr: false,
 		},
+		{
+			name: "dual-stack IpFamilies get the primary family",
+			args: args{
+				svc: &corev1.Service{
+					Spec: corev1.ServiceSpec{
+						IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+					},
+				},
+			},
+			want:    vpclattice.IpAddressTypeIpv6,
+			wantErr: false,
+		},
+		{
+			name: "dual-stack IpFamilies get the family of the policy",
+			args: args{
+				svc: &corev1.Service{
+					Spec: corev1.ServiceSpec{
+						IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+					},
+				},
+				tgp: tgpWithIpAddressType(vpclattice.IpAddressTypeIpv4),
+			},
+			want:    vpclattice.IpAddressTypeIpv4,
+			wantErr: false,
+		},
+		{
+			name: "policy family not in IpFamilies get error",
+			args: args{
+				svc: &corev1.Service{
+					Spec: corev1.ServiceSpec{
+						IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+					},
+				},
+				tgp: tgpWithIpAddressType(vpclattice.IpAddressTypeIpv6),
+			},
+			wantErr: true,
+		},
 		{
 			name: "IpFamilies empty get error",
 			args: args{
@@ -689,7 +879,7 @@ func Test_buildTargetGroupIpAddressType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := buildTargetGroupIpAddressType(tt.args.svc)
+			got, err := buildTargetGroupIpAddressType(tt.args.svc, tt.args.tgp)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("buildTargetGroupIpAddressType() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -700,3 +890,76 @@ func Test_buildTargetGroupIpAddressType(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseTargetGroupConfig_StatusMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusMatch string
+		wantErr     bool
+	}{
+		{name: "single code", statusMatch: "200"},
+		{name: "list of codes", statusMatch: "200,202,204"},
+		{name: "range of codes", statusMatch: "200-399"},
+		{name: "codes and ranges", statusMatch: "200-299,301,404-499"},
+		{name: "empty", statusMatch: "", wantErr: true},
+		{name: "regular expression", statusMatch: "2..", wantErr: true},
+		{name: "code out of range", statusMatch: "100", wantErr: true},
+		{name: "range out of range", statusMatch: "200-600", wantErr: true},
+		{name: "reversed range", statusMatch: "399-200", wantErr: true},
+		{name: "trailing comma", statusMatch: "200,", wantErr: true},
+		{name: "spaces", statusMatch: "200, 202", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusMatch := tt.statusMatch
+			tgp := &anv1alpha1.TargetGroupPolicy{
+				Spec: anv1alpha1.TargetGroupPolicySpec{
+					HealthCheck: &anv1alpha1.HealthCheckConfig{StatusMatch: &statusMatch},
+				},
+			}
+			_, _, hc, err := parseTargetGroupConfig(tgp)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid healthCheck.statusMatch")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &vpclattice.Matcher{HttpCode: &statusMatch}, hc.Matcher)
+		})
+	}
+}
+
+func Test_parseReconcileMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		tgp     *anv1alpha1.TargetGroupPolicy
+		want    model.ReconcileMode
+		wantErr bool
+	}{
+		{name: "no policy", want: model.ReconcileModeStrict},
+		{name: "no annotation", tgp: &anv1alpha1.TargetGroupPolicy{}, want: model.ReconcileModeStrict},
+		{name: "strict", tgp: tgpWithReconcileMode("strict"), want: model.ReconcileModeStrict},
+		{name: "additive", tgp: tgpWithReconcileMode("additive"), want: model.ReconcileModeAdditive},
+		{name: "invalid", tgp: tgpWithReconcileMode("merge"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := parseReconcileMode(tt.tgp)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, mode)
+		})
+	}
+}
+
+func tgpWithReconcileMode(mode string) *anv1alpha1.TargetGroupPolicy {
+	return &anv1alpha1.TargetGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{anv1alpha1.ReconcileModeAnnotationKey: mode},
+		},
+	}
+}
@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -11,11 +12,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
 	discoveryv1 "k8s.io/api/discovery/v1"
 )
 
@@ -24,6 +27,10 @@ const (
 	undefinedPort      = int32(0)
 )
 
+// ErrNoEndpoints is returned when building the targets of a Service without endpoints
+// with the requeue empty endpoints policy.
+var ErrNoEndpoints = errors.New("service has no endpoints")
+
 type LatticeTargetsBuilder interface {
 	Build(ctx context.Context, service *corev1.Service, backendRef core.BackendRef, stackTgId string) (core.Stack, error)
 	BuildForServiceExport(ctx context.Context, serviceExport *anv1alpha1.ServiceExport, stackTgId string) (core.Stack, error)
@@ -155,6 +162,10 @@ func (t *latticeTargetsModelBuildTask) buildLatticeTargets(ctx context.Context)
 		if err != nil {
 			return err
 		}
+		if len(targetList) == 0 && config.EmptyEndpoints == config.EmptyEndpointsPolicyRequeue {
+			return fmt.Errorf("%w: %s, retrying until its EndpointSlices have endpoints",
+				ErrNoEndpoints, k8s.NamespacedName(t.service))
+		}
 	}
 
 	spec := model.TargetsSpec{
@@ -178,8 +189,24 @@ func (t *latticeTargetsModelBuildTask) getTargetListFromEndpoints(ctx context.Co
 		return nil, err
 	}
 
-	var targetList []model.Target
+	// only register the addresses of the IP family of the target group, dual-stack Services have slices of both
+	var addressType discoveryv1.AddressType
+	stackTg := &model.TargetGroup{}
+	if err := t.stack.GetResource(t.stackTgId, stackTg); err == nil {
+		switch stackTg.Spec.IpAddressType {
+		case vpclattice.IpAddressTypeIpv4:
+			addressType = discoveryv1.AddressTypeIPv4
+		case vpclattice.IpAddressTypeIpv6:
+			addressType = discoveryv1.AddressTypeIPv6
+		}
+	}
+
+	var targetList, drainingTargetList []model.Target
+	drainingNodes := make(map[string]bool)
 	for _, epSlice := range epSlices.Items {
+		if addressType != "" && epSlice.AddressType != addressType {
+			continue
+		}
 		for _, port := range epSlice.Ports {
 			// Note that the Endpoint's port name is from ServicePort, but the actual registered port
 			// is from Pods(targets).
@@ -198,15 +225,42 @@ func (t *latticeTargetsModelBuildTask) getTargetListFromEndpoints(ctx context.Co
 						if ep.TargetRef != nil && ep.TargetRef.Kind == "Pod" {
 							target.TargetRef = types.NamespacedName{Namespace: ep.TargetRef.Namespace, Name: ep.TargetRef.Name}
 						}
+						// Neither endpoints of draining nodes, ahead of their pods termination.
+						if ep.NodeName != nil && t.isNodeDraining(ctx, drainingNodes, *ep.NodeName) {
+							drainingTargetList = append(drainingTargetList, target)
+							continue
+						}
 						targetList = append(targetList, target)
 					}
 				}
 			}
 		}
 	}
+	// Keep the targets of draining nodes rather than none, e.g. when all the nodes become NotReady at once.
+	if len(targetList) == 0 && len(drainingTargetList) > 0 {
+		t.log.Infof(ctx, "All the endpoints of service %s are on draining nodes, keeping their targets",
+			k8s.NamespacedName(t.service))
+		return drainingTargetList, nil
+	}
 	return targetList, nil
 }
 
+// Draining state of nodes is cached for the build. Nodes which cannot be read are not draining.
+func (t *latticeTargetsModelBuildTask) isNodeDraining(ctx context.Context, cache map[string]bool, nodeName string) bool {
+	if draining, ok := cache[nodeName]; ok {
+		return draining
+	}
+	draining := false
+	node := &corev1.Node{}
+	if err := t.client.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+		t.log.Debugf(ctx, "failed to get node %s, assuming it is not draining: %s", nodeName, err)
+	} else {
+		draining = k8s.IsNodeDraining(node)
+	}
+	cache[nodeName] = draining
+	return draining
+}
+
 func (t *latticeTargetsModelBuildTask) getDefinedPorts() map[int32]struct{} {
 	definedPorts := make(map[int32]struct{})
 
@@ -17,10 +17,12 @@ import (
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
 	discoveryv1 "k8s.io/api/discovery/v1"
 )
 
@@ -38,6 +40,7 @@ func Test_Targets(t *testing.T) {
 		name               string
 		port               int32
 		endpointSlice      []discoveryv1.EndpointSlice
+		nodes              []corev1.Node
 		svc                corev1.Service
 		serviceExport      anv1alpha1.ServiceExport
 		refByServiceExport bool
@@ -120,6 +123,153 @@ func Test_Targets(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Do not add endpoints of draining nodes to build spec",
+			port: 0,
+			endpointSlice: []discoveryv1.EndpointSlice{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns1",
+						Name:      "export1",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "export1"},
+					},
+					Ports: []discoveryv1.EndpointPort{
+						{Port: aws.Int32(8675)},
+					},
+					Endpoints: []discoveryv1.Endpoint{
+						{
+							Addresses:  []string{"10.10.1.1"},
+							Conditions: discoveryv1.EndpointConditions{Ready: aws.Bool(true)},
+							NodeName:   aws.String("ready-node"),
+						},
+						{
+							Addresses:  []string{"10.10.2.2"},
+							Conditions: discoveryv1.EndpointConditions{Ready: aws.Bool(true)},
+							NodeName:   aws.String("not-ready-node"),
+						},
+						{
+							Addresses:  []string{"10.10.3.3"},
+							Conditions: discoveryv1.EndpointConditions{Ready: aws.Bool(true)},
+							NodeName:   aws.String("cordoned-node"),
+						},
+						{
+							Addresses:  []string{"10.10.4.4"},
+							Conditions: discoveryv1.EndpointConditions{Ready: aws.Bool(true)},
+							NodeName:   aws.String("unknown-node"),
+						},
+					},
+				},
+			},
+			nodes: []corev1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "ready-node"},
+					Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+					}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "not-ready-node"},
+					Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionUnknown},
+					}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "cordoned-node"},
+					Spec:       corev1.NodeSpec{Unschedulable: true},
+					Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+					}},
+				},
+			},
+			svc: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "export1",
+				},
+			},
+			refByService: true,
+			wantErrIsNil: true,
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "10.10.1.1",
+					Port:     8675,
+					Ready:    true,
+				},
+				{
+					TargetIP: "10.10.4.4",
+					Port:     8675,
+					Ready:    true,
+				},
+			},
+		},
+		{
+			name: "Keep endpoints of draining nodes when all the nodes are draining",
+			port: 0,
+			endpointSlice: []discoveryv1.EndpointSlice{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns1",
+						Name:      "export1",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "export1"},
+					},
+					Ports: []discoveryv1.EndpointPort{
+						{Port: aws.Int32(8675)},
+					},
+					Endpoints: []discoveryv1.Endpoint{
+						{
+							Addresses:  []string{"10.10.2.2"},
+							Conditions: discoveryv1.EndpointConditions{Ready: aws.Bool(true)},
+							NodeName:   aws.String("not-ready-node"),
+						},
+						{
+							Addresses:  []string{"10.10.3.3"},
+							Conditions: discoveryv1.EndpointConditions{Ready: aws.Bool(true)},
+							NodeName:   aws.String("cordoned-node"),
+						},
+						{
+							Addresses:  []string{"10.10.5.5"},
+							Conditions: discoveryv1.EndpointConditions{Ready: aws.Bool(false), Terminating: aws.Bool(true)},
+							NodeName:   aws.String("cordoned-node"),
+						},
+					},
+				},
+			},
+			nodes: []corev1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "not-ready-node"},
+					Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+					}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "cordoned-node"},
+					Spec:       corev1.NodeSpec{Unschedulable: true},
+					Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+					}},
+				},
+			},
+			svc: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "export1",
+				},
+			},
+			refByService: true,
+			wantErrIsNil: true,
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "10.10.2.2",
+					Port:     8675,
+					Ready:    true,
+				},
+				{
+					TargetIP: "10.10.3.3",
+					Port:     8675,
+					Ready:    true,
+				},
+			},
+		},
 		{
 			name: "Add endpoints with matching service port to build spec",
 			port: 80,
@@ -453,6 +603,10 @@ func Test_Targets(t *testing.T) {
 				assert.NoError(t, k8sClient.Create(ctx, tt.endpointSlice[0].DeepCopy()))
 			}
 
+			for _, node := range tt.nodes {
+				assert.NoError(t, k8sClient.Create(ctx, node.DeepCopy()))
+			}
+
 			assert.NoError(t, k8sClient.Create(ctx, tt.svc.DeepCopy()))
 
 			br := gwv1beta1.HTTPBackendRef{}
@@ -492,3 +646,254 @@ func Test_Targets(t *testing.T) {
 		})
 	}
 }
+
+func Test_Targets_EmptyEndpointsPolicy(t *testing.T) {
+	ctx := context.TODO()
+	defer func() { config.EmptyEndpoints = config.EmptyEndpointsPolicyAccept }()
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "just-created",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Port: 80}},
+		},
+	}
+	br := gwv1beta1.HTTPBackendRef{}
+	br.Name = "just-created"
+	corebr := core.NewHTTPBackendRef(br)
+
+	tests := []struct {
+		name      string
+		policy    config.EmptyEndpointsPolicy
+		wantErrIs error
+	}{
+		{
+			name:   "accept builds targets without endpoints",
+			policy: config.EmptyEndpointsPolicyAccept,
+		},
+		{
+			name:      "requeue fails until the service has endpoints",
+			policy:    config.EmptyEndpointsPolicyRequeue,
+			wantErrIs: ErrNoEndpoints,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.EmptyEndpoints = tt.policy
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			discoveryv1.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(svc.DeepCopy()).Build()
+
+			stack := core.NewDefaultStack(core.StackID(types.NamespacedName{Name: "stack", Namespace: "ns"}))
+			builder := NewTargetsBuilder(gwlog.FallbackLogger, k8sClient, stack)
+			_, err := builder.Build(ctx, svc, &corebr, "tg-id")
+
+			var stackTargets []*model.Targets
+			_ = stack.ListResources(&stackTargets)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				assert.Empty(t, stackTargets)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, stackTargets, 1)
+			assert.Empty(t, stackTargets[0].Spec.TargetList)
+		})
+	}
+}
+
+func Test_Targets_IPFamilies(t *testing.T) {
+	ctx := context.TODO()
+
+	epSlice := func(name string, addressType discoveryv1.AddressType, address string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1",
+				Name:      name,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "svc"},
+			},
+			AddressType: addressType,
+			Ports:       []discoveryv1.EndpointPort{{Port: aws.Int32(8080)}},
+			Endpoints: []discoveryv1.Endpoint{{
+				Addresses:  []string{address},
+				Conditions: discoveryv1.EndpointConditions{Ready: aws.Bool(true)},
+			}},
+		}
+	}
+	svc := func(ipFamilies ...corev1.IPFamily) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "svc"},
+			Spec: corev1.ServiceSpec{
+				IPFamilies: ipFamilies,
+				Ports:      []corev1.ServicePort{{Port: 80}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		svc           *corev1.Service
+		epSlices      []*discoveryv1.EndpointSlice
+		ipAddressType string
+		wantTargetIPs []string
+	}{
+		{
+			name:          "IPv6",
+			svc:           svc(corev1.IPv6Protocol),
+			epSlices:      []*discoveryv1.EndpointSlice{epSlice("svc-v6", discoveryv1.AddressTypeIPv6, "2001:db8::1")},
+			ipAddressType: vpclattice.IpAddressTypeIpv6,
+			wantTargetIPs: []string{"2001:db8::1"},
+		},
+		{
+			name: "dual-stack with IPv4 target group",
+			svc:  svc(corev1.IPv4Protocol, corev1.IPv6Protocol),
+			epSlices: []*discoveryv1.EndpointSlice{
+				epSlice("svc-v4", discoveryv1.AddressTypeIPv4, "10.0.0.1"),
+				epSlice("svc-v6", discoveryv1.AddressTypeIPv6, "2001:db8::1"),
+			},
+			ipAddressType: vpclattice.IpAddressTypeIpv4,
+			wantTargetIPs: []string{"10.0.0.1"},
+		},
+		{
+			name: "dual-stack with IPv6 target group",
+			svc:  svc(corev1.IPv4Protocol, corev1.IPv6Protocol),
+			epSlices: []*discoveryv1.EndpointSlice{
+				epSlice("svc-v4", discoveryv1.AddressTypeIPv4, "10.0.0.1"),
+				epSlice("svc-v6", discoveryv1.AddressTypeIPv6, "2001:db8::1"),
+			},
+			ipAddressType: vpclattice.IpAddressTypeIpv6,
+			wantTargetIPs: []string{"2001:db8::1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			discoveryv1.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(tt.svc.DeepCopy()).Build()
+			for _, epSlice := range tt.epSlices {
+				assert.NoError(t, k8sClient.Create(ctx, epSlice))
+			}
+
+			stack := core.NewDefaultStack(core.StackID(types.NamespacedName{Name: "stack", Namespace: "ns"}))
+			tgSpec := model.TargetGroupSpec{
+				VpcId:           "vpc-id",
+				Type:            model.TargetGroupTypeIP,
+				Port:            80,
+				Protocol:        vpclattice.TargetGroupProtocolHttp,
+				ProtocolVersion: vpclattice.TargetGroupProtocolVersionHttp1,
+				IpAddressType:   tt.ipAddressType,
+			}
+			tgSpec.K8SClusterName = "cluster-name"
+			tgSpec.K8SSourceType = model.SourceTypeSvcExport
+			tgSpec.K8SServiceName = "svc"
+			tgSpec.K8SServiceNamespace = "ns1"
+			stackTg, err := model.NewTargetGroup(stack, tgSpec)
+			assert.NoError(t, err)
+
+			br := gwv1beta1.HTTPBackendRef{}
+			br.Name = "svc"
+			corebr := core.NewHTTPBackendRef(br)
+			builder := NewTargetsBuilder(gwlog.FallbackLogger, k8sClient, stack)
+			_, err = builder.Build(ctx, tt.svc, &corebr, stackTg.ID())
+			assert.NoError(t, err)
+
+			var stackTargets []*model.Targets
+			assert.NoError(t, stack.ListResources(&stackTargets))
+			assert.Len(t, stackTargets, 1)
+			var targetIPs []string
+			for _, target := range stackTargets[0].Spec.TargetList {
+				targetIPs = append(targetIPs, target.TargetIP)
+			}
+			assert.Equal(t, tt.wantTargetIPs, targetIPs)
+		})
+	}
+}
+
+func Test_Targets_ServiceSelectorChange(t *testing.T) {
+	ctx := context.TODO()
+
+	// the endpoint slice controller replaces the slices of a service when its selector changes
+	epSlice := func(name string, address string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1",
+				Name:      name,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "svc"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Ports:       []discoveryv1.EndpointPort{{Port: aws.Int32(8080)}},
+			Endpoints: []discoveryv1.Endpoint{{
+				Addresses:  []string{address},
+				Conditions: discoveryv1.EndpointConditions{Ready: aws.Bool(true)},
+			}},
+		}
+	}
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	discoveryv1.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "svc"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "v1"},
+				Ports:    []corev1.ServicePort{{Port: 80}},
+			},
+		},
+		epSlice("svc-v1", "10.0.0.1"),
+	).Build()
+
+	buildTargetIPs := func() []string {
+		svc := &corev1.Service{}
+		assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "ns1", Name: "svc"}, svc))
+
+		stack := core.NewDefaultStack(core.StackID(types.NamespacedName{Name: "stack", Namespace: "ns"}))
+		tgSpec := model.TargetGroupSpec{
+			VpcId:           "vpc-id",
+			Type:            model.TargetGroupTypeIP,
+			Port:            80,
+			Protocol:        vpclattice.TargetGroupProtocolHttp,
+			ProtocolVersion: vpclattice.TargetGroupProtocolVersionHttp1,
+			IpAddressType:   vpclattice.IpAddressTypeIpv4,
+		}
+		tgSpec.K8SClusterName = "cluster-name"
+		tgSpec.K8SSourceType = model.SourceTypeSvcExport
+		tgSpec.K8SServiceName = "svc"
+		tgSpec.K8SServiceNamespace = "ns1"
+		stackTg, err := model.NewTargetGroup(stack, tgSpec)
+		assert.NoError(t, err)
+
+		br := gwv1beta1.HTTPBackendRef{}
+		br.Name = "svc"
+		corebr := core.NewHTTPBackendRef(br)
+		_, err = NewTargetsBuilder(gwlog.FallbackLogger, k8sClient, stack).Build(ctx, svc, &corebr, stackTg.ID())
+		assert.NoError(t, err)
+
+		var stackTargets []*model.Targets
+		assert.NoError(t, stack.ListResources(&stackTargets))
+		assert.Len(t, stackTargets, 1)
+		var targetIPs []string
+		for _, target := range stackTargets[0].Spec.TargetList {
+			targetIPs = append(targetIPs, target.TargetIP)
+		}
+		return targetIPs
+	}
+
+	assert.Equal(t, []string{"10.0.0.1"}, buildTargetIPs())
+
+	svc := &corev1.Service{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "ns1", Name: "svc"}, svc))
+	svc.Spec.Selector = map[string]string{"app": "v2"}
+	assert.NoError(t, k8sClient.Update(ctx, svc))
+	assert.NoError(t, k8sClient.Delete(ctx, epSlice("svc-v1", "10.0.0.1")))
+	assert.NoError(t, k8sClient.Create(ctx, epSlice("svc-v2", "10.0.0.2")))
+
+	// the old target is not desired anymore, so the targets manager deregisters it
+	assert.Equal(t, []string{"10.0.0.2"}, buildTargetIPs())
+}
@@ -0,0 +1,117 @@
+// Package conditions is the catalog of the condition types and reasons the controllers set on the status of
+// the resources they reconcile. Controllers use its reasons only, so that a situation, e.g. a missing target,
+// is reported with the same reason on every kind of resource. Reasons defined by the Gateway API keep their
+// Gateway API value.
+package conditions
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Reason is the machine-readable reason of a condition
+type Reason string
+
+const (
+	TypeAccepted     = "Accepted"
+	TypeResolvedRefs = "ResolvedRefs"
+	TypeProgrammed   = "Programmed"
+
+	// TypeDriftDetected is True when the Lattice state of a resource differs from its desired state,
+	// e.g. after an out of band change, as of the last drift detection
+	TypeDriftDetected = "DriftDetected"
+)
+
+// Reasons of conditions which are True
+const (
+	ReasonAccepted     Reason = "Accepted"
+	ReasonResolvedRefs Reason = "ResolvedRefs"
+	ReasonProgrammed   Reason = "Programmed"
+	ReasonDrifted      Reason = "Drifted"
+)
+
+// Reasons of conditions which are False
+const (
+	ReasonPending Reason = "Pending"
+	ReasonInSync  Reason = "InSync"
+	ReasonUnknown Reason = "Unknown"
+
+	// the resource spec is invalid, or was rejected by VPC Lattice
+	ReasonInvalid     Reason = "Invalid"
+	ReasonInvalidKind Reason = "InvalidKind"
+	// the controller is not allowed to apply the resource to VPC Lattice
+	ReasonAccessDenied Reason = "AccessDenied"
+	ReasonConflicted   Reason = "Conflicted"
+
+	// the targetRef of a policy, or the target of a pod, does not exist
+	ReasonTargetNotFound  Reason = "TargetNotFound"
+	ReasonBackendNotFound Reason = "BackendNotFound"
+
+	ReasonNotOurClass           Reason = "NotOurClass"
+	ReasonNotAllowedByListeners Reason = "NotAllowedByListeners"
+	ReasonNoMatchingParent      Reason = "NoMatchingParent"
+
+	ReasonUnsupportedKind     Reason = "UnsupportedKind"
+	ReasonUnsupportedValue    Reason = "UnsupportedValue"
+	ReasonUnsupportedProtocol Reason = "UnsupportedProtocol"
+	// the route has more rules than a VPC Lattice listener allows
+	ReasonRuleLimitExceeded Reason = "RuleLimitExceeded"
+
+	ReasonPortUnavailable   Reason = "PortUnavailable"
+	ReasonInvalidRouteKinds Reason = "InvalidRouteKinds"
+)
+
+// Reasons of the pod readiness gate condition, along with ReasonTargetNotFound
+const (
+	ReasonHealthy                Reason = "Healthy"
+	ReasonUnhealthy              Reason = "Unhealthy"
+	ReasonUnused                 Reason = "Unused"
+	ReasonInitial                Reason = "Initial"
+	ReasonHealthCheckUnavailable Reason = "HealthCheckUnavailable"
+)
+
+var trueReasons = map[Reason]bool{
+	ReasonAccepted:     true,
+	ReasonResolvedRefs: true,
+	ReasonProgrammed:   true,
+	ReasonDrifted:      true,
+}
+
+// New returns a condition of an object generation. Its status follows the reason, it is True with Accepted,
+// ResolvedRefs, Programmed and Drifted, False otherwise.
+func New(conditionType string, generation int64, reason Reason, msg string) metav1.Condition {
+	status := metav1.ConditionFalse
+	if trueReasons[reason] {
+		status = metav1.ConditionTrue
+	}
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             string(reason),
+		Message:            msg,
+	}
+}
+
+// Set sets a condition of an object generation in conditions, see New
+func Set(conditions *[]metav1.Condition, conditionType string, generation int64, reason Reason, msg string) {
+	meta.SetStatusCondition(conditions, New(conditionType, generation, reason, msg))
+}
+
+// SetAccepted sets the Accepted condition of an object generation in conditions
+func SetAccepted(conditions *[]metav1.Condition, generation int64, reason Reason, msg string) {
+	Set(conditions, TypeAccepted, generation, reason, msg)
+}
+
+// HasReason returns true when the condition of the type is set with the reason
+func HasReason(conditions []metav1.Condition, conditionType string, reason Reason) bool {
+	cnd := meta.FindStatusCondition(conditions, conditionType)
+	return cnd != nil && cnd.Reason == string(reason)
+}
+
+// Changed returns true when setting cnd would change conditions, ignoring the transition time
+func Changed(conditions []metav1.Condition, cnd metav1.Condition) bool {
+	prev := meta.FindStatusCondition(conditions, cnd.Type)
+	return prev == nil || prev.Status != cnd.Status || prev.Reason != cnd.Reason ||
+		prev.Message != cnd.Message || prev.ObservedGeneration != cnd.ObservedGeneration
+}
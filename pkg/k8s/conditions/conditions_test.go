@@ -0,0 +1,157 @@
+package conditions
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestReasonsKeepGatewayAPIValues(t *testing.T) {
+	assert.Equal(t, string(gwv1alpha2.PolicyConditionAccepted), TypeAccepted)
+	assert.Equal(t, string(gwv1.RouteConditionResolvedRefs), TypeResolvedRefs)
+	assert.Equal(t, string(gwv1.GatewayConditionProgrammed), TypeProgrammed)
+
+	gatewayAPIReasons := map[Reason]string{
+		ReasonAccepted:              string(gwv1alpha2.PolicyReasonAccepted),
+		ReasonInvalid:               string(gwv1alpha2.PolicyReasonInvalid),
+		ReasonTargetNotFound:        string(gwv1alpha2.PolicyReasonTargetNotFound),
+		ReasonConflicted:            string(gwv1alpha2.PolicyReasonConflicted),
+		ReasonResolvedRefs:          string(gwv1.RouteReasonResolvedRefs),
+		ReasonInvalidKind:           string(gwv1.RouteReasonInvalidKind),
+		ReasonBackendNotFound:       string(gwv1.RouteReasonBackendNotFound),
+		ReasonNotAllowedByListeners: string(gwv1.RouteReasonNotAllowedByListeners),
+		ReasonNoMatchingParent:      string(gwv1.RouteReasonNoMatchingParent),
+		ReasonUnsupportedValue:      string(gwv1.RouteReasonUnsupportedValue),
+		ReasonUnsupportedProtocol:   string(gwv1.RouteReasonUnsupportedProtocol),
+		ReasonProgrammed:            string(gwv1.GatewayReasonProgrammed),
+		ReasonPending:               string(gwv1.GatewayReasonPending),
+		ReasonPortUnavailable:       string(gwv1.ListenerReasonPortUnavailable),
+		ReasonInvalidRouteKinds:     string(gwv1.ListenerReasonInvalidRouteKinds),
+	}
+	for reason, value := range gatewayAPIReasons {
+		assert.Equal(t, value, string(reason))
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		reason Reason
+		want   metav1.ConditionStatus
+	}{
+		{reason: ReasonAccepted, want: metav1.ConditionTrue},
+		{reason: ReasonResolvedRefs, want: metav1.ConditionTrue},
+		{reason: ReasonProgrammed, want: metav1.ConditionTrue},
+		{reason: ReasonDrifted, want: metav1.ConditionTrue},
+		{reason: ReasonInSync, want: metav1.ConditionFalse},
+		{reason: ReasonInvalid, want: metav1.ConditionFalse},
+		{reason: ReasonAccessDenied, want: metav1.ConditionFalse},
+		{reason: ReasonTargetNotFound, want: metav1.ConditionFalse},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.reason), func(t *testing.T) {
+			cnd := New(TypeAccepted, 3, tt.reason, "msg")
+			assert.Equal(t, metav1.Condition{
+				Type:               TypeAccepted,
+				Status:             tt.want,
+				ObservedGeneration: 3,
+				Reason:             string(tt.reason),
+				Message:            "msg",
+			}, cnd)
+		})
+	}
+}
+
+func TestSetAccepted(t *testing.T) {
+	var cnds []metav1.Condition
+	SetAccepted(&cnds, 1, ReasonTargetNotFound, "targetRef not found")
+	assert.Len(t, cnds, 1)
+	assert.True(t, HasReason(cnds, TypeAccepted, ReasonTargetNotFound))
+	assert.Equal(t, metav1.ConditionFalse, cnds[0].Status)
+
+	SetAccepted(&cnds, 2, ReasonAccepted, "")
+	assert.Len(t, cnds, 1)
+	assert.True(t, HasReason(cnds, TypeAccepted, ReasonAccepted))
+	assert.False(t, HasReason(cnds, TypeDriftDetected, ReasonAccepted))
+	assert.Equal(t, metav1.ConditionTrue, cnds[0].Status)
+	assert.Equal(t, int64(2), cnds[0].ObservedGeneration)
+}
+
+func TestChanged(t *testing.T) {
+	cnds := []metav1.Condition{New(TypeDriftDetected, 1, ReasonInSync, "")}
+	assert.False(t, Changed(cnds, New(TypeDriftDetected, 1, ReasonInSync, "")))
+	assert.True(t, Changed(cnds, New(TypeDriftDetected, 2, ReasonInSync, "")))
+	assert.True(t, Changed(cnds, New(TypeDriftDetected, 1, ReasonDrifted, "policy differs")))
+	assert.True(t, Changed(cnds, New(TypeAccepted, 1, ReasonAccepted, "")))
+}
+
+// The packages setting conditions must take their reasons from this catalog, instead of string literals,
+// their own constants or the Gateway API ones.
+func TestReasonsAreFromCatalog(t *testing.T) {
+	dirs := []string{"../../controllers", "../policyhelper", "../../deploy/lattice"}
+	gatewayAPIPackages := map[string]bool{"gwv1": true, "gwv1beta1": true, "gwv1alpha2": true}
+
+	notFromCatalog := func(expr ast.Expr) bool {
+		if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+			expr = call.Args[0]
+		}
+		switch e := expr.(type) {
+		case *ast.BasicLit:
+			return e.Kind == token.STRING
+		case *ast.SelectorExpr:
+			pkg, ok := e.X.(*ast.Ident)
+			return ok && gatewayAPIPackages[pkg.Name]
+		}
+		return false
+	}
+
+	fset := token.NewFileSet()
+	for _, dir := range dirs {
+		pkgs, err := parser.ParseDir(fset, dir, func(fi fs.FileInfo) bool {
+			return !strings.HasSuffix(fi.Name(), "_test.go")
+		}, 0)
+		assert.NoError(t, err)
+		for _, pkg := range pkgs {
+			for _, file := range pkg.Files {
+				ast.Inspect(file, func(n ast.Node) bool {
+					var values []ast.Expr
+					switch n := n.(type) {
+					case *ast.KeyValueExpr:
+						if key, ok := n.Key.(*ast.Ident); ok && key.Name == "Reason" {
+							values = append(values, n.Value)
+						}
+					case *ast.AssignStmt:
+						for i, lhs := range n.Lhs {
+							if sel, ok := lhs.(*ast.SelectorExpr); ok && sel.Sel.Name == "Reason" && i < len(n.Rhs) {
+								values = append(values, n.Rhs[i])
+							}
+						}
+					case *ast.ValueSpec:
+						for _, name := range n.Names {
+							if strings.Contains(name.Name, "Reason") && !strings.Contains(name.Name, "EventReason") {
+								t.Errorf("%s: reason %s is not declared in the conditions catalog",
+									fset.Position(name.Pos()), name.Name)
+							}
+						}
+					}
+					for _, value := range values {
+						if notFromCatalog(value) {
+							t.Errorf("%s: reason %s is not from the conditions catalog",
+								fset.Position(value.Pos()), types.ExprString(value))
+						}
+					}
+					return true
+				})
+			}
+		}
+	}
+}
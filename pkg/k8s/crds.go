@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	GatewayAPIInstallHint = "install the Gateway API CRDs, e.g. with kubectl apply -f config/crds/bases/k8s-gateway-v1.0.0.yaml " +
+		"of the controller repository, then restart the controller"
+
+	crdWaitInitialBackoff = time.Second
+	crdWaitMaxBackoff     = 30 * time.Second
+)
+
+var (
+	GatewayClassKind = gwv1beta1.SchemeGroupVersion.WithKind("GatewayClass")
+	GatewayKind      = gwv1beta1.SchemeGroupVersion.WithKind("Gateway")
+	HTTPRouteKind    = gwv1beta1.SchemeGroupVersion.WithKind("HTTPRoute")
+	GRPCRouteKind    = gwv1alpha2.SchemeGroupVersion.WithKind("GRPCRoute")
+	TLSRouteKind     = gwv1alpha2.SchemeGroupVersion.WithKind("TLSRoute")
+
+	// RequiredGatewayAPIKinds are the Gateway API kinds of the standard channel, without which gateways cannot be
+	// reconciled. GRPCRoute and TLSRoute are only in the experimental channel, their controllers are optional.
+	RequiredGatewayAPIKinds = []schema.GroupVersionKind{GatewayClassKind, GatewayKind, HTTPRouteKind}
+)
+
+// MissingKinds returns the kinds the API server does not serve, e.g. because their CRD is not installed
+func MissingKinds(discoveryClient discovery.DiscoveryInterface, gvks []schema.GroupVersionKind) ([]schema.GroupVersionKind, error) {
+	var missing []schema.GroupVersionKind
+	for _, gvk := range gvks {
+		served, err := isKindServed(discoveryClient, gvk)
+		if err != nil {
+			return nil, err
+		}
+		if !served {
+			missing = append(missing, gvk)
+		}
+	}
+	return missing, nil
+}
+
+// WaitForKinds waits up to timeout for the API server to serve the kinds, checking again with an exponential
+// backoff, and returns the kinds still missing then. Discovery errors are retried until the timeout too.
+func WaitForKinds(ctx context.Context, log gwlog.Logger, discoveryClient discovery.DiscoveryInterface,
+	gvks []schema.GroupVersionKind, timeout time.Duration) ([]schema.GroupVersionKind, error) {
+	deadline := time.Now().Add(timeout)
+	backoff := crdWaitInitialBackoff
+	for {
+		missing, err := MissingKinds(discoveryClient, gvks)
+		if err == nil && len(missing) == 0 {
+			return nil, nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return missing, err
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+		if err != nil {
+			log.Warnf(ctx, "Failed to discover served kinds, retrying in %s: %s", backoff, err)
+		} else {
+			log.Infof(ctx, "Waiting for the %s CRDs, retrying in %s", KindNames(missing), backoff)
+		}
+		select {
+		case <-ctx.Done():
+			return missing, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > crdWaitMaxBackoff {
+			backoff = crdWaitMaxBackoff
+		}
+	}
+}
+
+// KindNames returns the comma-separated kinds, e.g. for logging
+func KindNames(gvks []schema.GroupVersionKind) string {
+	names := make([]string, len(gvks))
+	for i, gvk := range gvks {
+		names[i] = gvk.Kind
+	}
+	return strings.Join(names, ", ")
+}
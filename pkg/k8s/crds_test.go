@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func servedKinds(gvks ...schema.GroupVersionKind) []*metav1.APIResourceList {
+	var lists []*metav1.APIResourceList
+	for _, gvk := range gvks {
+		var list *metav1.APIResourceList
+		for _, l := range lists {
+			if l.GroupVersion == gvk.GroupVersion().String() {
+				list = l
+			}
+		}
+		if list == nil {
+			list = &metav1.APIResourceList{GroupVersion: gvk.GroupVersion().String()}
+			lists = append(lists, list)
+		}
+		list.APIResources = append(list.APIResources, metav1.APIResource{Kind: gvk.Kind})
+	}
+	return lists
+}
+
+func TestMissingKinds(t *testing.T) {
+	tests := []struct {
+		name   string
+		served []schema.GroupVersionKind
+		want   []schema.GroupVersionKind
+	}{
+		{
+			name:   "all CRDs installed",
+			served: []schema.GroupVersionKind{GatewayClassKind, GatewayKind, HTTPRouteKind, GRPCRouteKind, TLSRouteKind},
+		},
+		{
+			name:   "no Gateway API CRDs",
+			served: nil,
+			want:   []schema.GroupVersionKind{GatewayClassKind, GatewayKind, HTTPRouteKind, GRPCRouteKind, TLSRouteKind},
+		},
+		{
+			name:   "standard channel only",
+			served: []schema.GroupVersionKind{GatewayClassKind, GatewayKind, HTTPRouteKind},
+			want:   []schema.GroupVersionKind{GRPCRouteKind, TLSRouteKind},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discoveryClient := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: servedKinds(tt.served...)}}
+			missing, err := MissingKinds(discoveryClient,
+				[]schema.GroupVersionKind{GatewayClassKind, GatewayKind, HTTPRouteKind, GRPCRouteKind, TLSRouteKind})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, missing)
+		})
+	}
+}
+
+func TestWaitForKinds_ReturnsMissingKindsAfterTimeout(t *testing.T) {
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: servedKinds(GatewayClassKind)}}
+
+	start := time.Now()
+	missing, err := WaitForKinds(context.TODO(), gwlog.FallbackLogger, discoveryClient, RequiredGatewayAPIKinds, 100*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, []schema.GroupVersionKind{GatewayKind, HTTPRouteKind}, missing)
+	assert.Less(t, time.Since(start), crdWaitInitialBackoff)
+}
+
+func TestWaitForKinds_WaitsForCRDsToBeInstalled(t *testing.T) {
+	fake := &k8stesting.Fake{}
+	checks := 0
+	fake.AddReactor("get", "resource", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		checks++
+		// the CRDs are installed while the controller waits
+		if checks > len(RequiredGatewayAPIKinds) {
+			fake.Resources = servedKinds(RequiredGatewayAPIKinds...)
+		}
+		return false, nil, nil
+	})
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: fake}
+
+	missing, err := WaitForKinds(context.TODO(), gwlog.FallbackLogger, discoveryClient, RequiredGatewayAPIKinds, time.Minute)
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+}
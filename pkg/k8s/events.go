@@ -1,10 +1,17 @@
 package k8s
 
+import (
+	"time"
+
+	"k8s.io/client-go/tools/record"
+)
+
 const (
 	// Generic events
 	ReconcilingEvent     = "Reconciling"
 	ReconciledEvent      = "Reconciled"
 	FailedReconcileEvent = "FailedReconcile"
+	LeakedResourcesEvent = "LeakedResources"
 
 	// Gateway events
 	GatewayEventReasonFailedAddFinalizer = "FailedAddFinalizer"
@@ -34,3 +41,26 @@ const (
 	ServiceImportEventReasonFailedBuildModel   = "FailedBuildModel"
 	ServiceImportEventReasonFailedDeployModel  = "FailedDeployModel"
 )
+
+const (
+	// number of events of an object with the same type and reason from which their messages are combined
+	eventAggregateMaxEvents = 3
+	eventAggregateInterval  = 10 * time.Minute
+)
+
+// EventCorrelatorOptions configures the aggregation of the events recorded by the controllers. Repeats of
+// an identical event increment the count of the recorded event, and events of an object with the same type
+// and reason but different messages, e.g. reconcile failures including request ids, are combined into a
+// single counted event once there are more than a few of them within the interval.
+func EventCorrelatorOptions() record.CorrelatorOptions {
+	return record.CorrelatorOptions{
+		MaxEvents:            eventAggregateMaxEvents,
+		MaxIntervalInSeconds: int(eventAggregateInterval.Seconds()),
+	}
+}
+
+// NewEventBroadcaster returns the broadcaster of the events of all controllers, aggregating repeated
+// events with EventCorrelatorOptions.
+func NewEventBroadcaster() record.EventBroadcaster {
+	return record.NewBroadcasterWithCorrelatorOptions(EventCorrelatorOptions())
+}
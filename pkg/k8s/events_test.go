@@ -0,0 +1,75 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+)
+
+func TestEventCorrelatorOptions(t *testing.T) {
+	clock := testclock.NewFakeClock(time.Now())
+	options := EventCorrelatorOptions()
+	options.Clock = clock
+	correlator := record.NewEventCorrelatorWithOptions(options)
+
+	failedReconcile := func(route, message string) *corev1.Event {
+		now := metav1.NewTime(clock.Now())
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s.%x", route, now.UnixNano()),
+				Namespace: "ns",
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      "HTTPRoute",
+				Namespace: "ns",
+				Name:      route,
+			},
+			Source:         corev1.EventSource{Component: "httproute"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         FailedReconcileEvent,
+			Message:        message,
+			Count:          1,
+			FirstTimestamp: now,
+			LastTimestamp:  now,
+		}
+	}
+
+	t.Run("identical failures are counted on a single event", func(t *testing.T) {
+		var names []string
+		for i := 1; i <= 20; i++ {
+			result, err := correlator.EventCorrelate(failedReconcile("identical", "failed to deploy model"))
+			assert.NoError(t, err)
+			assert.False(t, result.Skip)
+			assert.EqualValues(t, i, result.Event.Count)
+			assert.Equal(t, i > 1, result.Patch != nil)
+			names = append(names, result.Event.Name)
+			clock.Step(time.Second)
+		}
+		// every repeat patches the event created by the first failure
+		for _, name := range names {
+			assert.Equal(t, names[0], name)
+		}
+	})
+
+	t.Run("failures with different messages are combined", func(t *testing.T) {
+		var result *record.EventCorrelateResult
+		var err error
+		for i := 1; i <= 10; i++ {
+			result, err = correlator.EventCorrelate(failedReconcile("varying", fmt.Sprintf("request %d throttled", i)))
+			assert.NoError(t, err)
+			assert.False(t, result.Skip)
+			if i < eventAggregateMaxEvents {
+				assert.Equal(t, fmt.Sprintf("request %d throttled", i), result.Event.Message)
+			}
+			clock.Step(time.Second)
+		}
+		assert.Equal(t, "(combined from similar events): request 10 throttled", result.Event.Message)
+		assert.EqualValues(t, 10-eventAggregateMaxEvents+1, result.Event.Count)
+	})
+}
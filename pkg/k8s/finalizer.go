@@ -16,14 +16,18 @@ type FinalizerManager interface {
 	RemoveFinalizers(ctx context.Context, object client.Object, finalizers ...string) error
 }
 
-func NewDefaultFinalizerManager(k8sClient client.Client) FinalizerManager {
+// NewDefaultFinalizerManager returns a finalizer manager which also removes the legacy finalizers, e.g. finalizers
+// of earlier controller versions no controller removes anymore, of deleted objects along with their finalizers.
+func NewDefaultFinalizerManager(k8sClient client.Client, legacyFinalizers ...string) FinalizerManager {
 	return &defaultFinalizerManager{
-		k8sClient: k8sClient,
+		k8sClient:        k8sClient,
+		legacyFinalizers: legacyFinalizers,
 	}
 }
 
 type defaultFinalizerManager struct {
-	k8sClient client.Client
+	k8sClient        client.Client
+	legacyFinalizers []string
 }
 
 func (m *defaultFinalizerManager) AddFinalizers(ctx context.Context, obj client.Object, finalizers ...string) error {
@@ -55,7 +59,12 @@ func (m *defaultFinalizerManager) RemoveFinalizers(ctx context.Context, obj clie
 
 		oldObj := obj.DeepCopyObject().(client.Object)
 		needsUpdate := false
-		for _, finalizer := range finalizers {
+		toRemove := finalizers
+		if obj.GetDeletionTimestamp() != nil {
+			// legacy finalizers would otherwise keep deleted objects forever, live objects keep them
+			toRemove = append(toRemove[:len(toRemove):len(toRemove)], m.legacyFinalizers...)
+		}
+		for _, finalizer := range toRemove {
 			if HasFinalizer(obj, finalizer) {
 				controllerutil.RemoveFinalizer(obj, finalizer)
 				needsUpdate = true
@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFinalizerManager_RemovesLegacyFinalizersOfDeletedObjects(t *testing.T) {
+	ctx := context.TODO()
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+
+	now := metav1.Now()
+	deleted := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:              "deleted",
+		Namespace:         "ns",
+		DeletionTimestamp: &now,
+		Finalizers:        []string{"service.k8s.aws/resources", "current.k8s.aws/resources", "other.example.com/cleanup"},
+	}}
+	live := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:       "live",
+		Namespace:  "ns",
+		Finalizers: []string{"service.k8s.aws/resources", "current.k8s.aws/resources"},
+	}}
+	lastFinalizers := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:              "last",
+		Namespace:         "ns",
+		DeletionTimestamp: &now,
+		Finalizers:        []string{"current.k8s.aws/resources", "service.k8s.aws/resources"},
+	}}
+	k8sClient := testclient.NewClientBuilder().WithScheme(scheme).WithObjects(deleted, live, lastFinalizers).Build()
+	m := NewDefaultFinalizerManager(k8sClient, "service.k8s.aws/resources", "serviceexport.k8s.aws/resources")
+
+	assert.NoError(t, m.RemoveFinalizers(ctx, deleted, "current.k8s.aws/resources"))
+	got := &corev1.Service{}
+	assert.NoError(t, k8sClient.Get(ctx, NamespacedName(deleted), got))
+	assert.Equal(t, []string{"other.example.com/cleanup"}, got.Finalizers, "legacy finalizers are removed, unknown ones kept")
+
+	// objects which are not deleted keep their legacy finalizers
+	assert.NoError(t, m.RemoveFinalizers(ctx, live, "current.k8s.aws/resources"))
+	assert.NoError(t, k8sClient.Get(ctx, NamespacedName(live), got))
+	assert.Equal(t, []string{"service.k8s.aws/resources"}, got.Finalizers)
+
+	// once no finalizer is left the object is gone
+	assert.NoError(t, m.RemoveFinalizers(ctx, lastFinalizers, "current.k8s.aws/resources"))
+	assert.True(t, apierrors.IsNotFound(k8sClient.Get(ctx, NamespacedName(lastFinalizers), got)))
+}
+
+func TestFinalizerManager_NoLegacyFinalizers(t *testing.T) {
+	ctx := context.TODO()
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+
+	now := metav1.Now()
+	deleted := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:              "deleted",
+		Namespace:         "ns",
+		DeletionTimestamp: &now,
+		Finalizers:        []string{"service.k8s.aws/resources", "current.k8s.aws/resources"},
+	}}
+	k8sClient := testclient.NewClientBuilder().WithScheme(scheme).WithObjects(deleted).Build()
+	m := NewDefaultFinalizerManager(k8sClient)
+
+	assert.NoError(t, m.RemoveFinalizers(ctx, deleted, "current.k8s.aws/resources"))
+	got := &corev1.Service{}
+	assert.NoError(t, k8sClient.Get(ctx, NamespacedName(deleted), got))
+	assert.Equal(t, []string{"service.k8s.aws/resources"}, got.Finalizers)
+}
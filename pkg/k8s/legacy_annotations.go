@@ -0,0 +1,46 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// LegacyAnnotation is an annotation which has been replaced by a spec field. It is still honored
+// during its deprecation window, as if the value was set on the spec field.
+type LegacyAnnotation struct {
+	Key string
+	// Path of the spec field replacing the annotation, e.g. spec.healthCheck.path
+	Field string
+	// Apply sets the spec field of the object from the annotation value. It returns false without making
+	// changes when the spec field is already set, the spec field takes precedence over the annotation.
+	Apply func(obj client.Object, value string) (bool, error)
+}
+
+// ApplyLegacyAnnotations applies the legacy annotations present on the object to its spec, and logs a
+// deprecation warning for each of them. Call it on the object read from the cache before building
+// the model, and never write the object back, the annotations are not migrated on the cluster.
+func ApplyLegacyAnnotations(ctx context.Context, log gwlog.Logger, obj client.Object, legacy []LegacyAnnotation) error {
+	annotations := obj.GetAnnotations()
+	for _, la := range legacy {
+		value, ok := annotations[la.Key]
+		if !ok {
+			continue
+		}
+		applied, err := la.Apply(obj, value)
+		if err != nil {
+			return fmt.Errorf("invalid value for deprecated annotation %s on %s: %w", la.Key, NamespacedName(obj), err)
+		}
+		if applied {
+			log.Warnf(ctx, "Annotation %s on %s is deprecated and will be removed in a future release, set %s instead",
+				la.Key, NamespacedName(obj), la.Field)
+		} else {
+			log.Warnf(ctx, "Ignoring deprecated annotation %s on %s, %s is set",
+				la.Key, NamespacedName(obj), la.Field)
+		}
+	}
+	return nil
+}
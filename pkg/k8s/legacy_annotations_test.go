@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+var testLegacyAnnotations = []LegacyAnnotation{
+	{
+		Key:   AnnotationPrefix + "external-name",
+		Field: "spec.externalName",
+		Apply: func(obj client.Object, value string) (bool, error) {
+			svc := obj.(*corev1.Service)
+			if value == "" {
+				return false, errors.New("must not be empty")
+			}
+			if svc.Spec.ExternalName != "" {
+				return false, nil
+			}
+			svc.Spec.ExternalName = value
+			return true, nil
+		},
+	},
+}
+
+func TestApplyLegacyAnnotations(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		externalName string
+		wantName     string
+		wantWarning  string
+		wantErr      string
+	}{
+		{
+			name:     "no legacy annotation",
+			wantName: "",
+		},
+		{
+			name:        "legacy annotation is applied",
+			annotations: map[string]string{AnnotationPrefix + "external-name": "example.com"},
+			wantName:    "example.com",
+			wantWarning: "Annotation application-networking.k8s.aws/external-name on ns/svc is deprecated and will be removed in a future release, set spec.externalName instead",
+		},
+		{
+			name:         "spec field takes precedence",
+			annotations:  map[string]string{AnnotationPrefix + "external-name": "example.com"},
+			externalName: "spec.example.com",
+			wantName:     "spec.example.com",
+			wantWarning:  "Ignoring deprecated annotation application-networking.k8s.aws/external-name on ns/svc, spec.externalName is set",
+		},
+		{
+			name:        "invalid legacy annotation",
+			annotations: map[string]string{AnnotationPrefix + "external-name": ""},
+			wantErr:     "invalid value for deprecated annotation application-networking.k8s.aws/external-name on ns/svc: must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.WarnLevel)
+			log := &gwlog.TracedLogger{InnerLogger: zap.New(core).Sugar()}
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc", Annotations: tt.annotations},
+				Spec:       corev1.ServiceSpec{ExternalName: tt.externalName},
+			}
+
+			err := ApplyLegacyAnnotations(context.TODO(), log, svc, testLegacyAnnotations)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantName, svc.Spec.ExternalName)
+
+			entries := logs.TakeAll()
+			if tt.wantWarning == "" {
+				assert.Empty(t, entries)
+			} else {
+				assert.Len(t, entries, 1)
+				assert.Equal(t, tt.wantWarning, entries[0].Message)
+			}
+		})
+	}
+}
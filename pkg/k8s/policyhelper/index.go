@@ -0,0 +1,145 @@
+package policyhelper
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+)
+
+// Identity of the resource a policy is attached to
+type TargetRefKey struct {
+	GroupKind
+	types.NamespacedName
+}
+
+func PolicyTargetRefKey(policy Policy) TargetRefKey {
+	tr := policy.GetTargetRef()
+	ns := policy.GetNamespace()
+	if tr.Namespace != nil {
+		ns = string(*tr.Namespace)
+	}
+	return TargetRefKey{
+		GroupKind:      TargetRefGroupKind(tr),
+		NamespacedName: types.NamespacedName{Namespace: ns, Name: string(tr.Name)},
+	}
+}
+
+// TargetRefIndex is an in-memory index of policies by their targetRef, safe for concurrent use.
+// It is kept up to date from policy watch events through EventHandler, so admission checks such as
+// duplicate target detection do not need to list every policy.
+type TargetRefIndex struct {
+	lock     sync.RWMutex
+	byTarget map[TargetRefKey]map[types.NamespacedName]struct{}
+	byPolicy map[types.NamespacedName]TargetRefKey
+}
+
+func NewTargetRefIndex() *TargetRefIndex {
+	return &TargetRefIndex{
+		byTarget: make(map[TargetRefKey]map[types.NamespacedName]struct{}),
+		byPolicy: make(map[types.NamespacedName]TargetRefKey),
+	}
+}
+
+// Upsert adds the policy to the index, moving it if its targetRef changed.
+func (i *TargetRefIndex) Upsert(policy Policy) {
+	nsname := k8s.NamespacedName(policy)
+	if policy.GetTargetRef() == nil {
+		i.Remove(nsname)
+		return
+	}
+	key := PolicyTargetRefKey(policy)
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if old, ok := i.byPolicy[nsname]; ok {
+		if old == key {
+			return
+		}
+		i.removeLocked(nsname, old)
+	}
+	policies, ok := i.byTarget[key]
+	if !ok {
+		policies = make(map[types.NamespacedName]struct{})
+		i.byTarget[key] = policies
+	}
+	policies[nsname] = struct{}{}
+	i.byPolicy[nsname] = key
+}
+
+func (i *TargetRefIndex) Remove(nsname types.NamespacedName) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if key, ok := i.byPolicy[nsname]; ok {
+		i.removeLocked(nsname, key)
+	}
+}
+
+func (i *TargetRefIndex) removeLocked(nsname types.NamespacedName, key TargetRefKey) {
+	delete(i.byPolicy, nsname)
+	policies := i.byTarget[key]
+	delete(policies, nsname)
+	if len(policies) == 0 {
+		delete(i.byTarget, key)
+	}
+}
+
+// Policies returns the policies attached to the target, sorted by namespace and name.
+func (i *TargetRefIndex) Policies(key TargetRefKey) []types.NamespacedName {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	policies := make([]types.NamespacedName, 0, len(i.byTarget[key]))
+	for nsname := range i.byTarget[key] {
+		policies = append(policies, nsname)
+	}
+	sort.Slice(policies, func(a, b int) bool {
+		return policies[a].String() < policies[b].String()
+	})
+	return policies
+}
+
+// Conflicts returns the other policies attached to the same target as the given policy.
+func (i *TargetRefIndex) Conflicts(policy Policy) []types.NamespacedName {
+	if policy.GetTargetRef() == nil {
+		return nil
+	}
+	nsname := k8s.NamespacedName(policy)
+	var conflicts []types.NamespacedName
+	for _, other := range i.Policies(PolicyTargetRefKey(policy)) {
+		if other != nsname {
+			conflicts = append(conflicts, other)
+		}
+	}
+	return conflicts
+}
+
+// EventHandler returns a handler for policy watches that keeps the index up to date.
+// It does not enqueue any requests.
+func (i *TargetRefIndex) EventHandler() handler.EventHandler {
+	upsert := func(obj k8sclient.Object) {
+		if policy, ok := obj.(Policy); ok {
+			i.Upsert(policy)
+		}
+	}
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+			upsert(e.Object)
+		},
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+			upsert(e.ObjectNew)
+		},
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+			i.Remove(k8s.NamespacedName(e.Object))
+		},
+		GenericFunc: func(_ context.Context, e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+			upsert(e.Object)
+		},
+	}
+}
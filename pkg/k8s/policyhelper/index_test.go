@@ -0,0 +1,154 @@
+package policyhelper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+)
+
+func indexTestPolicy(name, targetName string) *anv1alpha1.IAMAuthPolicy {
+	return &anv1alpha1.IAMAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+		Spec: anv1alpha1.IAMAuthPolicySpec{
+			TargetRef: &gwv1alpha2.PolicyTargetReference{
+				Group: gwv1beta1.GroupName,
+				Kind:  "Gateway",
+				Name:  gwv1alpha2.ObjectName(targetName),
+			},
+		},
+	}
+}
+
+func gatewayKey(namespace, name string) TargetRefKey {
+	return TargetRefKey{
+		GroupKind:      GroupKind{gwv1beta1.GroupName, "Gateway"},
+		NamespacedName: types.NamespacedName{Namespace: namespace, Name: name},
+	}
+}
+
+func TestTargetRefIndex(t *testing.T) {
+	p1 := types.NamespacedName{Namespace: "ns", Name: "p1"}
+	p2 := types.NamespacedName{Namespace: "ns", Name: "p2"}
+
+	t.Run("add", func(t *testing.T) {
+		index := NewTargetRefIndex()
+		index.Upsert(indexTestPolicy("p2", "gw"))
+		index.Upsert(indexTestPolicy("p1", "gw"))
+
+		assert.Equal(t, []types.NamespacedName{p1, p2}, index.Policies(gatewayKey("ns", "gw")))
+		assert.Empty(t, index.Policies(gatewayKey("other-ns", "gw")))
+		assert.Equal(t, []types.NamespacedName{p2}, index.Conflicts(indexTestPolicy("p1", "gw")))
+	})
+
+	t.Run("add is idempotent", func(t *testing.T) {
+		index := NewTargetRefIndex()
+		index.Upsert(indexTestPolicy("p1", "gw"))
+		index.Upsert(indexTestPolicy("p1", "gw"))
+
+		assert.Equal(t, []types.NamespacedName{p1}, index.Policies(gatewayKey("ns", "gw")))
+		assert.Empty(t, index.Conflicts(indexTestPolicy("p1", "gw")))
+	})
+
+	t.Run("targetRef namespace", func(t *testing.T) {
+		index := NewTargetRefIndex()
+		policy := indexTestPolicy("p1", "gw")
+		ns := gwv1alpha2.Namespace("other-ns")
+		policy.Spec.TargetRef.Namespace = &ns
+		index.Upsert(policy)
+
+		assert.Empty(t, index.Policies(gatewayKey("ns", "gw")))
+		assert.Equal(t, []types.NamespacedName{p1}, index.Policies(gatewayKey("other-ns", "gw")))
+	})
+
+	t.Run("update moves policy to new target", func(t *testing.T) {
+		index := NewTargetRefIndex()
+		index.Upsert(indexTestPolicy("p1", "gw"))
+		index.Upsert(indexTestPolicy("p2", "gw"))
+		index.Upsert(indexTestPolicy("p1", "gw-2"))
+
+		assert.Equal(t, []types.NamespacedName{p2}, index.Policies(gatewayKey("ns", "gw")))
+		assert.Equal(t, []types.NamespacedName{p1}, index.Policies(gatewayKey("ns", "gw-2")))
+		assert.Empty(t, index.Conflicts(indexTestPolicy("p2", "gw")))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		index := NewTargetRefIndex()
+		index.Upsert(indexTestPolicy("p1", "gw"))
+		index.Upsert(indexTestPolicy("p2", "gw"))
+		index.Remove(p1)
+		index.Remove(types.NamespacedName{Namespace: "ns", Name: "unknown"})
+
+		assert.Equal(t, []types.NamespacedName{p2}, index.Policies(gatewayKey("ns", "gw")))
+		index.Remove(p2)
+		assert.Empty(t, index.Policies(gatewayKey("ns", "gw")))
+		assert.Empty(t, index.byTarget)
+		assert.Empty(t, index.byPolicy)
+	})
+
+	t.Run("nil targetRef removes policy", func(t *testing.T) {
+		index := NewTargetRefIndex()
+		index.Upsert(indexTestPolicy("p1", "gw"))
+		policy := indexTestPolicy("p1", "gw")
+		policy.Spec.TargetRef = nil
+		index.Upsert(policy)
+
+		assert.Empty(t, index.Policies(gatewayKey("ns", "gw")))
+		assert.Nil(t, index.Conflicts(policy))
+	})
+
+	t.Run("event handler", func(t *testing.T) {
+		ctx := context.TODO()
+		index := NewTargetRefIndex()
+		h := index.EventHandler()
+
+		h.Create(ctx, event.CreateEvent{Object: indexTestPolicy("p1", "gw")}, nil)
+		assert.Equal(t, []types.NamespacedName{p1}, index.Policies(gatewayKey("ns", "gw")))
+
+		h.Update(ctx, event.UpdateEvent{
+			ObjectOld: indexTestPolicy("p1", "gw"),
+			ObjectNew: indexTestPolicy("p1", "gw-2"),
+		}, nil)
+		assert.Empty(t, index.Policies(gatewayKey("ns", "gw")))
+		assert.Equal(t, []types.NamespacedName{p1}, index.Policies(gatewayKey("ns", "gw-2")))
+
+		h.Delete(ctx, event.DeleteEvent{Object: indexTestPolicy("p1", "gw-2")}, nil)
+		assert.Empty(t, index.Policies(gatewayKey("ns", "gw-2")))
+
+		h.Generic(ctx, event.GenericEvent{Object: &gwv1beta1.Gateway{}}, nil)
+		assert.Empty(t, index.byPolicy)
+	})
+
+	t.Run("concurrent access", func(t *testing.T) {
+		index := NewTargetRefIndex()
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				name := fmt.Sprintf("p%d", i)
+				for j := 0; j < 100; j++ {
+					index.Upsert(indexTestPolicy(name, fmt.Sprintf("gw-%d", j%3)))
+					index.Policies(gatewayKey("ns", "gw-0"))
+					if j%2 == 0 {
+						index.Remove(types.NamespacedName{Namespace: "ns", Name: name})
+					}
+				}
+				index.Upsert(indexTestPolicy(name, "gw"))
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Len(t, index.Policies(gatewayKey("ns", "gw")), 10)
+		assert.Len(t, index.byTarget, 1)
+	})
+}
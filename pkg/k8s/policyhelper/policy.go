@@ -20,44 +20,36 @@ import (
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	"github.com/aws/aws-application-networking-k8s/pkg/metrics"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
 var (
 	ErrGroupKind         = errors.New("group/kind error")
+	ErrUnsupportedKind   = errors.New("unsupported targetRef kind")
 	ErrTargetRefNotFound = errors.New("targetRef not found")
 	ErrTargetRefConflict = errors.New("targetRef has conflict")
+	ErrNotOurClass       = errors.New("targetRef gateway is not of a VPC Lattice gateway class")
 )
 
 type (
-	TargetRef       = gwv1alpha2.PolicyTargetReference
-	ConditionType   = gwv1alpha2.PolicyConditionType
-	ConditionReason = gwv1alpha2.PolicyConditionReason
-)
-
-const (
-	// GEP
-
-	ConditionTypeAccepted = gwv1alpha2.PolicyConditionAccepted
-
-	ReasonAccepted       = gwv1alpha2.PolicyReasonAccepted
-	ReasonInvalid        = gwv1alpha2.PolicyReasonInvalid
-	ReasonTargetNotFound = gwv1alpha2.PolicyReasonTargetNotFound
-	ReasonConflicted     = gwv1alpha2.PolicyReasonConflicted
-
-	// Non-GEP
-
-	ReasonUnknown = ConditionReason("Unknown")
+	TargetRef = gwv1alpha2.PolicyTargetReference
 )
 
 type (
-	TGP  = anv1alpha1.TargetGroupPolicy
-	TGPL = anv1alpha1.TargetGroupPolicyList
-	IAP  = anv1alpha1.IAMAuthPolicy
-	IAPL = anv1alpha1.IAMAuthPolicyList
-	VAP  = anv1alpha1.VpcAssociationPolicy
-	VAPL = anv1alpha1.VpcAssociationPolicyList
+	TGP   = anv1alpha1.TargetGroupPolicy
+	TGPL  = anv1alpha1.TargetGroupPolicyList
+	IAP   = anv1alpha1.IAMAuthPolicy
+	IAPL  = anv1alpha1.IAMAuthPolicyList
+	VAP   = anv1alpha1.VpcAssociationPolicy
+	VAPL  = anv1alpha1.VpcAssociationPolicyList
+	SNLP  = anv1alpha1.ServiceNetworkLogPolicy
+	SNLPL = anv1alpha1.ServiceNetworkLogPolicyList
+	SNRP  = anv1alpha1.ServiceNetworkResourcePolicy
+	SNRPL = anv1alpha1.ServiceNetworkResourcePolicyList
 )
 
 func NewVpcAssociationPolicyHandler(log gwlog.Logger, c k8sclient.Client) *PolicyHandler[*VAP] {
@@ -69,6 +61,26 @@ func NewVpcAssociationPolicyHandler(log gwlog.Logger, c k8sclient.Client) *Polic
 	return NewPolicyHandler[VAP, VAPL](phcfg)
 }
 
+func NewServiceNetworkLogPolicyHandler(log gwlog.Logger, c k8sclient.Client) *PolicyHandler[*SNLP] {
+	phcfg := PolicyHandlerConfig{
+		Log:               log,
+		Client:            c,
+		TargetRefKinds:    NewGroupKindSet(&gwv1beta1.Gateway{}),
+		CheckGatewayClass: true,
+	}
+	return NewPolicyHandler[SNLP, SNLPL](phcfg)
+}
+
+func NewServiceNetworkResourcePolicyHandler(log gwlog.Logger, c k8sclient.Client) *PolicyHandler[*SNRP] {
+	phcfg := PolicyHandlerConfig{
+		Log:               log,
+		Client:            c,
+		TargetRefKinds:    NewGroupKindSet(&gwv1beta1.Gateway{}),
+		CheckGatewayClass: true,
+	}
+	return NewPolicyHandler[SNRP, SNRPL](phcfg)
+}
+
 func NewTargetGroupPolicyHandler(log gwlog.Logger, c k8sclient.Client) *PolicyHandler[*TGP] {
 	phcfg := PolicyHandlerConfig{
 		Log:            log,
@@ -80,9 +92,10 @@ func NewTargetGroupPolicyHandler(log gwlog.Logger, c k8sclient.Client) *PolicyHa
 
 func NewIAMAuthPolicyHandler(log gwlog.Logger, c k8sclient.Client) *PolicyHandler[*IAP] {
 	phcfg := PolicyHandlerConfig{
-		Log:            log,
-		Client:         c,
-		TargetRefKinds: NewGroupKindSet(&gwv1beta1.Gateway{}, &gwv1beta1.HTTPRoute{}, &gwv1alpha2.GRPCRoute{}),
+		Log:               log,
+		Client:            c,
+		TargetRefKinds:    NewGroupKindSet(&gwv1beta1.Gateway{}, &gwv1beta1.HTTPRoute{}, &gwv1alpha2.GRPCRoute{}),
+		CheckGatewayClass: true,
 	}
 	return NewPolicyHandler[IAP, IAPL](phcfg)
 }
@@ -92,6 +105,19 @@ type Policy interface {
 	k8sclient.Object
 	GetTargetRef() *TargetRef
 	GetStatusConditions() *[]metav1.Condition
+	SetObservedGeneration(generation int64)
+}
+
+// MergeablePolicy is implemented by policies that can be merged with the other policies of
+// their target instead of conflicting with them, when merging is enabled on both.
+type MergeablePolicy interface {
+	Policy
+	MergeEnabled() bool
+}
+
+func mergeEnabled(policy Policy) bool {
+	mp, ok := policy.(MergeablePolicy)
+	return ok && mp.MergeEnabled()
 }
 
 type PolicyList[P Policy] interface {
@@ -111,15 +137,18 @@ func NewGroupKindSet(objs ...k8sclient.Object) *GroupKindSet {
 
 // A generic handler for common operations on particular policy type
 type PolicyHandler[P Policy] struct {
-	log    gwlog.Logger
-	kinds  *GroupKindSet
-	client PolicyClient[P]
+	log               gwlog.Logger
+	kinds             *GroupKindSet
+	client            PolicyClient[P]
+	checkGatewayClass bool
 }
 
 type PolicyHandlerConfig struct {
 	Log            gwlog.Logger
 	Client         k8sclient.Client
 	TargetRefKinds *GroupKindSet
+	// Reject policies targeting a Gateway which is not of a VPC Lattice GatewayClass
+	CheckGatewayClass bool
 }
 
 // Creates policy handler for specific policy. T and TL are type and list-type for Policy (struct type, not reference).
@@ -128,9 +157,10 @@ type PolicyHandlerConfig struct {
 //	ph := NewPolicyHandler[IAMAuthPolicy, IAMAuthPolicyList](cfg)
 func NewPolicyHandler[T, TL any, P policyPtr[T], PL policyListPtr[TL, P]](cfg PolicyHandlerConfig) *PolicyHandler[P] {
 	ph := &PolicyHandler[P]{
-		log:    cfg.Log,
-		client: newK8sPolicyClient[T, TL, P, PL](cfg.Client),
-		kinds:  cfg.TargetRefKinds,
+		log:               cfg.Log,
+		client:            newK8sPolicyClient[T, TL, P, PL](cfg.Client),
+		kinds:             cfg.TargetRefKinds,
+		checkGatewayClass: cfg.CheckGatewayClass,
 	}
 	return ph
 }
@@ -140,6 +170,7 @@ type PolicyClient[P Policy] interface {
 	List(ctx context.Context, namespace string) ([]P, error)
 	Get(ctx context.Context, nsname types.NamespacedName) (P, error)
 	TargetRefObj(ctx context.Context, policy P) (k8sclient.Object, error)
+	GatewayClass(ctx context.Context, name string) (*gwv1beta1.GatewayClass, error)
 	UpdateStatus(ctx context.Context, policy P) error
 }
 
@@ -205,6 +236,12 @@ func (pc *k8sPolicyClient[T, U, P, PL]) TargetRefObj(ctx context.Context, p P) (
 	return obj, nil
 }
 
+func (pc *k8sPolicyClient[T, U, P, PL]) GatewayClass(ctx context.Context, name string) (*gwv1beta1.GatewayClass, error) {
+	gwClass := &gwv1beta1.GatewayClass{}
+	err := pc.client.Get(ctx, types.NamespacedName{Name: name}, gwClass)
+	return gwClass, err
+}
+
 func (pc *k8sPolicyClient[T, U, P, PL]) UpdateStatus(ctx context.Context, policy P) error {
 	return pc.client.Status().Update(ctx, policy)
 }
@@ -240,18 +277,41 @@ func (h *PolicyHandler[P]) ObjResolvedPolicy(ctx context.Context, obj k8sclient.
 		return empty, nil
 	}
 	policy := objPolicies[0]
-	cnd := meta.FindStatusCondition(*policy.GetStatusConditions(), string(ConditionTypeAccepted))
-	if cnd != nil && cnd.Reason != string(ReasonAccepted) {
+	cnd := meta.FindStatusCondition(*policy.GetStatusConditions(), conditions.TypeAccepted)
+	if cnd != nil && cnd.Reason != string(conditions.ReasonAccepted) {
 		return empty, nil
 	}
 	return objPolicies[0], nil
 }
 
-// Add Watchers for configured Kinds to controller builder
-func (h *PolicyHandler[P]) AddWatchers(b *builder.Builder, objs ...k8sclient.Object) {
+// Returns the policies merged with the given policy, in conflict resolution order: the policies of
+// the same target with merging enabled, including the given one. Policies being deleted are left out.
+func (h *PolicyHandler[P]) MergedPolicies(ctx context.Context, policy P) ([]P, error) {
+	if !mergeEnabled(policy) {
+		return []P{policy}, nil
+	}
+	targetRefObj, err := h.client.TargetRefObj(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	objPolicies, err := h.ObjPolicies(ctx, targetRefObj)
+	if err != nil {
+		return nil, err
+	}
+	out := []P{}
+	for _, objPolicy := range objPolicies {
+		if mergeEnabled(objPolicy) && objPolicy.GetDeletionTimestamp().IsZero() {
+			out = append(out, objPolicy)
+		}
+	}
+	return out, nil
+}
+
+// Add Watchers for configured Kinds to controller builder, requests they enqueue are recorded by the tracker
+func (h *PolicyHandler[P]) AddWatchers(b *builder.Builder, tracker *metrics.QueueTracker, objs ...k8sclient.Object) {
 	h.log.Debugf(context.TODO(), "add watchers for types: %v", NewGroupKindSet(objs...).Items())
 	for _, watchObj := range objs {
-		b.Watches(watchObj, handler.EnqueueRequestsFromMapFunc(h.watchMapFn))
+		b.Watches(watchObj, tracker.EventHandler(handler.EnqueueRequestsFromMapFunc(h.watchMapFn)))
 	}
 }
 
@@ -286,7 +346,7 @@ func (h *PolicyHandler[P]) targetRefMatch(obj k8sclient.Object, tr *gwv1alpha2.P
 }
 
 // Validate Policy and update Accepted status condition.
-func (h *PolicyHandler[P]) ValidateAndUpdateCondition(ctx context.Context, policy P) (ConditionReason, error) {
+func (h *PolicyHandler[P]) ValidateAndUpdateCondition(ctx context.Context, policy P) (conditions.Reason, error) {
 	validationErr := h.ValidateTargetRef(ctx, policy)
 	reason := errToReason(validationErr)
 	msg := ""
@@ -295,7 +355,7 @@ func (h *PolicyHandler[P]) ValidateAndUpdateCondition(ctx context.Context, polic
 	}
 	err := h.UpdateAcceptedCondition(ctx, policy, reason, msg)
 	if err != nil {
-		return ReasonUnknown, err
+		return conditions.ReasonUnknown, err
 	}
 	return reason, nil
 }
@@ -306,8 +366,13 @@ func (h *PolicyHandler[P]) ValidateTargetRef(ctx context.Context, policy P) erro
 	// invalid
 	trGk := TargetRefGroupKind(tr)
 	if !h.kinds.Contains(trGk) {
-		return fmt.Errorf("%w: not supported GroupKind=%s/%s",
-			ErrGroupKind, tr.Group, tr.Kind)
+		group, ok := h.kindGroup(trGk.Kind)
+		if !ok {
+			return fmt.Errorf("%w: not supported Kind=%s",
+				ErrUnsupportedKind, tr.Kind)
+		}
+		return fmt.Errorf("%w: not supported GroupKind=%s/%s, Kind=%s must be in Group=%s",
+			ErrGroupKind, tr.Group, tr.Kind, tr.Kind, group)
 	}
 
 	// not found
@@ -320,6 +385,13 @@ func (h *PolicyHandler[P]) ValidateTargetRef(ctx context.Context, policy P) erro
 		return err
 	}
 
+	// not our class
+	if gw, ok := targetRefObj.(*gwv1beta1.Gateway); ok && h.checkGatewayClass {
+		if err := h.validateGatewayClass(ctx, gw); err != nil {
+			return err
+		}
+	}
+
 	// conflicted
 	objPolicies, err := h.ObjPolicies(ctx, targetRefObj)
 	if err != nil {
@@ -328,8 +400,17 @@ func (h *PolicyHandler[P]) ValidateTargetRef(ctx context.Context, policy P) erro
 	if len(objPolicies) > 0 {
 		resolvedPolicy := objPolicies[0]
 		if resolvedPolicy.GetName() != policy.GetName() {
-			return fmt.Errorf("%w, policy=%s",
-				ErrTargetRefConflict, resolvedPolicy.GetName())
+			resolvedMerge, policyMerge := mergeEnabled(resolvedPolicy), mergeEnabled(policy)
+			switch {
+			case resolvedMerge && policyMerge:
+				// merged with the resolved policy
+			case resolvedMerge != policyMerge:
+				return fmt.Errorf("%w, policy=%s, merge mode differs from the resolved policy",
+					ErrTargetRefConflict, resolvedPolicy.GetName())
+			default:
+				return fmt.Errorf("%w, policy=%s",
+					ErrTargetRefConflict, resolvedPolicy.GetName())
+			}
 		}
 	}
 
@@ -337,34 +418,63 @@ func (h *PolicyHandler[P]) ValidateTargetRef(ctx context.Context, policy P) erro
 	return nil
 }
 
-func errToReason(err error) ConditionReason {
+func (h *PolicyHandler[P]) validateGatewayClass(ctx context.Context, gw *gwv1beta1.Gateway) error {
+	gwClass, err := h.client.GatewayClass(ctx, string(gw.Spec.GatewayClassName))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w, gateway=%s/%s, gatewayClass %s not found",
+				ErrNotOurClass, gw.Namespace, gw.Name, gw.Spec.GatewayClassName)
+		}
+		return err
+	}
+	if gwClass.Spec.ControllerName != config.LatticeGatewayControllerName {
+		return fmt.Errorf("%w, gateway=%s/%s, controllerName=%s",
+			ErrNotOurClass, gw.Namespace, gw.Name, gwClass.Spec.ControllerName)
+	}
+	return nil
+}
+
+// returns the group of a supported kind
+func (h *PolicyHandler[P]) kindGroup(kind string) (string, bool) {
+	for _, gk := range h.kinds.Items() {
+		if gk.Kind == kind {
+			return gk.Group, true
+		}
+	}
+	return "", false
+}
+
+// ResultForReason returns the reconcile result for a validated policy. Policies with an unsupported
+// targetRef kind are retried after config.UnsupportedKindRequeue, others are reconciled again on change only.
+func ResultForReason(reason conditions.Reason) reconcile.Result {
+	if reason == conditions.ReasonUnsupportedKind {
+		return reconcile.Result{RequeueAfter: config.UnsupportedKindRequeue}
+	}
+	return reconcile.Result{}
+}
+
+func errToReason(err error) conditions.Reason {
 	switch {
 	case err == nil:
-		return ReasonAccepted
+		return conditions.ReasonAccepted
 	case errors.Is(err, ErrGroupKind):
-		return ReasonInvalid
+		return conditions.ReasonInvalid
+	case errors.Is(err, ErrUnsupportedKind):
+		return conditions.ReasonUnsupportedKind
 	case errors.Is(err, ErrTargetRefNotFound):
-		return ReasonTargetNotFound
+		return conditions.ReasonTargetNotFound
 	case errors.Is(err, ErrTargetRefConflict):
-		return ReasonConflicted
+		return conditions.ReasonConflicted
+	case errors.Is(err, ErrNotOurClass):
+		return conditions.ReasonNotOurClass
 	default:
-		return ReasonUnknown
+		return conditions.ReasonUnknown
 	}
 }
 
-func (h *PolicyHandler[P]) UpdateAcceptedCondition(ctx context.Context, policy P, reason ConditionReason, msg string) error {
-	status := metav1.ConditionTrue
-	if reason != ReasonAccepted {
-		status = metav1.ConditionFalse
-	}
-	cnd := metav1.Condition{
-		Type:               string(ConditionTypeAccepted),
-		Status:             status,
-		ObservedGeneration: policy.GetGeneration(),
-		Reason:             string(reason),
-		Message:            msg,
-	}
-	meta.SetStatusCondition(policy.GetStatusConditions(), cnd)
+func (h *PolicyHandler[P]) UpdateAcceptedCondition(ctx context.Context, policy P, reason conditions.Reason, msg string) error {
+	conditions.SetAccepted(policy.GetStatusConditions(), policy.GetGeneration(), reason, msg)
+	policy.SetObservedGeneration(policy.GetGeneration())
 	err := h.client.UpdateStatus(ctx, policy)
 	return err
 }
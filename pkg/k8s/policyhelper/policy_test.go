@@ -1,14 +1,24 @@
 package policyhelper
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
 func TestPolicyClient(t *testing.T) {
@@ -37,3 +47,127 @@ func TestGroupKindSet(t *testing.T) {
 	assert.True(t, gks.Contains(GroupKind{gwv1beta1.GroupName, "HTTPRoute"}))
 	assert.True(t, gks.Contains(GroupKind{gwv1alpha2.GroupName, "GRPCRoute"}))
 }
+
+func TestValidateTargetRefUnsupportedKind(t *testing.T) {
+	type iap = anv1alpha1.IAMAuthPolicy
+	type iapl = anv1alpha1.IAMAuthPolicyList
+	ctx := context.TODO()
+
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	anv1alpha1.AddToScheme(scheme)
+	gwv1beta1.AddToScheme(scheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&anv1alpha1.IAMAuthPolicy{}).Build()
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, gw))
+
+	policy := &anv1alpha1.IAMAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "ns"},
+		Spec: anv1alpha1.IAMAuthPolicySpec{
+			TargetRef: &gwv1alpha2.PolicyTargetReference{
+				Group: gwv1beta1.GroupName,
+				Kind:  "Service",
+				Name:  "gw",
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, policy))
+
+	ph := NewPolicyHandler[iap, iapl](PolicyHandlerConfig{
+		Log:            gwlog.FallbackLogger,
+		Client:         k8sClient,
+		TargetRefKinds: NewGroupKindSet(&gwv1beta1.Gateway{}, &gwv1beta1.HTTPRoute{}),
+	})
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		reason, err := ph.ValidateAndUpdateCondition(ctx, policy)
+		assert.Nil(t, err)
+		assert.Equal(t, conditions.ReasonUnsupportedKind, reason)
+
+		cnd := meta.FindStatusCondition(policy.Status.Conditions, conditions.TypeAccepted)
+		assert.NotNil(t, cnd)
+		assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+		assert.Equal(t, string(conditions.ReasonUnsupportedKind), cnd.Reason)
+	})
+
+	t.Run("supported kind in wrong group", func(t *testing.T) {
+		p := policy.DeepCopy()
+		p.Spec.TargetRef.Group = "example.com"
+		p.Spec.TargetRef.Kind = "Gateway"
+		err := ph.ValidateTargetRef(ctx, p)
+		assert.ErrorIs(t, err, ErrGroupKind)
+		assert.ErrorContains(t, err, "Kind=Gateway must be in Group="+gwv1beta1.GroupName)
+		assert.Equal(t, conditions.ReasonInvalid, errToReason(err))
+	})
+
+	t.Run("accepted after targetRef is fixed", func(t *testing.T) {
+		policy.Spec.TargetRef.Kind = "Gateway"
+		reason, err := ph.ValidateAndUpdateCondition(ctx, policy)
+		assert.Nil(t, err)
+		assert.Equal(t, conditions.ReasonAccepted, reason)
+	})
+}
+
+func TestUpdateConditionObservedGeneration(t *testing.T) {
+	type iap = anv1alpha1.IAMAuthPolicy
+	type iapl = anv1alpha1.IAMAuthPolicyList
+	ctx := context.TODO()
+
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	anv1alpha1.AddToScheme(scheme)
+	gwv1beta1.AddToScheme(scheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&anv1alpha1.IAMAuthPolicy{}).Build()
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, gw))
+	policy := &anv1alpha1.IAMAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "ns"},
+		Spec: anv1alpha1.IAMAuthPolicySpec{
+			TargetRef: &gwv1alpha2.PolicyTargetReference{
+				Group: gwv1beta1.GroupName,
+				Kind:  "Gateway",
+				Name:  "missing",
+			},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(ctx, policy))
+
+	ph := NewPolicyHandler[iap, iapl](PolicyHandlerConfig{
+		Log:            gwlog.FallbackLogger,
+		Client:         k8sClient,
+		TargetRefKinds: NewGroupKindSet(&gwv1beta1.Gateway{}),
+	})
+
+	// the target of the second generation exists, the status follows every reconcile
+	for i, targetName := range []string{"missing", "gw"} {
+		generation := int64(i + 1)
+		policy.Generation = generation
+		policy.Spec.TargetRef.Name = gwv1alpha2.ObjectName(targetName)
+		_, err := ph.ValidateAndUpdateCondition(ctx, policy)
+		assert.Nil(t, err)
+
+		latest := &anv1alpha1.IAMAuthPolicy{}
+		assert.Nil(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), latest))
+		assert.Equal(t, generation, latest.Status.ObservedGeneration)
+		cnd := meta.FindStatusCondition(latest.Status.Conditions, conditions.TypeAccepted)
+		assert.NotNil(t, cnd)
+		assert.Equal(t, generation, cnd.ObservedGeneration)
+	}
+}
+
+func TestResultForReason(t *testing.T) {
+	config.UnsupportedKindRequeue = 30 * time.Second
+	defer func() { config.UnsupportedKindRequeue = time.Minute }()
+
+	assert.Equal(t, 30*time.Second, ResultForReason(conditions.ReasonUnsupportedKind).RequeueAfter)
+	assert.Zero(t, ResultForReason(conditions.ReasonInvalid).RequeueAfter)
+	assert.Zero(t, ResultForReason(conditions.ReasonAccepted).RequeueAfter)
+}
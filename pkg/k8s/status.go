@@ -0,0 +1,313 @@
+package k8s
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	// how long the last status write of an object is remembered
+	statusWriteRetention = time.Hour
+)
+
+// groups of the objects whose status is only written by the controllers, other statuses, e.g. of pods, are
+// always written through
+var coalescedStatusGroups = map[string]bool{
+	gwv1beta1.GroupName:              true,
+	"application-networking.k8s.aws": true,
+}
+
+// NewStatusCoalescingClientFunc returns a client.NewClientFunc for the manager, whose client coalesces the status
+// writes of Gateway API and controller objects, see StatusCoalescingClient.
+func NewStatusCoalescingClientFunc(log gwlog.Logger, window time.Duration) client.NewClientFunc {
+	return func(config *rest.Config, options client.Options) (client.Client, error) {
+		c, err := client.New(config, options)
+		if err != nil {
+			return nil, err
+		}
+		return NewStatusCoalescingClient(log, c, window), nil
+	}
+}
+
+// StatusCoalescingClient persists only the meaningful status transitions of an object, and at most one status write
+// per object every window:
+//   - a status equal to the current one of the object, ignoring condition transition times, is not written
+//   - a status changed within the window after the last write is held back, and replaced by later changes, until the
+//     window elapses. Only the final status is then written, intermediate flaps are never persisted. A status that
+//     flaps back to the current one within the window is not written at all
+//   - a held back status is dropped when another writer changed the status in the meantime, e.g. another controller
+//     sharing the parents of a route status, as writing it would revert their change. The next reconcile writes the
+//     status right away then
+//
+// Held back writes return no error, failures of the deferred write are logged and left to the next reconcile.
+type StatusCoalescingClient struct {
+	client.Client
+	log    gwlog.Logger
+	window time.Duration
+	clock  clock.WithDelayedExecution
+
+	lock      sync.Mutex
+	writes    map[statusWriteKey]*statusWrite
+	lastPrune time.Time
+}
+
+type statusWriteKey struct {
+	gvk  string
+	name types.NamespacedName
+	uid  types.UID
+}
+
+type statusWrite struct {
+	// time and status hash of the last write
+	at   time.Time
+	hash string
+	// latest status held back until the window elapses, and the hash of the current status it was computed from
+	pending     client.Object
+	pendingBase string
+	timer       clock.Timer
+}
+
+func NewStatusCoalescingClient(log gwlog.Logger, c client.Client, window time.Duration) *StatusCoalescingClient {
+	return &StatusCoalescingClient{
+		Client: c,
+		log:    log,
+		window: window,
+		clock:  clock.RealClock{},
+		writes: make(map[statusWriteKey]*statusWrite),
+	}
+}
+
+func (c *StatusCoalescingClient) Status() client.SubResourceWriter {
+	return &coalescingStatusWriter{SubResourceWriter: c.Client.Status(), c: c}
+}
+
+type coalescingStatusWriter struct {
+	client.SubResourceWriter
+	c *StatusCoalescingClient
+}
+
+func (w *coalescingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return w.c.write(ctx, obj, func() error {
+		return w.SubResourceWriter.Update(ctx, obj, opts...)
+	})
+}
+
+func (w *coalescingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return w.c.write(ctx, obj, func() error {
+		return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+// write persists the status of obj with writeThrough, skips it or holds it back
+func (c *StatusCoalescingClient) write(ctx context.Context, obj client.Object, writeThrough func() error) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil || !coalescedStatusGroups[gvk.Group] {
+		return writeThrough()
+	}
+	hash, err := statusHash(obj)
+	if err != nil {
+		return writeThrough()
+	}
+	current, err := c.currentStatusHash(ctx, obj)
+	if err != nil {
+		return writeThrough()
+	}
+	key := statusWriteKey{gvk: gvk.String(), name: NamespacedName(obj), uid: obj.GetUID()}
+
+	c.lock.Lock()
+	now := c.clock.Now()
+	c.prune(now)
+	w, ok := c.writes[key]
+	if !ok {
+		w = &statusWrite{}
+		c.writes[key] = w
+	}
+	if hash == current {
+		// unchanged, or flapped back to the current status within the window
+		if w.pending != nil {
+			w.timer.Stop()
+			w.pending, w.timer = nil, nil
+		}
+		c.lock.Unlock()
+		return nil
+	}
+	if w.pending == nil && now.Sub(w.at) >= c.window {
+		w.at = now
+		c.lock.Unlock()
+		err := writeThrough()
+		c.persisted(key, hash, err)
+		return err
+	}
+	w.pending = obj.DeepCopyObject().(client.Object)
+	w.pendingBase = current
+	flushAt := w.at.Add(c.window)
+	if w.timer == nil {
+		w.timer = c.clock.AfterFunc(flushAt.Sub(now), func() { c.flush(key) })
+	}
+	c.lock.Unlock()
+	c.log.Debugf(ctx, "Holding back status update of %s %s until %s", gvk.Kind, key.name, flushAt.Format(time.RFC3339))
+	return nil
+}
+
+// currentStatusHash returns the hash of the status of the object as currently known to the client, e.g. to the cache
+// of the manager, including the changes of other writers
+func (c *StatusCoalescingClient) currentStatusHash(ctx context.Context, obj client.Object) (string, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return "", err
+	}
+	newObj, err := c.Scheme().New(gvk)
+	if err != nil {
+		return "", err
+	}
+	current, ok := newObj.(client.Object)
+	if !ok {
+		return "", fmt.Errorf("%T is not a client.Object", newObj)
+	}
+	if err := c.Get(ctx, NamespacedName(obj), current); err != nil {
+		return "", err
+	}
+	return statusHash(current)
+}
+
+// flush writes the status held back for key on the latest version of its object, unless the status was changed
+// by another writer since the held back status was computed
+func (c *StatusCoalescingClient) flush(key statusWriteKey) {
+	ctx := context.Background()
+	c.lock.Lock()
+	w, ok := c.writes[key]
+	if !ok || w.pending == nil {
+		c.lock.Unlock()
+		return
+	}
+	pending, base, written := w.pending, w.pendingBase, w.hash
+	w.pending, w.timer = nil, nil
+	// called by the timer, without the time of the clock
+	w.at = w.at.Add(c.window)
+	c.lock.Unlock()
+
+	hash, err := statusHash(pending)
+	if err != nil {
+		return
+	}
+	dropped := false
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := pending.DeepCopyObject().(client.Object)
+		if err := c.Get(ctx, key.name, latest); err != nil {
+			return err
+		}
+		if latest.GetUID() != key.uid {
+			// recreated since, its status is reconciled from scratch
+			return nil
+		}
+		latestHash, err := statusHash(latest)
+		if err != nil {
+			return err
+		}
+		switch {
+		case latestHash == hash:
+			return nil
+		case latestHash != base && latestHash != written:
+			dropped = true
+			return nil
+		}
+		// the status is the one pending was computed from, only the resource version is outdated
+		pending.SetResourceVersion(latest.GetResourceVersion())
+		return c.Client.Status().Update(ctx, pending)
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		c.log.Warnf(ctx, "Failed to write held back status of %s %s: %s", key.gvk, key.name, err)
+	}
+	if dropped {
+		c.log.Debugf(ctx, "Dropped held back status of %s %s, changed by another writer since", key.gvk, key.name)
+		err = errStatusChanged
+	}
+	c.persisted(key, hash, err)
+}
+
+var errStatusChanged = errors.New("status changed by another writer")
+
+// persisted records the result of a status write, a failed write does not delay the next one
+func (c *StatusCoalescingClient) persisted(key statusWriteKey, hash string, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	w, ok := c.writes[key]
+	if !ok {
+		return
+	}
+	if err != nil {
+		w.at = time.Time{}
+		return
+	}
+	w.hash = hash
+}
+
+// prune forgets objects without status writes for a while, e.g. deleted ones. Must be called with the lock held.
+func (c *StatusCoalescingClient) prune(now time.Time) {
+	if now.Sub(c.lastPrune) < statusWriteRetention {
+		return
+	}
+	c.lastPrune = now
+	for key, w := range c.writes {
+		if w.pending == nil && now.Sub(w.at) >= statusWriteRetention {
+			delete(c.writes, key)
+		}
+	}
+}
+
+// statusHash hashes the status of obj, without the transition times of its conditions, which change on every flap
+func statusHash(obj client.Object) (string, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+	status, ok := u["status"]
+	if !ok {
+		return "", fmt.Errorf("%T has no status", obj)
+	}
+	b, err := json.Marshal(withoutTransitionTimes(status))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func withoutTransitionTimes(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if key == "lastTransitionTime" {
+				continue
+			}
+			m[key] = withoutTransitionTimes(item)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, item := range v {
+			l[i] = withoutTransitionTimes(item)
+		}
+		return l
+	}
+	return value
+}
@@ -0,0 +1,197 @@
+package k8s
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func newStatusCoalescingTestClient(window time.Duration, objs ...client.Object) (*StatusCoalescingClient, *testclock.FakeClock, *int32) {
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	gwv1beta1.AddToScheme(scheme)
+
+	writes := int32(0)
+	k8sClient := testclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				atomic.AddInt32(&writes, 1)
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				atomic.AddInt32(&writes, 1)
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	c := NewStatusCoalescingClient(gwlog.FallbackLogger, k8sClient, window)
+	clock := testclock.NewFakeClock(time.Now())
+	c.clock = clock
+	return c, clock, &writes
+}
+
+func setProgrammed(gw *gwv1beta1.Gateway, status metav1.ConditionStatus, reason string) {
+	meta.SetStatusCondition(&gw.Status.Conditions, metav1.Condition{
+		Type:   "Programmed",
+		Status: status,
+		Reason: reason,
+	})
+}
+
+func programmedCondition(t *testing.T, c client.Client, name types.NamespacedName) *metav1.Condition {
+	gw := &gwv1beta1.Gateway{}
+	assert.NoError(t, c.Get(context.TODO(), name, gw))
+	return meta.FindStatusCondition(gw.Status.Conditions, "Programmed")
+}
+
+func TestStatusCoalescingClient_CollapsesRapidConditionChanges(t *testing.T) {
+	ctx := context.TODO()
+	gw := &gwv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"}}
+	c, clock, writes := newStatusCoalescingTestClient(time.Minute, gw)
+
+	for i := 0; i < 10; i++ {
+		latest := &gwv1beta1.Gateway{}
+		assert.NoError(t, c.Get(ctx, NamespacedName(gw), latest))
+		if i%2 == 0 {
+			setProgrammed(latest, metav1.ConditionFalse, "Pending")
+		} else {
+			setProgrammed(latest, metav1.ConditionTrue, "Programmed")
+		}
+		assert.NoError(t, c.Status().Update(ctx, latest))
+	}
+	// the first change is written right away, the others are held back
+	assert.Equal(t, int32(1), atomic.LoadInt32(writes))
+
+	// the final status is written once the window elapses
+	clock.Step(time.Minute)
+	assert.Equal(t, int32(2), atomic.LoadInt32(writes))
+	cnd := programmedCondition(t, c, NamespacedName(gw))
+	if assert.NotNil(t, cnd) {
+		assert.Equal(t, metav1.ConditionTrue, cnd.Status)
+		assert.Equal(t, "Programmed", cnd.Reason)
+	}
+
+	clock.Step(time.Minute)
+	assert.Equal(t, int32(2), atomic.LoadInt32(writes))
+}
+
+func TestStatusCoalescingClient_SkipsUnchangedAndFlappedBackStatus(t *testing.T) {
+	ctx := context.TODO()
+	gw := &gwv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"}}
+	c, clock, writes := newStatusCoalescingTestClient(time.Minute, gw)
+
+	update := func(status metav1.ConditionStatus, reason string) {
+		latest := &gwv1beta1.Gateway{}
+		assert.NoError(t, c.Get(ctx, NamespacedName(gw), latest))
+		setProgrammed(latest, status, reason)
+		// a new transition time alone is not a meaningful change
+		for i := range latest.Status.Conditions {
+			latest.Status.Conditions[i].LastTransitionTime = metav1.Now()
+		}
+		assert.NoError(t, c.Status().Update(ctx, latest))
+	}
+
+	update(metav1.ConditionTrue, "Programmed")
+	update(metav1.ConditionTrue, "Programmed")
+	// flaps back to the persisted status within the window
+	update(metav1.ConditionFalse, "Pending")
+	update(metav1.ConditionTrue, "Programmed")
+
+	clock.Step(2 * time.Minute)
+	assert.Equal(t, int32(1), atomic.LoadInt32(writes))
+
+	// once the window elapsed, changes are written right away again
+	update(metav1.ConditionFalse, "Pending")
+	assert.Equal(t, int32(2), atomic.LoadInt32(writes))
+}
+
+func TestStatusCoalescingClient_RestoresStatusChangedByOthers(t *testing.T) {
+	ctx := context.TODO()
+	gw := &gwv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"}}
+	c, clock, writes := newStatusCoalescingTestClient(time.Minute, gw)
+
+	latest := &gwv1beta1.Gateway{}
+	assert.NoError(t, c.Get(ctx, NamespacedName(gw), latest))
+	setProgrammed(latest, metav1.ConditionTrue, "Programmed")
+	assert.NoError(t, c.Status().Update(ctx, latest))
+
+	// e.g. cleared with kubectl
+	assert.NoError(t, c.Get(ctx, NamespacedName(gw), latest))
+	latest.Status.Conditions = nil
+	assert.NoError(t, c.Client.Status().Update(ctx, latest))
+	assert.Equal(t, int32(2), atomic.LoadInt32(writes))
+
+	clock.Step(time.Minute)
+	assert.NoError(t, c.Get(ctx, NamespacedName(gw), latest))
+	setProgrammed(latest, metav1.ConditionTrue, "Programmed")
+	assert.NoError(t, c.Status().Update(ctx, latest))
+	assert.Equal(t, int32(3), atomic.LoadInt32(writes))
+	assert.NotNil(t, programmedCondition(t, c, NamespacedName(gw)))
+}
+
+func TestStatusCoalescingClient_DropsHeldBackStatusChangedByOthers(t *testing.T) {
+	ctx := context.TODO()
+	gw := &gwv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns"}}
+	c, clock, writes := newStatusCoalescingTestClient(time.Minute, gw)
+
+	latest := &gwv1beta1.Gateway{}
+	assert.NoError(t, c.Get(ctx, NamespacedName(gw), latest))
+	setProgrammed(latest, metav1.ConditionTrue, "Programmed")
+	assert.NoError(t, c.Status().Update(ctx, latest))
+
+	// held back
+	assert.NoError(t, c.Get(ctx, NamespacedName(gw), latest))
+	setProgrammed(latest, metav1.ConditionFalse, "Pending")
+	assert.NoError(t, c.Status().Update(ctx, latest))
+
+	// another writer changes the status before the window elapses
+	assert.NoError(t, c.Get(ctx, NamespacedName(gw), latest))
+	meta.SetStatusCondition(&latest.Status.Conditions, metav1.Condition{Type: "Other", Status: metav1.ConditionTrue, Reason: "Other"})
+	assert.NoError(t, c.Client.Status().Update(ctx, latest))
+	assert.Equal(t, int32(2), atomic.LoadInt32(writes))
+
+	// the held back status, computed without the change of the other writer, is not written over it
+	clock.Step(time.Minute)
+	assert.Equal(t, int32(2), atomic.LoadInt32(writes))
+	assert.NoError(t, c.Get(ctx, NamespacedName(gw), latest))
+	assert.NotNil(t, meta.FindStatusCondition(latest.Status.Conditions, "Other"))
+
+	// the next status of the controller is written right away
+	setProgrammed(latest, metav1.ConditionFalse, "Pending")
+	assert.NoError(t, c.Status().Update(ctx, latest))
+	assert.Equal(t, int32(3), atomic.LoadInt32(writes))
+	assert.Equal(t, metav1.ConditionFalse, programmedCondition(t, c, NamespacedName(gw)).Status)
+}
+
+func TestStatusCoalescingClient_WritesThroughOtherStatuses(t *testing.T) {
+	ctx := context.TODO()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"}}
+	c, _, writes := newStatusCoalescingTestClient(time.Minute, pod)
+
+	for _, phase := range []corev1.PodPhase{corev1.PodPending, corev1.PodRunning, corev1.PodPending} {
+		latest := &corev1.Pod{}
+		assert.NoError(t, c.Get(ctx, NamespacedName(pod), latest))
+		latest.Status.Phase = phase
+		assert.NoError(t, c.Status().Update(ctx, latest))
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(writes))
+}
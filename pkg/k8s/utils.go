@@ -2,18 +2,59 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 )
 
 const AnnotationPrefix = "application-networking.k8s.aws/"
 
+// ServiceNetworkSelectorAnnotation selects the service network of a Gateway by its tags, e.g. "environment=prod",
+// instead of by the Gateway name
+const ServiceNetworkSelectorAnnotation = AnnotationPrefix + "service-network-selector"
+
+// MaintenanceWindowAnnotation restricts the disruptive changes of the VPC Lattice resources of a route, e.g. target
+// group recreations, to a recurring window, e.g. "0 2 * * 6 4h", see utils.ParseMaintenanceWindow
+const MaintenanceWindowAnnotation = AnnotationPrefix + "maintenance-window"
+
+// ReconcileHistoryAnnotation holds the outcomes of the last reconciles of an object, written with --reconcile-history
+const ReconcileHistoryAnnotation = AnnotationPrefix + "reconcile-history"
+
+// ReconcileHistoryOnlyChanged returns whether an update of an object changed its ReconcileHistoryAnnotation and
+// nothing else, i.e. the update recorded a reconcile of the object
+func ReconcileHistoryOnlyChanged(oldObj, newObj client.Object) bool {
+	if oldObj == nil || newObj == nil ||
+		oldObj.GetAnnotations()[ReconcileHistoryAnnotation] == newObj.GetAnnotations()[ReconcileHistoryAnnotation] {
+		return false
+	}
+	withoutHistory := func(obj client.Object) client.Object {
+		obj = obj.DeepCopyObject().(client.Object)
+		annotations := obj.GetAnnotations()
+		delete(annotations, ReconcileHistoryAnnotation)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+		obj.SetResourceVersion("")
+		obj.SetManagedFields(nil)
+		return obj
+	}
+	return equality.Semantic.DeepEqual(withoutHistory(oldObj), withoutHistory(newObj))
+}
+
 // NamespacedName returns the namespaced name for k8s objects
 func NamespacedName(obj client.Object) types.NamespacedName {
 	return types.NamespacedName{
@@ -29,6 +70,36 @@ func NamespaceOrDefault(namespace *v1beta1.Namespace) string {
 	return string(*namespace)
 }
 
+// ServiceNetworkSelector returns the tags which select the service network of the gateway, nil when the gateway
+// has no ServiceNetworkSelectorAnnotation and its service network is the one named after it
+func ServiceNetworkSelector(gw *v1beta1.Gateway) (map[string]string, error) {
+	value, ok := gw.Annotations[ServiceNetworkSelectorAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	selector, err := labels.ConvertSelectorToLabelsMap(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation %q: %w", ServiceNetworkSelectorAnnotation, value, err)
+	}
+	if len(selector) == 0 {
+		return nil, fmt.Errorf("invalid %s annotation %q: no tags to match", ServiceNetworkSelectorAnnotation, value)
+	}
+	return selector, nil
+}
+
+// MaintenanceWindow returns the maintenance window of obj, nil when it has no MaintenanceWindowAnnotation
+func MaintenanceWindow(obj client.Object) (*utils.MaintenanceWindow, error) {
+	value, ok := obj.GetAnnotations()[MaintenanceWindowAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	w, err := utils.ParseMaintenanceWindow(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", MaintenanceWindowAnnotation, err)
+	}
+	return w, nil
+}
+
 func IsGVKSupported(mgr ctrl.Manager, groupVersion string, kind string) (bool, error) {
 	gv, err := schema.ParseGroupVersion(groupVersion)
 	if err != nil {
@@ -39,7 +110,11 @@ func IsGVKSupported(mgr ctrl.Manager, groupVersion string, kind string) (bool, e
 	if err != nil {
 		return false, err
 	}
-	apiResources, err := discoveryClient.ServerResourcesForGroupVersion(gv.Group + "/" + gv.Version)
+	return isKindServed(discoveryClient, gv.WithKind(kind))
+}
+
+func isKindServed(discoveryClient discovery.DiscoveryInterface, gvk schema.GroupVersionKind) (bool, error) {
+	apiResources, err := discoveryClient.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return false, nil
@@ -47,7 +122,7 @@ func IsGVKSupported(mgr ctrl.Manager, groupVersion string, kind string) (bool, e
 		return false, err
 	}
 	for i := range apiResources.APIResources {
-		if apiResources.APIResources[i].Kind == kind {
+		if apiResources.APIResources[i].Kind == gvk.Kind {
 			return true, nil
 		}
 	}
@@ -64,3 +139,77 @@ func ObjExists(ctx context.Context, c client.Client, key types.NamespacedName, o
 	}
 	return true, nil
 }
+
+// IsNodeDraining returns true when the node is cordoned or not Ready, so the pods it hosts are
+// expected to terminate and should stop receiving traffic
+func IsNodeDraining(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return true
+	}
+	for _, cnd := range node.Status.Conditions {
+		if cnd.Type == corev1.NodeReady {
+			return cnd.Status != corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// HasServicePort returns true when the service exposes the port
+func HasServicePort(svc *corev1.Service, port int32) bool {
+	for _, p := range svc.Spec.Ports {
+		if p.Port == port {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNamespaceAllowedByListener returns true when the allowedRoutes of the listener permit routes of the
+// given namespace to attach. Without allowedRoutes, only routes of the Gateway namespace are allowed.
+// A Selector policy without a valid selector allows no namespace.
+func IsNamespaceAllowedByListener(ctx context.Context, c client.Client, gw *v1beta1.Gateway, listener v1beta1.Listener, namespace string) (bool, error) {
+	from := gwv1.NamespacesFromSame
+	var selector *metav1.LabelSelector
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil {
+		if listener.AllowedRoutes.Namespaces.From != nil {
+			from = *listener.AllowedRoutes.Namespaces.From
+		}
+		selector = listener.AllowedRoutes.Namespaces.Selector
+	}
+
+	switch from {
+	case gwv1.NamespacesFromAll:
+		return true, nil
+	case gwv1.NamespacesFromSelector:
+		if selector == nil {
+			return false, nil
+		}
+		nsSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return false, nil
+		}
+		ns := &corev1.Namespace{}
+		if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return nsSelector.Matches(labels.Set(ns.Labels)), nil
+	default:
+		return namespace == gw.Namespace, nil
+	}
+}
+
+// IsRouteKindServedByListener returns true when the listener protocol can serve routes of the given kind:
+// HTTPRoutes are served by HTTP and HTTPS listeners, GRPCRoutes by HTTPS listeners and TLSRoutes by
+// TLS passthrough listeners.
+func IsRouteKindServedByListener(listener v1beta1.Listener, kind string) bool {
+	switch kind {
+	case "HTTPRoute":
+		return listener.Protocol == gwv1.HTTPProtocolType || listener.Protocol == gwv1.HTTPSProtocolType
+	case "GRPCRoute":
+		return listener.Protocol == gwv1.HTTPSProtocolType
+	case "TLSRoute":
+		return listener.Protocol == gwv1.TLSProtocolType && listener.TLS != nil &&
+			listener.TLS.Mode != nil && *listener.TLS.Mode == gwv1.TLSModePassthrough
+	}
+	return false
+}
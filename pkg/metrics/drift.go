@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricDriftedResources = "drifted_resources"
+)
+
+var (
+	driftedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemController,
+		Name:      metricDriftedResources,
+		Help:      "Number of managed resources whose VPC Lattice state differs from their desired state, as of the last drift detection",
+	}, []string{labelKind})
+)
+
+func init() {
+	metrics.Registry.MustRegister(driftedResources)
+}
+
+// SetDriftedResources reports the number of drifted resources of the kind found by the last drift detection.
+func SetDriftedResources(kind string, count int) {
+	driftedResources.WithLabelValues(kind).Set(float64(count))
+}
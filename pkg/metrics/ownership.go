@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricOwnershipConflicts = "ownership_conflicts"
+)
+
+var (
+	ownershipConflicts = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemController,
+		Name:      metricOwnershipConflicts,
+		Help:      "Number of VPC Lattice resources once managed by the controller whose ownership tag was changed or removed out of band, as of the last ownership check",
+	}, []string{labelKind})
+)
+
+func init() {
+	metrics.Registry.MustRegister(ownershipConflicts)
+}
+
+// SetOwnershipConflicts reports the number of resources of the kind found with a conflicting ownership tag by the last ownership check.
+func SetOwnershipConflicts(kind string, count int) {
+	ownershipConflicts.WithLabelValues(kind).Set(float64(count))
+}
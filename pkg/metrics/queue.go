@@ -0,0 +1,187 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+)
+
+const (
+	metricSubsystemController = "lattice_controller"
+
+	metricQueueDepth           = "queue_depth"
+	metricQueueDurationSeconds = "queue_duration_seconds"
+
+	labelKind = "kind"
+)
+
+var (
+	queueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemController,
+		Name:      metricQueueDepth,
+		Help:      "Number of requests waiting in the controller workqueue to be reconciled",
+	}, []string{labelKind})
+	queueDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricSubsystemController,
+		Name:      metricQueueDurationSeconds,
+		Help:      "How long in seconds a request waits in the controller workqueue before being reconciled",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 12),
+	}, []string{labelKind})
+)
+
+func init() {
+	metrics.Registry.MustRegister(queueDepth, queueDurationSeconds)
+}
+
+// QueueTracker keeps track of the requests enqueued by the event handlers of a controller,
+// and reports the queue depth and time-in-queue once the reconciler picks them up.
+// Requeues triggered by the reconcile result are handled internally by controller-runtime and are not tracked.
+type QueueTracker struct {
+	kind    string
+	now     func() time.Time
+	lock    sync.Mutex
+	pending map[reconcile.Request]time.Time
+}
+
+func NewQueueTracker(kind string) *QueueTracker {
+	return &QueueTracker{
+		kind:    kind,
+		now:     time.Now,
+		pending: make(map[reconcile.Request]time.Time),
+	}
+}
+
+// EventHandler wraps the given handler so that the requests it enqueues are tracked.
+func (t *QueueTracker) EventHandler(h handler.EventHandler) handler.EventHandler {
+	return &trackedEventHandler{
+		handler: h,
+		tracker: t,
+	}
+}
+
+// Reconciler wraps the given reconciler so that tracked requests are observed when processing starts,
+// and their results are counted in the resync summary, see ResyncReporter.
+func (t *QueueTracker) Reconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		t.dequeued(req)
+		res, err := r.Reconcile(ctx, req)
+		cycleReconciles.observe(t.kind, err)
+		reconcileObservers.notify(t.kind, req, err)
+		return res, err
+	})
+}
+
+// ReconcileObserver is passed the result of every reconcile of the controllers, by kind of the reconciled object
+type ReconcileObserver func(kind string, req reconcile.Request, err error)
+
+var reconcileObservers = &observers{}
+
+type observers struct {
+	lock      sync.Mutex
+	observers []ReconcileObserver
+}
+
+// AddReconcileObserver registers an observer of the reconciles of all controllers wrapped by QueueTracker.Reconciler.
+func AddReconcileObserver(o ReconcileObserver) {
+	reconcileObservers.lock.Lock()
+	defer reconcileObservers.lock.Unlock()
+	reconcileObservers.observers = append(reconcileObservers.observers, o)
+}
+
+func (o *observers) notify(kind string, req reconcile.Request, err error) {
+	o.lock.Lock()
+	observers := o.observers
+	o.lock.Unlock()
+	for _, observer := range observers {
+		observer(kind, req, err)
+	}
+}
+
+func (t *QueueTracker) enqueued(item interface{}, readyAt time.Time) {
+	req, ok := item.(reconcile.Request)
+	if !ok {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// the workqueue deduplicates requests, the earliest one determines the time spent in queue
+	if _, exists := t.pending[req]; !exists {
+		t.pending[req] = readyAt
+	}
+	queueDepth.WithLabelValues(t.kind).Set(float64(len(t.pending)))
+}
+
+func (t *QueueTracker) dequeued(req reconcile.Request) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	readyAt, exists := t.pending[req]
+	if !exists {
+		return
+	}
+	delete(t.pending, req)
+	queueDepth.WithLabelValues(t.kind).Set(float64(len(t.pending)))
+
+	waited := t.now().Sub(readyAt)
+	if waited < 0 {
+		waited = 0
+	}
+	queueDurationSeconds.WithLabelValues(t.kind).Observe(waited.Seconds())
+}
+
+type trackedEventHandler struct {
+	handler handler.EventHandler
+	tracker *QueueTracker
+}
+
+func (h *trackedEventHandler) Create(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.handler.Create(ctx, e, h.wrap(q))
+}
+
+func (h *trackedEventHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	// recording a reconcile in the history of an object must not trigger another reconcile
+	if k8s.ReconcileHistoryOnlyChanged(e.ObjectOld, e.ObjectNew) {
+		return
+	}
+	h.handler.Update(ctx, e, h.wrap(q))
+}
+
+func (h *trackedEventHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.handler.Delete(ctx, e, h.wrap(q))
+}
+
+func (h *trackedEventHandler) Generic(ctx context.Context, e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.handler.Generic(ctx, e, h.wrap(q))
+}
+
+func (h *trackedEventHandler) wrap(q workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+	return &trackedQueue{
+		RateLimitingInterface: q,
+		tracker:               h.tracker,
+	}
+}
+
+type trackedQueue struct {
+	workqueue.RateLimitingInterface
+	tracker *QueueTracker
+}
+
+func (q *trackedQueue) Add(item interface{}) {
+	q.tracker.enqueued(item, q.tracker.now())
+	q.RateLimitingInterface.Add(item)
+}
+
+func (q *trackedQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.tracker.enqueued(item, q.tracker.now().Add(duration))
+	q.RateLimitingInterface.AddAfter(item, duration)
+}
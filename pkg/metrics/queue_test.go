@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+)
+
+func queueDurationSampleCount(t *testing.T, kind string) uint64 {
+	m := &dto.Metric{}
+	err := queueDurationSeconds.WithLabelValues(kind).(prometheus.Histogram).Write(m)
+	assert.Nil(t, err)
+	return m.GetHistogram().GetSampleCount()
+}
+
+func queueDurationSampleSum(t *testing.T, kind string) float64 {
+	m := &dto.Metric{}
+	err := queueDurationSeconds.WithLabelValues(kind).(prometheus.Histogram).Write(m)
+	assert.Nil(t, err)
+	return m.GetHistogram().GetSampleSum()
+}
+
+func Test_QueueTracker_UpdatesMetrics(t *testing.T) {
+	kind := "TestKind"
+	now := time.Now()
+	tracker := NewQueueTracker(kind)
+	tracker.now = func() time.Time { return now }
+
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	h := tracker.EventHandler(&handler.EnqueueRequestForObject{})
+
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"}}
+	}
+	ctx := context.TODO()
+	h.Create(ctx, event.CreateEvent{Object: newPod("a")}, q)
+	h.Create(ctx, event.CreateEvent{Object: newPod("b")}, q)
+	// duplicate requests are collapsed by the workqueue
+	h.Update(ctx, event.UpdateEvent{ObjectOld: newPod("a"), ObjectNew: newPod("a")}, q)
+
+	assert.Equal(t, 2, q.Len())
+	assert.Equal(t, float64(2), testutil.ToFloat64(queueDepth.WithLabelValues(kind)))
+	assert.Equal(t, uint64(0), queueDurationSampleCount(t, kind))
+
+	var reconciled []reconcile.Request
+	r := tracker.Reconciler(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		reconciled = append(reconciled, req)
+		return reconcile.Result{}, nil
+	}))
+
+	now = now.Add(3 * time.Second)
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "a", Namespace: "ns"}})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(queueDepth.WithLabelValues(kind)))
+	assert.Equal(t, uint64(1), queueDurationSampleCount(t, kind))
+	assert.InDelta(t, 3.0, queueDurationSampleSum(t, kind), 0.001)
+
+	now = now.Add(2 * time.Second)
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "b", Namespace: "ns"}})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(0), testutil.ToFloat64(queueDepth.WithLabelValues(kind)))
+	assert.Equal(t, uint64(2), queueDurationSampleCount(t, kind))
+	assert.InDelta(t, 8.0, queueDurationSampleSum(t, kind), 0.001)
+
+	// requests not enqueued through tracked handlers are not observed
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "c", Namespace: "ns"}})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), queueDurationSampleCount(t, kind))
+	assert.Len(t, reconciled, 3)
+}
+
+func Test_QueueTracker_AddAfter(t *testing.T) {
+	kind := "TestKindDelayed"
+	now := time.Now()
+	tracker := NewQueueTracker(kind)
+	tracker.now = func() time.Time { return now }
+
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "a", Namespace: "ns"}}
+	tracked := &trackedQueue{RateLimitingInterface: q, tracker: tracker}
+	tracked.AddAfter(req, 10*time.Second)
+	assert.Equal(t, float64(1), testutil.ToFloat64(queueDepth.WithLabelValues(kind)))
+
+	// time before the item becomes ready is not counted as time in queue
+	now = now.Add(11 * time.Second)
+	r := tracker.Reconciler(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, nil
+	}))
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(0), testutil.ToFloat64(queueDepth.WithLabelValues(kind)))
+	assert.InDelta(t, 1.0, queueDurationSampleSum(t, kind), 0.001)
+}
+
+func Test_QueueTracker_IgnoresReconcileHistoryUpdates(t *testing.T) {
+	tracker := NewQueueTracker("TestKindHistory")
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	h := tracker.EventHandler(&handler.EnqueueRequestForObject{})
+
+	pod := func(resourceVersion string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns",
+			ResourceVersion: resourceVersion, Annotations: annotations}}
+	}
+	ctx := context.TODO()
+	h.Update(ctx, event.UpdateEvent{
+		ObjectOld: pod("1", nil),
+		ObjectNew: pod("2", map[string]string{k8s.ReconcileHistoryAnnotation: `[{"time":"2026-10-15T10:00:00Z","result":"Success"}]`}),
+	}, q)
+	assert.Equal(t, 0, q.Len())
+
+	// other changes along with the history are reconciled
+	h.Update(ctx, event.UpdateEvent{
+		ObjectOld: pod("2", map[string]string{k8s.ReconcileHistoryAnnotation: "[]"}),
+		ObjectNew: pod("3", map[string]string{k8s.ReconcileHistoryAnnotation: `[{"time":"2026-10-15T10:00:00Z","result":"Success"}]`, "app": "v2"}),
+	}, q)
+	assert.Equal(t, 1, q.Len())
+}
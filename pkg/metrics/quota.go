@@ -0,0 +1,219 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	metricQuotaUsage = "quota_usage"
+	metricQuotaLimit = "quota_limit"
+
+	labelQuota = "quota"
+
+	QuotaServicesPerServiceNetwork = "services_per_service_network"
+	QuotaRulesPerListener          = "rules_per_listener"
+	QuotaTargetsPerTargetGroup     = "targets_per_target_group"
+
+	// default VPC Lattice quotas, see https://docs.aws.amazon.com/vpc-lattice/latest/ug/quotas.html
+	defaultServicesPerServiceNetwork = 500
+	defaultTargetsPerTargetGroup     = 1000
+)
+
+var (
+	quotaUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemController,
+		Name:      metricQuotaUsage,
+		Help:      "Highest usage of a VPC Lattice quota across the resources of the account, e.g. the rules of the listener with the most rules",
+	}, []string{labelQuota})
+	quotaLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemController,
+		Name:      metricQuotaLimit,
+		Help:      "Known limit of a VPC Lattice quota",
+	}, []string{labelQuota})
+)
+
+func init() {
+	metrics.Registry.MustRegister(quotaUsage, quotaLimit)
+}
+
+// usage and limit of each quota as of the last poll, for QuotaBackpressure
+var (
+	lastQuotaLock  sync.RWMutex
+	lastQuotaUsage = map[string]int{}
+	lastQuotaLimit = map[string]int{}
+)
+
+func setQuota(quota string, usage, limit int) {
+	lastQuotaLock.Lock()
+	defer lastQuotaLock.Unlock()
+	lastQuotaUsage[quota] = usage
+	lastQuotaLimit[quota] = limit
+	quotaUsage.WithLabelValues(quota).Set(float64(usage))
+}
+
+// QuotaBackpressure returns why the creation of resources counting against the quota is deferred, when the
+// last polled usage reached config.QuotaBackpressureThreshold percent of its limit, or empty string. Creations
+// are not deferred while the usage was never polled, or the threshold is not set.
+func QuotaBackpressure(quota string) string {
+	if config.QuotaBackpressureThreshold == 0 {
+		return ""
+	}
+	lastQuotaLock.RLock()
+	defer lastQuotaLock.RUnlock()
+	usage, ok := lastQuotaUsage[quota]
+	limit := lastQuotaLimit[quota]
+	if !ok || limit == 0 || usage*100 < limit*config.QuotaBackpressureThreshold {
+		return ""
+	}
+	return fmt.Sprintf("%s quota usage is %d of %d, at least %d%% of its limit", quota, usage, limit,
+		config.QuotaBackpressureThreshold)
+}
+
+// QuotaUsagePoller periodically counts the VPC Lattice resources limited by per-resource quotas, and
+// reports the highest usage and the limit of each quota, so operators can alert before hitting them.
+// All resources of the account are counted, as the quotas apply regardless of which controller manages them.
+type QuotaUsagePoller struct {
+	log      gwlog.Logger
+	cloud    pkg_aws.Cloud
+	interval time.Duration
+}
+
+func NewQuotaUsagePoller(log gwlog.Logger, cloud pkg_aws.Cloud, interval time.Duration) *QuotaUsagePoller {
+	return &QuotaUsagePoller{
+		log:      log,
+		cloud:    cloud,
+		interval: interval,
+	}
+}
+
+// Start polls the quota usage every interval until the context is cancelled, implements manager.Runnable.
+func (p *QuotaUsagePoller) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Poll(ctx); err != nil {
+			p.log.Warnf(ctx, "Failed to poll VPC Lattice quota usage: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll counts the resources and updates the gauges. Quotas counted before an error are still updated.
+func (p *QuotaUsagePoller) Poll(ctx context.Context) error {
+	quotas := []struct {
+		quota string
+		limit int
+		count func(context.Context) (int, error)
+	}{
+		{QuotaServicesPerServiceNetwork, defaultServicesPerServiceNetwork, p.maxServicesPerServiceNetwork},
+		{QuotaRulesPerListener, config.ListenerRuleLimit, p.maxRulesPerListener},
+		{QuotaTargetsPerTargetGroup, defaultTargetsPerTargetGroup, p.maxTargetsPerTargetGroup},
+	}
+	for _, q := range quotas {
+		quotaLimit.WithLabelValues(q.quota).Set(float64(q.limit))
+	}
+	for _, q := range quotas {
+		usage, err := q.count(ctx)
+		if err != nil {
+			return err
+		}
+		setQuota(q.quota, usage, q.limit)
+	}
+	return nil
+}
+
+func (p *QuotaUsagePoller) maxServicesPerServiceNetwork(ctx context.Context) (int, error) {
+	sns, err := p.cloud.Lattice().ListServiceNetworksAsList(ctx, &vpclattice.ListServiceNetworksInput{})
+	if err != nil {
+		return 0, err
+	}
+	usage := 0
+	for _, sn := range sns {
+		if count := int(aws.Int64Value(sn.NumberOfAssociatedServices)); count > usage {
+			usage = count
+		}
+	}
+	return usage, nil
+}
+
+// the default rule of a listener does not count against the quota
+func (p *QuotaUsagePoller) maxRulesPerListener(ctx context.Context) (int, error) {
+	svcs, err := p.cloud.Lattice().ListServicesAsList(ctx, &vpclattice.ListServicesInput{})
+	if err != nil {
+		return 0, err
+	}
+	usage := 0
+	for _, svc := range svcs {
+		listeners, err := p.cloud.Lattice().ListListenersAsList(ctx, &vpclattice.ListListenersInput{
+			ServiceIdentifier: svc.Id,
+		})
+		if services.IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		for _, listener := range listeners {
+			rules, err := p.cloud.Lattice().ListRulesAsList(ctx, &vpclattice.ListRulesInput{
+				ServiceIdentifier:  svc.Id,
+				ListenerIdentifier: listener.Id,
+			})
+			if services.IsNotFoundError(err) {
+				continue
+			}
+			if err != nil {
+				return 0, err
+			}
+			count := 0
+			for _, rule := range rules {
+				if !aws.BoolValue(rule.IsDefault) {
+					count++
+				}
+			}
+			if count > usage {
+				usage = count
+			}
+		}
+	}
+	return usage, nil
+}
+
+func (p *QuotaUsagePoller) maxTargetsPerTargetGroup(ctx context.Context) (int, error) {
+	tgs, err := p.cloud.Lattice().ListTargetGroupsAsList(ctx, &vpclattice.ListTargetGroupsInput{})
+	if err != nil {
+		return 0, err
+	}
+	usage := 0
+	for _, tg := range tgs {
+		targets, err := p.cloud.Lattice().ListTargetsAsList(ctx, &vpclattice.ListTargetsInput{
+			TargetGroupIdentifier: tg.Id,
+		})
+		if services.IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if len(targets) > usage {
+			usage = len(targets)
+		}
+	}
+	return usage, nil
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func Test_QuotaUsagePoller_Poll(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	rules := func(n int) []*vpclattice.RuleSummary {
+		summaries := []*vpclattice.RuleSummary{{IsDefault: aws.Bool(true)}}
+		for i := 0; i < n; i++ {
+			summaries = append(summaries, &vpclattice.RuleSummary{IsDefault: aws.Bool(false)})
+		}
+		return summaries
+	}
+	targets := func(n int) []*vpclattice.TargetSummary {
+		return make([]*vpclattice.TargetSummary, n)
+	}
+
+	mockLattice := mocks.NewMockLattice(c)
+	mockLattice.EXPECT().ListServiceNetworksAsList(ctx, gomock.Any()).Return([]*vpclattice.ServiceNetworkSummary{
+		{Id: aws.String("sn-1"), NumberOfAssociatedServices: aws.Int64(12)},
+		{Id: aws.String("sn-2"), NumberOfAssociatedServices: aws.Int64(420)},
+	}, nil)
+	mockLattice.EXPECT().ListServicesAsList(ctx, gomock.Any()).Return([]*vpclattice.ServiceSummary{
+		{Id: aws.String("svc-1")},
+		{Id: aws.String("svc-2")},
+		{Id: aws.String("svc-deleted")},
+	}, nil)
+	mockLattice.EXPECT().ListListenersAsList(ctx, &vpclattice.ListListenersInput{ServiceIdentifier: aws.String("svc-1")}).
+		Return([]*vpclattice.ListenerSummary{{Id: aws.String("listener-1")}, {Id: aws.String("listener-2")}}, nil)
+	mockLattice.EXPECT().ListListenersAsList(ctx, &vpclattice.ListListenersInput{ServiceIdentifier: aws.String("svc-2")}).
+		Return([]*vpclattice.ListenerSummary{{Id: aws.String("listener-3")}}, nil)
+	mockLattice.EXPECT().ListListenersAsList(ctx, &vpclattice.ListListenersInput{ServiceIdentifier: aws.String("svc-deleted")}).
+		Return(nil, awserr.New(vpclattice.ErrCodeResourceNotFoundException, "not found", nil))
+	mockLattice.EXPECT().ListRulesAsList(ctx, &vpclattice.ListRulesInput{ServiceIdentifier: aws.String("svc-1"), ListenerIdentifier: aws.String("listener-1")}).
+		Return(rules(3), nil)
+	mockLattice.EXPECT().ListRulesAsList(ctx, &vpclattice.ListRulesInput{ServiceIdentifier: aws.String("svc-1"), ListenerIdentifier: aws.String("listener-2")}).
+		Return(rules(8), nil)
+	mockLattice.EXPECT().ListRulesAsList(ctx, &vpclattice.ListRulesInput{ServiceIdentifier: aws.String("svc-2"), ListenerIdentifier: aws.String("listener-3")}).
+		Return(rules(0), nil)
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return([]*vpclattice.TargetGroupSummary{
+		{Id: aws.String("tg-1")},
+		{Id: aws.String("tg-2")},
+	}, nil)
+	mockLattice.EXPECT().ListTargetsAsList(ctx, &vpclattice.ListTargetsInput{TargetGroupIdentifier: aws.String("tg-1")}).
+		Return(targets(250), nil)
+	mockLattice.EXPECT().ListTargetsAsList(ctx, &vpclattice.ListTargetsInput{TargetGroupIdentifier: aws.String("tg-2")}).
+		Return(targets(7), nil)
+
+	poller := NewQuotaUsagePoller(gwlog.FallbackLogger, pkg_aws.NewDefaultCloud(mockLattice, pkg_aws.CloudConfig{}), 0)
+	assert.Nil(t, poller.Poll(ctx))
+
+	assert.Equal(t, 420.0, testutil.ToFloat64(quotaUsage.WithLabelValues(QuotaServicesPerServiceNetwork)))
+	assert.Equal(t, 8.0, testutil.ToFloat64(quotaUsage.WithLabelValues(QuotaRulesPerListener)))
+	assert.Equal(t, 250.0, testutil.ToFloat64(quotaUsage.WithLabelValues(QuotaTargetsPerTargetGroup)))
+
+	assert.Equal(t, 500.0, testutil.ToFloat64(quotaLimit.WithLabelValues(QuotaServicesPerServiceNetwork)))
+	assert.Equal(t, float64(config.ListenerRuleLimit), testutil.ToFloat64(quotaLimit.WithLabelValues(QuotaRulesPerListener)))
+	assert.Equal(t, 1000.0, testutil.ToFloat64(quotaLimit.WithLabelValues(QuotaTargetsPerTargetGroup)))
+}
+
+func Test_QuotaUsagePoller_PollError(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	mockLattice := mocks.NewMockLattice(c)
+	mockLattice.EXPECT().ListServiceNetworksAsList(ctx, gomock.Any()).Return([]*vpclattice.ServiceNetworkSummary{
+		{Id: aws.String("sn-1"), NumberOfAssociatedServices: aws.Int64(3)},
+	}, nil)
+	mockLattice.EXPECT().ListServicesAsList(ctx, gomock.Any()).Return(nil, errors.New("throttled"))
+
+	poller := NewQuotaUsagePoller(gwlog.FallbackLogger, pkg_aws.NewDefaultCloud(mockLattice, pkg_aws.CloudConfig{}), 0)
+	assert.EqualError(t, poller.Poll(ctx), "throttled")
+
+	// quotas counted before the error are updated
+	assert.Equal(t, 3.0, testutil.ToFloat64(quotaUsage.WithLabelValues(QuotaServicesPerServiceNetwork)))
+}
+
+func Test_QuotaBackpressure(t *testing.T) {
+	defer func() { config.QuotaBackpressureThreshold = 0 }()
+	setQuota(QuotaServicesPerServiceNetwork, 450, 500)
+
+	config.QuotaBackpressureThreshold = 0
+	assert.Empty(t, QuotaBackpressure(QuotaServicesPerServiceNetwork), "disabled")
+
+	config.QuotaBackpressureThreshold = 91
+	assert.Empty(t, QuotaBackpressure(QuotaServicesPerServiceNetwork))
+
+	config.QuotaBackpressureThreshold = 90
+	assert.Equal(t, "services_per_service_network quota usage is 450 of 500, at least 90% of its limit",
+		QuotaBackpressure(QuotaServicesPerServiceNetwork))
+
+	assert.Empty(t, QuotaBackpressure("never_polled"))
+}
@@ -0,0 +1,173 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	metricResyncReconciles = "resync_reconciles"
+	metricResyncAPICalls   = "resync_api_calls"
+
+	labelResult = "result"
+
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+var (
+	resyncReconciles = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemController,
+		Name:      metricResyncReconciles,
+		Help:      "Number of reconciles during the last resync cycle, by result",
+	}, []string{labelKind, labelResult})
+	resyncAPICalls = prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemController,
+		Name:      metricResyncAPICalls,
+		Help:      "Number of AWS API requests made during the last resync cycle, retries included",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(resyncReconciles, resyncAPICalls)
+}
+
+// reconcile results of every controller since the last resync summary, recorded by QueueTracker.Reconciler
+var cycleReconciles = &reconcileTally{}
+
+type reconcileTally struct {
+	lock   sync.Mutex
+	counts map[string]*ReconcileCounts
+}
+
+func (t *reconcileTally) observe(kind string, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.counts == nil {
+		t.counts = make(map[string]*ReconcileCounts)
+	}
+	counts, ok := t.counts[kind]
+	if !ok {
+		counts = &ReconcileCounts{}
+		t.counts[kind] = counts
+	}
+	counts.Reconciled++
+	if err != nil {
+		counts.Failed++
+	}
+}
+
+func (t *reconcileTally) reset() map[string]*ReconcileCounts {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	counts := t.counts
+	t.counts = nil
+	return counts
+}
+
+// ReconcileCounts are the reconciles of a kind during a resync cycle, failed ones included
+type ReconcileCounts struct {
+	Reconciled int `json:"reconciled"`
+	Failed     int `json:"failed"`
+}
+
+// ResyncSummary summarizes the work of the controllers during a resync cycle
+type ResyncSummary struct {
+	Period     time.Duration
+	Reconciled int
+	Failed     int
+	APICalls   int
+	Kinds      map[string]*ReconcileCounts
+}
+
+// ResyncReporter logs a summary of the reconciles and AWS API requests of every resync period, a periodic
+// heartbeat of the controller health, and reports it as metrics.
+type ResyncReporter struct {
+	log      gwlog.Logger
+	period   time.Duration
+	apiCalls func() int
+
+	lastAPICalls int
+	cycleStart   time.Time
+	// kinds reported by the last summary, whose gauges are reset when they have no reconcile in the next cycle
+	lastKinds map[string]bool
+}
+
+// NewResyncReporter returns a reporter summarizing every period. apiCalls returns the number of AWS API requests made
+// since the controller started.
+func NewResyncReporter(log gwlog.Logger, period time.Duration, apiCalls func() int) *ResyncReporter {
+	return &ResyncReporter{
+		log:          log,
+		period:       period,
+		apiCalls:     apiCalls,
+		lastAPICalls: apiCalls(),
+		cycleStart:   time.Now(),
+	}
+}
+
+func (r *ResyncReporter) Start(ctx context.Context) error {
+	// reconciles before the elected leader starts the reporter are not part of its first cycle
+	cycleReconciles.reset()
+	r.lastAPICalls = r.apiCalls()
+	r.cycleStart = time.Now()
+
+	ticker := time.NewTicker(r.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.Report(ctx)
+		}
+	}
+}
+
+// Report logs the summary of the cycle since the last report, sets the resync metrics and starts a new cycle.
+func (r *ResyncReporter) Report(ctx context.Context) ResyncSummary {
+	now := time.Now()
+	apiCalls := r.apiCalls()
+	summary := ResyncSummary{
+		Period:   now.Sub(r.cycleStart),
+		APICalls: apiCalls - r.lastAPICalls,
+		Kinds:    cycleReconciles.reset(),
+	}
+	r.lastAPICalls, r.cycleStart = apiCalls, now
+	if summary.Kinds == nil {
+		summary.Kinds = make(map[string]*ReconcileCounts)
+	}
+
+	for kind, counts := range summary.Kinds {
+		summary.Reconciled += counts.Reconciled
+		summary.Failed += counts.Failed
+		resyncReconciles.WithLabelValues(kind, resultSuccess).Set(float64(counts.Reconciled - counts.Failed))
+		resyncReconciles.WithLabelValues(kind, resultFailure).Set(float64(counts.Failed))
+	}
+	for kind := range r.lastKinds {
+		if _, ok := summary.Kinds[kind]; !ok {
+			resyncReconciles.WithLabelValues(kind, resultSuccess).Set(0)
+			resyncReconciles.WithLabelValues(kind, resultFailure).Set(0)
+		}
+	}
+	resyncAPICalls.Set(float64(summary.APICalls))
+
+	r.lastKinds = make(map[string]bool, len(summary.Kinds))
+	for kind := range summary.Kinds {
+		r.lastKinds[kind] = true
+	}
+
+	r.log.Infow(ctx, "Resync cycle summary",
+		"period", summary.Period.Round(time.Second).String(),
+		"reconciled", summary.Reconciled,
+		"failed", summary.Failed,
+		"apiCalls", summary.APICalls,
+		"kinds", summary.Kinds,
+	)
+	return summary
+}
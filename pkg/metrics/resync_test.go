@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func Test_ResyncReporter_SummarizesReconciles(t *testing.T) {
+	ctx := context.TODO()
+	core, logs := observer.New(zap.InfoLevel)
+	apiCalls := 100
+	reporter := NewResyncReporter(&gwlog.TracedLogger{InnerLogger: zap.New(core).Sugar()}, time.Hour,
+		func() int { return apiCalls })
+	// starts from an empty cycle, whatever the other tests reconciled
+	cycleReconciles.reset()
+
+	reconcileAll := func(kind string, names ...string) {
+		r := NewQueueTracker(kind).Reconciler(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+			if req.Name == "broken" {
+				return reconcile.Result{}, errors.New("failed")
+			}
+			apiCalls += 3
+			return reconcile.Result{}, nil
+		}))
+		for _, name := range names {
+			r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: name}})
+		}
+	}
+	reconcileAll("ResyncGateway", "gw-1", "broken")
+	reconcileAll("ResyncHTTPRoute", "route-1", "route-2", "route-3", "broken")
+
+	summary := reporter.Report(ctx)
+	assert.Equal(t, 6, summary.Reconciled)
+	assert.Equal(t, 2, summary.Failed)
+	assert.Equal(t, 12, summary.APICalls)
+	assert.Equal(t, map[string]*ReconcileCounts{
+		"ResyncGateway":   {Reconciled: 2, Failed: 1},
+		"ResyncHTTPRoute": {Reconciled: 4, Failed: 1},
+	}, summary.Kinds)
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(resyncReconciles.WithLabelValues("ResyncHTTPRoute", resultSuccess)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(resyncReconciles.WithLabelValues("ResyncHTTPRoute", resultFailure)))
+	assert.Equal(t, float64(12), testutil.ToFloat64(resyncAPICalls))
+
+	entries := logs.TakeAll()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "Resync cycle summary", entries[0].Message)
+	fields := entries[0].ContextMap()
+	assert.EqualValues(t, 6, fields["reconciled"])
+	assert.EqualValues(t, 2, fields["failed"])
+	assert.EqualValues(t, 12, fields["apiCalls"])
+
+	// the next cycle starts empty, and kinds without reconciles are reset
+	reconcileAll("ResyncGateway", "gw-1")
+	summary = reporter.Report(ctx)
+	assert.Equal(t, 1, summary.Reconciled)
+	assert.Equal(t, 0, summary.Failed)
+	assert.Equal(t, 3, summary.APICalls)
+	assert.Equal(t, float64(1), testutil.ToFloat64(resyncReconciles.WithLabelValues("ResyncGateway", resultSuccess)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(resyncReconciles.WithLabelValues("ResyncHTTPRoute", resultSuccess)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(resyncReconciles.WithLabelValues("ResyncHTTPRoute", resultFailure)))
+}
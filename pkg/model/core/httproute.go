@@ -178,6 +178,15 @@ func (r *HTTPRouteRule) Matches() []RouteMatch {
 	return routeMatches
 }
 
+// Filters returns the rule filters together with the filters of each backendRef
+func (r *HTTPRouteRule) Filters() []gwv1beta1.HTTPRouteFilter {
+	filters := append([]gwv1beta1.HTTPRouteFilter{}, r.r.Filters...)
+	for _, backendRef := range r.r.BackendRefs {
+		filters = append(filters, backendRef.Filters...)
+	}
+	return filters
+}
+
 func (r *HTTPRouteRule) Equals(routeRule RouteRule) bool {
 	other, ok := routeRule.(*HTTPRouteRule)
 	if !ok {
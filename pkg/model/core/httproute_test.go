@@ -804,3 +804,19 @@ func TestHTTPRouteMatch_Equals(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPRouteRule_Filters(t *testing.T) {
+	rule := &HTTPRouteRule{gwv1beta1.HTTPRouteRule{
+		Filters: []gwv1beta1.HTTPRouteFilter{{Type: gwv1beta1.HTTPRouteFilterType("RequestHeaderModifier")}},
+		BackendRefs: []gwv1beta1.HTTPBackendRef{
+			{Filters: []gwv1beta1.HTTPRouteFilter{{Type: "RequestMirror"}}},
+			{},
+		},
+	}}
+
+	filters := rule.Filters()
+	assert.Len(t, filters, 2)
+	assert.Equal(t, gwv1beta1.HTTPRouteFilterType("RequestHeaderModifier"), filters[0].Type)
+	assert.Equal(t, gwv1beta1.HTTPRouteFilterType("RequestMirror"), filters[1].Type)
+	assert.Len(t, rule.r.Filters, 1)
+}
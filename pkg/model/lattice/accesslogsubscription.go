@@ -9,7 +9,10 @@ import (
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 )
 
-const AccessLogPolicyTagKey = aws.TagBase + "AccessLogPolicy"
+const (
+	AccessLogPolicyTagKey         = aws.TagBase + "AccessLogPolicy"
+	ServiceNetworkLogPolicyTagKey = aws.TagBase + "ServiceNetworkLogPolicy"
+)
 
 type SourceType string
 
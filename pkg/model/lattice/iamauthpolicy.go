@@ -1,7 +1,11 @@
 package lattice
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"reflect"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
@@ -12,15 +16,144 @@ type IAMAuthPolicy struct {
 	Name       string
 	ResourceId string
 	Policy     string
+	// Hash of the policy document last applied to ResourceId, see Applied
+	LastAppliedHash string
 }
 
 type IAMAuthPolicyStatus struct {
 	ResourceId string
 }
 
+const defaultIAMPolicyVersion = "2012-10-17"
+
+// Hash of the policy document, kept on the IAMAuthPolicy to tell whether the document changed since it was applied.
+func IAMAuthPolicyHash(policy string) string {
+	sum := sha256.Sum256([]byte(policy))
+	return hex.EncodeToString(sum[:])
+}
+
+// Applied returns whether the policy document was last applied to the resource unchanged.
+func (p IAMAuthPolicy) Applied(resourceId string) bool {
+	return p.LastAppliedHash != "" && p.ResourceId == resourceId && p.LastAppliedHash == IAMAuthPolicyHash(p.Policy)
+}
+
 func NewIAMAuthPolicy(k8sPolicy *anv1alpha1.IAMAuthPolicy) IAMAuthPolicy {
+	return newIAMAuthPolicy(k8sPolicy, k8sPolicy.Spec.Policy)
+}
+
+// Builds the auth policy of the first policy's targetRef from the statements of all given policies,
+// see MergeIAMAuthPolicyDocuments.
+func NewMergedIAMAuthPolicy(k8sPolicies []*anv1alpha1.IAMAuthPolicy) (IAMAuthPolicy, error) {
+	docs := make([]string, len(k8sPolicies))
+	for i, k8sPolicy := range k8sPolicies {
+		docs[i] = k8sPolicy.Spec.Policy
+	}
+	policy, err := MergeIAMAuthPolicyDocuments(docs)
+	if err != nil {
+		return IAMAuthPolicy{}, err
+	}
+	return newIAMAuthPolicy(k8sPolicies[0], policy), nil
+}
+
+// Merges the Statement arrays of the policy documents into a single document, keeping the order of the
+// documents and their statements. Identical statements are included once, and statements with the same
+// Sid but different content are rejected. Version is taken from the first document that has one.
+func MergeIAMAuthPolicyDocuments(docs []string) (string, error) {
+	version := ""
+	statements := []map[string]interface{}{}
+	sids := map[string]struct{}{}
+	for i, doc := range docs {
+		var parsed struct {
+			Version   string
+			Statement json.RawMessage
+		}
+		if err := json.Unmarshal([]byte(doc), &parsed); err != nil {
+			return "", fmt.Errorf("invalid policy document %d: %w", i, err)
+		}
+		if version == "" {
+			version = parsed.Version
+		}
+		docStatements, err := parseStatements(parsed.Statement)
+		if err != nil {
+			return "", fmt.Errorf("invalid Statement of policy document %d: %w", i, err)
+		}
+		for _, statement := range docStatements {
+			if containsStatement(statements, statement) {
+				continue
+			}
+			if sid, ok := statement["Sid"].(string); ok && sid != "" {
+				if _, exists := sids[sid]; exists {
+					return "", fmt.Errorf("duplicate statement Sid %s with different content", sid)
+				}
+				sids[sid] = struct{}{}
+			}
+			statements = append(statements, statement)
+		}
+	}
+	if version == "" {
+		version = defaultIAMPolicyVersion
+	}
+	merged, err := json.Marshal(map[string]interface{}{
+		"Version":   version,
+		"Statement": statements,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(merged), nil
+}
+
+// Returns the statements of the policy document.
+func IAMAuthPolicyStatements(doc string) ([]map[string]interface{}, error) {
+	var parsed struct {
+		Statement json.RawMessage
+	}
+	if err := json.Unmarshal([]byte(doc), &parsed); err != nil {
+		return nil, err
+	}
+	return parseStatements(parsed.Statement)
+}
+
+// Statement is either a single statement object or an array of them
+func parseStatements(raw json.RawMessage) ([]map[string]interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var statements []map[string]interface{}
+	if err := json.Unmarshal(raw, &statements); err == nil {
+		return statements, nil
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(raw, &statement); err != nil {
+		return nil, err
+	}
+	return []map[string]interface{}{statement}, nil
+}
+
+func containsStatement(statements []map[string]interface{}, statement map[string]interface{}) bool {
+	for _, s := range statements {
+		if reflect.DeepEqual(s, statement) {
+			return true
+		}
+	}
+	return false
+}
+
+// IAMAuthPolicyResourceType returns the type of the Lattice resource a policy targeting the kind is applied to,
+// or empty string for kinds a policy cannot target.
+func IAMAuthPolicyResourceType(kind string) string {
+	switch kind {
+	case "Gateway":
+		return ServiceNetworkType
+	case "HTTPRoute", "GRPCRoute":
+		return ServiceType
+	default:
+		return ""
+	}
+}
+
+func newIAMAuthPolicy(k8sPolicy *anv1alpha1.IAMAuthPolicy, policy string) IAMAuthPolicy {
 	kind := k8sPolicy.Spec.TargetRef.Kind
-	policy := k8sPolicy.Spec.Policy
 	switch kind {
 	case "Gateway":
 		return IAMAuthPolicy{
@@ -0,0 +1,280 @@
+package lattice
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The JSON schema of the policy documents of IAMAuthPolicies. Only the keywords it uses are supported by
+// jsonSchema: $ref, type, enum, pattern, properties, required, additionalProperties, propertyNames, items, minItems
+// and anyOf. A description, when set, is used in the violation messages of enum and pattern.
+//
+//go:embed iamauthpolicy_schema.json
+var iamAuthPolicySchemaJSON []byte
+
+var iamAuthPolicySchema = mustParseJSONSchema(iamAuthPolicySchemaJSON)
+
+// ValidateIAMAuthPolicyDocument validates the policy document against the schema of VPC Lattice auth policies, e.g.
+// known actions, condition operators and condition keys. The error lists every violation with its location
+// in the document, e.g. Statement[0].Effect.
+func ValidateIAMAuthPolicyDocument(doc string) error {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(doc), &parsed); err != nil {
+		return fmt.Errorf("invalid policy document: %w", err)
+	}
+	violations := iamAuthPolicySchema.validate("", parsed)
+	if len(violations) > 0 {
+		return fmt.Errorf("invalid policy document: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Description          string                 `json:"description"`
+	Type                 string                 `json:"type"`
+	Enum                 []interface{}          `json:"enum"`
+	Pattern              string                 `json:"pattern"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties"`
+	PropertyNames        *jsonSchema            `json:"propertyNames"`
+	Items                *jsonSchema            `json:"items"`
+	MinItems             int                    `json:"minItems"`
+	AnyOf                []*jsonSchema          `json:"anyOf"`
+	Definitions          map[string]*jsonSchema `json:"definitions"`
+
+	// false schema, e.g. "additionalProperties": false, matched by no value
+	never   bool
+	pattern *regexp.Regexp
+	root    *jsonSchema
+}
+
+func (s *jsonSchema) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		s.never = !b
+		return nil
+	}
+	type plain jsonSchema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+func mustParseJSONSchema(data []byte) *jsonSchema {
+	root := &jsonSchema{}
+	if err := json.Unmarshal(data, root); err != nil {
+		panic(fmt.Sprintf("invalid JSON schema: %s", err))
+	}
+	if err := root.compile(root); err != nil {
+		panic(fmt.Sprintf("invalid JSON schema: %s", err))
+	}
+	return root
+}
+
+func (s *jsonSchema) compile(root *jsonSchema) error {
+	if s == nil {
+		return nil
+	}
+	s.root = root
+	if s.Ref != "" {
+		if _, err := s.resolve(); err != nil {
+			return err
+		}
+	}
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.pattern = pattern
+	}
+	children := []*jsonSchema{s.AdditionalProperties, s.PropertyNames, s.Items}
+	children = append(children, s.AnyOf...)
+	for _, child := range s.Properties {
+		children = append(children, child)
+	}
+	for _, child := range s.Definitions {
+		children = append(children, child)
+	}
+	for _, child := range children {
+		if err := child.compile(root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve returns the schema s refers to, only local references to definitions are supported
+func (s *jsonSchema) resolve() (*jsonSchema, error) {
+	if s.Ref == "" {
+		return s, nil
+	}
+	name, ok := strings.CutPrefix(s.Ref, "#/definitions/")
+	if !ok || s.root.Definitions[name] == nil {
+		return nil, fmt.Errorf("unknown $ref %s", s.Ref)
+	}
+	return s.root.Definitions[name].resolve()
+}
+
+// validate returns the violations of the value at path
+func (s *jsonSchema) validate(path string, value interface{}) []string {
+	s, _ = s.resolve()
+	if s.never {
+		return []string{fmt.Sprintf("%s is not allowed", location(path))}
+	}
+	if s.Type != "" && jsonType(value) != s.Type {
+		return []string{fmt.Sprintf("%s must be %s %s, not %s", location(path), article(s.Type), s.Type, jsonType(value))}
+	}
+	if len(s.AnyOf) > 0 {
+		return s.validateAnyOf(path, value)
+	}
+
+	var violations []string
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		violations = append(violations, fmt.Sprintf("%s %s must be %s", location(path), quote(value), s.expected()))
+	}
+	if str, ok := value.(string); ok && s.pattern != nil && !s.pattern.MatchString(str) {
+		violations = append(violations, fmt.Sprintf("%s %s must be %s", location(path), quote(value), s.expected()))
+	}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		violations = append(violations, s.validateObject(path, typed)...)
+	case []interface{}:
+		if len(typed) < s.MinItems {
+			violations = append(violations, fmt.Sprintf("%s must have at least %d items", location(path), s.MinItems))
+		}
+		if s.Items != nil {
+			for i, item := range typed {
+				violations = append(violations, s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item)...)
+			}
+		}
+	}
+	return violations
+}
+
+func (s *jsonSchema) validateObject(path string, object map[string]interface{}) []string {
+	var violations []string
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			violations = append(violations, fmt.Sprintf("%s is missing %s", location(path), name))
+		}
+	}
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propertyPath := name
+		if path != "" {
+			propertyPath = path + "." + name
+		}
+		if s.PropertyNames != nil {
+			if nameViolations := s.PropertyNames.validate(path, name); len(nameViolations) > 0 {
+				violations = append(violations, fmt.Sprintf("%s has an unknown key %q, keys must be %s",
+					location(path), name, s.PropertyNames.expected()))
+				continue
+			}
+		}
+		if property, ok := s.Properties[name]; ok {
+			violations = append(violations, property.validate(propertyPath, object[name])...)
+			continue
+		}
+		if s.AdditionalProperties != nil {
+			if s.AdditionalProperties.never {
+				violations = append(violations, fmt.Sprintf("%s has an unknown key %q", location(path), name))
+				continue
+			}
+			violations = append(violations, s.AdditionalProperties.validate(propertyPath, object[name])...)
+		}
+	}
+	return violations
+}
+
+// validateAnyOf reports the violations of the alternative of the same type as the value, the value is of none of
+// their types otherwise
+func (s *jsonSchema) validateAnyOf(path string, value interface{}) []string {
+	var types []string
+	var sameType *jsonSchema
+	for _, alternative := range s.AnyOf {
+		alternative, _ = alternative.resolve()
+		violations := alternative.validate(path, value)
+		if len(violations) == 0 {
+			return nil
+		}
+		if alternative.Type == jsonType(value) && sameType == nil {
+			sameType = alternative
+		}
+		if alternative.Type != "" {
+			types = append(types, article(alternative.Type)+" "+alternative.Type)
+		}
+	}
+	if sameType != nil {
+		return sameType.validate(path, value)
+	}
+	return []string{fmt.Sprintf("%s must be %s, not %s", location(path), strings.Join(types, " or "), jsonType(value))}
+}
+
+// expected describes the values matching an enum or a pattern
+func (s *jsonSchema) expected() string {
+	if s.Description != "" {
+		return s.Description
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			values[i] = quote(v)
+		}
+		return "one of " + strings.Join(values, ", ")
+	}
+	return "matching " + s.Pattern
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	default:
+		return "null"
+	}
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func location(path string) string {
+	if path == "" {
+		return "policy"
+	}
+	return path
+}
+
+func quote(value interface{}) string {
+	b, _ := json.Marshal(value)
+	return string(b)
+}
+
+func article(word string) string {
+	if strings.ContainsAny(word[:1], "aeiou") {
+		return "an"
+	}
+	return "a"
+}
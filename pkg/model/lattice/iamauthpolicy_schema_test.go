@@ -0,0 +1,103 @@
+package lattice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIAMAuthPolicyDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			doc: `{"Version":"2012-10-17","Statement":[{"Sid":"AllowOrg","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root"]},
+				"Action":"vpc-lattice-svcs:Invoke","Resource":"arn:aws:vpc-lattice:us-west-2:123456789012:service/svc-0123456789abcdef/*",
+				"Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-123","vpc-lattice-svcs:RequestMethod":["GET","HEAD"]},
+				"ForAnyValue:StringLikeIfExists":{"vpc-lattice-svcs:RequestHeader/x-team":"ops*"},"Bool":{"aws:SecureTransport":true}}}]}`,
+		},
+		{
+			name: "single statement and wildcards",
+			doc:  `{"Statement":{"Effect":"Deny","NotPrincipal":"*","NotAction":["vpc-lattice-svcs:*"],"Resource":"*"}}`,
+		},
+		{
+			name: "empty",
+			doc:  `{}`,
+		},
+		{
+			name:    "not JSON",
+			doc:     `{"Statement":`,
+			wantErr: "invalid policy document: unexpected end of JSON input",
+		},
+		{
+			name:    "unknown top level key",
+			doc:     `{"Statements":[]}`,
+			wantErr: `invalid policy document: policy has an unknown key "Statements"`,
+		},
+		{
+			name:    "unknown version",
+			doc:     `{"Version":"2024-01-01"}`,
+			wantErr: `invalid policy document: Version "2024-01-01" must be one of "2012-10-17", "2008-10-17"`,
+		},
+		{
+			name:    "missing effect",
+			doc:     `{"Statement":[{"Principal":"*","Action":"*","Resource":"*"}]}`,
+			wantErr: "invalid policy document: Statement[0] is missing Effect",
+		},
+		{
+			name:    "effect of the wrong case",
+			doc:     `{"Statement":[{"Effect":"allow","Principal":"*","Action":"*","Resource":"*"}]}`,
+			wantErr: `invalid policy document: Statement[0].Effect "allow" must be one of "Allow", "Deny"`,
+		},
+		{
+			name:    "action of another service",
+			doc:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":["vpc-lattice-svcs:Invoke","vpc-lattice:Invoke"],"Resource":"*"}]}`,
+			wantErr: `invalid policy document: Statement[0].Action[1] "vpc-lattice:Invoke" must be a VPC Lattice action, e.g. vpc-lattice-svcs:Invoke, or *`,
+		},
+		{
+			name:    "unknown condition key",
+			doc:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*","Condition":{"StringEquals":{"vpc-lattice-svcs:Method":"GET"}}}]}`,
+			wantErr: `invalid policy document: Statement[0].Condition.StringEquals has an unknown key "vpc-lattice-svcs:Method", keys must be a condition key of VPC Lattice auth policies`,
+		},
+		{
+			name:    "unknown condition operator",
+			doc:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*","Condition":{"StringEqual":{"aws:PrincipalOrgID":"o-123"}}}]}`,
+			wantErr: `invalid policy document: Statement[0].Condition has an unknown key "StringEqual", keys must be an IAM condition operator, e.g. StringEquals`,
+		},
+		{
+			name:    "resource of another service",
+			doc:     `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"arn:aws:s3:::bucket"}]}`,
+			wantErr: `invalid policy document: Statement[0].Resource "arn:aws:s3:::bucket" must be a VPC Lattice ARN`,
+		},
+		{
+			name:    "principal of the wrong type",
+			doc:     `{"Statement":[{"Effect":"Allow","Principal":["*"],"Action":"*","Resource":"*"}]}`,
+			wantErr: "invalid policy document: Statement[0].Principal must be a string or an object, not array",
+		},
+		{
+			name:    "unknown principal type",
+			doc:     `{"Statement":[{"Effect":"Allow","Principal":{"User":"alice"},"Action":"*","Resource":"*"}]}`,
+			wantErr: `invalid policy document: Statement[0].Principal has an unknown key "User"`,
+		},
+		{
+			name:    "every violation is reported",
+			doc:     `{"Statement":[{"Sid":"allow-all","Effect":"Allow","Principal":"*","Action":[],"Resource":"*"},{"Effect":"Deny","Principal":"*","Action":"*","Resources":"*"}]}`,
+			wantErr: `invalid policy document: Statement[0].Action must have at least 1 items; Statement[0].Sid "allow-all" must be alphanumeric; Statement[1] has an unknown key "Resources"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIAMAuthPolicyDocument(tt.doc)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
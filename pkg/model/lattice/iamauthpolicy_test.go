@@ -0,0 +1,68 @@
+package lattice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeIAMAuthPolicyDocuments(t *testing.T) {
+	tests := []struct {
+		name      string
+		docs      []string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name: "statements of two policies",
+			docs: []string{
+				`{"Version":"2012-10-17","Statement":[{"Sid":"a","Effect":"Allow","Principal":"*","Action":"vpc-lattice-svcs:Invoke","Resource":"*"}]}`,
+				`{"Statement":{"Sid":"b","Effect":"Deny","Principal":"*","Action":"*","Resource":"*"}}`,
+			},
+			expected: `{"Statement":[` +
+				`{"Action":"vpc-lattice-svcs:Invoke","Effect":"Allow","Principal":"*","Resource":"*","Sid":"a"},` +
+				`{"Action":"*","Effect":"Deny","Principal":"*","Resource":"*","Sid":"b"}],` +
+				`"Version":"2012-10-17"}`,
+		},
+		{
+			name: "identical statements are merged",
+			docs: []string{
+				`{"Statement":[{"Sid":"a","Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`,
+				`{"Version":"2012-10-17","Statement":[{"Sid":"a","Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`,
+			},
+			expected: `{"Statement":[{"Action":"*","Effect":"Allow","Principal":"*","Resource":"*","Sid":"a"}],"Version":"2012-10-17"}`,
+		},
+		{
+			name: "default version",
+			docs: []string{
+				`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`,
+			},
+			expected: `{"Statement":[{"Action":"*","Effect":"Allow","Principal":"*","Resource":"*"}],"Version":"2012-10-17"}`,
+		},
+		{
+			name: "same Sid with different content",
+			docs: []string{
+				`{"Statement":[{"Sid":"a","Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`,
+				`{"Statement":[{"Sid":"a","Effect":"Deny","Principal":"*","Action":"*","Resource":"*"}]}`,
+			},
+			expectErr: true,
+		},
+		{
+			name:      "invalid json",
+			docs:      []string{`{"Statement":`},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := MergeIAMAuthPolicyDocuments(tt.docs)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, merged)
+		})
+	}
+}
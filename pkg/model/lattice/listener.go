@@ -8,6 +8,11 @@ import (
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 )
 
+const (
+	MinListenerPort = 1
+	MaxListenerPort = 65535
+)
+
 var validListenerProtocols = map[string]struct{}{
 	"HTTP":            {},
 	"HTTPS":           {},
@@ -65,6 +70,9 @@ func (spec *ListenerSpec) Validate() error {
 	if _, exists := validListenerProtocols[spec.Protocol]; !exists {
 		return fmt.Errorf("invalid listener protocol %s", spec.Protocol)
 	}
+	if !IsValidListenerPort(spec.Port) {
+		return fmt.Errorf("invalid listener port %d, must be between %d and %d", spec.Port, MinListenerPort, MaxListenerPort)
+	}
 	if spec.DefaultAction == nil {
 		return fmt.Errorf("listener default action is required")
 	}
@@ -81,3 +89,7 @@ func (spec *ListenerSpec) Validate() error {
 	}
 	return nil
 }
+
+func IsValidListenerPort(port int64) bool {
+	return port >= MinListenerPort && port <= MaxListenerPort
+}
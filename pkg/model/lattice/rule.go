@@ -1,6 +1,7 @@
 package lattice
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go/service/vpclattice"
@@ -20,6 +21,19 @@ const (
 	DefaultActionFixedResponseStatusCode = 404
 )
 
+// RuleLimitExceededError is returned when a listener would have more rules than VPC Lattice allows.
+type RuleLimitExceededError struct {
+	Rules int
+	Limit int
+}
+
+func (e *RuleLimitExceededError) Error() string {
+	if e.Rules == 0 {
+		return fmt.Sprintf("listener reached the VPC Lattice limit of %d rules", e.Limit)
+	}
+	return fmt.Sprintf("route has %d rules, exceeding the VPC Lattice limit of %d rules per listener", e.Rules, e.Limit)
+}
+
 type RuleSpec struct {
 	StackListenerId string                   `json:"stacklistenerid"`
 	PathMatchValue  string                   `json:"pathmatchvalue"`
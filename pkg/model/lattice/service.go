@@ -18,6 +18,13 @@ type ServiceSpec struct {
 	ServiceNetworkNames []string `json:"servicenetworkhnames"`
 	CustomerDomainName  string   `json:"customerdomainname"`
 	CustomerCertARN     string   `json:"customercertarn"`
+	// Tags from spec.infrastructure of the parent Gateway
+	InfrastructureTags services.Tags `json:"infrastructuretags,omitempty"`
+	// Tag selectors of the service networks, by their name in ServiceNetworkNames, whose parent Gateway selects
+	// its service network by tags rather than by name
+	ServiceNetworkSelectors map[string]map[string]string `json:"servicenetworkselectors,omitempty"`
+	// Maintenance window of the route, outside of it listeners are not replaced
+	MaintenanceWindow string `json:"maintenancewindow,omitempty"`
 }
 
 type ServiceStatus struct {
@@ -1,6 +1,12 @@
 package lattice
 
 import (
+	"strings"
+
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 )
 
@@ -31,6 +37,16 @@ type ServiceNetworkStatus struct {
 	SnvaSecurityGroupIds []*string `json:"securityGroupIds"`
 }
 
+// VpcAssociationSwitchoverPhase describes the progress of moving the cluster VPC association
+// from one service network to another.
+type VpcAssociationSwitchoverPhase string
+
+const (
+	VpcAssociationSwitchoverAssociating    VpcAssociationSwitchoverPhase = "Associating"
+	VpcAssociationSwitchoverDisassociating VpcAssociationSwitchoverPhase = "Disassociating"
+	VpcAssociationSwitchoverCompleted      VpcAssociationSwitchoverPhase = "Completed"
+)
+
 func NewServiceNetwork(stack core.Stack, id string, spec ServiceNetworkSpec) *ServiceNetwork {
 
 	servicenetwork := &ServiceNetwork{
@@ -46,3 +62,24 @@ func NewServiceNetwork(stack core.Stack, id string, spec ServiceNetworkSpec) *Se
 	return servicenetwork
 
 }
+
+// GatewayInfrastructureTags returns the tags of Lattice resources provisioned for a Gateway, from the
+// labels and annotations of its spec.infrastructure. Annotations win over labels of the same key.
+// Keys reserved for the controller or AWS are skipped.
+func GatewayInfrastructureTags(infra *gwv1.GatewayInfrastructure) services.Tags {
+	if infra == nil {
+		return nil
+	}
+	tags := services.Tags{}
+	for _, kvs := range []map[gwv1.AnnotationKey]gwv1.AnnotationValue{infra.Labels, infra.Annotations} {
+		for k, v := range kvs {
+			key := string(k)
+			if strings.HasPrefix(key, pkg_aws.TagBase) || strings.HasPrefix(strings.ToLower(key), "aws:") {
+				continue
+			}
+			value := string(v)
+			tags[key] = &value
+		}
+	}
+	return tags
+}
@@ -43,6 +43,9 @@ type TargetGroupSpec struct {
 	ProtocolVersion   string                        `json:"protocolversion"`
 	IpAddressType     string                        `json:"ipaddresstype"`
 	HealthCheckConfig *vpclattice.HealthCheckConfig `json:"healthcheckconfig"`
+	ReconcileMode     ReconcileMode                 `json:"reconcilemode"`
+	// Maintenance window of the route, outside of it target groups are not recreated
+	MaintenanceWindow string `json:"maintenancewindow,omitempty"`
 	TargetGroupTagFields
 }
 type TargetGroupTagFields struct {
@@ -65,6 +68,16 @@ type TargetGroupType string
 type K8SSourceType string
 type RouteType string
 
+// ReconcileMode decides how fields of existing target groups that are not set by the model are reconciled
+type ReconcileMode string
+
+const (
+	// Fields not set by the model are reset to the controller defaults.
+	ReconcileModeStrict ReconcileMode = "strict"
+	// Fields not set by the model keep their current value, e.g. one edited outside of the controller.
+	ReconcileModeAdditive ReconcileMode = "additive"
+)
+
 const (
 	TargetGroupTypeIP TargetGroupType = "IP"
 
@@ -72,6 +85,7 @@ const (
 	SourceTypeHTTPRoute K8SSourceType = "HTTPRoute"
 	SourceTypeGRPCRoute K8SSourceType = "GRPCRoute"
 	SourceTypeTLSRoute  K8SSourceType = "TLSRoute"
+	SourceTypePrewarm   K8SSourceType = "Prewarm"
 	SourceTypeInvalid   K8SSourceType = "INVALID"
 )
 
@@ -97,7 +111,7 @@ func TagsFromTGTagFields(tagFields TargetGroupTagFields) map[string]*string {
 		K8SSourceTypeKey:       &st,
 		K8SProtocolVersionKey:  &tagFields.K8SProtocolVersion,
 	}
-	if tagFields.K8SSourceType != SourceTypeSvcExport {
+	if tagFields.K8SSourceType != SourceTypeSvcExport && tagFields.K8SSourceType != SourceTypePrewarm {
 		tags[K8SRouteNameKey] = &tagFields.K8SRouteName
 		tags[K8SRouteNamespaceKey] = &tagFields.K8SRouteNamespace
 	}
@@ -126,6 +140,8 @@ func GetParentRefType(s string) K8SSourceType {
 		return SourceTypeTLSRoute
 	case string(SourceTypeSvcExport):
 		return SourceTypeSvcExport
+	case string(SourceTypePrewarm):
+		return SourceTypePrewarm
 	default:
 		return SourceTypeInvalid
 	}
@@ -160,6 +176,10 @@ func (t *TargetGroupTagFields) IsSourceTypeServiceExport() bool {
 	return t.K8SSourceType == SourceTypeSvcExport
 }
 
+func (t *TargetGroupTagFields) IsSourceTypePrewarm() bool {
+	return t.K8SSourceType == SourceTypePrewarm
+}
+
 func (t *TargetGroupTagFields) IsSourceTypeRoute() bool {
 	return t.K8SSourceType == SourceTypeHTTPRoute ||
 		t.K8SSourceType == SourceTypeGRPCRoute ||
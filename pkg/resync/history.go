@@ -0,0 +1,116 @@
+package resync
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	// MaxReconcileHistory bounds the number of reconciles kept in the annotation, which counts towards the
+	// size limit of the annotations of the object
+	MaxReconcileHistory = 10
+
+	maxReconcileErrorLength = 256
+	reconcileHistoryTimeout = 10 * time.Second
+
+	reconcileResultSuccess = "Success"
+	reconcileResultError   = "Error"
+)
+
+type reconcileEntry struct {
+	Time   string `json:"time"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ReconcileHistory records the outcomes of the last reconciles of every object of the kinds registered with
+// the resyncer in its k8s.ReconcileHistoryAnnotation, oldest first, e.g. to see why a route failed
+// to reconcile with kubectl rather than the controller logs.
+type ReconcileHistory struct {
+	log      gwlog.Logger
+	resyncer *Resyncer
+	client   client.Client
+	// reads the latest annotations of the object, not the ones of the cache which may miss the last recorded reconcile
+	reader client.Reader
+	size   int
+	now    func() time.Time
+}
+
+func NewReconcileHistory(log gwlog.Logger, resyncer *Resyncer, client client.Client, reader client.Reader, size int) *ReconcileHistory {
+	return &ReconcileHistory{
+		log:      log,
+		resyncer: resyncer,
+		client:   client,
+		reader:   reader,
+		size:     size,
+		now:      time.Now,
+	}
+}
+
+// Observe records the result of a reconcile, see metrics.ReconcileObserver. Failures to record it are only logged,
+// they do not fail the reconcile.
+func (h *ReconcileHistory) Observe(kind string, req reconcile.Request, err error) {
+	// e.g. Services and Pods, which are reconciled to prewarm target groups and inject readiness gates
+	obj := h.resyncer.newObject(kind)
+	if obj == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileHistoryTimeout)
+	defer cancel()
+
+	if getErr := h.reader.Get(ctx, req.NamespacedName, obj); getErr != nil {
+		if !apierrors.IsNotFound(getErr) {
+			h.log.Debugf(ctx, "Failed to record reconcile of %s %s: %s", kind, req.NamespacedName, getErr)
+		}
+		return
+	}
+	patch := client.MergeFromWithOptions(obj.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[k8s.ReconcileHistoryAnnotation] = h.record(annotations[k8s.ReconcileHistoryAnnotation], err)
+	obj.SetAnnotations(annotations)
+	if patchErr := h.client.Patch(ctx, obj, patch); patchErr != nil {
+		h.log.Debugf(ctx, "Failed to record reconcile of %s %s: %s", kind, req.NamespacedName, patchErr)
+	}
+}
+
+// record appends the result of a reconcile to the history, dropping the oldest entries beyond its size.
+// A history which is not valid, e.g. edited by hand, is replaced.
+func (h *ReconcileHistory) record(history string, err error) string {
+	var entries []reconcileEntry
+	if json.Unmarshal([]byte(history), &entries) != nil {
+		entries = nil
+	}
+	entry := reconcileEntry{
+		Time:   h.now().UTC().Format(time.RFC3339),
+		Result: reconcileResultSuccess,
+	}
+	if err != nil {
+		entry.Result = reconcileResultError
+		entry.Error = truncate(err.Error(), maxReconcileErrorLength)
+	}
+	entries = append(entries, entry)
+	if len(entries) > h.size {
+		entries = entries[len(entries)-h.size:]
+	}
+	b, _ := json.Marshal(entries)
+	return string(b)
+}
+
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+	return string(runes[:length-3]) + "..."
+}
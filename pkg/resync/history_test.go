@@ -0,0 +1,83 @@
+package resync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func reconcileHistory(t *testing.T, h *ReconcileHistory, name types.NamespacedName) []reconcileEntry {
+	route := &gwv1beta1.HTTPRoute{}
+	assert.Nil(t, h.reader.Get(context.TODO(), name, route))
+	var entries []reconcileEntry
+	assert.Nil(t, json.Unmarshal([]byte(route.Annotations[k8s.ReconcileHistoryAnnotation]), &entries))
+	return entries
+}
+
+func TestReconcileHistory_RollsOver(t *testing.T) {
+	r := newTestResyncer(t)
+	k8sClient := r.client.(client.Client)
+	h := NewReconcileHistory(gwlog.FallbackLogger, r, k8sClient, k8sClient, 3)
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "route-1"}}
+	for i := 0; i < 5; i++ {
+		var err error
+		if i%2 == 1 {
+			err = errors.New("service quota exceeded")
+		}
+		h.Observe("HTTPRoute", req, err)
+		now = now.Add(time.Minute)
+	}
+
+	assert.Equal(t, []reconcileEntry{
+		{Time: "2026-10-15T10:02:00Z", Result: "Success"},
+		{Time: "2026-10-15T10:03:00Z", Result: "Error", Error: "service quota exceeded"},
+		{Time: "2026-10-15T10:04:00Z", Result: "Success"},
+	}, reconcileHistory(t, h, req.NamespacedName))
+}
+
+func TestReconcileHistory_TruncatesErrors(t *testing.T) {
+	r := newTestResyncer(t)
+	k8sClient := r.client.(client.Client)
+	h := NewReconcileHistory(gwlog.FallbackLogger, r, k8sClient, k8sClient, 3)
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "route-1"}}
+	h.Observe("HTTPRoute", req, errors.New(strings.Repeat("x", 1000)))
+
+	entries := reconcileHistory(t, h, req.NamespacedName)
+	assert.Len(t, entries, 1)
+	assert.Len(t, entries[0].Error, maxReconcileErrorLength)
+	assert.True(t, strings.HasSuffix(entries[0].Error, "..."))
+}
+
+func TestReconcileHistory_ReplacesInvalidHistory(t *testing.T) {
+	h := &ReconcileHistory{size: 3, now: func() time.Time { return time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC) }}
+	assert.Equal(t, `[{"time":"2026-10-15T10:00:00Z","result":"Success"}]`, h.record("edited by hand", nil))
+}
+
+func TestReconcileHistory_IgnoresUnregisteredKindsAndDeletedObjects(t *testing.T) {
+	r := newTestResyncer(t)
+	k8sClient := r.client.(client.Client)
+	h := NewReconcileHistory(gwlog.FallbackLogger, r, k8sClient, k8sClient, 3)
+
+	h.Observe("Service", reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "route-1"}}, nil)
+	h.Observe("HTTPRoute", reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "deleted"}}, nil)
+
+	route := &gwv1beta1.HTTPRoute{}
+	assert.Nil(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "ns1", Name: "route-1"}, route))
+	assert.NotContains(t, route.Annotations, k8s.ReconcileHistoryAnnotation)
+}
@@ -0,0 +1,84 @@
+package resync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// ReconcilePath is followed by <kind>/<namespace>/<name> of the object to reconcile, e.g. /reconcile/HTTPRoute/default/my-route
+const ReconcilePath = "/reconcile/"
+
+var ErrUnknownKind = errors.New("kind is not reconciled by the controller")
+
+// Reconcile enqueues the named object of the given kind, e.g. HTTPRoute, for reconcile. Kinds are matched
+// case-insensitively. It blocks until the object is handed to its controllers or the context is cancelled.
+func (r *Resyncer) Reconcile(ctx context.Context, kind string, name types.NamespacedName) error {
+	r.lock.Lock()
+	var matched []registration
+	for _, k := range r.kinds {
+		if strings.EqualFold(k.kind, kind) {
+			matched = append(matched, k)
+		}
+	}
+	r.lock.Unlock()
+	if len(matched) == 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+
+	obj := matched[0].newObject()
+	if err := r.client.Get(ctx, name, obj); err != nil {
+		return err
+	}
+	for _, k := range matched {
+		select {
+		case k.events <- event.GenericEvent{Object: &requestedObject{Object: obj}}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	r.log.Infof(ctx, "Enqueued %s %s for reconcile", matched[0].kind, name)
+	return nil
+}
+
+// requestedObject marks the objects whose reconcile is requested by a user, rather than by a resync of all objects,
+// so that the controllers reconcile them before queued resyncs, see eventhandlers.PriorityGate
+type requestedObject struct {
+	client.Object
+}
+
+func (o *requestedObject) UserRequested() bool {
+	return true
+}
+
+// serveReconcile enqueues the object named by the request path on POST requests.
+func (r *Resyncer) serveReconcile(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, ReconcilePath), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		http.Error(w, "path must be "+ReconcilePath+"<kind>/<namespace>/<name>", http.StatusBadRequest)
+		return
+	}
+	kind, name := parts[0], types.NamespacedName{Namespace: parts[1], Name: parts[2]}
+	err := r.Reconcile(req.Context(), kind, name)
+	switch {
+	case err == nil:
+		fmt.Fprintf(w, "enqueued %s %s\n", kind, name)
+	case errors.Is(err, ErrUnknownKind), apierrors.IsNotFound(err):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		r.log.Errorf(req.Context(), "Reconcile of %s %s failed: %s", kind, name, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,81 @@
+package resync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestResyncer_Reconcile(t *testing.T) {
+	r := newTestResyncer(t)
+
+	err := r.Reconcile(context.TODO(), "httproute", types.NamespacedName{Namespace: "ns2", Name: "route-2"})
+	assert.Nil(t, err)
+	assert.Empty(t, drain(r.kinds[0].events))
+	assert.Equal(t, []string{"ns2/route-2"}, drain(r.kinds[1].events))
+	assert.Empty(t, drain(r.kinds[2].events))
+
+	err = r.Reconcile(context.TODO(), "HTTPRoute", types.NamespacedName{Namespace: "ns1", Name: "route-2"})
+	assert.NotNil(t, err)
+	assert.Empty(t, drain(r.kinds[1].events))
+
+	err = r.Reconcile(context.TODO(), "Service", types.NamespacedName{Namespace: "ns1", Name: "svc"})
+	assert.ErrorIs(t, err, ErrUnknownKind)
+}
+
+func TestResyncer_ServeReconcile(t *testing.T) {
+	r := newTestResyncer(t)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		wantCode int
+		want     []string
+	}{
+		{
+			name:     "enqueues the named object",
+			method:   http.MethodPost,
+			path:     ReconcilePath + "IAMAuthPolicy/ns1/policy",
+			wantCode: http.StatusOK,
+			want:     []string{"ns1/policy"},
+		},
+		{
+			name:     "get is not allowed",
+			method:   http.MethodGet,
+			path:     ReconcilePath + "IAMAuthPolicy/ns1/policy",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "missing name",
+			method:   http.MethodPost,
+			path:     ReconcilePath + "IAMAuthPolicy/ns1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "object not found",
+			method:   http.MethodPost,
+			path:     ReconcilePath + "IAMAuthPolicy/ns2/policy",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "unknown kind",
+			method:   http.MethodPost,
+			path:     ReconcilePath + "ConfigMap/ns1/policy",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.serveReconcile(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.want, drain(r.kinds[2].events))
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package resync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	Path = "/resync"
+
+	eventChannelBufferLength = 100
+	serverShutdownTimeout    = 5 * time.Second
+)
+
+type registration struct {
+	list   client.ObjectList
+	events chan event.GenericEvent
+	// kind of the list items, e.g. HTTPRoute, and constructor of an empty item
+	kind      string
+	newObject func() client.Object
+}
+
+// Resyncer enqueues every object of the registered kinds for reconcile on demand, e.g. after a controller
+// outage, instead of waiting for the periodic resync. Controllers register the kinds they manage with Source.
+type Resyncer struct {
+	log    gwlog.Logger
+	client client.Reader
+
+	lock  sync.Mutex
+	kinds []registration
+}
+
+func NewResyncer(log gwlog.Logger, client client.Reader) *Resyncer {
+	return &Resyncer{
+		log:    log,
+		client: client,
+	}
+}
+
+// Source returns the source of resync events for objects of the given list type.
+func (r *Resyncer) Source(list client.ObjectList) source.Source {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	events := make(chan event.GenericEvent, eventChannelBufferLength)
+	itemType := reflect.ValueOf(list).Elem().FieldByName("Items").Type().Elem()
+	r.kinds = append(r.kinds, registration{
+		list:   list,
+		events: events,
+		kind:   itemType.Name(),
+		newObject: func() client.Object {
+			return reflect.New(itemType).Interface().(client.Object)
+		},
+	})
+	return &source.Channel{Source: events}
+}
+
+// Resync enqueues all objects of the registered kinds and returns the number of enqueued objects.
+// It blocks until every object is handed to its controller or the context is cancelled.
+func (r *Resyncer) Resync(ctx context.Context) (int, error) {
+	r.lock.Lock()
+	kinds := append([]registration{}, r.kinds...)
+	r.lock.Unlock()
+
+	enqueued := 0
+	for _, kind := range kinds {
+		objs, err := r.list(ctx, kind)
+		if err != nil {
+			return enqueued, err
+		}
+		for _, obj := range objs {
+			select {
+			case kind.events <- event.GenericEvent{Object: obj}:
+				enqueued++
+			case <-ctx.Done():
+				return enqueued, ctx.Err()
+			}
+		}
+	}
+	r.log.Infof(ctx, "Enqueued %d objects for resync", enqueued)
+	return enqueued, nil
+}
+
+func (r *Resyncer) list(ctx context.Context, kind registration) ([]client.Object, error) {
+	list := kind.list.DeepCopyObject().(client.ObjectList)
+	if err := r.client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list %T: %w", list, err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	var objs []client.Object
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok {
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+// newObject returns an empty object of the registered kind, or nil when the kind is not registered
+func (r *Resyncer) newObject(kind string) client.Object {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, k := range r.kinds {
+		if k.kind == kind {
+			return k.newObject()
+		}
+	}
+	return nil
+}
+
+// ServeHTTP triggers a resync on POST requests.
+func (r *Resyncer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	enqueued, err := r.Resync(req.Context())
+	if err != nil {
+		r.log.Errorf(req.Context(), "Resync failed after enqueuing %d objects: %s", enqueued, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "enqueued %d objects\n", enqueued)
+}
+
+// Server returns a runnable serving the resync endpoint on the given address. It only runs on
+// the elected leader, as other replicas do not run controllers to consume the events.
+func (r *Resyncer) Server(addr string) manager.Runnable {
+	return r.server(addr, Path, r)
+}
+
+// ReconcileServer returns a runnable serving the reconcile endpoint on the given address, on the elected leader only.
+func (r *Resyncer) ReconcileServer(addr string) manager.Runnable {
+	return r.server(addr, ReconcilePath, http.HandlerFunc(r.serveReconcile))
+}
+
+func (r *Resyncer) server(addr string, path string, handler http.Handler) manager.Runnable {
+	return manager.RunnableFunc(func(ctx context.Context) error {
+		mux := http.NewServeMux()
+		mux.Handle(path, handler)
+		srv := &http.Server{Addr: addr, Handler: mux}
+
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+			defer cancel()
+			srv.Shutdown(shutdownCtx)
+		}()
+
+		r.log.Infof(ctx, "Serving endpoint on %s%s", addr, path)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	})
+}
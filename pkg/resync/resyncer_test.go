@@ -0,0 +1,85 @@
+package resync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func newTestResyncer(t *testing.T) *Resyncer {
+	ctx := context.TODO()
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	gwv1beta1.AddToScheme(scheme)
+	anv1alpha1.AddToScheme(scheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(scheme).Build()
+
+	objs := []client.Object{
+		&gwv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns1"}},
+		&gwv1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route-1", Namespace: "ns1"}},
+		&gwv1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route-2", Namespace: "ns2"}},
+		&anv1alpha1.IAMAuthPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "ns1"}},
+	}
+	for _, obj := range objs {
+		assert.Nil(t, k8sClient.Create(ctx, obj))
+	}
+
+	r := NewResyncer(gwlog.FallbackLogger, k8sClient)
+	r.Source(&gwv1beta1.GatewayList{})
+	r.Source(&gwv1beta1.HTTPRouteList{})
+	r.Source(&anv1alpha1.IAMAuthPolicyList{})
+	r.Source(&anv1alpha1.TargetGroupPolicyList{})
+	return r
+}
+
+func drain(events chan event.GenericEvent) []string {
+	var names []string
+	for {
+		select {
+		case e := <-events:
+			names = append(names, e.Object.GetNamespace()+"/"+e.Object.GetName())
+		default:
+			return names
+		}
+	}
+}
+
+func TestResyncer_Resync(t *testing.T) {
+	r := newTestResyncer(t)
+
+	enqueued, err := r.Resync(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, 4, enqueued)
+
+	assert.Equal(t, []string{"ns1/gw"}, drain(r.kinds[0].events))
+	assert.ElementsMatch(t, []string{"ns1/route-1", "ns2/route-2"}, drain(r.kinds[1].events))
+	assert.Equal(t, []string{"ns1/policy"}, drain(r.kinds[2].events))
+	assert.Empty(t, drain(r.kinds[3].events))
+}
+
+func TestResyncer_ServeHTTP(t *testing.T) {
+	r := newTestResyncer(t)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Empty(t, drain(r.kinds[0].events))
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "enqueued 4 objects\n", rec.Body.String())
+}
@@ -0,0 +1,126 @@
+package resync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type objectKey struct {
+	kind string
+	name types.NamespacedName
+}
+
+func (k objectKey) String() string {
+	return k.kind + " " + k.name.String()
+}
+
+// RunOnce reconciles every object of the kinds registered with the resyncer a single time and then stops the
+// controller, e.g. to validate the resources of a cluster in a CI pipeline. Reconcile results are passed to Observe,
+// the run fails when the last reconcile of any object failed, or when objects are not reconciled within the timeout.
+type RunOnce struct {
+	log      gwlog.Logger
+	resyncer *Resyncer
+	timeout  time.Duration
+	// stops the manager once all objects are reconciled
+	stop func()
+
+	lock    sync.Mutex
+	results map[objectKey]error
+	updated chan struct{}
+}
+
+func NewRunOnce(log gwlog.Logger, resyncer *Resyncer, timeout time.Duration, stop func()) *RunOnce {
+	return &RunOnce{
+		log:      log,
+		resyncer: resyncer,
+		timeout:  timeout,
+		stop:     stop,
+		results:  make(map[objectKey]error),
+		updated:  make(chan struct{}, 1),
+	}
+}
+
+// Observe records the result of a reconcile, see metrics.ReconcileObserver. Later results of an object replace
+// earlier ones, e.g. a retry succeeding after a conflict.
+func (o *RunOnce) Observe(kind string, req reconcile.Request, err error) {
+	o.lock.Lock()
+	o.results[objectKey{kind: kind, name: req.NamespacedName}] = err
+	o.lock.Unlock()
+
+	select {
+	case o.updated <- struct{}{}:
+	default:
+	}
+}
+
+// Start enqueues all objects of the registered kinds and waits for their reconcile. It returns an error naming
+// the objects whose reconcile failed, which stops the manager with that error, and stops the manager otherwise.
+func (o *RunOnce) Start(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	o.resyncer.lock.Lock()
+	kinds := append([]registration{}, o.resyncer.kinds...)
+	o.resyncer.lock.Unlock()
+
+	var expected []objectKey
+	for _, kind := range kinds {
+		objs, err := o.resyncer.list(ctx, kind)
+		if err != nil {
+			return err
+		}
+		for _, obj := range objs {
+			expected = append(expected, objectKey{kind: kind.kind, name: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}})
+		}
+	}
+	if _, err := o.resyncer.Resync(ctx); err != nil {
+		return err
+	}
+
+	for {
+		pending, failed := o.check(expected)
+		if len(pending) == 0 {
+			o.log.Infow(ctx, "Reconciled all objects once", "reconciled", len(expected), "failed", len(failed))
+			if len(failed) > 0 {
+				return fmt.Errorf("reconcile of %d of %d objects failed: %w", len(failed), len(expected), errors.Join(failed...))
+			}
+			o.stop()
+			return nil
+		}
+		select {
+		case <-o.updated:
+		case <-ctx.Done():
+			return fmt.Errorf("%d of %d objects were not reconciled within %s, e.g. %s",
+				len(pending), len(expected), o.timeout, pending[0])
+		}
+	}
+}
+
+// check returns the expected objects without reconcile result, and the errors of the failed ones
+func (o *RunOnce) check(expected []objectKey) ([]objectKey, []error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	var pending []objectKey
+	var failed []error
+	for _, key := range expected {
+		err, reconciled := o.results[key]
+		switch {
+		case !reconciled:
+			pending = append(pending, key)
+		case err != nil:
+			failed = append(failed, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i].Error() < failed[j].Error() })
+	return pending, failed
+}
@@ -0,0 +1,88 @@
+package resync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// reconcileEvents reconciles the objects of the resync events of a kind with the given reconcile function,
+// passing the results to the run as the controllers do
+func reconcileEvents(ctx context.Context, o *RunOnce, kind registration, reconcileFn func(types.NamespacedName) error) {
+	for {
+		select {
+		case e := <-kind.events:
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: e.Object.GetNamespace(), Name: e.Object.GetName()}}
+			o.Observe(kind.kind, req, reconcileFn(req.NamespacedName))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func startRunOnce(t *testing.T, timeout time.Duration, reconcileFn func(types.NamespacedName) error) (bool, error) {
+	r := newTestResyncer(t)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	var stopped bool
+	o := NewRunOnce(gwlog.FallbackLogger, r, timeout, func() { stopped = true })
+	for _, kind := range r.kinds {
+		go reconcileEvents(ctx, o, kind, reconcileFn)
+	}
+	err := o.Start(ctx)
+	return stopped, err
+}
+
+func TestRunOnce_AllSucceed(t *testing.T) {
+	stopped, err := startRunOnce(t, time.Minute, func(types.NamespacedName) error { return nil })
+	assert.Nil(t, err)
+	assert.True(t, stopped)
+}
+
+func TestRunOnce_FailingObject(t *testing.T) {
+	stopped, err := startRunOnce(t, time.Minute, func(name types.NamespacedName) error {
+		if name.Name == "route-2" {
+			return errors.New("service quota exceeded")
+		}
+		return nil
+	})
+	assert.ErrorContains(t, err, "reconcile of 1 of 4 objects failed")
+	assert.ErrorContains(t, err, "HTTPRoute ns2/route-2: service quota exceeded")
+	assert.False(t, stopped)
+}
+
+func TestRunOnce_LastResultCounts(t *testing.T) {
+	r := newTestResyncer(t)
+	o := NewRunOnce(gwlog.FallbackLogger, r, time.Minute, func() {})
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "route-1"}}
+	o.Observe("HTTPRoute", req, errors.New("conflict"))
+	o.Observe("HTTPRoute", req, nil)
+
+	pending, failed := o.check([]objectKey{{kind: "HTTPRoute", name: req.NamespacedName}})
+	assert.Empty(t, pending)
+	assert.Empty(t, failed)
+}
+
+func TestRunOnce_Timeout(t *testing.T) {
+	r := newTestResyncer(t)
+	o := NewRunOnce(gwlog.FallbackLogger, r, 50*time.Millisecond, func() {})
+	// events are handed to the controllers but never reconciled
+	for _, kind := range r.kinds {
+		go func(events chan event.GenericEvent) {
+			for range events {
+			}
+		}(kind.events)
+	}
+
+	err := o.Start(context.TODO())
+	assert.ErrorContains(t, err, "4 of 4 objects were not reconciled within 50ms")
+}
@@ -6,6 +6,9 @@ import (
 	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 )
 
 // HandleReconcileError will handle errors from reconcile handlers, which respects runtime errors.
@@ -14,6 +17,11 @@ func HandleReconcileError(err error) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	// checked first, as RetryError matches any error
+	if services.IsServerError(err) {
+		return ctrl.Result{RequeueAfter: config.ServerErrorRequeue}, nil
+	}
+
 	retryErr := NewRetryError()
 	if errors.As(err, &retryErr) {
 		return ctrl.Result{RequeueAfter: time.Second * 20}, nil
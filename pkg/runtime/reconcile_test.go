@@ -0,0 +1,110 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestHandleReconcileError(t *testing.T) {
+	serverErr := func(code string, status int) error {
+		return fmt.Errorf("failed to deploy: %w", awserr.NewRequestFailure(awserr.New(code, "failed", nil), status, "req-id"))
+	}
+
+	tests := []struct {
+		name        string
+		err         error
+		expectedRes ctrl.Result
+	}{
+		{
+			name: "no error",
+		},
+		{
+			name:        "internal server error",
+			err:         serverErr(vpclattice.ErrCodeInternalServerException, 500),
+			expectedRes: ctrl.Result{RequeueAfter: config.ServerErrorRequeue},
+		},
+		{
+			name:        "service unavailable",
+			err:         serverErr("ServiceUnavailable", 503),
+			expectedRes: ctrl.Result{RequeueAfter: config.ServerErrorRequeue},
+		},
+		{
+			name:        "throttling",
+			err:         serverErr(vpclattice.ErrCodeThrottlingException, 429),
+			expectedRes: ctrl.Result{RequeueAfter: time.Second * 20},
+		},
+		{
+			name:        "not found",
+			err:         serverErr(vpclattice.ErrCodeResourceNotFoundException, 404),
+			expectedRes: ctrl.Result{RequeueAfter: time.Second * 20},
+		},
+		{
+			name:        "other error",
+			err:         errors.New("ERROR"),
+			expectedRes: ctrl.Result{RequeueAfter: time.Second * 20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := HandleReconcileError(tt.err)
+			assert.Equal(t, tt.expectedRes, res)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+// A 5xx from Lattice is wrapped by the managers, the reconciler still backs off on it
+func TestHandleReconcileError_ServerErrorFromManager(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	mockLattice := services.NewMockLattice(c)
+	cfg := pkg_aws.CloudConfig{VpcId: "vpc-id", AccountId: "account-id"}
+	cl := pkg_aws.NewDefaultCloud(mockLattice, cfg)
+	m := lattice.NewServiceManager(gwlog.FallbackLogger, cl)
+
+	svc := &model.Service{
+		Spec: model.ServiceSpec{
+			ServiceTagFields: model.ServiceTagFields{
+				RouteName:      "svc",
+				RouteNamespace: "ns",
+				RouteType:      core.HttpRouteType,
+			},
+		},
+	}
+
+	mockLattice.EXPECT().
+		FindServices(gomock.Any(), gomock.Any()).
+		Return(nil, services.NewNotFoundError("", "")).
+		Times(1)
+	mockLattice.EXPECT().
+		CreateServiceWithContext(gomock.Any(), gomock.Any()).
+		Return(nil, awserr.NewRequestFailure(
+			awserr.New(vpclattice.ErrCodeInternalServerException, "internal error", nil), 500, "req-id")).
+		Times(1)
+
+	_, err := m.Upsert(context.Background(), svc)
+	assert.NotNil(t, err)
+
+	res, err := HandleReconcileError(err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: config.ServerErrorRequeue}, res)
+	assert.Nil(t, err)
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -60,10 +62,47 @@ func SliceFilter[T any](in []T, f FilterFunc[T]) []T {
 	return out
 }
 
+type ServiceNameStrategy string
+
+const (
+	// Route name and namespace are truncated to fit Lattice limits. Long names sharing a prefix may collide.
+	ServiceNameStrategyTruncate ServiceNameStrategy = "truncate"
+	// Route name and namespace are followed by a hash of both, so truncated names stay unique.
+	ServiceNameStrategyHash ServiceNameStrategy = "hash"
+
+	latticeServiceNameMaxLength = 40
+	serviceNameHashLength       = 8
+)
+
+// Strategy used by LatticeServiceName, set once on startup.
+var LatticeServiceNameStrategy = ServiceNameStrategyTruncate
+
+func ParseServiceNameStrategy(s string) (ServiceNameStrategy, error) {
+	switch strategy := ServiceNameStrategy(s); strategy {
+	case ServiceNameStrategyTruncate, ServiceNameStrategyHash:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid service name strategy %q, must be one of %q, %q",
+			s, ServiceNameStrategyTruncate, ServiceNameStrategyHash)
+	}
+}
+
 func LatticeServiceName(k8sSourceRouteName string, k8sSourceRouteNamespace string) string {
+	if LatticeServiceNameStrategy == ServiceNameStrategyHash {
+		return hashedLatticeServiceName(k8sSourceRouteName, k8sSourceRouteNamespace)
+	}
 	return fmt.Sprintf("%s-%s", Truncate(k8sSourceRouteName, 20), Truncate(k8sSourceRouteNamespace, 18))
 }
 
+// <name>-<namespace>-<hash>, where <name>-<namespace> is truncated to fit the Lattice length limit.
+// The hash covers the full name and namespace, "a-b/c" and "a/b-c" get different names.
+func hashedLatticeServiceName(name string, namespace string) string {
+	sum := sha256.Sum256([]byte(name + "/" + namespace))
+	hash := hex.EncodeToString(sum[:])[:serviceNameHashLength]
+	prefix := Truncate(fmt.Sprintf("%s-%s", name, namespace), latticeServiceNameMaxLength-serviceNameHashLength-1)
+	return fmt.Sprintf("%s-%s", prefix, hash)
+}
+
 func TargetRefToLatticeResourceName(
 	targetRef *gwv1alpha2.PolicyTargetReference,
 	parentNamespace string,
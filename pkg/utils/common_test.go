@@ -61,3 +61,50 @@ func TestSet(t *testing.T) {
 	})
 
 }
+
+func TestLatticeServiceName(t *testing.T) {
+	longName := "a-very-long-route-name-exceeding-limits"
+	longNamespace := "a-very-long-namespace-name"
+
+	t.Run("truncate strategy is the default", func(t *testing.T) {
+		assert.Equal(t, ServiceNameStrategyTruncate, LatticeServiceNameStrategy)
+		assert.Equal(t, "route-ns", LatticeServiceName("route", "ns"))
+		assert.Equal(t, "a-very-long-route-na-a-very-long-namesp", LatticeServiceName(longName, longNamespace))
+		// names sharing a prefix collide
+		assert.Equal(t, LatticeServiceName(longName+"-1", longNamespace), LatticeServiceName(longName+"-2", longNamespace))
+	})
+
+	t.Run("hash strategy", func(t *testing.T) {
+		LatticeServiceNameStrategy = ServiceNameStrategyHash
+		defer func() { LatticeServiceNameStrategy = ServiceNameStrategyTruncate }()
+
+		name := LatticeServiceName("route", "ns")
+		assert.Regexp(t, "^route-ns-[0-9a-f]{8}$", name)
+		assert.Equal(t, name, LatticeServiceName("route", "ns"))
+
+		long := LatticeServiceName(longName, longNamespace)
+		assert.Len(t, long, 40)
+		assert.Regexp(t, "^a-very-long-route-name-exceedin-[0-9a-f]{8}$", long)
+
+		// truncated names sharing a prefix stay unique
+		assert.NotEqual(t, LatticeServiceName(longName+"-1", longNamespace), LatticeServiceName(longName+"-2", longNamespace))
+		// the separator between name and namespace is ambiguous, the hash is not
+		assert.NotEqual(t, LatticeServiceName("a-b", "c"), LatticeServiceName("a", "b-c"))
+
+		// truncation does not leave a hyphen before the hash
+		assert.Regexp(t, "^route-name-cut-right-before-ns-[0-9a-f]{8}$", LatticeServiceName("route-name-cut-right-before-ns", "ns"))
+	})
+}
+
+func TestParseServiceNameStrategy(t *testing.T) {
+	strategy, err := ParseServiceNameStrategy("hash")
+	assert.Nil(t, err)
+	assert.Equal(t, ServiceNameStrategyHash, strategy)
+
+	strategy, err = ParseServiceNameStrategy("truncate")
+	assert.Nil(t, err)
+	assert.Equal(t, ServiceNameStrategyTruncate, strategy)
+
+	_, err = ParseServiceNameStrategy("template")
+	assert.NotNil(t, err)
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/tracing"
 )
 
 type key string
@@ -67,12 +71,22 @@ func GetTraceID(ctx context.Context) string {
 	return ""
 }
 
+// StartReconcileTrace also starts the root span of the reconcile, parent of the spans of the AWS API calls it makes.
 func StartReconcileTrace(ctx context.Context, log Logger, k8sresourcetype, name, namespace string) context.Context {
 	ctx = NewTrace(ctx)
 	AddMetadata(ctx, "type", k8sresourcetype)
 	AddMetadata(ctx, "name", name)
 	AddMetadata(ctx, "namespace", namespace)
 
+	ctx, _ = tracing.Tracer().Start(ctx, "reconcile "+k8sresourcetype,
+		trace.WithNewRoot(),
+		trace.WithAttributes(
+			attribute.String("k8s.resource.type", k8sresourcetype),
+			attribute.String("k8s.resource.name", name),
+			attribute.String("k8s.namespace.name", namespace),
+			attribute.String(traceID, GetTraceID(ctx)),
+		))
+
 	log.Infow(ctx, ReconcileStart, getMetadata(ctx)...)
 
 	return ctx
@@ -80,4 +94,5 @@ func StartReconcileTrace(ctx context.Context, log Logger, k8sresourcetype, name,
 
 func EndReconcileTrace(ctx context.Context, log Logger) {
 	log.Infow(ctx, ReconcileEnd, getMetadata(ctx)...)
+	trace.SpanFromContext(ctx).End()
 }
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxMaintenanceWindowDuration = 7 * 24 * time.Hour
+	// how far ahead the next window is looked for, a yearly window is the rarest one
+	maintenanceWindowLookahead = 366 * 24 * time.Hour
+)
+
+// MaintenanceWindow is a recurring window of time, in UTC, which starts on the minutes matching a cron schedule
+// and lasts for a duration.
+type MaintenanceWindow struct {
+	spec     string
+	minutes  cronField
+	hours    cronField
+	days     cronField
+	months   cronField
+	weekdays cronField
+	duration time.Duration
+}
+
+// a cron field, the values it matches, and whether it is restricted, i.e. not *
+type cronField struct {
+	values     map[int]bool
+	restricted bool
+}
+
+// ParseMaintenanceWindow parses a window made of the 5 fields of a cron schedule, minute, hour, day of month,
+// month and day of week, followed by the duration of the window, e.g. "0 2 * * 6 4h" for every Saturday from 02:00
+// to 06:00 UTC. Fields are *, a value, a range a-b, a step */n or a-b/n, or a comma-separated list of those.
+// Days of week go from 0, Sunday, to 6, 7 is Sunday as well.
+func ParseMaintenanceWindow(spec string) (*MaintenanceWindow, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("maintenance window %q must have 5 cron fields and a duration", spec)
+	}
+	w := &MaintenanceWindow{spec: spec}
+	var err error
+	parsers := []struct {
+		field    *cronField
+		name     string
+		min, max int
+	}{
+		{&w.minutes, "minute", 0, 59},
+		{&w.hours, "hour", 0, 23},
+		{&w.days, "day of month", 1, 31},
+		{&w.months, "month", 1, 12},
+		{&w.weekdays, "day of week", 0, 7},
+	}
+	for i, p := range parsers {
+		if *p.field, err = parseCronField(fields[i], p.min, p.max); err != nil {
+			return nil, fmt.Errorf("maintenance window %q has an invalid %s: %w", spec, p.name, err)
+		}
+	}
+	if w.weekdays.values[7] {
+		w.weekdays.values[0] = true
+	}
+
+	w.duration, err = time.ParseDuration(fields[5])
+	if err != nil {
+		return nil, fmt.Errorf("maintenance window %q has an invalid duration: %w", spec, err)
+	}
+	if w.duration < time.Minute || w.duration > maxMaintenanceWindowDuration {
+		return nil, fmt.Errorf("maintenance window %q must last between 1m and %s", spec, maxMaintenanceWindowDuration)
+	}
+	return w, nil
+}
+
+func parseCronField(field string, min, max int) (cronField, error) {
+	f := cronField{values: make(map[int]bool), restricted: field != "*"}
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return f, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+		low, high := min, max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = strconv.Atoi(bounds[0]); err != nil {
+				return f, fmt.Errorf("invalid value %q", part)
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = strconv.Atoi(bounds[1]); err != nil {
+					return f, fmt.Errorf("invalid value %q", part)
+				}
+			} else if step > 1 {
+				// a/n is a shorthand for a-max/n
+				high = max
+			}
+		}
+		if low < min || high > max || low > high {
+			return f, fmt.Errorf("%q is out of the range %d-%d", part, min, max)
+		}
+		for v := low; v <= high; v += step {
+			f.values[v] = true
+		}
+	}
+	return f, nil
+}
+
+// starts returns true when a window starts at the minute of t
+func (w *MaintenanceWindow) starts(t time.Time) bool {
+	if !w.minutes.values[t.Minute()] || !w.hours.values[t.Hour()] || !w.months.values[int(t.Month())] {
+		return false
+	}
+	dayMatch, weekdayMatch := w.days.values[t.Day()], w.weekdays.values[int(t.Weekday())]
+	// like cron, a schedule restricting both days of month and of week matches either of them
+	if w.days.restricted && w.weekdays.restricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
+
+// Contains returns true when t is within a window
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	t = t.UTC()
+	minute := t.Truncate(time.Minute)
+	for start := minute; t.Sub(start) < w.duration; start = start.Add(-time.Minute) {
+		if w.starts(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the start of the first window after t, the zero time when none starts within a year
+func (w *MaintenanceWindow) Next(t time.Time) time.Time {
+	t = t.UTC()
+	start := t.Truncate(time.Minute).Add(time.Minute)
+	for end := t.Add(maintenanceWindowLookahead); start.Before(end); start = start.Add(time.Minute) {
+		if w.starts(start) {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+func (w *MaintenanceWindow) String() string {
+	return w.spec
+}
+
+// InMaintenanceWindow returns true when disruptive changes are allowed at t by the window spec, always when no
+// window is set. Specs are validated when the annotation is read, an invalid one does not defer changes.
+func InMaintenanceWindow(spec string, t time.Time) bool {
+	if spec == "" {
+		return true
+	}
+	w, err := ParseMaintenanceWindow(spec)
+	if err != nil {
+		return true
+	}
+	return w.Contains(t)
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"0 2 * * 6 4h", false},
+		{"*/15 0-6 1,15 * 1-5 30m", false},
+		{"30 22 * 1-12/3 7 2h", false},
+		{"0 2 * * 6", true},
+		{"0 2 * * 6 4h extra", true},
+		{"60 2 * * 6 4h", true},
+		{"0 2 0 * * 4h", true},
+		{"0 2 * * 8 4h", true},
+		{"0 6-2 * * * 4h", true},
+		{"0 */0 * * * 4h", true},
+		{"0 2 * * sat 4h", true},
+		{"0 2 * * 6 always", true},
+		{"0 2 * * 6 30s", true},
+		{"0 2 * * 6 200h", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			w, err := ParseMaintenanceWindow(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.spec, w.String())
+		})
+	}
+}
+
+func TestMaintenanceWindow_ContainsAndNext(t *testing.T) {
+	// every Saturday from 02:00 to 06:00 UTC
+	w, err := ParseMaintenanceWindow("0 2 * * 6 4h")
+	assert.NoError(t, err)
+
+	saturday := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Saturday, saturday.Weekday())
+
+	assert.False(t, w.Contains(saturday.Add(time.Hour+59*time.Minute)))
+	assert.True(t, w.Contains(saturday.Add(2*time.Hour)))
+	assert.True(t, w.Contains(saturday.Add(5*time.Hour+59*time.Minute)))
+	assert.False(t, w.Contains(saturday.Add(6*time.Hour)))
+	// the window is in UTC
+	assert.True(t, w.Contains(saturday.Add(3*time.Hour).In(time.FixedZone("UTC-8", -8*60*60))))
+
+	assert.Equal(t, saturday.Add(2*time.Hour), w.Next(saturday))
+	assert.Equal(t, saturday.Add(7*24*time.Hour+2*time.Hour), w.Next(saturday.Add(2*time.Hour)))
+
+	// a window spanning midnight, which started the day before
+	w, err = ParseMaintenanceWindow("0 22 * * 5 4h")
+	assert.NoError(t, err)
+	assert.True(t, w.Contains(saturday.Add(time.Hour)))
+	assert.False(t, w.Contains(saturday.Add(2*time.Hour)))
+
+	// restricting both days of month and of week matches either of them
+	w, err = ParseMaintenanceWindow("0 0 15 * 0 1h")
+	assert.NoError(t, err)
+	assert.True(t, w.Contains(time.Date(2024, time.June, 15, 0, 30, 0, 0, time.UTC)))
+	assert.True(t, w.Contains(time.Date(2024, time.June, 2, 0, 30, 0, 0, time.UTC)))
+	assert.False(t, w.Contains(time.Date(2024, time.June, 3, 0, 30, 0, 0, time.UTC)))
+
+	// a date which never occurs
+	w, err = ParseMaintenanceWindow("0 0 31 2 * 1h")
+	assert.NoError(t, err)
+	assert.True(t, w.Next(saturday).IsZero())
+}
+
+func TestInMaintenanceWindow(t *testing.T) {
+	saturday := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, InMaintenanceWindow("", saturday))
+	assert.False(t, InMaintenanceWindow("0 2 * * 6 4h", saturday))
+	assert.True(t, InMaintenanceWindow("0 2 * * 6 4h", saturday.Add(3*time.Hour)))
+}
@@ -0,0 +1,19 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(context.TODO(), "")
+	assert.Nil(t, err)
+	assert.Nil(t, shutdown(context.TODO()))
+
+	for _, endpoint := range []string{"otel-collector:4317", "grpc://otel-collector:4317", "http://"} {
+		_, err := Setup(context.TODO(), endpoint)
+		assert.NotNil(t, err, endpoint)
+	}
+}
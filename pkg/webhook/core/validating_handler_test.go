@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type funcValidator struct {
+	validateCreate func(ctx context.Context, obj runtime.Object) error
+	validateUpdate func(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error
+}
+
+func (v *funcValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &corev1.Pod{}, nil
+}
+
+func (v *funcValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validateCreate(ctx, obj)
+}
+
+func (v *funcValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	return v.validateUpdate(ctx, obj, oldObj)
+}
+
+func Test_validatingHandler_Handle(t *testing.T) {
+	schema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(schema)
+
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"},
+	}
+	podRaw, err := json.Marshal(pod)
+	assert.NoError(t, err)
+	denied := func(msg string) admission.Response {
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Code:    http.StatusForbidden,
+					Message: msg,
+					Reason:  "Forbidden",
+				},
+			},
+		}
+	}
+	allowed := admission.Response{
+		AdmissionResponse: admissionv1.AdmissionResponse{
+			Allowed: true,
+			Result:  &metav1.Status{Code: http.StatusOK},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		operation admissionv1.Operation
+		err       error
+		want      admission.Response
+	}{
+		{
+			name:      "[create] allow request",
+			operation: admissionv1.Create,
+			want:      allowed,
+		},
+		{
+			name:      "[create] reject request",
+			operation: admissionv1.Create,
+			err:       errors.New("not allowed"),
+			want:      denied("not allowed"),
+		},
+		{
+			name:      "[update] allow request",
+			operation: admissionv1.Update,
+			want:      allowed,
+		},
+		{
+			name:      "[update] reject request",
+			operation: admissionv1.Update,
+			err:       errors.New("not allowed"),
+			want:      denied("not allowed"),
+		},
+		{
+			name:      "[delete] allow request",
+			operation: admissionv1.Delete,
+			err:       errors.New("not allowed"),
+			want:      allowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &funcValidator{
+				validateCreate: func(ctx context.Context, obj runtime.Object) error {
+					assert.Equal(t, "foo", obj.(*corev1.Pod).Name)
+					return tt.err
+				},
+				validateUpdate: func(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+					assert.Equal(t, "foo", obj.(*corev1.Pod).Name)
+					assert.Equal(t, "foo", oldObj.(*corev1.Pod).Name)
+					return tt.err
+				},
+			}
+			h := &validatingHandler{
+				log:       gwlog.FallbackLogger,
+				validator: validator,
+				decoder:   admission.NewDecoder(schema),
+			}
+			resp := h.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: tt.operation,
+					Object:    runtime.RawExtension{Raw: podRaw},
+					OldObject: runtime.RawExtension{Raw: podRaw},
+				},
+			})
+			assert.Equal(t, tt.want, resp)
+		})
+	}
+}
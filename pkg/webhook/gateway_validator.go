@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+	"github.com/aws/aws-application-networking-k8s/pkg/webhook/core"
+)
+
+const (
+	apiPathValidateGateway = "/validate-gateway"
+
+	// VPC Lattice service network names are at most 63 characters
+	serviceNetworkNameMaxLength = 63
+)
+
+// Rejects lattice Gateways whose names cannot be VPC Lattice service network names. The gateway
+// name is used as the service network name as is, while service and target group names derived
+// from routes and services are truncated to fit their limits by the controller.
+func NewGatewayValidator(log gwlog.Logger, scheme *runtime.Scheme, k8sClient client.Client) *gatewayValidator {
+	return &gatewayValidator{
+		log:       log,
+		scheme:    scheme,
+		k8sClient: k8sClient,
+	}
+}
+
+var _ core.Validator = &gatewayValidator{}
+
+type gatewayValidator struct {
+	log       gwlog.Logger
+	scheme    *runtime.Scheme
+	k8sClient client.Client
+}
+
+func (v *gatewayValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &gwv1beta1.Gateway{}, nil
+}
+
+func (v *gatewayValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	gw := obj.(*gwv1beta1.Gateway)
+	if config.ServiceNetworkOverrideMode || len(gw.Name) <= serviceNetworkNameMaxLength {
+		// the gateway is mapped to the default service network in override mode
+		return nil
+	}
+	if !v.isLatticeGateway(ctx, gw) {
+		return nil
+	}
+	return fmt.Errorf("gateway name %s is %d characters, VPC Lattice service network names are at most %d characters. Use a shorter name, e.g. %s",
+		gw.Name, len(gw.Name), serviceNetworkNameMaxLength, utils.Truncate(gw.Name, serviceNetworkNameMaxLength))
+}
+
+// Gateway names are immutable
+func (v *gatewayValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	return nil
+}
+
+// Gateways of unknown classes are not lattice gateways, they might belong to another controller
+func (v *gatewayValidator) isLatticeGateway(ctx context.Context, gw *gwv1beta1.Gateway) bool {
+	gwClass := &gwv1beta1.GatewayClass{}
+	err := v.k8sClient.Get(ctx, types.NamespacedName{Name: string(gw.Spec.GatewayClassName)}, gwClass)
+	if err != nil {
+		v.log.Debugf(ctx, "Unable to retrieve gateway class %s for gateway %s/%s, %s",
+			gw.Spec.GatewayClassName, gw.Namespace, gw.Name, err)
+		return false
+	}
+	return gwClass.Spec.ControllerName == config.LatticeGatewayControllerName
+}
+
+func (v *gatewayValidator) SetupWithManager(log gwlog.Logger, mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidateGateway, core.ValidatingWebhookForValidator(log, v.scheme, v))
+}
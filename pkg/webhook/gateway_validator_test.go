@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func Test_gatewayValidator(t *testing.T) {
+	tests := []struct {
+		name             string
+		gatewayName      string
+		gatewayClassName string
+		overrideMode     bool
+		wantErr          string
+	}{
+		{
+			name:             "name at the limit",
+			gatewayName:      strings.Repeat("a", 63),
+			gatewayClassName: "amazon-vpc-lattice",
+		},
+		{
+			name:             "name beyond the limit",
+			gatewayName:      strings.Repeat("a", 40) + "-" + strings.Repeat("b", 23),
+			gatewayClassName: "amazon-vpc-lattice",
+			wantErr: "gateway name " + strings.Repeat("a", 40) + "-" + strings.Repeat("b", 23) +
+				" is 64 characters, VPC Lattice service network names are at most 63 characters. Use a shorter name, e.g. " +
+				strings.Repeat("a", 40) + "-" + strings.Repeat("b", 22),
+		},
+		{
+			name:             "name beyond the limit of other controller",
+			gatewayName:      strings.Repeat("a", 64),
+			gatewayClassName: "other",
+		},
+		{
+			name:             "name beyond the limit of unknown class",
+			gatewayName:      strings.Repeat("a", 64),
+			gatewayClassName: "unknown",
+		},
+		{
+			name:             "name beyond the limit in service network override mode",
+			gatewayName:      strings.Repeat("a", 64),
+			gatewayClassName: "amazon-vpc-lattice",
+			overrideMode:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			defer func(mode bool) { config.ServiceNetworkOverrideMode = mode }(config.ServiceNetworkOverrideMode)
+			config.ServiceNetworkOverrideMode = tt.overrideMode
+
+			k8sScheme := runtime.NewScheme()
+			gwv1beta1.AddToScheme(k8sScheme)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(
+				&gwv1beta1.GatewayClass{
+					ObjectMeta: metav1.ObjectMeta{Name: "amazon-vpc-lattice"},
+					Spec:       gwv1beta1.GatewayClassSpec{ControllerName: config.LatticeGatewayControllerName},
+				},
+				&gwv1beta1.GatewayClass{
+					ObjectMeta: metav1.ObjectMeta{Name: "other"},
+					Spec:       gwv1beta1.GatewayClassSpec{ControllerName: "example.com/other"},
+				},
+			).Build()
+
+			v := NewGatewayValidator(gwlog.FallbackLogger, k8sScheme, k8sClient)
+			gw := &gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.gatewayName, Namespace: "ns"},
+				Spec:       gwv1beta1.GatewaySpec{GatewayClassName: gwv1beta1.ObjectName(tt.gatewayClassName)},
+			}
+
+			err := v.ValidateCreate(ctx, gw)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.NoError(t, v.ValidateUpdate(ctx, gw, gw.DeepCopy()))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+	"github.com/aws/aws-application-networking-k8s/pkg/webhook/core"
+)
+
+const (
+	apiPathValidateIAMAuthPolicy = "/validate-iamauthpolicy"
+)
+
+// Rejects IAMAuthPolicies allowing actions that do not match the allowed actions. Allowed actions
+// are IAM action names, e.g. vpc-lattice-svcs:Invoke, and can use * and ? wildcards. Actions of the
+// policy are matched literally, so a policy allowing vpc-lattice-svcs:* requires vpc-lattice-svcs:*
+// or a broader allowed action. Deny statements are not restricted.
+//
+// Also rejects targetRef changes unless --policy-target-ref-change is move, policies are then deleted and
+// recreated to move them instead. With move, the controller removes the policy from the previous target.
+func NewIAMAuthPolicyValidator(log gwlog.Logger, scheme *runtime.Scheme, allowedActions []string) *iamAuthPolicyValidator {
+	return &iamAuthPolicyValidator{
+		log:            log,
+		scheme:         scheme,
+		allowedActions: allowedActions,
+	}
+}
+
+var _ core.Validator = &iamAuthPolicyValidator{}
+
+type iamAuthPolicyValidator struct {
+	log            gwlog.Logger
+	scheme         *runtime.Scheme
+	allowedActions []string
+}
+
+func (v *iamAuthPolicyValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &anv1alpha1.IAMAuthPolicy{}, nil
+}
+
+func (v *iamAuthPolicyValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validate(obj.(*anv1alpha1.IAMAuthPolicy))
+}
+
+func (v *iamAuthPolicyValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	policy := obj.(*anv1alpha1.IAMAuthPolicy)
+	oldPolicy := oldObj.(*anv1alpha1.IAMAuthPolicy)
+	if config.PolicyTargetRefChange == config.PolicyTargetRefChangeReject &&
+		oldPolicy.Spec.TargetRef != nil && policy.Spec.TargetRef != nil &&
+		policyhelper.PolicyTargetRefKey(oldPolicy) != policyhelper.PolicyTargetRefKey(policy) {
+		return fmt.Errorf("targetRef is immutable, delete the policy and create a new one targeting %s %s instead",
+			policy.Spec.TargetRef.Kind, policy.Spec.TargetRef.Name)
+	}
+	return v.validate(policy)
+}
+
+func (v *iamAuthPolicyValidator) validate(policy *anv1alpha1.IAMAuthPolicy) error {
+	if len(v.allowedActions) == 0 {
+		return nil
+	}
+	statements, err := model.IAMAuthPolicyStatements(policy.Spec.Policy)
+	if err != nil {
+		return fmt.Errorf("invalid policy, actions cannot be checked against the allowed actions: %w", err)
+	}
+	var forbidden []string
+	for _, statement := range statements {
+		if effect, _ := statement["Effect"].(string); !strings.EqualFold(effect, "Allow") {
+			continue
+		}
+		if _, ok := statement["NotAction"]; ok && !v.isAllowed("*") {
+			// allows every action but the listed ones
+			forbidden = append(forbidden, "NotAction")
+		}
+		for _, action := range statementActions(statement["Action"]) {
+			if !v.isAllowed(action) {
+				forbidden = append(forbidden, action)
+			}
+		}
+	}
+	if len(forbidden) > 0 {
+		return fmt.Errorf("policy allows actions which are not allowed: %s. Allowed actions are: %s",
+			strings.Join(forbidden, ", "), strings.Join(v.allowedActions, ", "))
+	}
+	return nil
+}
+
+// IAM action names are case-insensitive
+func (v *iamAuthPolicyValidator) isAllowed(action string) bool {
+	for _, allowed := range v.allowedActions {
+		if ok, _ := path.Match(strings.ToLower(allowed), strings.ToLower(action)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Action is either a single action or an array of them
+func statementActions(action interface{}) []string {
+	switch typed := action.(type) {
+	case string:
+		return []string{typed}
+	case []interface{}:
+		var actions []string
+		for _, a := range typed {
+			if s, ok := a.(string); ok {
+				actions = append(actions, s)
+			}
+		}
+		return actions
+	}
+	return nil
+}
+
+func (v *iamAuthPolicyValidator) SetupWithManager(log gwlog.Logger, mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidateIAMAuthPolicy, core.ValidatingWebhookForValidator(log, v.scheme, v))
+}
@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func Test_iamAuthPolicyValidator(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowedActions []string
+		policy         string
+		wantErr        string
+	}{
+		{
+			name:           "allowed action",
+			allowedActions: []string{"vpc-lattice-svcs:Invoke"},
+			policy:         `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"vpc-lattice-svcs:invoke","Resource":"*"}]}`,
+		},
+		{
+			name:           "action allowed by wildcard",
+			allowedActions: []string{"vpc-lattice-svcs:*"},
+			policy:         `{"Statement":{"Effect":"Allow","Principal":"*","Action":["vpc-lattice-svcs:Invoke"],"Resource":"*"}}`,
+		},
+		{
+			name:           "disallowed actions",
+			allowedActions: []string{"vpc-lattice-svcs:Invoke"},
+			policy: `{"Statement":[
+				{"Effect":"Allow","Principal":"*","Action":["vpc-lattice-svcs:Invoke","vpc-lattice-svcs:*"],"Resource":"*"},
+				{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`,
+			wantErr: "policy allows actions which are not allowed: vpc-lattice-svcs:*, *. Allowed actions are: vpc-lattice-svcs:Invoke",
+		},
+		{
+			name:           "NotAction is disallowed",
+			allowedActions: []string{"vpc-lattice-svcs:Invoke"},
+			policy:         `{"Statement":[{"Effect":"Allow","Principal":"*","NotAction":"vpc-lattice-svcs:Invoke","Resource":"*"}]}`,
+			wantErr:        "policy allows actions which are not allowed: NotAction. Allowed actions are: vpc-lattice-svcs:Invoke",
+		},
+		{
+			name:           "deny statements are not restricted",
+			allowedActions: []string{"vpc-lattice-svcs:Invoke"},
+			policy:         `{"Statement":[{"Effect":"Deny","Principal":"*","Action":"*","Resource":"*"}]}`,
+		},
+		{
+			name:           "invalid policy",
+			allowedActions: []string{"vpc-lattice-svcs:Invoke"},
+			policy:         `{"Statement":`,
+			wantErr:        "invalid policy, actions cannot be checked against the allowed actions: unexpected end of JSON input",
+		},
+		{
+			name:   "no allowed actions",
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewIAMAuthPolicyValidator(gwlog.FallbackLogger, runtime.NewScheme(), tt.allowedActions)
+			policy := &anv1alpha1.IAMAuthPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "ns"},
+				Spec:       anv1alpha1.IAMAuthPolicySpec{Policy: tt.policy},
+			}
+
+			errs := []error{
+				v.ValidateCreate(context.TODO(), policy),
+				v.ValidateUpdate(context.TODO(), policy, &anv1alpha1.IAMAuthPolicy{}),
+			}
+			for _, err := range errs {
+				if tt.wantErr == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func Test_iamAuthPolicyValidator_TargetRef(t *testing.T) {
+	targetRef := func(kind, name string, namespace *string) *gwv1alpha2.PolicyTargetReference {
+		return &gwv1alpha2.PolicyTargetReference{
+			Group:     gwv1beta1.GroupName,
+			Kind:      gwv1alpha2.Kind(kind),
+			Name:      gwv1alpha2.ObjectName(name),
+			Namespace: (*gwv1alpha2.Namespace)(namespace),
+		}
+	}
+	tests := []struct {
+		name         string
+		oldTargetRef *gwv1alpha2.PolicyTargetReference
+		targetRef    *gwv1alpha2.PolicyTargetReference
+		move         bool
+		wantErr      string
+	}{
+		{
+			name:         "unchanged targetRef",
+			oldTargetRef: targetRef("HTTPRoute", "route", nil),
+			targetRef:    targetRef("HTTPRoute", "route", nil),
+		},
+		{
+			name:         "explicit policy namespace",
+			oldTargetRef: targetRef("HTTPRoute", "route", nil),
+			targetRef:    targetRef("HTTPRoute", "route", aws.String("ns")),
+		},
+		{
+			name:         "changed name",
+			oldTargetRef: targetRef("HTTPRoute", "route", nil),
+			targetRef:    targetRef("HTTPRoute", "other-route", nil),
+			wantErr:      "targetRef is immutable, delete the policy and create a new one targeting HTTPRoute other-route instead",
+		},
+		{
+			name:         "changed kind",
+			oldTargetRef: targetRef("HTTPRoute", "gw", nil),
+			targetRef:    targetRef("Gateway", "gw", nil),
+			wantErr:      "targetRef is immutable, delete the policy and create a new one targeting Gateway gw instead",
+		},
+		{
+			name:         "changed kind with move",
+			oldTargetRef: targetRef("HTTPRoute", "route", nil),
+			targetRef:    targetRef("Gateway", "gw", nil),
+			move:         true,
+		},
+		{
+			name:         "changed namespace",
+			oldTargetRef: targetRef("HTTPRoute", "route", nil),
+			targetRef:    targetRef("HTTPRoute", "route", aws.String("other-ns")),
+			wantErr:      "targetRef is immutable, delete the policy and create a new one targeting HTTPRoute route instead",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() { config.PolicyTargetRefChange = config.PolicyTargetRefChangeReject }()
+			if tt.move {
+				config.PolicyTargetRefChange = config.PolicyTargetRefChangeMove
+			}
+			v := NewIAMAuthPolicyValidator(gwlog.FallbackLogger, runtime.NewScheme(), nil)
+			newPolicy := func(targetRef *gwv1alpha2.PolicyTargetReference) *anv1alpha1.IAMAuthPolicy {
+				return &anv1alpha1.IAMAuthPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "ns"},
+					Spec:       anv1alpha1.IAMAuthPolicySpec{Policy: "{}", TargetRef: targetRef},
+				}
+			}
+
+			err := v.ValidateUpdate(context.TODO(), newPolicy(tt.targetRef), newPolicy(tt.oldTargetRef))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
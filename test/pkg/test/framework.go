@@ -101,6 +101,12 @@ func addOptionalCRDs(scheme *runtime.Scheme) {
 
 	scheme.AddKnownTypes(awsGatewayControllerCRDGroupVersion, &anv1alpha1.AccessLogPolicy{}, &anv1alpha1.AccessLogPolicyList{})
 	metav1.AddToGroupVersion(scheme, awsGatewayControllerCRDGroupVersion)
+
+	scheme.AddKnownTypes(awsGatewayControllerCRDGroupVersion, &anv1alpha1.ServiceNetworkLogPolicy{}, &anv1alpha1.ServiceNetworkLogPolicyList{})
+	metav1.AddToGroupVersion(scheme, awsGatewayControllerCRDGroupVersion)
+
+	scheme.AddKnownTypes(awsGatewayControllerCRDGroupVersion, &anv1alpha1.ServiceNetworkResourcePolicy{}, &anv1alpha1.ServiceNetworkResourcePolicyList{})
+	metav1.AddToGroupVersion(scheme, awsGatewayControllerCRDGroupVersion)
 }
 
 type Framework struct {
@@ -30,6 +30,7 @@ import (
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/test/pkg/test"
@@ -606,7 +607,7 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 		}).Should(Succeed())
 	})
 
-	It("creation sets Access Log Policy status to Invalid when the targetRef's Kind is not Gateway, HTTPRoute, or GRPCRoute", func() {
+	It("creation sets Access Log Policy status to UnsupportedKind when the targetRef's Kind is not Gateway, HTTPRoute, or GRPCRoute", func() {
 		accessLogPolicy := &anv1alpha1.AccessLogPolicy{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      k8sResourceName,
@@ -624,7 +625,7 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 		}
 		testFramework.ExpectCreated(ctx, accessLogPolicy)
 
-		// Policy status should be Invalid
+		// Policy status should be UnsupportedKind
 		Eventually(func(g Gomega) {
 			alpNamespacedName := types.NamespacedName{
 				Name:      accessLogPolicy.Name,
@@ -637,7 +638,7 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(alp.Status.Conditions[0].Type).To(BeEquivalentTo(string(gwv1alpha2.PolicyConditionAccepted)))
 			g.Expect(alp.Status.Conditions[0].Status).To(BeEquivalentTo(metav1.ConditionFalse))
 			g.Expect(alp.Status.Conditions[0].ObservedGeneration).To(BeEquivalentTo(1))
-			g.Expect(alp.Status.Conditions[0].Reason).To(BeEquivalentTo(string(gwv1alpha2.PolicyReasonInvalid)))
+			g.Expect(alp.Status.Conditions[0].Reason).To(BeEquivalentTo(string(conditions.ReasonUnsupportedKind)))
 		}).Should(Succeed())
 	})
 
@@ -9,6 +9,7 @@ import (
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	"github.com/aws/aws-application-networking-k8s/pkg/controllers"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s/conditions"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/test/pkg/test"
 
@@ -63,7 +64,7 @@ var _ = Describe("IAM Auth Policy", Ordered, func() {
 	}
 
 	type K8sResults struct {
-		statusReason      gwv1alpha2.PolicyConditionReason
+		statusReason      conditions.Reason
 		annotationResType string
 		annotationResId   string
 	}
@@ -129,19 +130,19 @@ var _ = Describe("IAM Auth Policy", Ordered, func() {
 
 	It("GroupName Error", func() {
 		policy := newPolicyWithGroup("group-name-err", "wrong.group", "Gateway", "gw")
-		testK8sPolicy(policy, K8sResults{statusReason: gwv1alpha2.PolicyReasonInvalid})
+		testK8sPolicy(policy, K8sResults{statusReason: conditions.ReasonInvalid})
 		testFramework.Delete(ctx, policy)
 	})
 
 	It("Kind Error", func() {
 		policy := newPolicy("kind-err", "WrongKind", "gw")
-		testK8sPolicy(policy, K8sResults{statusReason: gwv1alpha2.PolicyReasonInvalid})
+		testK8sPolicy(policy, K8sResults{statusReason: conditions.ReasonUnsupportedKind})
 		testFramework.Delete(ctx, policy)
 	})
 
 	It("TargetRef Not Found", func() {
 		policy := newPolicy("not-found", "Gateway", "not-found")
-		testK8sPolicy(policy, K8sResults{statusReason: gwv1alpha2.PolicyReasonTargetNotFound})
+		testK8sPolicy(policy, K8sResults{statusReason: conditions.ReasonTargetNotFound})
 		testFramework.Delete(ctx, policy)
 	})
 
@@ -149,7 +150,7 @@ var _ = Describe("IAM Auth Policy", Ordered, func() {
 		policy1 := newPolicy("conflict-1", "Gateway", "test-gateway")
 		policy2 := newPolicy("conflict-2", "Gateway", "test-gateway")
 		// at least second policy should be in conflicted state
-		testK8sPolicy(policy2, K8sResults{statusReason: gwv1alpha2.PolicyReasonConflicted})
+		testK8sPolicy(policy2, K8sResults{statusReason: conditions.ReasonConflicted})
 		testFramework.ExpectDeletedThenNotFound(ctx, policy1, policy2)
 	})
 
@@ -160,7 +161,7 @@ var _ = Describe("IAM Auth Policy", Ordered, func() {
 
 		// accepted
 		wantResults := K8sResults{
-			statusReason:      gwv1alpha2.PolicyReasonAccepted,
+			statusReason:      conditions.ReasonAccepted,
 			annotationResType: model.ServiceNetworkType,
 			annotationResId:   snId,
 		}
@@ -184,7 +185,7 @@ var _ = Describe("IAM Auth Policy", Ordered, func() {
 
 		// accepted
 		wantResults := K8sResults{
-			statusReason:      gwv1alpha2.PolicyReasonAccepted,
+			statusReason:      conditions.ReasonAccepted,
 			annotationResType: model.ServiceType,
 			annotationResId:   svcId,
 		}
@@ -206,10 +207,10 @@ type StatusConditionsReader interface {
 	GetStatusConditions() *[]apimachineryv1.Condition
 }
 
-func GetPolicyStatusReason(obj StatusConditionsReader) gwv1alpha2.PolicyConditionReason {
-	cnd := meta.FindStatusCondition(*obj.GetStatusConditions(), string(gwv1alpha2.PolicyConditionAccepted))
+func GetPolicyStatusReason(obj StatusConditionsReader) conditions.Reason {
+	cnd := meta.FindStatusCondition(*obj.GetStatusConditions(), conditions.TypeAccepted)
 	if cnd != nil {
-		return gwv1alpha2.PolicyConditionReason(cnd.Reason)
+		return conditions.Reason(cnd.Reason)
 	}
 	return ""
 }
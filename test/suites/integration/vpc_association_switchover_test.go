@@ -0,0 +1,134 @@
+package integration
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/controllers"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
+	"github.com/aws/aws-application-networking-k8s/test/pkg/test"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+var _ = Describe("Test vpc association switchover", Serial, Ordered, func() {
+	const (
+		testSuite = "vpc-association-switchover"
+	)
+
+	var (
+		gateway              *gwv1.Gateway
+		serviceNetwork       *vpclattice.ServiceNetworkSummary
+		vpcAssociationPolicy *v1alpha1.VpcAssociationPolicy
+		sgId                 v1alpha1.SecurityGroupId
+	)
+
+	BeforeAll(func() {
+		name := "k8s-test-switchover-" + utils.RandomAlphaString(10)
+		createSgOutput, err := testFramework.Ec2Client.CreateSecurityGroupWithContext(ctx, &ec2.CreateSecurityGroupInput{
+			Description: aws.String("k8s-test-lattice-switchover-sg"),
+			GroupName:   aws.String(name),
+			VpcId:       aws.String(test.CurrentClusterVpcId),
+		})
+		Expect(err).To(BeNil())
+		sgId = v1alpha1.SecurityGroupId(*createSgOutput.GroupId)
+
+		createSnOutput, err := testFramework.LatticeClient.CreateServiceNetworkWithContext(ctx, &vpclattice.CreateServiceNetworkInput{
+			Name: aws.String(name),
+			Tags: testFramework.NewTestTags(testSuite),
+		})
+		Expect(err).To(BeNil())
+		serviceNetwork = &vpclattice.ServiceNetworkSummary{Id: createSnOutput.Id, Arn: createSnOutput.Arn, Name: createSnOutput.Name}
+
+		vpcAssociationPolicy = &v1alpha1.VpcAssociationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: k8snamespace,
+			},
+			Spec: v1alpha1.VpcAssociationPolicySpec{
+				TargetRef: &gwv1alpha2.PolicyTargetReference{
+					Group:     gwv1.GroupName,
+					Kind:      "Gateway",
+					Name:      gwv1alpha2.ObjectName(name),
+					Namespace: lo.ToPtr(gwv1alpha2.Namespace(k8snamespace)),
+				},
+				SecurityGroupIds: []v1alpha1.SecurityGroupId{sgId},
+				Tags:             map[string]string{"team": testSuite},
+			},
+		}
+		testFramework.ExpectCreated(ctx, vpcAssociationPolicy)
+
+		gateway = testFramework.NewGateway(name, k8snamespace)
+	})
+
+	It("Switch over the cluster VPC to the Gateway's service network, expecting the association with the policy's security group and tags", func() {
+		gateway.Annotations[controllers.ServiceNetworkSwitchoverAnnotation] = testGateway.Name
+		testFramework.ExpectCreated(ctx, gateway)
+
+		Eventually(func(g Gomega) {
+			associated, snva, err := testFramework.IsVpcAssociatedWithServiceNetwork(ctx, test.CurrentClusterVpcId, serviceNetwork)
+			g.Expect(err).To(BeNil())
+			g.Expect(associated).To(BeTrue())
+			output, err := testFramework.LatticeClient.GetServiceNetworkVpcAssociationWithContext(ctx, &vpclattice.GetServiceNetworkVpcAssociationInput{
+				ServiceNetworkVpcAssociationIdentifier: snva.Id,
+			})
+			g.Expect(err).To(BeNil())
+			g.Expect(aws.StringValueSlice(output.SecurityGroupIds)).To(Equal([]string{string(sgId)}))
+			tagsOutput, err := testFramework.LatticeClient.ListTagsForResourceWithContext(ctx, &vpclattice.ListTagsForResourceInput{
+				ResourceArn: snva.Arn,
+			})
+			g.Expect(err).To(BeNil())
+			g.Expect(aws.StringValue(tagsOutput.Tags["team"])).To(Equal(testSuite))
+		}).WithTimeout(5 * time.Minute).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			// the previous association is removed once the new one is active
+			associated, _, _ := testFramework.IsVpcAssociatedWithServiceNetwork(ctx, test.CurrentClusterVpcId, testServiceNetwork)
+			g.Expect(associated).To(BeFalse())
+		}).WithTimeout(5 * time.Minute).Should(Succeed())
+	})
+
+	AfterAll(func() {
+		// deleting the policy removes the association with the switched over service network
+		testFramework.ExpectDeletedThenNotFound(ctx, vpcAssociationPolicy, gateway)
+		Eventually(func(g Gomega) {
+			associations, err := testFramework.LatticeClient.ListServiceNetworkVpcAssociationsAsList(ctx, &vpclattice.ListServiceNetworkVpcAssociationsInput{
+				ServiceNetworkIdentifier: serviceNetwork.Id,
+			})
+			g.Expect(err).To(BeNil())
+			g.Expect(associations).To(BeEmpty())
+		}).WithTimeout(5 * time.Minute).Should(Succeed())
+
+		_, err := testFramework.LatticeClient.DeleteServiceNetworkWithContext(ctx, &vpclattice.DeleteServiceNetworkInput{
+			ServiceNetworkIdentifier: serviceNetwork.Id,
+		})
+		Expect(err).To(BeNil())
+		_, err = testFramework.Ec2Client.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{
+			GroupId: aws.String(string(sgId)),
+		})
+		Expect(err).To(BeNil())
+
+		// Re-create SNVA manually to recover network state before the switchover.
+		_, err = testFramework.Cloud.Lattice().CreateServiceNetworkVpcAssociationWithContext(ctx, &vpclattice.CreateServiceNetworkVpcAssociationInput{
+			ServiceNetworkIdentifier: testServiceNetwork.Id,
+			VpcIdentifier:            &config.VpcID,
+			Tags:                     testFramework.Cloud.DefaultTags(),
+		})
+		Expect(err).To(BeNil())
+
+		Eventually(func(g Gomega) {
+			associated, _, err := testFramework.IsVpcAssociatedWithServiceNetwork(ctx, test.CurrentClusterVpcId, testServiceNetwork)
+			g.Expect(err).To(BeNil())
+			g.Expect(associated).To(BeTrue())
+		}).WithTimeout(5 * time.Minute).Should(Succeed())
+	})
+})